@@ -0,0 +1,60 @@
+// Command devtoken prints a bearer token signed with the first HMAC key of the BFF configuration,
+// for calling the API locally. Run it from its directory like the API:
+//
+//	go run . -roles admin
+//	go run . -roles customer -customer CUST001
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"billing-system/bff/config"
+	"billing-system/pkg/auth"
+)
+
+func main() {
+	subject := flag.String("sub", "dev", "subject of the token")
+	roles := flag.String("roles", string(auth.RoleAdmin), "comma separated roles: customer, ops, finance, admin")
+	customerID := flag.String("customer", "", "customer a customer token acts for")
+	ttl := flag.Duration("ttl", time.Hour, "validity of the token")
+	flag.Parse()
+
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	cfg := config.Service.Auth
+	var key *auth.StaticKeyConfig
+	for i := range cfg.StaticKeys {
+		if cfg.StaticKeys[i].Secret != "" {
+			key = &cfg.StaticKeys[i]
+			break
+		}
+	}
+	if key == nil {
+		log.Fatal("No static key with a secret is configured, tokens of a JWKS file come from its identity provider")
+	}
+
+	now := time.Now()
+	claims := auth.Claims{
+		Subject:    *subject,
+		Issuer:     cfg.Issuer,
+		ExpiresAt:  now.Add(*ttl).Unix(),
+		IssuedAt:   now.Unix(),
+		Roles:      strings.Split(*roles, ","),
+		CustomerID: *customerID,
+	}
+	if cfg.Audience != "" {
+		claims.Audience = []string{cfg.Audience}
+	}
+
+	token, err := auth.SignHS256(claims, key.ID, []byte(key.Secret))
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
@@ -2,6 +2,10 @@ package config
 
 import (
 	"os"
+	"time"
+
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 
 	"gopkg.in/yaml.v3"
 )
@@ -13,14 +17,111 @@ type Config struct {
 	Server             ServerConfig             `yaml:"server"`
 	BillingConnection  AdapterConnectionAddress `yaml:"billing_connection"`
 	ShipmentConnection AdapterConnectionAddress `yaml:"shipment_connection"`
+	Auth               auth.Config              `yaml:"auth"`
+	TLS                mtls.Config              `yaml:"tls"`
+	Summary            SummaryConfig            `yaml:"summary"`
+	Events             EventsConfig             `yaml:"events"`
+	RateLimit          RateLimitConfig          `yaml:"rate_limit"`
+	APIKeys            APIKeysConfig            `yaml:"api_keys"`
+	BulkImport         BulkImportConfig         `yaml:"bulk_import"`
 }
 
 type ServerConfig struct {
 	Address string `yaml:"address"`
+	// Mode is dev or production, in dev responses are checked against the OpenAPI specification and mismatches logged
+	Mode string `yaml:"mode"`
+	// TrustedProxies are the addresses or CIDRs of the proxies whose X-Forwarded-For is believed, none when empty
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// ModeDev is the server mode of local development
+const ModeDev = "dev"
+
+// SummaryConfig configures the views aggregated from several services
+type SummaryConfig struct {
+	// CallTimeout is the deadline of each service call, a slower service is left out of the view
+	CallTimeout time.Duration `yaml:"call_timeout"`
+}
+
+// EventsConfig configures the streams of order events, over server-sent events or WebSockets
+type EventsConfig struct {
+	// WriteTimeout is how long a write to a client may block, a slower client is disconnected and resumes where it stopped
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	// Retry is the reconnection delay suggested to clients of server-sent events
+	Retry time.Duration `yaml:"retry"`
+}
+
+// Stores of the rate limit buckets
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// RateLimitConfig configures the token buckets limiting the requests of each client, identified by the subject
+// of its token or by its IP address
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Store is memory, for a single BFF, or redis to share the limits between BFFs
+	Store   string      `yaml:"store"`
+	Redis   RedisConfig `yaml:"redis"`
+	Default LimitConfig `yaml:"default"`
+	// Routes have limits of their own, requests to them do not count against the default
+	Routes []RouteLimitConfig `yaml:"routes"`
+	// PerAddress limits the requests of each IP address to authenticated routes before their credentials
+	// are verified, so invalid tokens and API keys cannot be tried unthrottled. Disabled when requests is zero.
+	PerAddress LimitConfig `yaml:"per_address"`
+}
+
+type RedisConfig struct {
+	Address  string `yaml:"address"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	// KeyPrefix starts the keys of the buckets
+	KeyPrefix string `yaml:"key_prefix"`
+}
+
+// LimitConfig allows requests per period on average and up to burst at once, burst defaults to requests
+type LimitConfig struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	Burst    int           `yaml:"burst"`
+}
+
+// RouteLimitConfig limits one route, path is in gin form such as /api/v1/orders/:id
+type RouteLimitConfig struct {
+	Method      string `yaml:"method"`
+	Path        string `yaml:"path"`
+	LimitConfig `yaml:",inline"`
+}
+
+// APIKeysConfig configures the authentication of server-to-server clients by API keys
+type APIKeysConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CacheTTL is how long a verified key is accepted without asking the billing service again
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+	// TokenTTL is the validity of the tokens forwarded to the services for a key, longer than CacheTTL
+	TokenTTL time.Duration `yaml:"token_ttl"`
+	// SigningKey signs the forwarded tokens, the services must trust it
+	SigningKey auth.StaticKeyConfig `yaml:"signing_key"`
+}
+
+// BulkImportConfig configures the imports of orders from uploaded files, run in the background by the BFF
+type BulkImportConfig struct {
+	// Concurrency is how many orders are created at once, shared by all jobs
+	Concurrency int `yaml:"concurrency"`
+	// MaxRows and MaxUploadBytes bound the size of an upload
+	MaxRows        int   `yaml:"max_rows"`
+	MaxUploadBytes int64 `yaml:"max_upload_bytes"`
+	// CallTimeout is the deadline of the creation of each order
+	CallTimeout time.Duration `yaml:"call_timeout"`
+	// Retention is how long a finished job can be looked up
+	Retention time.Duration `yaml:"retention"`
 }
 
 type AdapterConnectionAddress struct {
 	Address string `yaml:"address"`
+	// ServerName is the name the server certificate must be valid for, the host of the address when empty
+	ServerName string `yaml:"server_name"`
 }
 
 var Service Config
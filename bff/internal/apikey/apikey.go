@@ -0,0 +1,193 @@
+// Package apikey authenticates server-to-server clients, such as an ERP, by their API keys.
+// The billing service verifies a key and the BFF signs a short-lived token for it, which is forwarded to the
+// services like the bearer token of a user. The token carries the id and scopes of the key, so the services
+// authorize the calls of a key like any other and record the key on what it creates.
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"billing-system/bff/config"
+	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+)
+
+// Defaults of the configuration
+const (
+	defaultCacheTTL = 30 * time.Second
+	defaultTokenTTL = 5 * time.Minute
+)
+
+// VerifyFunc verifies the secret of a key with the billing service and returns the key
+type VerifyFunc func(ctx context.Context, secret string) (*billingPb.APIKey, error)
+
+// cached is a verified key, accepted until expires
+type cached struct {
+	principal *auth.Principal
+	token     string
+	expires   time.Time
+}
+
+// Authenticator turns API keys into principals and the tokens forwarded for them
+type Authenticator struct {
+	verify   VerifyFunc
+	keyID    string
+	secret   []byte
+	issuer   string
+	audience string
+	cacheTTL time.Duration
+	tokenTTL time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	cache     map[[sha256.Size]byte]cached
+	lastSweep time.Time
+}
+
+// New creates the authenticator of the configuration, nil when API keys are disabled.
+// The tokens it signs are issued for the issuer and audience the services check.
+func New(cfg config.APIKeysConfig, authCfg auth.Config, verify VerifyFunc) (*Authenticator, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.SigningKey.Secret == "" {
+		return nil, errors.New("api keys need a signing key with a secret")
+	}
+
+	cacheTTL, tokenTTL := cfg.CacheTTL, cfg.TokenTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+	if tokenTTL <= 0 {
+		tokenTTL = defaultTokenTTL
+	}
+	// A cached token must not expire while it is still handed out
+	if cacheTTL >= tokenTTL {
+		return nil, fmt.Errorf("api key cache ttl %s must be shorter than the token ttl %s", cacheTTL, tokenTTL)
+	}
+
+	return &Authenticator{
+		verify:   verify,
+		keyID:    cfg.SigningKey.ID,
+		secret:   []byte(cfg.SigningKey.Secret),
+		issuer:   authCfg.Issuer,
+		audience: authCfg.Audience,
+		cacheTTL: cacheTTL,
+		tokenTTL: tokenTTL,
+		now:      time.Now,
+		cache:    make(map[[sha256.Size]byte]cached),
+	}, nil
+}
+
+// Authenticate returns the principal of a key and the token to forward for it.
+// A key verified within the cache TTL is not verified again, so a revoked key is refused once its entry expires.
+// Errors of the billing service are returned as they are, an unknown, expired or revoked key is Unauthenticated.
+func (a *Authenticator) Authenticate(ctx context.Context, secret string) (*auth.Principal, string, error) {
+	// Secrets are only held as hashes, like in the billing service
+	cacheKey := sha256.Sum256([]byte(secret))
+	now := a.now()
+	if entry, ok := a.lookup(cacheKey, now); ok {
+		return entry.principal, entry.token, nil
+	}
+
+	key, err := a.verify(ctx, secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	principal := &auth.Principal{
+		Subject:    "apikey:" + key.KeyId,
+		CustomerID: key.CustomerId,
+		APIKeyID:   key.KeyId,
+	}
+	for _, role := range key.Roles {
+		if auth.Role(role).IsKnown() {
+			principal.Roles = append(principal.Roles, auth.Role(role))
+		}
+	}
+	for _, scope := range key.Scopes {
+		principal.Scopes = append(principal.Scopes, auth.Permission(scope))
+	}
+
+	// Neither the token nor the cache entry outlive the key
+	tokenExpires, expires := now.Add(a.tokenTTL), now.Add(a.cacheTTL)
+	if key.ExpiresAt != "" {
+		keyExpires, err := time.Parse(time.RFC3339, key.ExpiresAt)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid expiry of api key %s: %w", key.KeyId, err)
+		}
+		tokenExpires, expires = earliest(tokenExpires, keyExpires), earliest(expires, keyExpires)
+	}
+
+	token, err := a.sign(key, tokenExpires, now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	a.store(cacheKey, cached{principal: principal, token: token, expires: expires}, now)
+	return principal, token, nil
+}
+
+// sign returns the token forwarded for the key
+func (a *Authenticator) sign(key *billingPb.APIKey, expires time.Time, now time.Time) (string, error) {
+	claims := auth.Claims{
+		Subject:    "apikey:" + key.KeyId,
+		Issuer:     a.issuer,
+		ExpiresAt:  expires.Unix(),
+		IssuedAt:   now.Unix(),
+		Roles:      key.Roles,
+		CustomerID: key.CustomerId,
+		APIKeyID:   key.KeyId,
+		Scopes:     key.Scopes,
+	}
+	if a.audience != "" {
+		claims.Audience = []string{a.audience}
+	}
+
+	token, err := auth.SignHS256(claims, a.keyID, a.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the token of api key %s: %w", key.KeyId, err)
+	}
+	return token, nil
+}
+
+// lookup returns the cached entry of a key that has not expired
+func (a *Authenticator) lookup(cacheKey [sha256.Size]byte, now time.Time) (cached, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.cache[cacheKey]
+	if !ok || !now.Before(entry.expires) {
+		return cached{}, false
+	}
+	return entry, true
+}
+
+// store caches an entry and drops the expired entries once per cache TTL, so keys no longer used do not hold memory
+func (a *Authenticator) store(cacheKey [sha256.Size]byte, entry cached, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.cache[cacheKey] = entry
+	if now.Sub(a.lastSweep) < a.cacheTTL {
+		return
+	}
+	a.lastSweep = now
+	for k, e := range a.cache {
+		if !now.Before(e.expires) {
+			delete(a.cache, k)
+		}
+	}
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
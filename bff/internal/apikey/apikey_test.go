@@ -0,0 +1,111 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"billing-system/bff/config"
+	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	authCfg := auth.Config{Enabled: true, Issuer: "billing-system", Audience: "services",
+		StaticKeys: []auth.StaticKeyConfig{{ID: "bff", Secret: "secret"}}}
+	verifier, err := auth.NewVerifier(authCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	key := &billingPb.APIKey{KeyId: "0a1b2c3d4e5f", CustomerId: "CUST001", Roles: []string{"customer"},
+		Scopes: []string{string(auth.PermissionOrdersWrite)}}
+	calls := 0
+	verify := func(_ context.Context, secret string) (*billingPb.APIKey, error) {
+		calls++
+		if secret != "bsk_0a1b2c3d4e5f_secret" {
+			return nil, status.Error(codes.Unauthenticated, "unknown api key")
+		}
+		return key, nil
+	}
+
+	cfg := config.APIKeysConfig{Enabled: true, CacheTTL: time.Minute, TokenTTL: 5 * time.Minute,
+		SigningKey: auth.StaticKeyConfig{ID: "bff", Secret: "secret"}}
+	authenticator, err := New(cfg, authCfg, verify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator.now = func() time.Time { return now }
+
+	principal, token, err := authenticator.Authenticate(context.Background(), "bsk_0a1b2c3d4e5f_secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.APIKeyID != "0a1b2c3d4e5f" || principal.CustomerID != "CUST001" || !principal.Can(auth.PermissionOrdersWrite) || principal.Can(auth.PermissionOrdersRead) {
+		t.Errorf("principal = %+v, want key 0a1b2c3d4e5f of CUST001 limited to orders:write", principal)
+	}
+
+	// The services see the same principal in the forwarded token
+	forwarded, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forwarded.Subject != "apikey:0a1b2c3d4e5f" || forwarded.APIKeyID != principal.APIKeyID || len(forwarded.Scopes) != 1 {
+		t.Errorf("forwarded principal = %+v, want the principal of the key", forwarded)
+	}
+
+	if _, _, err := authenticator.Authenticate(context.Background(), "bsk_0a1b2c3d4e5f_secret"); err != nil || calls != 1 {
+		t.Errorf("err = %v, calls = %d, want the key verified once within the cache TTL", err, calls)
+	}
+	authenticator.now = func() time.Time { return now.Add(time.Minute) }
+	if _, _, err := authenticator.Authenticate(context.Background(), "bsk_0a1b2c3d4e5f_secret"); err != nil || calls != 2 {
+		t.Errorf("err = %v, calls = %d, want the key verified again once its entry expired", err, calls)
+	}
+
+	if _, _, err := authenticator.Authenticate(context.Background(), "bsk_0a1b2c3d4e5f_wrong"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("err = %v, want the Unauthenticated error of the billing service", err)
+	}
+}
+
+func TestAuthenticate_KeyExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	calls := 0
+	verify := func(context.Context, string) (*billingPb.APIKey, error) {
+		calls++
+		return &billingPb.APIKey{KeyId: "0a1b2c3d4e5f", Roles: []string{"ops"}, ExpiresAt: now.Add(10 * time.Second).Format(time.RFC3339)}, nil
+	}
+
+	cfg := config.APIKeysConfig{Enabled: true, SigningKey: auth.StaticKeyConfig{ID: "bff", Secret: "secret"}}
+	authenticator, err := New(cfg, auth.Config{}, verify)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator.now = func() time.Time { return now }
+	if _, _, err := authenticator.Authenticate(context.Background(), "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The entry expires with the key, well before the cache TTL
+	authenticator.now = func() time.Time { return now.Add(10 * time.Second) }
+	if _, _, err := authenticator.Authenticate(context.Background(), "key"); err != nil || calls != 2 {
+		t.Errorf("err = %v, calls = %d, want the key verified again once it expired", err, calls)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if authenticator, err := New(config.APIKeysConfig{}, auth.Config{}, nil); authenticator != nil || err != nil {
+		t.Errorf("New(disabled) = %v, %v, want nil, nil", authenticator, err)
+	}
+	if _, err := New(config.APIKeysConfig{Enabled: true}, auth.Config{}, nil); err == nil {
+		t.Error("New without a signing key secret succeeded")
+	}
+	cfg := config.APIKeysConfig{Enabled: true, CacheTTL: 10 * time.Minute, TokenTTL: 5 * time.Minute,
+		SigningKey: auth.StaticKeyConfig{ID: "bff", Secret: "secret"}}
+	if _, err := New(cfg, auth.Config{}, nil); err == nil {
+		t.Error("New with a cache TTL longer than the token TTL succeeded")
+	}
+}
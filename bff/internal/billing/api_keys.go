@@ -0,0 +1,172 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	billingPb "billing-system/billing_service/proto"
+)
+
+// IssueAPIKey handles HTTP request to issue an API key, the response is the only one carrying its secret
+func (h *Handler) IssueAPIKey(ctx *gin.Context) {
+	var request IssueAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	billingClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	pbResponse, err := billingClient.IssueAPIKey(ctx, &billingPb.IssueAPIKeyRequest{
+		Name:       request.Name,
+		CustomerId: request.CustomerID,
+		Roles:      request.Roles,
+		Scopes:     request.Scopes,
+		ExpiresAt:  request.ExpiresAt,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.SuccessResponse(IssuedAPIKeyResponse{
+		APIKey: convertPbAPIKeyToResponse(pbResponse.ApiKey),
+		Secret: pbResponse.Secret,
+	}))
+}
+
+// ListAPIKeys handles HTTP request to list the API keys of a customer or every API key
+func (h *Handler) ListAPIKeys(ctx *gin.Context) {
+	var query ListAPIKeysQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	billingClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	pbResponse, err := billingClient.ListAPIKeys(ctx, &billingPb.ListAPIKeysRequest{CustomerId: query.CustomerID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	keys := make([]APIKeyResponse, len(pbResponse.ApiKeys))
+	for i, key := range pbResponse.ApiKeys {
+		keys[i] = convertPbAPIKeyToResponse(key)
+	}
+	ctx.JSON(http.StatusOK, common.SuccessResponse(keys))
+}
+
+// RotateAPIKey handles HTTP request to replace an API key, the previous key stays valid during the overlap
+func (h *Handler) RotateAPIKey(ctx *gin.Context) {
+	keyID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid api key id"))
+		return
+	}
+
+	// The body is optional, without one the configured overlap applies
+	var request RotateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	billingClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	pbResponse, err := billingClient.RotateAPIKey(ctx, &billingPb.RotateAPIKeyRequest{
+		Id:             keyID,
+		OverlapMinutes: int32(request.OverlapMinutes),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, common.SuccessResponse(IssuedAPIKeyResponse{
+		APIKey: convertPbAPIKeyToResponse(pbResponse.ApiKey),
+		Secret: pbResponse.Secret,
+	}))
+}
+
+// RevokeAPIKey handles HTTP request to revoke an API key at once
+func (h *Handler) RevokeAPIKey(ctx *gin.Context) {
+	keyID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid api key id"))
+		return
+	}
+
+	billingClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	pbResponse, err := billingClient.RevokeAPIKey(ctx, &billingPb.APIKeyRequest{Id: keyID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.SuccessResponse(convertPbAPIKeyToResponse(pbResponse.ApiKey)))
+}
+
+// VerifyAPIKey verifies the secret of an API key with the billing service, for the authentication of API key clients
+func (h *Handler) VerifyAPIKey(ctx context.Context, secret string) (*billingPb.APIKey, error) {
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		return nil, common.ServiceUnavailable("billing")
+	}
+
+	pbResponse, err := client.(billingPb.BillingServiceClient).VerifyAPIKey(ctx, &billingPb.VerifyAPIKeyRequest{Secret: secret})
+	if err != nil {
+		return nil, err
+	}
+	return pbResponse.ApiKey, nil
+}
+
+// client returns the billing service client, or adds the error of an unavailable service to the request
+func (h *Handler) client(ctx *gin.Context) (billingPb.BillingServiceClient, bool) {
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		ctx.Error(common.ServiceUnavailable("billing"))
+		return nil, false
+	}
+	return client.(billingPb.BillingServiceClient), true
+}
+
+func convertPbAPIKeyToResponse(pbKey *billingPb.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:            pbKey.Id,
+		KeyID:         pbKey.KeyId,
+		Name:          pbKey.Name,
+		CustomerID:    pbKey.CustomerId,
+		Roles:         pbKey.Roles,
+		Scopes:        pbKey.Scopes,
+		ExpiresAt:     pbKey.ExpiresAt,
+		LastUsedAt:    pbKey.LastUsedAt,
+		RevokedAt:     pbKey.RevokedAt,
+		RotatedFromID: pbKey.RotatedFromId,
+		CreatedBy:     pbKey.CreatedBy,
+		CreatedAt:     pbKey.CreatedAt,
+	}
+}
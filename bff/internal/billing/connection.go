@@ -2,10 +2,11 @@ package billing
 
 import (
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"billing-system/bff/config"
 	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 )
 
 type BillingConnectionAdapter struct {
@@ -13,9 +14,15 @@ type BillingConnectionAdapter struct {
 }
 
 func (billingConnectionAdapter *BillingConnectionAdapter) NewConnection() (*grpc.ClientConn, error) {
+	creds, err := mtls.ClientCredentials(config.Service.TLS, config.Service.BillingConnection.ServerName)
+	if err != nil {
+		return nil, err
+	}
 	conn, err := grpc.Dial(
 		config.Service.BillingConnection.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(auth.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(auth.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package billing
 import (
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -23,7 +24,7 @@ func NewHandler() *Handler {
 func (h *Handler) CreateOrder(ctx *gin.Context) {
 	var request CreateOrderRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, common.ErrorResponse(http.StatusBadRequest, err.Error()))
+		ctx.Error(common.BindError(err))
 		return
 	}
 
@@ -31,16 +32,30 @@ func (h *Handler) CreateOrder(ctx *gin.Context) {
 	client, _, err := h.BillingConnection.NewClient()
 	if err != nil {
 		log.Println("Error connecting to billing service:", err)
-		ctx.JSON(http.StatusInternalServerError, common.ErrorResponse(http.StatusInternalServerError, "Failed to connect to billing service"))
+		ctx.Error(common.ServiceUnavailable("billing"))
 		return
 	}
 	billingClient := client.(billingPb.BillingServiceClient)
 
-	// Convert request to protobuf
+	// Call billing service
+	pbResponse, err := billingClient.CreateOrder(ctx, ToProtoCreateOrderRequest(request))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// Convert response
+	response := convertPbOrderToResponse(pbResponse.Order)
+	ctx.JSON(http.StatusOK, common.SuccessResponse(response))
+}
+
+// ToProtoCreateOrderRequest converts a request to create an order to protobuf
+func ToProtoCreateOrderRequest(request CreateOrderRequest) *billingPb.CreateOrderRequest {
 	pbRequest := &billingPb.CreateOrderRequest{
 		CustomerId: request.CustomerID,
 		Items:      make([]*billingPb.ItemRequest, len(request.Items)),
 		Payments:   make([]*billingPb.PaymentRequest, len(request.Payments)),
+		QuoteToken: request.QuoteToken,
 	}
 
 	// Convert items
@@ -59,20 +74,100 @@ func (h *Handler) CreateOrder(ctx *gin.Context) {
 			Amount: payment.Amount,
 		}
 	}
+	return pbRequest
+}
 
-	// Call billing service
-	pbResponse, err := billingClient.CreateOrder(ctx, pbRequest)
+// GetOrder returns an order with its items and payments, customers only get their own orders
+func (h *Handler) GetOrder(ctx *gin.Context) {
+	orderID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, common.ErrorResponse(http.StatusInternalServerError, err.Error()))
+		ctx.Error(common.BadRequest("invalid order id"))
 		return
 	}
 
-	// Convert response
-	response := convertPbOrderToResponse(pbResponse.Order)
-	ctx.JSON(http.StatusOK, common.SuccessResponse(response))
+	// Get billing service client
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		ctx.Error(common.ServiceUnavailable("billing"))
+		return
+	}
+	billingClient := client.(billingPb.BillingServiceClient)
+
+	pbResponse, err := billingClient.GetOrder(ctx, &billingPb.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.SuccessResponse(convertPbOrderToResponse(pbResponse.Order)))
+}
+
+// QuoteOrder prices a cart without creating an order, optionally locking the prices
+func (h *Handler) QuoteOrder(ctx *gin.Context) {
+	var request QuoteOrderRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	// Get billing service client
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		ctx.Error(common.ServiceUnavailable("billing"))
+		return
+	}
+	billingClient := client.(billingPb.BillingServiceClient)
+
+	pbRequest := &billingPb.QuoteOrderRequest{
+		CustomerId:  request.CustomerID,
+		Items:       make([]*billingPb.ItemRequest, len(request.Items)),
+		LockMinutes: int32(request.LockMinutes),
+	}
+	for i, item := range request.Items {
+		pbRequest.Items[i] = &billingPb.ItemRequest{
+			Sku:      item.Sku,
+			Quantity: int32(item.Quantity),
+		}
+	}
+
+	pbResponse, err := billingClient.QuoteOrder(ctx, pbRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, common.SuccessResponse(convertPbQuoteToResponse(pbResponse)))
 }
 
 // Helper functions for request/response conversion
+func convertPbQuoteToResponse(pbQuote *billingPb.QuoteOrderResponse) QuoteResponse {
+	response := QuoteResponse{
+		CustomerID:  pbQuote.CustomerId,
+		Lines:       make([]QuoteLineResponse, len(pbQuote.Lines)),
+		TotalAmount: pbQuote.TotalAmount,
+		TaxAmount:   pbQuote.TaxAmount,
+		QuoteToken:  pbQuote.QuoteToken,
+		ExpiresAt:   pbQuote.ExpiresAt,
+	}
+
+	for i, line := range pbQuote.Lines {
+		response.Lines[i] = QuoteLineResponse{
+			Sku:           line.Sku,
+			ItemID:        line.ItemId,
+			Quantity:      int(line.Quantity),
+			UnitPrice:     line.UnitPrice,
+			LineTotal:     line.LineTotal,
+			PriceListCode: line.PriceListCode,
+			PriceTier:     int(line.PriceTier),
+			TaxAmount:     line.TaxAmount,
+		}
+	}
+
+	return response
+}
+
 func convertPbOrderToResponse(pbOrder *billingPb.Order) OrderResponse {
 	response := OrderResponse{
 		ID:          pbOrder.Id,
@@ -81,6 +176,7 @@ func convertPbOrderToResponse(pbOrder *billingPb.Order) OrderResponse {
 		Status:      pbOrder.Status.String(),
 		CreatedAt:   pbOrder.CreatedAt,
 		UpdatedAt:   pbOrder.UpdatedAt,
+		APIKeyID:    pbOrder.ApiKeyId,
 		Items:       make([]OrderItemResponse, len(pbOrder.Items)),
 		Payments:    make([]PaymentResponse, len(pbOrder.Payments)),
 	}
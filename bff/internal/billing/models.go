@@ -1,10 +1,19 @@
 package billing
 
 // CreateOrderRequest represents a request to create a new order
+// Items of a locked quote are taken from its token, so items are left empty when QuoteToken is set
 type CreateOrderRequest struct {
 	CustomerID string           `json:"customer_id" binding:"required"`
-	Items      []ItemRequest    `json:"items" binding:"required,dive"`
+	Items      []ItemRequest    `json:"items" binding:"required_without=QuoteToken,excluded_with=QuoteToken,dive"`
 	Payments   []PaymentRequest `json:"payments" binding:"required,dive"`
+	QuoteToken string           `json:"quote_token"`
+}
+
+// QuoteOrderRequest represents a request to price a cart without creating an order
+type QuoteOrderRequest struct {
+	CustomerID  string        `json:"customer_id" binding:"required"`
+	Items       []ItemRequest `json:"items" binding:"required,min=1,dive"`
+	LockMinutes int           `json:"lock_minutes" binding:"omitempty,min=0"`
 }
 
 // ItemRequest represents an item in a create order request
@@ -25,6 +34,28 @@ type CreateOrderResponse struct {
 	Order OrderResponse `json:"order"`
 }
 
+// QuoteResponse represents a priced cart in responses
+type QuoteResponse struct {
+	CustomerID  string              `json:"customer_id"`
+	Lines       []QuoteLineResponse `json:"lines"`
+	TotalAmount float64             `json:"total_amount"`
+	TaxAmount   float64             `json:"tax_amount"`
+	QuoteToken  string              `json:"quote_token,omitempty"`
+	ExpiresAt   string              `json:"expires_at,omitempty"`
+}
+
+// QuoteLineResponse represents the price of one item of a quote in responses
+type QuoteLineResponse struct {
+	Sku           string  `json:"sku"`
+	ItemID        int64   `json:"item_id"`
+	Quantity      int     `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	LineTotal     float64 `json:"line_total"`
+	PriceListCode string  `json:"price_list_code,omitempty"`
+	PriceTier     int     `json:"price_tier,omitempty"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
 // OrderResponse represents an order in responses
 type OrderResponse struct {
 	ID          int64               `json:"id"`
@@ -35,6 +66,8 @@ type OrderResponse struct {
 	Payments    []PaymentResponse   `json:"payments"`
 	CreatedAt   string              `json:"created_at"`
 	UpdatedAt   string              `json:"updated_at"`
+	// APIKeyID is the public id of the API key the order was created with, empty for users
+	APIKeyID string `json:"api_key_id,omitempty"`
 }
 
 // OrderItemResponse represents an order item in responses
@@ -53,7 +86,47 @@ type PaymentResponse struct {
 	Amount  float64 `json:"amount"`
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
+// IssueAPIKeyRequest represents a request to issue an API key to a server-to-server client.
+// CustomerID is required for and only allowed on keys with the customer role, scopes narrow the permissions of the roles.
+// Shipment scopes add the scopes of the billing calls of the shipment service: orders:read, and invoices:write for shipments:write.
+type IssueAPIKeyRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	CustomerID string   `json:"customer_id"`
+	Roles      []string `json:"roles" binding:"required,min=1"`
+	Scopes     []string `json:"scopes"`
+	// ExpiresAt is an RFC3339 time, the key does not expire when empty
+	ExpiresAt string `json:"expires_at"`
+}
+
+// RotateAPIKeyRequest represents a request to replace an API key.
+// The previous key stays valid for OverlapMinutes, the configured overlap of the billing service when zero.
+type RotateAPIKeyRequest struct {
+	OverlapMinutes int `json:"overlap_minutes" binding:"omitempty,min=0"`
+}
+
+// ListAPIKeysQuery represents the query parameters of an API key list request
+type ListAPIKeysQuery struct {
+	CustomerID string `form:"customer_id"`
+}
+
+// APIKeyResponse represents an API key in responses, its secret is never returned again after it is issued
+type APIKeyResponse struct {
+	ID            int64    `json:"id"`
+	KeyID         string   `json:"key_id"`
+	Name          string   `json:"name"`
+	CustomerID    string   `json:"customer_id,omitempty"`
+	Roles         []string `json:"roles"`
+	Scopes        []string `json:"scopes,omitempty"`
+	ExpiresAt     string   `json:"expires_at,omitempty"`
+	LastUsedAt    string   `json:"last_used_at,omitempty"`
+	RevokedAt     string   `json:"revoked_at,omitempty"`
+	RotatedFromID int64    `json:"rotated_from_id,omitempty"`
+	CreatedBy     string   `json:"created_by,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+// IssuedAPIKeyResponse represents a new API key with the secret its client presents, shown only once
+type IssuedAPIKeyResponse struct {
+	APIKey APIKeyResponse `json:"api_key"`
+	Secret string         `json:"secret"`
 }
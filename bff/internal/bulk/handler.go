@@ -0,0 +1,203 @@
+// Package bulk imports orders from CSV or JSONL uploads in background jobs.
+// Every row is validated like the body of an order creation when the file is uploaded, then the valid rows
+// are created through the billing service a few at a time. Jobs report their progress and the errors of their
+// rows, and the failed rows can be downloaded as a CSV file that can be uploaded again once fixed.
+// Jobs are kept in the memory of the BFF that accepted the upload.
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/config"
+	"billing-system/bff/internal/billing"
+	"billing-system/bff/internal/common"
+	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+)
+
+// Defaults of the configuration
+const (
+	defaultConcurrency    = 4
+	defaultMaxRows        = 1000
+	defaultMaxUploadBytes = 5 << 20
+	defaultCallTimeout    = 10 * time.Second
+	defaultRetention      = 24 * time.Hour
+)
+
+// Handler serves the bulk imports, it shares the connection of the billing handler
+type Handler struct {
+	BillingConnection *billing.BillingConnectionAdapter
+	jobs              *Store
+	// slots bounds the orders created at once by all jobs, so parallel uploads do not multiply the load on billing
+	slots chan struct{}
+}
+
+// NewHandler creates a bulk import handler creating orders over the given connection
+func NewHandler(billingConnection *billing.BillingConnectionAdapter) *Handler {
+	retention := config.Service.BulkImport.Retention
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	return &Handler{
+		BillingConnection: billingConnection,
+		jobs:              NewStore(retention),
+		slots:             make(chan struct{}, concurrency()),
+	}
+}
+
+// ImportOrders validates the rows of an uploaded CSV or JSONL file and starts a job creating their orders.
+// The response is the job, its progress is looked up at the URL of the Location header.
+func (h *Handler) ImportOrders(ctx *gin.Context) {
+	format, ok := formatOf(ctx.ContentType())
+	if !ok {
+		ctx.Error(common.NewAPIError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "upload a text/csv or application/x-ndjson file"))
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadBytes())
+	rows, err := parse(format, body, maxRows())
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		ctx.Error(common.NewAPIError(http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", fmt.Sprintf("the file is larger than %d bytes", tooLarge.Limit)))
+		return
+	case errors.Is(err, errTooManyRows):
+		ctx.Error(common.BadRequest(fmt.Sprintf("the file has more than %d rows", maxRows())))
+		return
+	case err != nil:
+		ctx.Error(common.BadRequest(err.Error()))
+		return
+	case len(rows) == 0:
+		ctx.Error(common.BadRequest("the file has no rows"))
+		return
+	}
+
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		ctx.Error(common.ServiceUnavailable("billing"))
+		return
+	}
+
+	var owner string
+	if principal, ok := auth.FromContext(ctx.Request.Context()); ok {
+		owner = principal.Subject
+	}
+	job, err := newJob(owner, format, rows, time.Now())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	h.jobs.add(job)
+
+	// The job outlives the request, its calls keep the principal and token of the upload
+	go job.run(context.WithoutCancel(ctx.Request.Context()), client.(billingPb.BillingServiceClient), rows, h.slots, callTimeout())
+
+	ctx.Header("Location", "/api/v1/jobs/"+job.id)
+	ctx.JSON(http.StatusAccepted, common.SuccessResponse(job.response()))
+}
+
+// GetJob returns the progress of an import job with the errors of its rows
+func (h *Handler) GetJob(ctx *gin.Context) {
+	job, ok := h.job(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, common.SuccessResponse(job.response()))
+}
+
+// DownloadFailedRows returns the rows of an import job that failed so far as a CSV file.
+// The file has the columns of a CSV upload followed by the row and its error, which are ignored when it is uploaded again.
+// Cells a spreadsheet would evaluate as formulas are prefixed with a quote, removed again on upload.
+func (h *Handler) DownloadFailedRows(ctx *gin.Context) {
+	job, ok := h.job(ctx)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := append(columns[:], "row", "error_reason", "error_message")
+	writer.Write(header)
+
+	job.mu.Lock()
+	for _, r := range job.failedRows() {
+		record := append(r.cells[:], strconv.Itoa(r.line), r.err.Reason, describe(r.err))
+		for i, cell := range record {
+			record[i] = escapeFormula(cell)
+		}
+		writer.Write(record)
+	}
+	job.mu.Unlock()
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="job-%s-failed-rows.csv"`, job.id))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// job returns the job of the id parameter, jobs of other callers are not found unless the caller is an admin
+func (h *Handler) job(ctx *gin.Context) (*Job, bool) {
+	job, ok := h.jobs.get(ctx.Param("id"))
+	if ok {
+		principal, authenticated := auth.FromContext(ctx.Request.Context())
+		ok = !authenticated || principal.HasRole(auth.RoleAdmin) || principal.Subject == job.owner
+	}
+	if !ok {
+		ctx.Error(common.NewAPIError(http.StatusNotFound, common.ReasonNotFound, "job not found"))
+		return nil, false
+	}
+	return job, true
+}
+
+// describe returns the message of an error with its field violations, for a CSV cell
+func describe(err *common.APIError) string {
+	parts := []string{err.Message}
+	for _, violation := range err.FieldViolations {
+		parts = append(parts, violation.Field+": "+violation.Description)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func concurrency() int {
+	if n := config.Service.BulkImport.Concurrency; n > 0 {
+		return n
+	}
+	return defaultConcurrency
+}
+
+func maxRows() int {
+	if n := config.Service.BulkImport.MaxRows; n > 0 {
+		return n
+	}
+	return defaultMaxRows
+}
+
+func maxUploadBytes() int64 {
+	if n := config.Service.BulkImport.MaxUploadBytes; n > 0 {
+		return n
+	}
+	return defaultMaxUploadBytes
+}
+
+func callTimeout() time.Duration {
+	if timeout := config.Service.BulkImport.CallTimeout; timeout > 0 {
+		return timeout
+	}
+	return defaultCallTimeout
+}
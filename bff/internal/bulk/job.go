@@ -0,0 +1,194 @@
+package bulk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"billing-system/bff/internal/billing"
+	"billing-system/bff/internal/common"
+	billingPb "billing-system/billing_service/proto"
+)
+
+// Statuses of a job, a completed job may have failed rows
+const (
+	StatusRunning   = "RUNNING"
+	StatusCompleted = "COMPLETED"
+)
+
+// Job is the import of the rows of an upload
+type Job struct {
+	id        string
+	owner     string
+	format    string
+	total     int
+	createdAt time.Time
+
+	mu         sync.Mutex
+	created    []CreatedOrder
+	failed     []*row
+	finishedAt time.Time
+}
+
+// newJob creates the job of the rows, rows that could not be read or are invalid fail at once
+func newJob(owner, format string, rows []*row, now time.Time) (*Job, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		id:        hex.EncodeToString(random),
+		owner:     owner,
+		format:    format,
+		total:     len(rows),
+		createdAt: now,
+	}
+	for _, r := range rows {
+		if r.err != nil {
+			job.failed = append(job.failed, r)
+		}
+	}
+	return job, nil
+}
+
+// run creates the orders of the valid rows, each call with its own deadline and holding one of the slots,
+// which are shared with the other jobs. The job is finished once every row was tried, a failed call only fails its row.
+func (j *Job) run(ctx context.Context, client billingPb.BillingServiceClient, rows []*row, slots chan struct{}, callTimeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, r := range rows {
+		if r.err != nil {
+			continue
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			callCtx, cancel := context.WithTimeout(ctx, callTimeout)
+			response, err := client.CreateOrder(callCtx, billing.ToProtoCreateOrderRequest(r.request))
+			cancel()
+			if err != nil {
+				r.err = common.AsAPIError(err)
+				j.fail(r)
+				return
+			}
+			j.succeed(r, response.Order.Id)
+		}()
+	}
+	wg.Wait()
+
+	j.mu.Lock()
+	j.finishedAt = time.Now()
+	j.mu.Unlock()
+}
+
+func (j *Job) succeed(r *row, orderID int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.created = append(j.created, CreatedOrder{Row: r.line, Reference: r.cells[columnReference], OrderID: orderID})
+}
+
+func (j *Job) fail(r *row) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.failed = append(j.failed, r)
+}
+
+// finished reports whether every row of the job was tried, and when the last one was
+func (j *Job) finished() (time.Time, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.finishedAt, !j.finishedAt.IsZero()
+}
+
+// response returns the progress of the job, its orders and errors in the order of their rows
+func (j *Job) response() JobResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	response := JobResponse{
+		ID:            j.id,
+		Status:        StatusRunning,
+		Format:        j.format,
+		TotalRows:     j.total,
+		ProcessedRows: len(j.created) + len(j.failed),
+		SucceededRows: len(j.created),
+		FailedRows:    len(j.failed),
+		CreatedAt:     j.createdAt.Format(time.RFC3339),
+		Orders:        slices.Clone(j.created),
+		Errors:        make([]RowError, len(j.failed)),
+	}
+	if response.Orders == nil {
+		response.Orders = []CreatedOrder{}
+	}
+	if !j.finishedAt.IsZero() {
+		response.Status = StatusCompleted
+		response.FinishedAt = j.finishedAt.Format(time.RFC3339)
+	}
+	for i, r := range j.failedRows() {
+		response.Errors[i] = RowError{
+			Row:             r.line,
+			Reference:       r.cells[columnReference],
+			Reason:          r.err.Reason,
+			Message:         r.err.Message,
+			FieldViolations: r.err.FieldViolations,
+		}
+	}
+	slices.SortFunc(response.Orders, func(a, b CreatedOrder) int { return a.Row - b.Row })
+	return response
+}
+
+// failedRows returns the failed rows in the order of their lines, the caller holds the lock
+func (j *Job) failedRows() []*row {
+	failed := slices.Clone(j.failed)
+	slices.SortFunc(failed, func(a, b *row) int { return a.line - b.line })
+	return failed
+}
+
+// Store keeps the jobs of the BFF in memory, finished jobs are dropped after the retention
+type Store struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewStore creates a store keeping finished jobs for the retention
+func NewStore(retention time.Duration) *Store {
+	return &Store{retention: retention, now: time.Now, jobs: make(map[string]*Job)}
+}
+
+// add stores a job and drops the jobs finished longer than the retention ago
+func (s *Store) add(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for id, stored := range s.jobs {
+		if finishedAt, ok := stored.finished(); ok && now.Sub(finishedAt) > s.retention {
+			delete(s.jobs, id)
+		}
+	}
+	s.jobs[job.id] = job
+}
+
+// get returns the job with the id, false when there is none or it expired
+func (s *Store) get(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	if finishedAt, done := job.finished(); done && s.now().Sub(finishedAt) > s.retention {
+		delete(s.jobs, id)
+		return nil, false
+	}
+	return job, true
+}
@@ -0,0 +1,147 @@
+package bulk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"billing-system/bff/internal/billing"
+	billingPb "billing-system/billing_service/proto"
+)
+
+// fakeBilling creates orders for every customer but CUST404 and records how many calls ran at once
+type fakeBilling struct {
+	billingPb.BillingServiceClient
+
+	mu      sync.Mutex
+	running int
+	peak    int
+	nextID  int64
+}
+
+func (f *fakeBilling) CreateOrder(_ context.Context, req *billingPb.CreateOrderRequest, _ ...grpc.CallOption) (*billingPb.CreateOrderResponse, error) {
+	f.mu.Lock()
+	f.running++
+	f.peak = max(f.peak, f.running)
+	f.nextID++
+	id := f.nextID
+	f.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	f.running--
+	f.mu.Unlock()
+	if req.CustomerId == "CUST404" {
+		return nil, status.Error(codes.NotFound, "customer not found")
+	}
+	return &billingPb.CreateOrderResponse{Order: &billingPb.Order{Id: id, CustomerId: req.CustomerId}}, nil
+}
+
+func TestJob_Run(t *testing.T) {
+	var rows []*row
+	for line := 2; line < 12; line++ {
+		customerID := "CUST001"
+		if line == 5 {
+			customerID = "CUST404"
+		}
+		rows = append(rows, &row{line: line, request: billing.CreateOrderRequest{CustomerID: customerID}})
+	}
+	invalid := &row{line: 12}
+	invalid.cells[columnReference] = "A-12"
+	invalid.readCells()
+	rows = append(rows, invalid)
+
+	job, err := newJob("user-1", FormatCSV, rows, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response := job.response(); response.Status != StatusRunning || response.FailedRows != 1 || response.ProcessedRows != 1 {
+		t.Errorf("before run = %+v, want a running job with the invalid row failed", response)
+	}
+
+	client := &fakeBilling{}
+	job.run(context.Background(), client, rows, make(chan struct{}, 3), time.Second)
+
+	response := job.response()
+	if response.Status != StatusCompleted || response.FinishedAt == "" {
+		t.Errorf("status = %s, finished at %q, want a completed job", response.Status, response.FinishedAt)
+	}
+	if response.TotalRows != 11 || response.ProcessedRows != 11 || response.SucceededRows != 9 || response.FailedRows != 2 {
+		t.Errorf("rows = %d total, %d processed, %d succeeded, %d failed, want 11, 11, 9 and 2",
+			response.TotalRows, response.ProcessedRows, response.SucceededRows, response.FailedRows)
+	}
+	if client.peak > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", client.peak)
+	}
+	for i := 1; i < len(response.Orders); i++ {
+		if response.Orders[i-1].Row >= response.Orders[i].Row {
+			t.Fatalf("orders = %+v, want them in the order of their rows", response.Orders)
+		}
+	}
+
+	if len(response.Errors) != 2 {
+		t.Fatalf("errors = %+v, want 2", response.Errors)
+	}
+	if e := response.Errors[0]; e.Row != 5 || e.Reason != "NOT_FOUND" {
+		t.Errorf("first error = %+v, want the NOT_FOUND of the billing service at row 5", e)
+	}
+	if e := response.Errors[1]; e.Row != 12 || e.Reference != "A-12" || e.Reason != "INVALID_REQUEST" {
+		t.Errorf("second error = %+v, want the invalid row 12", e)
+	}
+}
+
+func TestJob_RunSharesSlots(t *testing.T) {
+	client := &fakeBilling{}
+	slots := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for range 3 {
+		var rows []*row
+		for line := 2; line < 8; line++ {
+			rows = append(rows, &row{line: line, request: billing.CreateOrderRequest{CustomerID: "CUST001"}})
+		}
+		job, err := newJob("user-1", FormatCSV, rows, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.run(context.Background(), client, rows, slots, time.Second)
+		}()
+	}
+	wg.Wait()
+
+	if client.peak > 2 {
+		t.Errorf("peak concurrency of 3 jobs = %d, want at most the 2 shared slots", client.peak)
+	}
+	if client.nextID != 18 {
+		t.Errorf("orders created = %d, want 18", client.nextID)
+	}
+}
+
+func TestStore_Retention(t *testing.T) {
+	now := time.Now()
+	store := NewStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	store.add(&Job{id: "finished", finishedAt: now.Add(-2 * time.Hour)})
+	store.add(&Job{id: "running"})
+	if _, ok := store.jobs["finished"]; ok {
+		t.Error("a job finished before the retention was kept")
+	}
+	if _, ok := store.get("running"); !ok {
+		t.Error("a running job was not found")
+	}
+
+	// Jobs expire on lookup too, before the next job is added
+	store.jobs["finished"] = &Job{id: "finished", finishedAt: now.Add(-2 * time.Hour)}
+	if _, ok := store.get("finished"); ok {
+		t.Error("a job finished before the retention was found")
+	}
+}
@@ -0,0 +1,34 @@
+package bulk
+
+import "billing-system/bff/internal/common"
+
+// JobResponse is the progress of an import job, rows are numbered by their line in the file
+type JobResponse struct {
+	ID            string         `json:"id"`
+	Status        string         `json:"status"`
+	Format        string         `json:"format"`
+	TotalRows     int            `json:"total_rows"`
+	ProcessedRows int            `json:"processed_rows"`
+	SucceededRows int            `json:"succeeded_rows"`
+	FailedRows    int            `json:"failed_rows"`
+	CreatedAt     string         `json:"created_at"`
+	FinishedAt    string         `json:"finished_at,omitempty"`
+	Orders        []CreatedOrder `json:"orders"`
+	Errors        []RowError     `json:"errors"`
+}
+
+// CreatedOrder is the order created from a row
+type CreatedOrder struct {
+	Row       int    `json:"row"`
+	Reference string `json:"reference,omitempty"`
+	OrderID   int64  `json:"order_id"`
+}
+
+// RowError is why a row was not imported, with the reason and field violations of the validation or of the billing service
+type RowError struct {
+	Row             int                     `json:"row"`
+	Reference       string                  `json:"reference,omitempty"`
+	Reason          string                  `json:"reason"`
+	Message         string                  `json:"message"`
+	FieldViolations []common.FieldViolation `json:"field_violations,omitempty"`
+}
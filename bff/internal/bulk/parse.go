@@ -0,0 +1,296 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"billing-system/bff/internal/billing"
+	"billing-system/bff/internal/common"
+)
+
+// Formats of an upload
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// mediaTypes maps the media types of an upload to its format
+var mediaTypes = map[string]string{
+	"text/csv":             FormatCSV,
+	"application/x-ndjson": FormatJSONL,
+	"application/jsonl":    FormatJSONL,
+}
+
+// Columns of a CSV upload, also the first columns of the failed rows so they can be uploaded again once fixed.
+// Items are written as sku:quantity and payments as method:amount, several separated by semicolons.
+const (
+	columnReference = iota
+	columnCustomerID
+	columnItems
+	columnPayments
+	columnQuoteToken
+	columnCount
+)
+
+var columns = [columnCount]string{"reference", "customer_id", "items", "payments", "quote_token"}
+
+// maxLineBytes bounds a line of a JSONL upload
+const maxLineBytes = 1 << 20
+
+// errTooManyRows is returned by the parsers when an upload holds more rows than allowed
+var errTooManyRows = errors.New("too many rows")
+
+// row is an order of an upload. A row that cannot be read or is invalid carries its error and is not imported.
+type row struct {
+	// line is the line of the row in the file, the header is line 1 of a CSV upload
+	line    int
+	cells   [columnCount]string
+	request billing.CreateOrderRequest
+	err     *common.APIError
+}
+
+// formatOf returns the format of an upload with the Content-Type, false for unsupported media types
+func formatOf(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	format, ok := mediaTypes[mediaType]
+	return format, ok
+}
+
+// parse reads the rows of an upload, an error means the whole upload cannot be read
+func parse(format string, r io.Reader, maxRows int) ([]*row, error) {
+	if format == FormatCSV {
+		return parseCSV(r, maxRows)
+	}
+	return parseJSONL(r, maxRows)
+}
+
+// parseCSV reads a CSV upload with a header row. Columns are found by name, in any order, and unknown columns are ignored.
+func parseCSV(r io.Reader, maxRows int) ([]*row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	// Spreadsheets may start the file with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	positions := [columnCount]int{}
+	for i := range positions {
+		positions[i] = -1
+	}
+	for i, name := range header {
+		for column, known := range columns {
+			if strings.EqualFold(strings.TrimSpace(name), known) {
+				positions[column] = i
+			}
+		}
+	}
+	if positions[columnCustomerID] < 0 || positions[columnPayments] < 0 {
+		return nil, errors.New("the CSV header must name the customer_id and payments columns")
+	}
+	if positions[columnItems] < 0 && positions[columnQuoteToken] < 0 {
+		return nil, errors.New("the CSV header must name the items or quote_token column")
+	}
+	// Rows may have fewer or more cells than the header, missing cells are empty
+	reader.FieldsPerRecord = -1
+
+	var rows []*row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if blank(record) {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, errTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		parsed := &row{line: line}
+		for column, position := range positions {
+			if position >= 0 && position < len(record) {
+				parsed.cells[column] = unescapeFormula(strings.TrimSpace(record[position]))
+			}
+		}
+		parsed.readCells()
+		rows = append(rows, parsed)
+	}
+}
+
+// readCells sets the request of a CSV row from its cells and validates it
+func (r *row) readCells() {
+	r.request = billing.CreateOrderRequest{
+		CustomerID: r.cells[columnCustomerID],
+		QuoteToken: r.cells[columnQuoteToken],
+	}
+
+	var violations []common.FieldViolation
+	for i, entry := range split(r.cells[columnItems]) {
+		sku, quantity, ok := cutLast(entry)
+		n, err := strconv.Atoi(quantity)
+		if !ok || err != nil {
+			violations = append(violations, common.FieldViolation{
+				Field:       fmt.Sprintf("items[%d]", i),
+				Reason:      "INVALID_FORMAT",
+				Description: fmt.Sprintf("%q is not sku:quantity", entry),
+			})
+			continue
+		}
+		r.request.Items = append(r.request.Items, billing.ItemRequest{Sku: sku, Quantity: n})
+	}
+	for i, entry := range split(r.cells[columnPayments]) {
+		method, amount, ok := cutLast(entry)
+		value, err := strconv.ParseFloat(amount, 64)
+		if !ok || err != nil {
+			violations = append(violations, common.FieldViolation{
+				Field:       fmt.Sprintf("payments[%d]", i),
+				Reason:      "INVALID_FORMAT",
+				Description: fmt.Sprintf("%q is not method:amount", entry),
+			})
+			continue
+		}
+		r.request.Payments = append(r.request.Payments, billing.PaymentRequest{Method: method, Amount: value})
+	}
+
+	if len(violations) > 0 {
+		r.err = common.BadRequest("invalid row")
+		r.err.FieldViolations = violations
+		return
+	}
+	r.validate()
+}
+
+// parseJSONL reads a JSONL upload, each line is the body of an order creation with an optional reference
+func parseJSONL(r io.Reader, maxRows int) ([]*row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	var rows []*row
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, errTooManyRows
+		}
+
+		parsed := &row{line: line}
+		var decoded struct {
+			billing.CreateOrderRequest
+			Reference string `json:"reference"`
+		}
+		if err := json.Unmarshal(text, &decoded); err != nil {
+			parsed.err = common.BindError(err)
+			parsed.err.Message = "invalid row: " + parsed.err.Message
+		} else {
+			parsed.request = decoded.CreateOrderRequest
+			parsed.cells = cellsOf(decoded.Reference, decoded.CreateOrderRequest)
+			parsed.validate()
+		}
+		rows = append(rows, parsed)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid JSONL: %w", err)
+	}
+	return rows, nil
+}
+
+// validate checks the request of a row like the body of an order creation
+func (r *row) validate() {
+	if err := binding.Validator.ValidateStruct(&r.request); err != nil {
+		r.err = common.BindError(err)
+		r.err.Message = "invalid row"
+	}
+}
+
+// cellsOf returns the CSV cells of a request, for the failed rows of JSONL uploads
+func cellsOf(reference string, request billing.CreateOrderRequest) [columnCount]string {
+	items := make([]string, len(request.Items))
+	for i, item := range request.Items {
+		items[i] = item.Sku + ":" + strconv.Itoa(item.Quantity)
+	}
+	payments := make([]string, len(request.Payments))
+	for i, payment := range request.Payments {
+		payments[i] = payment.Method + ":" + strconv.FormatFloat(payment.Amount, 'f', -1, 64)
+	}
+
+	var cells [columnCount]string
+	cells[columnReference] = reference
+	cells[columnCustomerID] = request.CustomerID
+	cells[columnItems] = strings.Join(items, ";")
+	cells[columnPayments] = strings.Join(payments, ";")
+	cells[columnQuoteToken] = request.QuoteToken
+	return cells
+}
+
+// split returns the non-empty entries of a cell separated by semicolons
+func split(cell string) []string {
+	var entries []string
+	for _, entry := range strings.Split(cell, ";") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// cutLast splits an entry around its last colon, so SKUs may contain colons
+func cutLast(entry string) (string, string, bool) {
+	i := strings.LastIndex(entry, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:]), true
+}
+
+// formulaPrefixes start the cells spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@"
+
+// escapeFormula prefixes a cell that a spreadsheet would evaluate with a quote, so it is shown as text
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// unescapeFormula removes the quote escapeFormula added, so a downloaded file can be uploaded again
+func unescapeFormula(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+func blank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
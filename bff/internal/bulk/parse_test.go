@@ -0,0 +1,177 @@
+package bulk
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"billing-system/bff/internal/common"
+)
+
+// TestMain names fields as the router does, before the first validation caches the names of the requests
+func TestMain(m *testing.M) {
+	common.UseJSONFieldNames()
+	os.Exit(m.Run())
+}
+
+func TestParseCSV(t *testing.T) {
+	// Columns in any order, a byte order mark, an unknown column, a blank line and a short row
+	file := "\ufeffcustomer_id,payments,note,items,reference\n" +
+		"CUST001,card:20;voucher:5.5,ignored,SKU-1:3;SKU:2:1,A-1\n" +
+		"\n" +
+		"CUST002,card:10,,SKU-1:0,A-2\n" +
+		"CUST003,card:ten,,SKU-1\n" +
+		"CUST004\n"
+	rows, err := parseCSV(strings.NewReader(file), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("rows = %d, want 4", len(rows))
+	}
+
+	first := rows[0]
+	if first.err != nil {
+		t.Fatalf("first row failed: %v", first.err)
+	}
+	if first.line != 2 || first.cells[columnReference] != "A-1" || first.request.CustomerID != "CUST001" {
+		t.Errorf("first row = line %d, cells %q, want line 2 of CUST001 with reference A-1", first.line, first.cells)
+	}
+	items, payments := first.request.Items, first.request.Payments
+	if len(items) != 2 || items[1].Sku != "SKU:2" || items[1].Quantity != 1 || len(payments) != 2 || payments[1].Amount != 5.5 {
+		t.Errorf("items = %+v, payments = %+v", items, payments)
+	}
+
+	tests := []struct {
+		row    *row
+		line   int
+		fields []string
+	}{
+		{rows[1], 4, []string{"items[0].quantity"}},
+		{rows[2], 5, []string{"items[0]", "payments[0]"}},
+		{rows[3], 6, []string{"items", "payments"}},
+	}
+	for _, tt := range tests {
+		if tt.row.line != tt.line || tt.row.err == nil || tt.row.err.Reason != common.ReasonInvalidRequest {
+			t.Errorf("row at line %d = line %d, err %v, want an invalid row at line %d", tt.line, tt.row.line, tt.row.err, tt.line)
+			continue
+		}
+		var fields []string
+		for _, violation := range tt.row.err.FieldViolations {
+			fields = append(fields, violation.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(tt.fields, ",") {
+			t.Errorf("row at line %d violations = %v, want %v", tt.line, fields, tt.fields)
+		}
+	}
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "Empty", file: ""},
+		{name: "Missing columns", file: "customer_id,items\nCUST001,SKU-1:1\n"},
+		{name: "Unterminated quote", file: "customer_id,items,payments\n\"CUST001,SKU-1:1,card:10\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCSV(strings.NewReader(tt.file), 10); err == nil {
+				t.Error("parseCSV succeeded")
+			}
+		})
+	}
+
+	file := "customer_id,items,payments\nCUST001,SKU-1:1,card:10\nCUST001,SKU-1:1,card:10\n"
+	if _, err := parseCSV(strings.NewReader(file), 1); !errors.Is(err, errTooManyRows) {
+		t.Errorf("err = %v, want errTooManyRows", err)
+	}
+}
+
+func TestParseJSONL(t *testing.T) {
+	file := `{"reference":"A-1","customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":3}],"payments":[{"method":"card","amount":30}]}` + "\n" +
+		"\n" +
+		`{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":"3"}]}` + "\n" +
+		`{"customer_id":"CUST001","payments":[{"method":"card","amount":30}]}` + "\n" +
+		`not json` + "\n"
+	rows, err := parseJSONL(strings.NewReader(file), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("rows = %d, want 4", len(rows))
+	}
+
+	if rows[0].err != nil || rows[0].line != 1 {
+		t.Fatalf("first row = line %d, err %v, want a valid row at line 1", rows[0].line, rows[0].err)
+	}
+	if cells := rows[0].cells; cells[columnReference] != "A-1" || cells[columnItems] != "SKU-1:3" || cells[columnPayments] != "card:30" {
+		t.Errorf("cells = %q, want the row written as CSV cells", cells)
+	}
+	for i, line := range []int{3, 4, 5} {
+		if r := rows[i+1]; r.line != line || r.err == nil {
+			t.Errorf("row %d = line %d, err %v, want an invalid row at line %d", i+1, r.line, r.err, line)
+		}
+	}
+	if violations := rows[2].err.FieldViolations; len(violations) != 1 || violations[0].Field != "items" {
+		t.Errorf("violations = %+v, want items required without a quote token", violations)
+	}
+
+	if _, err := parseJSONL(strings.NewReader(file), 2); !errors.Is(err, errTooManyRows) {
+		t.Errorf("err = %v, want errTooManyRows", err)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		contentType string
+		format      string
+		ok          bool
+	}{
+		{"text/csv; charset=utf-8", FormatCSV, true},
+		{"application/x-ndjson", FormatJSONL, true},
+		{"application/jsonl", FormatJSONL, true},
+		{"application/json", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if format, ok := formatOf(tt.contentType); format != tt.format || ok != tt.ok {
+			t.Errorf("formatOf(%q) = %q, %v, want %q, %v", tt.contentType, format, ok, tt.format, tt.ok)
+		}
+	}
+}
+
+func TestFormulaEscaping(t *testing.T) {
+	tests := []struct {
+		cell    string
+		escaped string
+	}{
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"+1", "'+1"},
+		{"-2", "'-2"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"A-1", "A-1"},
+		{"'quoted", "'quoted"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		escaped := escapeFormula(tt.cell)
+		if escaped != tt.escaped {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.cell, escaped, tt.escaped)
+		}
+		if cell := unescapeFormula(escaped); cell != tt.cell {
+			t.Errorf("unescapeFormula(%q) = %q, want %q", escaped, cell, tt.cell)
+		}
+	}
+
+	// An escaped reference is read back without its quote
+	rows, err := parseCSV(strings.NewReader("reference,customer_id,items,payments\n'=A1,CUST001,SKU-1:1,card:10\n"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reference := rows[0].cells[columnReference]; reference != "=A1" {
+		t.Errorf("reference = %q, want =A1", reference)
+	}
+}
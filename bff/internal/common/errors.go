@@ -0,0 +1,206 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the domain of the errors raised by the BFF itself, the services report their own
+const Domain = "bff.billing-system"
+
+// Reasons of the errors raised by the BFF itself
+const (
+	ReasonInvalidRequest     = "INVALID_REQUEST"
+	ReasonNotFound           = "NOT_FOUND"
+	ReasonServiceUnavailable = "SERVICE_UNAVAILABLE"
+	ReasonInternal           = "INTERNAL"
+)
+
+// ErrorEnvelope is the body of every error response
+type ErrorEnvelope struct {
+	Error *APIError `json:"error"`
+}
+
+// APIError is an error returned to API clients.
+// Reason is machine-readable and stable, Message is for humans and may change.
+type APIError struct {
+	Code            int               `json:"code"`
+	Reason          string            `json:"reason"`
+	Message         string            `json:"message"`
+	Domain          string            `json:"domain"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	FieldViolations []FieldViolation  `json:"field_violations,omitempty"`
+}
+
+// FieldViolation describes why a field of the request is invalid, Field is its JSON path such as items[0].quantity
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Reason      string `json:"reason"`
+	Description string `json:"description"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Code, e.Reason, e.Message)
+}
+
+// NewAPIError returns an error raised by the BFF itself
+func NewAPIError(code int, reason, message string) *APIError {
+	return &APIError{Code: code, Reason: reason, Message: message, Domain: Domain}
+}
+
+// BadRequest returns the error of a request the BFF cannot read, such as an id that is not a number
+func BadRequest(message string) *APIError {
+	return NewAPIError(http.StatusBadRequest, ReasonInvalidRequest, message)
+}
+
+// ServiceUnavailable returns the error of a service the BFF cannot connect to
+func ServiceUnavailable(service string) *APIError {
+	return NewAPIError(http.StatusServiceUnavailable, ReasonServiceUnavailable, fmt.Sprintf("%s service is unavailable, try again later", service))
+}
+
+// BindError converts an error of binding the request body or query to a bad request with a violation per invalid field
+func BindError(err error) *APIError {
+	apiErr := BadRequest("invalid request")
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			apiErr.FieldViolations = append(apiErr.FieldViolations, FieldViolation{
+				Field:       fieldPath(fieldErr.Namespace()),
+				Reason:      strings.ToUpper(fieldErr.Tag()),
+				Description: fieldErr.Error(),
+			})
+		}
+	case errors.As(err, &typeErr):
+		apiErr.FieldViolations = []FieldViolation{{
+			Field:       typeErr.Field,
+			Reason:      "INVALID_TYPE",
+			Description: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+		}}
+	default:
+		apiErr.Message = err.Error()
+	}
+	return apiErr
+}
+
+// fieldPath drops the struct name from the namespace of a validation error
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+// UseJSONFieldNames makes validation errors name fields as clients send them, by their json or form tag
+func UseJSONFieldNames() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+}
+
+// FromGRPC converts the status of a failed service call, with the reason and field violations of its details.
+// Server errors without details are not described, their message may reveal internals.
+func FromGRPC(err error) *APIError {
+	st := status.Convert(err)
+	apiErr := &APIError{
+		Code:    HTTPStatusFromGRPC(st.Code()),
+		Reason:  reasonFromCode(st.Code()),
+		Message: st.Message(),
+		Domain:  Domain,
+	}
+
+	described := false
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			described = true
+			apiErr.Reason = detail.Reason
+			apiErr.Domain = detail.Domain
+			apiErr.Metadata = detail.Metadata
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				apiErr.FieldViolations = append(apiErr.FieldViolations, FieldViolation{
+					Field:       violation.Field,
+					Reason:      violation.Reason,
+					Description: violation.Description,
+				})
+			}
+		}
+	}
+	if !described && apiErr.Code >= http.StatusInternalServerError {
+		apiErr.Message = http.StatusText(apiErr.Code)
+	}
+	return apiErr
+}
+
+// HTTPStatusFromGRPC returns the HTTP status of a gRPC code.
+// Invalid arguments are unprocessable, the request was read but the services rejected it.
+func HTTPStatusFromGRPC(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusUnprocessableEntity
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// reasonFromCode returns the reason of a status without ErrorInfo, such as NOT_FOUND for NotFound
+func reasonFromCode(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// AsAPIError returns the API error of err, gRPC statuses are converted and anything else is an internal error
+func AsAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	if _, ok := status.FromError(err); ok {
+		return FromGRPC(err)
+	}
+	return NewAPIError(http.StatusInternalServerError, ReasonInternal, http.StatusText(http.StatusInternalServerError))
+}
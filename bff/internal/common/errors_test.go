@@ -0,0 +1,103 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromGRPC(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid shipment items").WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_ITEMS", Domain: "shipment.billing-system", Metadata: map[string]string{"created_shipment_ids": "4"}},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "items[1].quantity", Reason: "EXCEEDS_REMAINING", Description: "line 1: quantity 5 for SKU SKU001 exceeds the 3 left to ship"},
+		}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiErr := FromGRPC(st.Err())
+	if apiErr.Code != http.StatusUnprocessableEntity || apiErr.Reason != "INVALID_ITEMS" || apiErr.Domain != "shipment.billing-system" {
+		t.Errorf("error = %+v, want 422 INVALID_ITEMS of the shipment service", apiErr)
+	}
+	if apiErr.Metadata["created_shipment_ids"] != "4" {
+		t.Errorf("metadata = %v", apiErr.Metadata)
+	}
+	if len(apiErr.FieldViolations) != 1 || apiErr.FieldViolations[0].Field != "items[1].quantity" || apiErr.FieldViolations[0].Reason != "EXCEEDS_REMAINING" {
+		t.Errorf("field violations = %+v", apiErr.FieldViolations)
+	}
+}
+
+func TestFromGRPC_WithoutDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantReason  string
+		wantMessage string
+	}{
+		{name: "Not found", err: status.Error(codes.NotFound, "shipment not found"), wantCode: http.StatusNotFound, wantReason: "NOT_FOUND", wantMessage: "shipment not found"},
+		{name: "Failed precondition", err: status.Error(codes.FailedPrecondition, "quote expired"), wantCode: http.StatusConflict, wantReason: "FAILED_PRECONDITION", wantMessage: "quote expired"},
+		{name: "Unavailable hides the transport error", err: status.Error(codes.Unavailable, "dial tcp 10.0.0.3:50051: connection refused"), wantCode: http.StatusServiceUnavailable, wantReason: "UNAVAILABLE", wantMessage: "Service Unavailable"},
+		{name: "Deadline exceeded", err: status.Error(codes.DeadlineExceeded, "context deadline exceeded"), wantCode: http.StatusServiceUnavailable, wantReason: "DEADLINE_EXCEEDED", wantMessage: "Service Unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := FromGRPC(tt.err)
+			if apiErr.Code != tt.wantCode || apiErr.Reason != tt.wantReason || apiErr.Message != tt.wantMessage {
+				t.Errorf("error = %+v, want %d %s %q", apiErr, tt.wantCode, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestBindError(t *testing.T) {
+	UseJSONFieldNames()
+
+	type item struct {
+		Sku      string `json:"sku" binding:"required"`
+		Quantity int    `json:"quantity" binding:"required,min=1"`
+	}
+	type request struct {
+		OrderID int64  `json:"order_id" binding:"required"`
+		Items   []item `json:"items" binding:"required,dive"`
+	}
+
+	err := binding.Validator.ValidateStruct(&request{Items: []item{{Sku: "SKU001", Quantity: 1}, {Quantity: -1}}})
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	apiErr := BindError(err)
+	if apiErr.Code != http.StatusBadRequest || apiErr.Reason != ReasonInvalidRequest {
+		t.Errorf("error = %+v, want 400 %s", apiErr, ReasonInvalidRequest)
+	}
+	want := map[string]string{"order_id": "REQUIRED", "items[1].sku": "REQUIRED", "items[1].quantity": "MIN"}
+	if len(apiErr.FieldViolations) != len(want) {
+		t.Fatalf("field violations = %+v, want %v", apiErr.FieldViolations, want)
+	}
+	for _, violation := range apiErr.FieldViolations {
+		if want[violation.Field] != violation.Reason {
+			t.Errorf("violation %+v not expected, want %v", violation, want)
+		}
+	}
+}
+
+func TestAsAPIError(t *testing.T) {
+	if apiErr := AsAPIError(ServiceUnavailable("billing")); apiErr.Code != http.StatusServiceUnavailable || apiErr.Reason != ReasonServiceUnavailable {
+		t.Errorf("API error = %+v, want it returned as is", apiErr)
+	}
+	if apiErr := AsAPIError(status.Error(codes.NotFound, "order not found")); apiErr.Code != http.StatusNotFound {
+		t.Errorf("status error = %+v, want 404", apiErr)
+	}
+	if apiErr := AsAPIError(errors.New("boom")); apiErr.Code != http.StatusInternalServerError || apiErr.Message == "boom" {
+		t.Errorf("plain error = %+v, want 500 without its message", apiErr)
+	}
+}
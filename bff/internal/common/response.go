@@ -20,8 +20,3 @@ func NewResponse(code int, message string, data interface{}) StandardResponse {
 func SuccessResponse(data interface{}) StandardResponse {
 	return NewResponse(200, "success", data)
 }
-
-// ErrorResponse creates a standard error response
-func ErrorResponse(code int, message string) StandardResponse {
-	return NewResponse(code, message, nil)
-}
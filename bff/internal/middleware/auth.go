@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"billing-system/bff/internal/apikey"
+	"billing-system/bff/internal/common"
+	"billing-system/pkg/auth"
+)
+
+// Authenticate verifies the bearer token or API key of the request and puts its principal in the request context,
+// where gRPC calls made with the request find the token to forward. A nil verifier lets every request through,
+// a nil authenticator refuses API keys.
+func Authenticate(verifier *auth.Verifier, apiKeys *apikey.Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if verifier == nil {
+			ctx.Next()
+			return
+		}
+
+		if key := apiKey(ctx.GetHeader("Authorization")); key != "" && apiKeys != nil {
+			authenticateAPIKey(ctx, apiKeys, key)
+			return
+		}
+
+		token := auth.BearerToken(ctx.GetHeader("Authorization"))
+		if token == "" {
+			unauthenticated(ctx, "TOKEN_MISSING", "a bearer token is required")
+			return
+		}
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			reason := "INVALID_TOKEN"
+			if errors.Is(err, auth.ErrExpiredToken) {
+				reason = "TOKEN_EXPIRED"
+			}
+			unauthenticated(ctx, reason, err.Error())
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), principal, token))
+		ctx.Next()
+	}
+}
+
+// authenticateAPIKey puts the principal of an API key and the token signed for it in the request context.
+// An unknown, expired or revoked key is a 401 with the reason of the billing service.
+func authenticateAPIKey(ctx *gin.Context, apiKeys *apikey.Authenticator, key string) {
+	principal, token, err := apiKeys.Authenticate(ctx.Request.Context(), key)
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			ctx.Header("WWW-Authenticate", `ApiKey realm="billing-system"`)
+		}
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), principal, token))
+	ctx.Next()
+}
+
+// apiKey returns the key of an Authorization header with the ApiKey scheme, empty for other schemes
+func apiKey(header string) string {
+	if scheme, key, ok := strings.Cut(header, " "); ok && strings.EqualFold(scheme, "ApiKey") {
+		return strings.TrimSpace(key)
+	}
+	return ""
+}
+
+// unauthenticated aborts the request with a 401 challenging the client for a bearer token
+func unauthenticated(ctx *gin.Context, reason, message string) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="billing-system"`)
+	ctx.Error(common.NewAPIError(http.StatusUnauthorized, reason, message))
+	ctx.Abort()
+}
+
+// Require aborts requests whose principal lacks the permission.
+// Requests without a principal pass, Authenticate only lets them through when authentication is disabled.
+func Require(permission auth.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := auth.FromContext(ctx.Request.Context())
+		if ok && !principal.Can(permission) {
+			apiErr := common.NewAPIError(http.StatusForbidden, "PERMISSION_DENIED", "permission denied")
+			apiErr.Metadata = map[string]string{"permission": string(permission)}
+			ctx.Error(apiErr)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	"billing-system/pkg/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := auth.Config{Enabled: true, StaticKeys: []auth.StaticKeyConfig{{ID: "dev", Secret: "secret"}}}
+	verifier, err := auth.NewVerifier(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/orders", Authenticate(verifier, nil), Require(auth.PermissionOrdersRead), func(ctx *gin.Context) {
+		principal, _ := auth.FromContext(ctx.Request.Context())
+		ctx.String(http.StatusOK, principal.Subject)
+	})
+
+	token := func(roles ...string) string {
+		signed, err := auth.SignHS256(auth.Claims{Subject: "user-1", ExpiresAt: time.Now().Add(time.Hour).Unix(), Roles: roles}, "dev", []byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantReason    string
+	}{
+		{name: "Permitted", authorization: token("customer"), wantStatus: http.StatusOK},
+		{name: "Missing token", wantStatus: http.StatusUnauthorized, wantReason: "TOKEN_MISSING"},
+		{name: "Invalid token", authorization: "Bearer abc.def.ghi", wantStatus: http.StatusUnauthorized, wantReason: "INVALID_TOKEN"},
+		{name: "Role without the permission", authorization: token("unknown"), wantStatus: http.StatusForbidden, wantReason: "PERMISSION_DENIED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantReason == "" {
+				return
+			}
+			var envelope common.ErrorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil || envelope.Error.Reason != tt.wantReason {
+				t.Errorf("body = %s, want reason %s", rec.Body, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Package middleware holds the gin middleware shared by the BFF routes
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+)
+
+// ErrorHandler writes the error a handler added with ctx.Error as an error envelope.
+// Handlers that already wrote a response, such as a download failing midway, are left as they are.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		err := ctx.Errors.Last().Err
+		apiErr := common.AsAPIError(err)
+		if apiErr.Code >= http.StatusInternalServerError {
+			log.Printf("%s %s failed: %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+		}
+		ctx.JSON(apiErr.Code, common.ErrorEnvelope{Error: apiErr})
+	}
+}
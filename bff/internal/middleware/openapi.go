@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/openapi"
+)
+
+// maxValidatedResponseBytes bounds the response bodies kept for validation, downloads are larger and not JSON
+const maxValidatedResponseBytes = 1 << 20
+
+// ValidateRequests rejects requests whose parameters or JSON body do not match the operation of their route in the specification.
+// Routes missing from the specification pass, the drift test of the router keeps every route documented.
+func ValidateRequests(spec *openapi.Spec) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		op, ok := spec.Operation(ctx.Request.Method, ctx.FullPath())
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		params := make(map[string]string, len(ctx.Params))
+		for _, param := range ctx.Params {
+			params[param.Key] = param.Value
+		}
+		violations, err := spec.ValidateRequest(op, ctx.Request, params)
+		if err != nil {
+			ctx.Error(common.BadRequest(err.Error()))
+			ctx.Abort()
+			return
+		}
+		if len(violations) > 0 {
+			apiErr := common.BadRequest("request does not match the API specification")
+			for _, violation := range violations {
+				apiErr.FieldViolations = append(apiErr.FieldViolations, common.FieldViolation{
+					Field:       violation.Field,
+					Reason:      violation.Reason,
+					Description: violation.Description,
+				})
+			}
+			ctx.Error(apiErr)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// ValidateResponses logs the responses that do not match the specification, it is meant for development.
+// It must be used before ErrorHandler so the error envelopes are checked too.
+func ValidateResponses(spec *openapi.Spec) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		op, ok := spec.Operation(ctx.Request.Method, ctx.FullPath())
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		if recorder.truncated {
+			return
+		}
+		violations := spec.ValidateResponse(op, recorder.Status(), recorder.Header(), recorder.body.Bytes())
+		if len(violations) > 0 {
+			described := make([]string, len(violations))
+			for i, violation := range violations {
+				described[i] = violation.String()
+			}
+			log.Printf("%s %s response %d does not match the API specification: %s",
+				ctx.Request.Method, ctx.FullPath(), recorder.Status(), strings.Join(described, "; "))
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the response body as it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.record(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.record([]byte(s))
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Unwrap lets http.ResponseController reach the connection, to set the write deadlines of event streams
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *responseRecorder) record(data []byte) {
+	if r.truncated || r.body.Len()+len(data) > maxValidatedResponseBytes {
+		r.truncated = true
+		return
+	}
+	r.body.Write(data)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/ratelimit"
+	"billing-system/pkg/auth"
+)
+
+// RateLimit takes a token from the bucket of the client for the route of each request, and rejects the request
+// with 429 when the bucket is empty. Responses carry the RateLimit headers of the IETF draft, rejections Retry-After.
+// When the store fails the request passes, so an unreachable Redis does not take the API down. A nil limiter lets every request through.
+func RateLimit(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return rateLimit(limiter, ClientKey)
+}
+
+// RateLimitByAddress limits the requests of each IP address whether they are authenticated or not.
+// Placed before Authenticate, it throttles clients trying invalid tokens or API keys,
+// whose verification would otherwise run unlimited. It behaves like RateLimit otherwise.
+func RateLimitByAddress(limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return rateLimit(limiter, func(ctx *gin.Context) string {
+		return "address:" + ctx.ClientIP()
+	})
+}
+
+func rateLimit(limiter *ratelimit.Limiter, clientKey func(*gin.Context) string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if limiter == nil {
+			ctx.Next()
+			return
+		}
+
+		result, err := limiter.Allow(ctx.Request.Context(), ctx.Request.Method, ctx.FullPath(), clientKey(ctx))
+		if err != nil {
+			log.Printf("Rate limit of %s %s not applied: %v", ctx.Request.Method, ctx.FullPath(), err)
+			ctx.Next()
+			return
+		}
+
+		limit := result.Limit
+		ctx.Header("RateLimit-Limit", strconv.Itoa(limit.Capacity()))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s;burst=%d", limit.Requests, ceilSeconds(limit.Per), limit.Capacity()))
+		if !result.Allowed {
+			ctx.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			ctx.Error(common.NewAPIError(http.StatusTooManyRequests, "RATE_LIMITED", "too many requests, retry after the time in Retry-After"))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// ClientKey identifies the client of a request for rate limiting: its API key or the subject of its token
+// when it was authenticated, its IP address otherwise
+func ClientKey(ctx *gin.Context) string {
+	if principal, ok := auth.FromContext(ctx.Request.Context()); ok {
+		if principal.APIKeyID != "" {
+			return "apikey:" + principal.APIKeyID
+		}
+		return "user:" + principal.Subject
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+// ceilSeconds formats a duration as whole seconds rounded up, the unit of the rate limit headers
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/ratelimit"
+	"billing-system/pkg/auth"
+)
+
+// failingStore fails every take, like an unreachable Redis
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(store ratelimit.Store) *gin.Engine {
+		limiter, err := ratelimit.NewLimiter(store, ratelimit.Limit{Requests: 100, Per: time.Minute}, []ratelimit.Rule{
+			{Method: http.MethodPost, Path: "/orders", Limit: ratelimit.Limit{Requests: 2, Per: time.Minute, Burst: 1}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		router := gin.New()
+		router.Use(ErrorHandler())
+		// Requests with a subject header stand for authenticated users
+		router.Use(func(ctx *gin.Context) {
+			if subject := ctx.GetHeader("X-Subject"); subject != "" {
+				ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), &auth.Principal{Subject: subject}, "token"))
+			}
+		})
+		router.POST("/orders", RateLimit(limiter), func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+		return router
+	}
+	post := func(router *gin.Engine, subject string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		if subject != "" {
+			req.Header.Set("X-Subject", subject)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("limited", func(t *testing.T) {
+		router := newRouter(ratelimit.NewMemoryStore())
+
+		rec := post(router, "user-1")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+		}
+		for header, want := range map[string]string{"RateLimit-Limit": "1", "RateLimit-Remaining": "0", "RateLimit-Reset": "30", "RateLimit-Policy": "2;w=60;burst=1"} {
+			if got := rec.Header().Get(header); got != want {
+				t.Errorf("%s = %q, want %q", header, got, want)
+			}
+		}
+
+		rec = post(router, "user-1")
+		if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "30" {
+			t.Fatalf("status = %d, Retry-After = %q, want 429 and 30", rec.Code, rec.Header().Get("Retry-After"))
+		}
+		var envelope common.ErrorEnvelope
+		if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil || envelope.Error.Reason != "RATE_LIMITED" {
+			t.Errorf("body = %s, want reason RATE_LIMITED", rec.Body)
+		}
+
+		// Another user, and an anonymous client keyed by its address, have buckets of their own
+		if rec := post(router, "user-2"); rec.Code != http.StatusOK {
+			t.Errorf("status = %d for another user, want 200", rec.Code)
+		}
+		if rec := post(router, ""); rec.Code != http.StatusOK {
+			t.Errorf("status = %d for an anonymous client, want 200", rec.Code)
+		}
+	})
+
+	t.Run("store failure lets requests through", func(t *testing.T) {
+		router := newRouter(failingStore{})
+		if rec := post(router, "user-1"); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Errorf("status = %d with RateLimit-Limit %q, want 200 without rate limit headers", rec.Code, rec.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
+
+func TestRateLimitByAddress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.Limit{Requests: 2, Per: time.Minute, Burst: 1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := gin.New()
+	router.Use(ErrorHandler())
+	verified := 0
+	// Every credential is rejected, like a client trying invalid API keys
+	router.GET("/orders", RateLimitByAddress(limiter), func(ctx *gin.Context) {
+		verified++
+		ctx.Error(common.NewAPIError(http.StatusUnauthorized, "UNAUTHENTICATED", "invalid api key"))
+		ctx.Abort()
+	})
+	get := func(addr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.RemoteAddr = addr
+		req.Header.Set("Authorization", "ApiKey invalid")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := get("192.0.2.1:1234"); code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", code)
+	}
+	if code := get("192.0.2.1:5678"); code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", code)
+	}
+	if verified != 1 {
+		t.Errorf("credentials verified %d times, want 1", verified)
+	}
+
+	// Another address has a bucket of its own
+	if code := get("192.0.2.2:1234"); code != http.StatusUnauthorized {
+		t.Errorf("status = %d for another address, want 401", code)
+	}
+}
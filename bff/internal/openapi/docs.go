@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage renders the specification with Swagger UI, its assets are loaded from a CDN
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>billing-system API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui", persistAuthorization: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+// ServeSpec serves the specification as JSON
+func ServeSpec(spec *Spec) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("X-API-Version", spec.Info.Version)
+		ctx.Data(http.StatusOK, "application/json", spec.JSON())
+	}
+}
+
+// ServeSwaggerUI serves a Swagger UI page showing the specification served at specURL
+func ServeSwaggerUI(specURL string) gin.HandlerFunc {
+	page := []byte(fmt.Sprintf(swaggerUIPage, specURL))
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	}
+}
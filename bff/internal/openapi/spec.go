@@ -0,0 +1,302 @@
+// Package openapi holds the OpenAPI 3 specification of the BFF REST API and validates requests and responses against it.
+// Only the parts of the specification the BFF uses are modelled, schemas support the keywords listed on Schema.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:embed openapi.json
+var specJSON []byte
+
+// Spec is a parsed OpenAPI document
+type Spec struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	raw []byte
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Responses  map[string]*Response  `json:"responses"`
+}
+
+// PathItem holds the operations of a path by HTTP method
+type PathItem struct {
+	Get    *Operation `json:"get"`
+	Post   *Operation `json:"post"`
+	Put    *Operation `json:"put"`
+	Patch  *Operation `json:"patch"`
+	Delete *Operation `json:"delete"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter, Ref points at a parameter of the components
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a documented response, Ref points at a response of the components
+type Response struct {
+	Ref         string                `json:"$ref"`
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema, of which the keywords below are checked.
+// ExclusiveMinimum is the OpenAPI 3.0 boolean form, Nullable allows null in addition to Type.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Nullable             bool               `json:"nullable"`
+	Enum                 []any              `json:"enum"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum"`
+	AllOf                []*Schema          `json:"allOf"`
+	OneOf                []*Schema          `json:"oneOf"`
+}
+
+// Load parses the specification embedded in the BFF
+func Load() (*Spec, error) {
+	return Parse(specJSON)
+}
+
+// Parse parses an OpenAPI document and checks that every reference in it resolves
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	spec.raw = data
+	if err := spec.checkRefs(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// JSON returns the document as it is served to clients
+func (s *Spec) JSON() []byte {
+	return s.raw
+}
+
+// Route is a documented operation, Path is in gin form such as /api/v1/orders/:id
+type Route struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+// Routes returns every documented operation sorted by path and method
+func (s *Spec) Routes() []Route {
+	var routes []Route
+	for path, item := range s.Paths {
+		for method, op := range item.operations() {
+			routes = append(routes, Route{Method: method, Path: ginPath(path), Operation: op})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// Operation returns the operation of a gin route, such as GET /api/v1/orders/:id
+func (s *Spec) Operation(method, route string) (*Operation, bool) {
+	item, ok := s.Paths[specPath(route)]
+	if !ok {
+		return nil, false
+	}
+	op, ok := item.operations()[method]
+	return op, ok && op != nil
+}
+
+func (p *PathItem) operations() map[string]*Operation {
+	ops := make(map[string]*Operation, 5)
+	for method, op := range map[string]*Operation{
+		http.MethodGet: p.Get, http.MethodPost: p.Post, http.MethodPut: p.Put, http.MethodPatch: p.Patch, http.MethodDelete: p.Delete,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+// specPath converts the parameters of a gin route to OpenAPI templates, :id becomes {id}
+func specPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ginPath converts the templates of an OpenAPI path to gin parameters, {id} becomes :id
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// schema resolves a schema reference of the components
+func (s *Spec) schema(schema *Schema) (*Schema, error) {
+	if schema.Ref == "" {
+		return schema, nil
+	}
+	name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+	resolved := s.Components.Schemas[name]
+	if !ok || resolved == nil {
+		return nil, fmt.Errorf("unresolved schema reference %s", schema.Ref)
+	}
+	return resolved, nil
+}
+
+// parameter resolves a parameter reference of the components
+func (s *Spec) parameter(param *Parameter) (*Parameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	name, ok := strings.CutPrefix(param.Ref, "#/components/parameters/")
+	resolved := s.Components.Parameters[name]
+	if !ok || resolved == nil {
+		return nil, fmt.Errorf("unresolved parameter reference %s", param.Ref)
+	}
+	return resolved, nil
+}
+
+// response resolves a response reference of the components
+func (s *Spec) response(response *Response) (*Response, error) {
+	if response.Ref == "" {
+		return response, nil
+	}
+	name, ok := strings.CutPrefix(response.Ref, "#/components/responses/")
+	resolved := s.Components.Responses[name]
+	if !ok || resolved == nil {
+		return nil, fmt.Errorf("unresolved response reference %s", response.Ref)
+	}
+	return resolved, nil
+}
+
+// checkRefs resolves every reference of the document, so a typo fails at startup rather than on a request
+func (s *Spec) checkRefs() error {
+	var checkSchema func(schema *Schema) error
+	checkSchema = func(schema *Schema) error {
+		if schema == nil {
+			return nil
+		}
+		if schema.Ref != "" {
+			_, err := s.schema(schema)
+			return err
+		}
+		nested := []*Schema{schema.Items, schema.AdditionalProperties}
+		nested = append(nested, schema.AllOf...)
+		nested = append(nested, schema.OneOf...)
+		for _, property := range schema.Properties {
+			nested = append(nested, property)
+		}
+		for _, n := range nested {
+			if err := checkSchema(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	checkContent := func(content map[string]*MediaType) error {
+		for _, media := range content {
+			if err := checkSchema(media.Schema); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, schema := range s.Components.Schemas {
+		if err := checkSchema(schema); err != nil {
+			return err
+		}
+	}
+	for _, response := range s.Components.Responses {
+		if err := checkContent(response.Content); err != nil {
+			return err
+		}
+	}
+	for _, route := range s.Routes() {
+		for _, param := range route.Operation.Parameters {
+			resolved, err := s.parameter(param)
+			if err != nil {
+				return err
+			}
+			if err := checkSchema(resolved.Schema); err != nil {
+				return err
+			}
+		}
+		if body := route.Operation.RequestBody; body != nil {
+			if err := checkContent(body.Content); err != nil {
+				return err
+			}
+		}
+		for _, response := range route.Operation.Responses {
+			resolved, err := s.response(response)
+			if err != nil {
+				return err
+			}
+			if err := checkContent(resolved.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Reasons of the violations, named like the validation tags the handlers bind with
+const (
+	ReasonRequired     = "REQUIRED"
+	ReasonInvalidType  = "INVALID_TYPE"
+	ReasonInvalidJSON  = "INVALID_JSON"
+	ReasonMin          = "MIN"
+	ReasonMax          = "MAX"
+	ReasonEnum         = "ONEOF"
+	ReasonFormat       = "FORMAT"
+	ReasonNoMatch      = "NO_MATCH"
+	ReasonUndocumented = "UNDOCUMENTED"
+	ReasonUnresolved   = "UNRESOLVED_REFERENCE"
+)
+
+// Violation is a part of a request or response that does not match the specification.
+// Field is the JSON path of the value such as items[0].quantity, or the name of a parameter.
+type Violation struct {
+	Field       string
+	Reason      string
+	Description string
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return fmt.Sprintf("%s: %s", v.Reason, v.Description)
+	}
+	return fmt.Sprintf("%s %s: %s", v.Field, v.Reason, v.Description)
+}
+
+// ValidateRequest checks the parameters and JSON body of a request against its operation.
+// The body is read and put back for the handler. Bodies of other media types, such as carrier webhooks, are not checked.
+func (s *Spec) ValidateRequest(op *Operation, req *http.Request, pathParams map[string]string) ([]Violation, error) {
+	var violations []Violation
+
+	query := req.URL.Query()
+	for _, param := range op.Parameters {
+		param, err := s.parameter(param)
+		if err != nil {
+			return nil, err
+		}
+		var value string
+		var present bool
+		switch param.In {
+		case "path":
+			value, present = pathParams[param.Name]
+		case "query":
+			present = query.Has(param.Name)
+			value = query.Get(param.Name)
+		default:
+			continue
+		}
+		if !present || value == "" {
+			if param.Required {
+				violations = append(violations, Violation{Field: param.Name, Reason: ReasonRequired, Description: param.In + " parameter is required"})
+			}
+			continue
+		}
+		violations = append(violations, s.validateParameter(param, value)...)
+	}
+
+	if op.RequestBody == nil {
+		return violations, nil
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return violations, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			violations = append(violations, Violation{Reason: ReasonRequired, Description: "request body is required"})
+		}
+		return violations, nil
+	}
+	return append(violations, s.validateJSON(media.Schema, body)...), nil
+}
+
+// ValidateResponse checks that the status and media type of a response are documented and its JSON body matches the schema
+func (s *Spec) ValidateResponse(op *Operation, status int, header http.Header, body []byte) []Violation {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		if response, ok = op.Responses["default"]; !ok {
+			return []Violation{{Field: "status", Reason: ReasonUndocumented, Description: fmt.Sprintf("status %d is not documented", status)}}
+		}
+	}
+	response, err := s.response(response)
+	if err != nil {
+		return []Violation{{Reason: ReasonUnresolved, Description: err.Error()}}
+	}
+	if len(response.Content) == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return []Violation{{Field: "Content-Type", Reason: ReasonUndocumented, Description: "response has no media type"}}
+	}
+	media := matchMediaType(response.Content, mediaType)
+	if media == nil {
+		return []Violation{{Field: "Content-Type", Reason: ReasonUndocumented, Description: fmt.Sprintf("media type %s is not documented", mediaType)}}
+	}
+	if mediaType != "application/json" || media.Schema == nil {
+		return nil
+	}
+	return s.validateJSON(media.Schema, body)
+}
+
+// matchMediaType returns the documented media type of a response, image/* and */* match any subtype
+func matchMediaType(content map[string]*MediaType, mediaType string) *MediaType {
+	if media, ok := content[mediaType]; ok {
+		return media
+	}
+	if kind, _, ok := strings.Cut(mediaType, "/"); ok {
+		if media, ok := content[kind+"/*"]; ok {
+			return media
+		}
+	}
+	return content["*/*"]
+}
+
+// validateJSON decodes a JSON document and validates it against the schema
+func (s *Spec) validateJSON(schema *Schema, data []byte) []Violation {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []Violation{{Reason: ReasonInvalidJSON, Description: err.Error()}}
+	}
+	var violations []Violation
+	s.validateValue(schema, value, "", &violations)
+	return violations
+}
+
+// validateParameter converts a parameter to the type of its schema and validates it
+func (s *Spec) validateParameter(param *Parameter, raw string) []Violation {
+	if param.Schema == nil {
+		return nil
+	}
+	schema, err := s.schema(param.Schema)
+	if err != nil {
+		return []Violation{{Field: param.Name, Reason: ReasonUnresolved, Description: err.Error()}}
+	}
+
+	var value any = raw
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return []Violation{{Field: param.Name, Reason: ReasonInvalidType, Description: fmt.Sprintf("expected an integer, got %q", raw)}}
+		}
+		value = json.Number(raw)
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return []Violation{{Field: param.Name, Reason: ReasonInvalidType, Description: fmt.Sprintf("expected a number, got %q", raw)}}
+		}
+		value = json.Number(raw)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []Violation{{Field: param.Name, Reason: ReasonInvalidType, Description: fmt.Sprintf("expected a boolean, got %q", raw)}}
+		}
+		value = b
+	}
+
+	var violations []Violation
+	s.validateValue(schema, value, param.Name, &violations)
+	return violations
+}
+
+// validateValue validates a decoded JSON value, numbers are json.Number
+func (s *Spec) validateValue(schema *Schema, value any, field string, violations *[]Violation) {
+	add := func(reason, format string, args ...any) {
+		*violations = append(*violations, Violation{Field: field, Reason: reason, Description: fmt.Sprintf(format, args...)})
+	}
+
+	schema, err := s.schema(schema)
+	if err != nil {
+		add(ReasonUnresolved, "%v", err)
+		return
+	}
+	for _, part := range schema.AllOf {
+		s.validateValue(part, value, field, violations)
+	}
+	if len(schema.OneOf) > 0 {
+		matches := 0
+		for _, alternative := range schema.OneOf {
+			var alternativeViolations []Violation
+			s.validateValue(alternative, value, field, &alternativeViolations)
+			if len(alternativeViolations) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			add(ReasonNoMatch, "matches %d of the %d allowed schemas instead of exactly one", matches, len(schema.OneOf))
+		}
+	}
+
+	if value == nil {
+		if schema.Type != "" && !schema.Nullable {
+			add(ReasonInvalidType, "expected %s, got null", schema.Type)
+		}
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			add(ReasonInvalidType, "expected an object, got %s", jsonType(value))
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				*violations = append(*violations, Violation{Field: join(field, name), Reason: ReasonRequired, Description: "field is required"})
+			}
+		}
+		for name, property := range object {
+			if propertySchema, ok := schema.Properties[name]; ok {
+				s.validateValue(propertySchema, property, join(field, name), violations)
+			} else if schema.AdditionalProperties != nil {
+				s.validateValue(schema.AdditionalProperties, property, join(field, name), violations)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			add(ReasonInvalidType, "expected an array, got %s", jsonType(value))
+			return
+		}
+		if schema.MinItems != nil && len(array) < *schema.MinItems {
+			add(ReasonMin, "expected at least %d items, got %d", *schema.MinItems, len(array))
+		}
+		if schema.MaxItems != nil && len(array) > *schema.MaxItems {
+			add(ReasonMax, "expected at most %d items, got %d", *schema.MaxItems, len(array))
+		}
+		if schema.Items != nil {
+			for i, item := range array {
+				s.validateValue(schema.Items, item, fmt.Sprintf("%s[%d]", field, i), violations)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			add(ReasonInvalidType, "expected a string, got %s", jsonType(value))
+			return
+		}
+		length := utf8.RuneCountInString(str)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			add(ReasonMin, "expected at least %d characters, got %d", *schema.MinLength, length)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			add(ReasonMax, "expected at most %d characters, got %d", *schema.MaxLength, length)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				add(ReasonFormat, "expected an RFC 3339 date-time, got %q", str)
+			}
+		}
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			add(ReasonInvalidType, "expected %s, got %s", schema.Type, jsonType(value))
+			return
+		}
+		if schema.Type == "integer" {
+			if _, err := number.Int64(); err != nil {
+				add(ReasonInvalidType, "expected an integer, got %s", number)
+				return
+			}
+		}
+		n, err := number.Float64()
+		if err != nil {
+			add(ReasonInvalidType, "expected a number, got %s", number)
+			return
+		}
+		if schema.Minimum != nil && (n < *schema.Minimum || (schema.ExclusiveMinimum && n == *schema.Minimum)) {
+			qualifier := "at least"
+			if schema.ExclusiveMinimum {
+				qualifier = "more than"
+			}
+			add(ReasonMin, "expected %s %v, got %s", qualifier, *schema.Minimum, number)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			add(ReasonMax, "expected at most %v, got %s", *schema.Maximum, number)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			add(ReasonInvalidType, "expected a boolean, got %s", jsonType(value))
+			return
+		}
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed any) bool {
+		return fmt.Sprint(allowed) == fmt.Sprint(value)
+	}) {
+		add(ReasonEnum, "expected one of %v, got %v", schema.Enum, value)
+	}
+}
+
+// join appends a property to a JSON path
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "null"
+	}
+}
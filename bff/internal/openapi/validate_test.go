@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParse_UnresolvedReference(t *testing.T) {
+	doc := `{"openapi":"3.0.3","paths":{"/a":{"get":{"responses":{"200":{"description":"ok",
+		"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}}`
+	if _, err := Parse([]byte(doc)); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Fatalf("err = %v, want unresolved reference", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Operation(http.MethodGet, "/api/v1/orders/:id"); !ok {
+		t.Fatal("GET /api/v1/orders/:id is not documented")
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	spec, err := Parse([]byte(`{"openapi":"3.0.3","paths":{},"components":{"schemas":{
+		"Amount":{"type":"number","minimum":0,"exclusiveMinimum":true},
+		"Item":{"type":"object","required":["sku"],"properties":{"sku":{"type":"string","minLength":1}}},
+		"Data":{"oneOf":[{"$ref":"#/components/schemas/Item"},{"type":"array","items":{"$ref":"#/components/schemas/Item"}}]},
+		"List":{"type":"array","nullable":true,"items":{"type":"integer"}}
+	}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := func(name string) *Schema { return &Schema{Ref: "#/components/schemas/" + name} }
+
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		want   []string
+	}{
+		{"exclusive minimum", "Amount", `0`, []string{" MIN"}},
+		{"above exclusive minimum", "Amount", `0.01`, nil},
+		{"nested path", "Data", `[{"sku":"A"},{"sku":""}]`, []string{" NO_MATCH"}},
+		{"one of object", "Data", `{"sku":"A"}`, nil},
+		{"one of array", "Data", `[{"sku":"A"}]`, nil},
+		{"required", "Item", `{}`, []string{"sku REQUIRED"}},
+		{"nullable", "List", `null`, nil},
+		{"integer", "List", `[1, 1.5]`, []string{"[1] INVALID_TYPE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, violation := range spec.validateJSON(ref(tt.schema), []byte(tt.value)) {
+				got = append(got, violation.Field+" "+violation.Reason)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package ratelimit limits the requests of each client with token buckets.
+// A bucket holds up to the burst of its limit and refills at its rate, each request takes a token.
+// Buckets are kept in a Store, in memory for a single BFF or in Redis when several share the limits.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Limit allows Requests per Per on average, and up to Burst at once
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	// Burst is the capacity of the bucket, Requests when zero
+	Burst int
+}
+
+// Rate returns the tokens refilled per second
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Capacity returns the most tokens the bucket holds
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+func (l Limit) validate() error {
+	if l.Requests <= 0 || l.Per <= 0 || l.Burst < 0 {
+		return fmt.Errorf("a limit needs positive requests and period and a burst not negative, got %d per %v burst %d", l.Requests, l.Per, l.Burst)
+	}
+	return nil
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Limit   Limit
+	Allowed bool
+	// Remaining is the number of whole tokens left after the request
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next token, zero when the request was allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets
+type Store interface {
+	// Take takes a token from the bucket of the key, a bucket not seen before is full
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// refill returns the tokens of a bucket that held tokens at updated, capped at the capacity
+func refill(limit Limit, tokens float64, updated, now time.Time) float64 {
+	if elapsed := now.Sub(updated); elapsed > 0 {
+		tokens += elapsed.Seconds() * limit.Rate()
+	}
+	return math.Min(tokens, float64(limit.Capacity()))
+}
+
+// result describes a bucket left with tokens after a request
+func result(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.Rate()
+	r := Result{
+		Limit:     limit,
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Capacity()) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// Rule limits the requests to one route, Path is in gin form such as /api/v1/orders/:id
+type Rule struct {
+	Method string
+	Path   string
+	Limit  Limit
+}
+
+// Limiter applies the limit of each route to the requests of each client
+type Limiter struct {
+	store        Store
+	defaultLimit Limit
+	routes       map[string]Limit
+	now          func() time.Time
+}
+
+// NewLimiter returns a limiter taking tokens from the store.
+// Requests to a route with a rule count against a bucket of that route, the other requests against one shared bucket per client.
+func NewLimiter(store Store, defaultLimit Limit, rules []Rule) (*Limiter, error) {
+	if store == nil {
+		return nil, errors.New("a limiter needs a store")
+	}
+	if err := defaultLimit.validate(); err != nil {
+		return nil, fmt.Errorf("default rate limit: %w", err)
+	}
+	routes := make(map[string]Limit, len(rules))
+	for _, rule := range rules {
+		if rule.Method == "" || !strings.HasPrefix(rule.Path, "/") {
+			return nil, fmt.Errorf("rate limit rule %s %s needs a method and a path", rule.Method, rule.Path)
+		}
+		if err := rule.Limit.validate(); err != nil {
+			return nil, fmt.Errorf("rate limit of %s %s: %w", rule.Method, rule.Path, err)
+		}
+		routes[routeKey(rule.Method, rule.Path)] = rule.Limit
+	}
+	return &Limiter{store: store, defaultLimit: defaultLimit, routes: routes, now: time.Now}, nil
+}
+
+// Allow takes a token for a request of the client to the route
+func (l *Limiter) Allow(ctx context.Context, method, route, client string) (Result, error) {
+	limit, scope := l.defaultLimit, "*"
+	if routeLimit, ok := l.routes[routeKey(method, route)]; ok {
+		limit, scope = routeLimit, routeKey(method, route)
+	}
+	return l.store.Take(ctx, client+"|"+scope, limit, l.now())
+}
+
+func routeKey(method, path string) string {
+	if method == "" {
+		method = http.MethodGet
+	}
+	return strings.ToUpper(method) + " " + path
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// testStores returns a memory store and a Redis store backed by miniredis
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"redis":  NewRedisStore(client, "test:"),
+	}
+}
+
+func TestStoreTake(t *testing.T) {
+	limit := Limit{Requests: 60, Per: time.Minute, Burst: 3}
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			take := func(key string, now time.Time) Result {
+				t.Helper()
+				r, err := store.Take(ctx, key, limit, now)
+				if err != nil {
+					t.Fatalf("Take returned %v", err)
+				}
+				return r
+			}
+
+			// A new bucket allows the burst at once
+			for want := 2; want >= 0; want-- {
+				r := take("client-a", start)
+				if !r.Allowed || r.Remaining != want {
+					t.Fatalf("got allowed %v remaining %d, want allowed with %d remaining", r.Allowed, r.Remaining, want)
+				}
+			}
+			r := take("client-a", start)
+			if r.Allowed || r.Remaining != 0 || r.RetryAfter != time.Second || r.Reset != 3*time.Second {
+				t.Fatalf("got %+v past the burst, want denied, retry after 1s and reset after 3s", r)
+			}
+
+			// Other clients have buckets of their own
+			if r := take("client-b", start); !r.Allowed {
+				t.Fatalf("got %+v for another client, want allowed", r)
+			}
+
+			// A token is refilled each second, half a second is not enough
+			if r := take("client-a", start.Add(500*time.Millisecond)); r.Allowed || r.RetryAfter != 500*time.Millisecond {
+				t.Fatalf("got %+v after half a second, want denied and retry after 500ms", r)
+			}
+			if r := take("client-a", start.Add(time.Second)); !r.Allowed || r.Remaining != 0 {
+				t.Fatalf("got %+v after a second, want allowed with none remaining", r)
+			}
+
+			// A bucket never holds more than its burst
+			if r := take("client-a", start.Add(time.Hour)); !r.Allowed || r.Remaining != 2 {
+				t.Fatalf("got %+v after an hour, want allowed with 2 remaining", r)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Per: time.Second}
+	start := time.Now()
+
+	store.Take(context.Background(), "gone", limit, start)
+	store.Take(context.Background(), "active", limit, start.Add(sweepInterval))
+	if _, ok := store.buckets["gone"]; ok || len(store.buckets) != 1 {
+		t.Errorf("buckets = %v, want only the active client", store.buckets)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	if _, err := NewLimiter(NewMemoryStore(), Limit{Requests: 0, Per: time.Minute}, nil); err == nil {
+		t.Error("NewLimiter accepted a default limit without requests")
+	}
+	if _, err := NewLimiter(NewMemoryStore(), Limit{Requests: 1, Per: time.Minute}, []Rule{{Method: "POST", Path: "orders", Limit: Limit{Requests: 1, Per: time.Second}}}); err == nil {
+		t.Error("NewLimiter accepted a rule without a leading slash")
+	}
+
+	limiter, err := NewLimiter(NewMemoryStore(), Limit{Requests: 100, Per: time.Minute}, []Rule{
+		{Method: "post", Path: "/api/v1/orders", Limit: Limit{Requests: 1, Per: time.Minute}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if r, _ := limiter.Allow(ctx, "POST", "/api/v1/orders", "user:1"); !r.Allowed || r.Limit.Requests != 1 {
+		t.Fatalf("got %+v for the first order, want allowed by the route limit", r)
+	}
+	if r, _ := limiter.Allow(ctx, "POST", "/api/v1/orders", "user:1"); r.Allowed {
+		t.Fatalf("got %+v for the second order, want denied by the route limit", r)
+	}
+	// Other routes count against the default limit of the client
+	if r, _ := limiter.Allow(ctx, "GET", "/api/v1/orders/:id", "user:1"); !r.Allowed || r.Limit.Requests != 100 || r.Remaining != 99 {
+		t.Fatalf("got %+v for another route, want allowed by the default limit", r)
+	}
+	if r, _ := limiter.Allow(ctx, "POST", "/api/v1/orders", "user:2"); !r.Allowed {
+		t.Fatalf("got %+v for another client, want allowed", r)
+	}
+}
@@ -2,9 +2,10 @@ package shipment
 
 import (
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"billing-system/bff/config"
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 	shipmentPb "billing-system/shipment_service/proto"
 )
 
@@ -13,9 +14,15 @@ type ShipmentConnectionAdapter struct {
 }
 
 func (shipmentConnectionAdapter *ShipmentConnectionAdapter) NewConnection() (*grpc.ClientConn, error) {
+	creds, err := mtls.ClientCredentials(config.Service.TLS, config.Service.ShipmentConnection.ServerName)
+	if err != nil {
+		return nil, err
+	}
 	conn, err := grpc.Dial(
 		config.Service.ShipmentConnection.Address, // Shipment service address
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(auth.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(auth.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, err
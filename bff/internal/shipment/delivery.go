@@ -0,0 +1,117 @@
+package shipment
+
+import (
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deliveryFileExtensions are the extensions delivery files are downloaded with
+var deliveryFileExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
+// GetDeliveryProof handles HTTP request to get the proof of delivery of a shipment, with the files it holds
+func (h *Handler) GetDeliveryProof(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.GetShipment(ctx, &shipmentPb.GetShipmentRequest{ShipmentId: shipmentID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if protoResp.Shipment.DeliveryProof == nil {
+		ctx.Error(common.NewAPIError(http.StatusNotFound, "DELIVERY_PROOF_NOT_FOUND", "shipment has no delivery proof"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp.Shipment.DeliveryProof,
+	})
+}
+
+// DownloadDeliveryFile handles HTTP request to view a signature or photo of a delivery proof.
+// The file is streamed from the shipment service as it is received.
+func (h *Handler) DownloadDeliveryFile(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+	fileID, err := strconv.ParseInt(ctx.Param("file_id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid file id"))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	stream, err := shipmentClient.GetDeliveryFile(ctx, &shipmentPb.GetDeliveryFileRequest{ShipmentId: shipmentID, FileId: fileID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// Errors of a server stream arrive with its first message
+	first, err := stream.Recv()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	writeDeliveryFile(ctx, shipmentID, fileID, first, stream)
+}
+
+// writeDeliveryFile writes the first chunk of a delivery file and copies the rest of the stream after it
+func writeDeliveryFile(
+	ctx *gin.Context,
+	shipmentID, fileID int64,
+	first *shipmentPb.DeliveryFileChunk,
+	stream shipmentPb.ShipmentService_GetDeliveryFileClient,
+) {
+	filename := fmt.Sprintf("shipment-%d-%s-%d%s", shipmentID, strings.ToLower(first.Kind), fileID, deliveryFileExtensions[first.ContentType])
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	ctx.Header("Content-Type", first.ContentType)
+	ctx.Status(http.StatusOK)
+
+	chunk := first
+	for {
+		if _, err := ctx.Writer.Write(chunk.Data); err != nil {
+			log.Printf("Failed to write delivery file %d: %v", fileID, err)
+			return
+		}
+
+		var err error
+		if chunk, err = stream.Recv(); err == io.EOF {
+			return
+		} else if err != nil {
+			// The status is already sent, the client sees a truncated body
+			log.Printf("Failed to receive delivery file %d: %v", fileID, err)
+			ctx.Abort()
+			return
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package shipment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"billing-system/bff/config"
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+const (
+	defaultEventWriteTimeout = 10 * time.Second
+	defaultEventRetry        = 3 * time.Second
+
+	// Types of the messages of an order event stream
+	eventTypeShipment  = "shipment"
+	eventTypeHeartbeat = "heartbeat"
+)
+
+// StreamOrderEvents handles HTTP request to follow the shipment status changes of an order as server-sent events.
+// Each event carries its id, a client reconnecting with Last-Event-ID resumes after it without gaps,
+// though events recorded shortly before it may be sent again and are dropped by id.
+// Heartbeat comments keep an idle connection open.
+// A client reading slower than events arrive is disconnected once a write blocks for the write timeout,
+// the shipment service meanwhile stops sending as the gRPC flow control window fills up.
+func (h *Handler) StreamOrderEvents(ctx *gin.Context) {
+	stream, first, cancel, ok := h.watchOrder(ctx)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	// Proxies such as nginx would otherwise buffer the stream
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	writer := http.NewResponseController(ctx.Writer)
+	write := func(frame string) error {
+		if err := writer.SetWriteDeadline(time.Now().Add(eventWriteTimeout())); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := io.WriteString(ctx.Writer, frame); err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	if err := write(fmt.Sprintf("retry: %d\n\n", eventRetry().Milliseconds())); err != nil {
+		log.Printf("Failed to open event stream of order %d: %v", first.OrderId, err)
+		return
+	}
+	forwardOrderEvents(stream, first, func(update *shipmentPb.WatchUpdate) error {
+		if update.Heartbeat {
+			return write(": heartbeat\n\n")
+		}
+		data, err := json.Marshal(newOrderEventMessage(update))
+		if err != nil {
+			return err
+		}
+		return write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", update.Event.Id, eventTypeShipment, data))
+	})
+}
+
+// StreamOrderEventsWebSocket handles HTTP request to follow the shipment status changes of an order over a WebSocket.
+// Every message is a JSON OrderEventMessage, heartbeats included. The stream resumes after the last_event_id query
+// parameter, and a client reading slower than events arrive is disconnected like on the server-sent event stream.
+func (h *Handler) StreamOrderEventsWebSocket(ctx *gin.Context) {
+	if !strings.EqualFold(ctx.GetHeader("Upgrade"), "websocket") {
+		ctx.Error(common.BadRequest("expected a WebSocket upgrade request"))
+		return
+	}
+
+	stream, first, cancel, ok := h.watchOrder(ctx)
+	if !ok {
+		return
+	}
+	defer cancel()
+
+	server := websocket.Server{
+		// Callers authenticate with a bearer token rather than cookies, so any origin may connect
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			// Reading handles the control frames of the client, and ends the watch once it closes the socket
+			go func() {
+				io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			forwardOrderEvents(stream, first, func(update *shipmentPb.WatchUpdate) error {
+				if err := conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout())); err != nil {
+					return err
+				}
+				return websocket.JSON.Send(conn, newOrderEventMessage(update))
+			})
+		},
+	}
+	// The upgrade takes over the connection, the status is set for the logs and response checks
+	ctx.Status(http.StatusSwitchingProtocols)
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// watchOrder starts a watch of the order of the request and receives its opening update, so a rejected watch
+// is answered with an error envelope before the stream starts. Cancel ends the watch.
+func (h *Handler) watchOrder(ctx *gin.Context) (shipmentPb.ShipmentService_WatchOrderClient, *shipmentPb.WatchUpdate, context.CancelFunc, bool) {
+	orderID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid order id"))
+		return nil, nil, nil, false
+	}
+	afterEventID, err := lastEventID(ctx)
+	if err != nil {
+		ctx.Error(common.BadRequest(err.Error()))
+		return nil, nil, nil, false
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream, err := shipmentClient.WatchOrder(watchCtx, &shipmentPb.WatchOrderRequest{OrderId: orderID, AfterEventId: afterEventID})
+	if err != nil {
+		cancel()
+		ctx.Error(err)
+		return nil, nil, nil, false
+	}
+
+	// Errors of a server stream arrive with its first message
+	first, err := stream.Recv()
+	if err != nil {
+		cancel()
+		ctx.Error(err)
+		return nil, nil, nil, false
+	}
+	return stream, first, cancel, true
+}
+
+// lastEventID returns the id of the last event a client received, from the Last-Event-ID header browsers send
+// when they reconnect, or else from the last_event_id query parameter. Zero streams the whole history.
+func lastEventID(ctx *gin.Context) (int64, error) {
+	value := ctx.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = ctx.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id %q", value)
+	}
+	return id, nil
+}
+
+// forwardOrderEvents writes the first update and each one received after it, until the stream ends or a write fails.
+// The status is already sent, so failures are only logged, the client reconnects and resumes.
+func forwardOrderEvents(stream shipmentPb.ShipmentService_WatchOrderClient, first *shipmentPb.WatchUpdate, write func(*shipmentPb.WatchUpdate) error) {
+	update := first
+	for {
+		if err := write(update); err != nil {
+			log.Printf("Disconnected event stream of order %d, the client is gone or too slow: %v", first.OrderId, err)
+			return
+		}
+
+		var err error
+		if update, err = stream.Recv(); err == io.EOF || status.Code(err) == codes.Canceled {
+			return
+		} else if err != nil {
+			log.Printf("Failed to receive events of order %d: %v", first.OrderId, err)
+			return
+		}
+	}
+}
+
+// eventWriteTimeout returns how long a write to an event stream may block
+func eventWriteTimeout() time.Duration {
+	if timeout := config.Service.Events.WriteTimeout; timeout > 0 {
+		return timeout
+	}
+	return defaultEventWriteTimeout
+}
+
+// eventRetry returns the reconnection delay suggested to clients of server-sent events
+func eventRetry() time.Duration {
+	if retry := config.Service.Events.Retry; retry > 0 {
+		return retry
+	}
+	return defaultEventRetry
+}
@@ -1,14 +1,22 @@
 package shipment
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"billing-system/bff/internal/common"
 	shipmentPb "billing-system/shipment_service/proto"
 )
 
+// maxWebhookBytes bounds the size of carrier webhook bodies
+const maxWebhookBytes = 1 << 20
+
 // Handler struct
 type Handler struct {
 	ShipmentConnection *ShipmentConnectionAdapter
@@ -25,7 +33,7 @@ func NewHandler() *Handler {
 func (h *Handler) CreateShipment(ctx *gin.Context) {
 	var request CreateShipmentRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(common.BindError(err))
 		return
 	}
 
@@ -33,39 +41,284 @@ func (h *Handler) CreateShipment(ctx *gin.Context) {
 	client, _, err := h.ShipmentConnection.NewClient()
 	if err != nil {
 		log.Println("Error connecting to shipment service:", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to connect to shipment service"})
+		ctx.Error(common.ServiceUnavailable("shipment"))
 		return
 	}
 
 	shipmentClient := client.(shipmentPb.ShipmentServiceClient)
 
 	// Convert request to protobuf
-	protoItems := make([]*shipmentPb.ShipmentItemRequest, len(request.Items))
-	for i, item := range request.Items {
-		protoItems[i] = &shipmentPb.ShipmentItemRequest{
-			Sku:      item.Sku,
-			Quantity: item.Quantity,
+	protoReq := &shipmentPb.CreateShipmentRequest{
+		OrderId:               request.OrderID,
+		Items:                 toProtoItems(request.Items),
+		CarrierCode:           request.CarrierCode,
+		DestinationPostalCode: request.DestinationPostalCode,
+		WarehouseId:           request.WarehouseID,
+		Parcels:               toProtoParcels(request.Parcels),
+	}
+	if request.ShipTo != nil {
+		protoReq.ShipTo = &shipmentPb.Address{
+			Name:       request.ShipTo.Name,
+			Line1:      request.ShipTo.Line1,
+			Line2:      request.ShipTo.Line2,
+			City:       request.ShipTo.City,
+			Region:     request.ShipTo.Region,
+			PostalCode: request.ShipTo.PostalCode,
+			Country:    request.ShipTo.Country,
+			Phone:      request.ShipTo.Phone,
 		}
 	}
-
-	protoReq := &shipmentPb.CreateShipmentRequest{
-		OrderId: request.OrderID,
-		Items:   protoItems,
+	for _, planned := range request.Plan {
+		protoReq.Plan = append(protoReq.Plan, &shipmentPb.PlannedShipment{
+			WarehouseId:   planned.WarehouseID,
+			WarehouseCode: planned.WarehouseCode,
+			Items:         toProtoItems(planned.Items),
+		})
 	}
 
 	// Call shipment service
 	protoResp, err := shipmentClient.CreateShipment(ctx, protoReq)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
-	// Convert response
+	// Convert response, a plan returns every shipment created from it
 	response := &ShipmentResponse{
 		Code:    int(protoResp.Code),
 		Message: protoResp.Message,
 		Data:    protoResp.Data,
 	}
+	if len(request.Plan) > 0 {
+		response.Data = protoResp.Shipments
+	}
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// GetShipment handles HTTP request to get a shipment with its items
+func (h *Handler) GetShipment(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	// Get shipment service client
+	client, _, err := h.ShipmentConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		ctx.Error(common.ServiceUnavailable("shipment"))
+		return
+	}
+
+	shipmentClient := client.(shipmentPb.ShipmentServiceClient)
+
+	protoResp, err := shipmentClient.GetShipment(ctx, &shipmentPb.GetShipmentRequest{ShipmentId: shipmentID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp.Shipment,
+	})
+}
+
+// ListShipments handles HTTP request to list shipments filtered by order, status and creation date
+func (h *Handler) ListShipments(ctx *gin.Context) {
+	var query ListShipmentsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	// Get shipment service client
+	client, _, err := h.ShipmentConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		ctx.Error(common.ServiceUnavailable("shipment"))
+		return
+	}
+
+	shipmentClient := client.(shipmentPb.ShipmentServiceClient)
+
+	protoResp, err := shipmentClient.ListShipments(ctx, &shipmentPb.ListShipmentsRequest{
+		OrderId:     query.OrderID,
+		Status:      query.Status,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		PageSize:    query.PageSize,
+		Cursor:      query.Cursor,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data: ListShipmentsResponse{
+			Shipments:  protoResp.Shipments,
+			NextCursor: protoResp.NextCursor,
+		},
+	})
+}
+
+// GetTracking handles HTTP request to get the tracking history of a shipment
+func (h *Handler) GetTracking(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	// Get shipment service client
+	client, _, err := h.ShipmentConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		ctx.Error(common.ServiceUnavailable("shipment"))
+		return
+	}
+
+	shipmentClient := client.(shipmentPb.ShipmentServiceClient)
+
+	protoResp, err := shipmentClient.GetTrackingHistory(ctx, &shipmentPb.GetTrackingHistoryRequest{ShipmentId: shipmentID})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data: TrackingResponse{
+			Shipment: protoResp.Shipment,
+			Events:   protoResp.Events,
+		},
+	})
+}
+
+// PackShipment handles HTTP request to record the parcels a shipment is packed in, replacing the ones recorded before
+func (h *Handler) PackShipment(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	var request PackShipmentRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.PackShipment(ctx, &shipmentPb.PackShipmentRequest{
+		ShipmentId: shipmentID,
+		Parcels:    toProtoParcels(request.Parcels),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp.Shipment,
+	})
+}
+
+// DownloadShippingDocument handles HTTP request to download the label or packing slip of a shipment.
+// The document type is label or packing-slip, labels are also available as ?format=zpl.
+func (h *Handler) DownloadShippingDocument(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	var query ShippingDocumentQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	document, err := shipmentClient.GetShippingDocument(ctx, &shipmentPb.GetShippingDocumentRequest{
+		ShipmentId:   shipmentID,
+		DocumentType: strings.ReplaceAll(ctx.Param("type"), "-", "_"),
+		Format:       query.Format,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.Filename))
+	ctx.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// toProtoParcels converts requested parcels to their protobuf form
+func toProtoParcels(parcels []ParcelRequest) []*shipmentPb.ParcelRequest {
+	protoParcels := make([]*shipmentPb.ParcelRequest, len(parcels))
+	for i, parcel := range parcels {
+		protoParcels[i] = &shipmentPb.ParcelRequest{
+			WeightKg: parcel.WeightKg,
+			LengthCm: parcel.LengthCm,
+			WidthCm:  parcel.WidthCm,
+			HeightCm: parcel.HeightCm,
+			Items:    toProtoItems(parcel.Items),
+		}
+	}
+	return protoParcels
+}
+
+// CarrierWebhook forwards a tracking push from a carrier to the shipment service as it was received,
+// the shipment service verifies its signature
+func (h *Handler) CarrierWebhook(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookBytes))
+	if err != nil {
+		ctx.Error(common.BadRequest("failed to read body"))
+		return
+	}
+
+	headers := make(map[string]string, len(ctx.Request.Header))
+	for key := range ctx.Request.Header {
+		headers[key] = ctx.Request.Header.Get(key)
+	}
+
+	// Get shipment service client
+	client, _, err := h.ShipmentConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		ctx.Error(common.ServiceUnavailable("shipment"))
+		return
+	}
+
+	shipmentClient := client.(shipmentPb.ShipmentServiceClient)
+
+	protoResp, err := shipmentClient.HandleCarrierWebhook(ctx, &shipmentPb.CarrierWebhookRequest{
+		CarrierCode: ctx.Param("code"),
+		Headers:     headers,
+		Body:        body,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"applied": protoResp.Applied})
+}
@@ -0,0 +1,308 @@
+package shipment
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+const (
+	// maxManifestBytes bounds the size of manifest uploads
+	maxManifestBytes = 5 << 20
+	// maxManifestLines is the most lines the shipment service accepts in a batch
+	maxManifestLines = 1000
+)
+
+// Columns of a manifest. Items are written as sku:quantity, several separated by semicolons,
+// the ship_to columns are the recipient printed on the labels.
+const (
+	manifestReference = iota
+	manifestOrderID
+	manifestWarehouseID
+	manifestItems
+	manifestCarrierCode
+	manifestDestinationPostalCode
+	manifestShipToName
+	manifestShipToLine1
+	manifestShipToLine2
+	manifestShipToCity
+	manifestShipToRegion
+	manifestShipToPostalCode
+	manifestShipToCountry
+	manifestShipToPhone
+	manifestColumnCount
+)
+
+var manifestColumns = [manifestColumnCount]string{
+	"reference", "order_id", "warehouse_id", "items", "carrier_code", "destination_postal_code",
+	"ship_to_name", "ship_to_line1", "ship_to_line2", "ship_to_city", "ship_to_region", "ship_to_postal_code", "ship_to_country", "ship_to_phone",
+}
+
+// errTooManyLines is returned by parseManifest when a manifest holds more lines than a batch accepts
+var errTooManyLines = errors.New("too many lines")
+
+// manifestLine is a shipment of a manifest. A line that cannot be read carries its error and is not sent.
+type manifestLine struct {
+	// line is the line in the file, the header is line 1
+	line      int
+	reference string
+	request   *shipmentPb.CreateShipmentRequest
+	err       *common.APIError
+}
+
+// UploadManifest creates the shipments of a warehouse manifest uploaded as a CSV file, with their invoices.
+// Every line gets a result, a line that cannot be read or created fails alone and the response counts the failures by reason.
+func (h *Handler) UploadManifest(ctx *gin.Context) {
+	if mediaType, _, err := mime.ParseMediaType(ctx.ContentType()); err != nil || mediaType != "text/csv" {
+		ctx.Error(common.NewAPIError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "upload a text/csv file"))
+		return
+	}
+
+	lines, err := parseManifest(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxManifestBytes))
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		ctx.Error(common.NewAPIError(http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", fmt.Sprintf("the file is larger than %d bytes", tooLarge.Limit)))
+		return
+	case errors.Is(err, errTooManyLines):
+		ctx.Error(common.BadRequest(fmt.Sprintf("the file has more than %d lines", maxManifestLines)))
+		return
+	case err != nil:
+		ctx.Error(common.BadRequest(err.Error()))
+		return
+	case len(lines) == 0:
+		ctx.Error(common.BadRequest("the file has no lines"))
+		return
+	}
+
+	response := ManifestResponse{Total: len(lines), Confirmed: []ManifestLineResult{}, Failed: []ManifestLineResult{}, Reasons: map[string]int{}}
+	var valid []manifestLine
+	for _, line := range lines {
+		if line.err == nil {
+			valid = append(valid, line)
+			continue
+		}
+		response.Failed = append(response.Failed, ManifestLineResult{
+			Line:            line.line,
+			Reference:       line.reference,
+			Reason:          line.err.Reason,
+			Message:         line.err.Message,
+			FieldViolations: line.err.FieldViolations,
+		})
+		response.Reasons[line.err.Reason]++
+	}
+
+	if len(valid) > 0 {
+		shipmentClient, ok := h.client(ctx)
+		if !ok {
+			return
+		}
+		protoResp, err := createShipments(ctx, shipmentClient, valid)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		for _, result := range protoResp.Confirmed {
+			response.Confirmed = append(response.Confirmed, ManifestLineResult{Line: int(result.Line), Reference: result.Reference, Shipment: result.Shipment})
+		}
+		for _, result := range protoResp.Failed {
+			response.Failed = append(response.Failed, ManifestLineResult{Line: int(result.Line), Reference: result.Reference, Reason: result.Reason, Message: result.Message})
+		}
+		for reason, count := range protoResp.Reasons {
+			response.Reasons[reason] += int(count)
+		}
+		slices.SortFunc(response.Failed, func(a, b ManifestLineResult) int { return a.Line - b.Line })
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    response,
+	})
+}
+
+// createShipments streams the lines to the shipment service and returns its results
+func createShipments(ctx *gin.Context, client shipmentPb.ShipmentServiceClient, lines []manifestLine) (*shipmentPb.CreateShipmentsResponse, error) {
+	stream, err := client.CreateShipments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		err := stream.Send(&shipmentPb.CreateShipmentsRequest{Shipment: line.request, Line: int32(line.line), Reference: line.reference})
+		// The service ended the stream, its error is returned by CloseAndRecv
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// parseManifest reads a manifest with a header row. Columns are found by name, in any order, and unknown columns are ignored.
+func parseManifest(r io.Reader) ([]manifestLine, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	// Spreadsheets may start the file with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	positions := [manifestColumnCount]int{}
+	for i := range positions {
+		positions[i] = -1
+	}
+	for i, name := range header {
+		for column, known := range manifestColumns {
+			if strings.EqualFold(strings.TrimSpace(name), known) {
+				positions[column] = i
+			}
+		}
+	}
+	if positions[manifestOrderID] < 0 || positions[manifestItems] < 0 {
+		return nil, errors.New("the CSV header must name the order_id and items columns")
+	}
+	// Lines may have fewer or more cells than the header, missing cells are empty
+	reader.FieldsPerRecord = -1
+
+	var lines []manifestLine
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if blankRecord(record) {
+			continue
+		}
+		if len(lines) == maxManifestLines {
+			return nil, errTooManyLines
+		}
+
+		var cells [manifestColumnCount]string
+		for column, position := range positions {
+			if position >= 0 && position < len(record) {
+				cells[column] = strings.TrimSpace(record[position])
+			}
+		}
+		number, _ := reader.FieldPos(0)
+		lines = append(lines, readManifestLine(number, cells))
+	}
+}
+
+// readManifestLine converts the cells of a manifest line to the request of its shipment
+func readManifestLine(number int, cells [manifestColumnCount]string) manifestLine {
+	line := manifestLine{
+		line:      number,
+		reference: cells[manifestReference],
+		request: &shipmentPb.CreateShipmentRequest{
+			CarrierCode:           cells[manifestCarrierCode],
+			DestinationPostalCode: cells[manifestDestinationPostalCode],
+		},
+	}
+
+	var violations []common.FieldViolation
+	orderID, err := strconv.ParseInt(cells[manifestOrderID], 10, 64)
+	switch {
+	case cells[manifestOrderID] == "":
+		violations = append(violations, common.FieldViolation{Field: "order_id", Reason: "REQUIRED", Description: "order_id is required"})
+	case err != nil || orderID <= 0:
+		violations = append(violations, common.FieldViolation{
+			Field:       "order_id",
+			Reason:      "INVALID_FORMAT",
+			Description: fmt.Sprintf("%q is not an order id", cells[manifestOrderID]),
+		})
+	}
+	line.request.OrderId = orderID
+
+	if cell := cells[manifestWarehouseID]; cell != "" {
+		warehouseID, err := strconv.ParseInt(cell, 10, 64)
+		if err != nil || warehouseID <= 0 {
+			violations = append(violations, common.FieldViolation{
+				Field:       "warehouse_id",
+				Reason:      "INVALID_FORMAT",
+				Description: fmt.Sprintf("%q is not a warehouse id", cell),
+			})
+		}
+		line.request.WarehouseId = warehouseID
+	}
+
+	var entries []string
+	for _, entry := range strings.Split(cells[manifestItems], ";") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	for i, entry := range entries {
+		separator := strings.LastIndex(entry, ":")
+		var quantity int64
+		if separator > 0 {
+			quantity, err = strconv.ParseInt(strings.TrimSpace(entry[separator+1:]), 10, 32)
+		}
+		if separator <= 0 || err != nil || quantity <= 0 {
+			violations = append(violations, common.FieldViolation{
+				Field:       fmt.Sprintf("items[%d]", i),
+				Reason:      "INVALID_FORMAT",
+				Description: fmt.Sprintf("%q is not sku:quantity with a positive quantity", entry),
+			})
+			continue
+		}
+		line.request.Items = append(line.request.Items, &shipmentPb.ShipmentItemRequest{
+			Sku:      strings.TrimSpace(entry[:separator]),
+			Quantity: int32(quantity),
+		})
+	}
+	if len(line.request.Items) == 0 && len(violations) == 0 {
+		violations = append(violations, common.FieldViolation{Field: "items", Reason: "REQUIRED", Description: "at least one item is required"})
+	}
+
+	if !blankRecord(cells[manifestShipToName : manifestShipToPhone+1]) {
+		line.request.ShipTo = &shipmentPb.Address{
+			Name:       cells[manifestShipToName],
+			Line1:      cells[manifestShipToLine1],
+			Line2:      cells[manifestShipToLine2],
+			City:       cells[manifestShipToCity],
+			Region:     cells[manifestShipToRegion],
+			PostalCode: cells[manifestShipToPostalCode],
+			Country:    cells[manifestShipToCountry],
+			Phone:      cells[manifestShipToPhone],
+		}
+	}
+
+	if len(violations) > 0 {
+		line.err = common.BadRequest("invalid line")
+		line.err.FieldViolations = violations
+		line.request = nil
+	}
+	return line
+}
+
+func blankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
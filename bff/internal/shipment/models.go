@@ -1,5 +1,10 @@
 package shipment
 
+import (
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
 // Request and response types
 type ShipmentItemRequest struct {
 	Sku      string `json:"sku"`
@@ -7,8 +12,50 @@ type ShipmentItemRequest struct {
 }
 
 type CreateShipmentRequest struct {
-	OrderID int64                 `json:"order_id"`
-	Items   []ShipmentItemRequest `json:"items"`
+	OrderID               int64                 `json:"order_id"`
+	Items                 []ShipmentItemRequest `json:"items"`
+	CarrierCode           string                `json:"carrier_code"`
+	DestinationPostalCode string                `json:"destination_postal_code"`
+	WarehouseID           int64                 `json:"warehouse_id"`
+	// Plan creates one shipment per warehouse instead, as proposed by the allocation route
+	Plan []PlannedShipment `json:"plan"`
+	// Parcels are the packages the items are already packed in
+	Parcels []ParcelRequest `json:"parcels"`
+	// ShipTo is the recipient printed on labels
+	ShipTo *Address `json:"ship_to"`
+}
+
+// Address represents the postal address of a recipient
+type Address struct {
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+}
+
+// ParcelRequest represents a packed parcel and the quantity of each SKU in it
+type ParcelRequest struct {
+	WeightKg float64               `json:"weight_kg"`
+	LengthCm float64               `json:"length_cm"`
+	WidthCm  float64               `json:"width_cm"`
+	HeightCm float64               `json:"height_cm"`
+	Items    []ShipmentItemRequest `json:"items"`
+}
+
+// PackShipmentRequest represents the body of a request recording the parcels of a shipment
+type PackShipmentRequest struct {
+	Parcels []ParcelRequest `json:"parcels" binding:"required,min=1"`
+}
+
+// PlannedShipment represents the part of an order shipped from one warehouse
+type PlannedShipment struct {
+	WarehouseID   int64                 `json:"warehouse_id"`
+	WarehouseCode string                `json:"warehouse_code"`
+	Items         []ShipmentItemRequest `json:"items"`
 }
 
 type ShipmentResponse struct {
@@ -16,3 +63,96 @@ type ShipmentResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 }
+
+// ListShipmentsQuery represents the query parameters of a shipment list request
+type ListShipmentsQuery struct {
+	OrderID     int64  `form:"order_id"`
+	Status      string `form:"status"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	PageSize    int32  `form:"page_size" binding:"omitempty,min=1,max=200"`
+	Cursor      string `form:"cursor"`
+}
+
+// ListShipmentsResponse represents a page of shipments
+type ListShipmentsResponse struct {
+	Shipments  []*shipmentPb.ShipmentData `json:"shipments"`
+	NextCursor string                     `json:"next_cursor,omitempty"`
+}
+
+// ManifestResponse summarizes the shipments created from a manifest, lines are numbered by their line in the file
+type ManifestResponse struct {
+	Total     int                  `json:"total"`
+	Confirmed []ManifestLineResult `json:"confirmed"`
+	Failed    []ManifestLineResult `json:"failed"`
+	// Reasons counts the failed lines by reason
+	Reasons map[string]int `json:"reasons"`
+}
+
+// ManifestLineResult represents the shipment created from a line of a manifest, or why it was not
+type ManifestLineResult struct {
+	Line            int                      `json:"line"`
+	Reference       string                   `json:"reference,omitempty"`
+	Shipment        *shipmentPb.ShipmentData `json:"shipment,omitempty"`
+	Reason          string                   `json:"reason,omitempty"`
+	Message         string                   `json:"message,omitempty"`
+	FieldViolations []common.FieldViolation  `json:"field_violations,omitempty"`
+}
+
+// TrackingResponse represents a shipment with its status changes, oldest first
+type TrackingResponse struct {
+	Shipment *shipmentPb.ShipmentData    `json:"shipment"`
+	Events   []*shipmentPb.ShipmentEvent `json:"events"`
+}
+
+// RequestReturnRequest represents the body of a return request for items of a shipment
+type RequestReturnRequest struct {
+	Reason string                `json:"reason"`
+	Items  []ShipmentItemRequest `json:"items" binding:"required,min=1"`
+}
+
+// ReturnActionRequest represents the body of a request moving a return to its next status
+type ReturnActionRequest struct {
+	Note string `json:"note"`
+}
+
+// WarehouseRequest represents the body of a request storing a warehouse by code
+type WarehouseRequest struct {
+	Code       string `json:"code" binding:"required"`
+	Name       string `json:"name"`
+	PostalCode string `json:"postal_code" binding:"required"`
+	Priority   int32  `json:"priority"`
+	Active     bool   `json:"active"`
+}
+
+// WarehouseStockRequest represents the body of a request storing the stock of a warehouse
+type WarehouseStockRequest struct {
+	Stock []ShipmentItemRequest `json:"stock" binding:"required,min=1"`
+}
+
+// AllocationRequest represents the body of a request splitting an order across warehouses
+type AllocationRequest struct {
+	DestinationPostalCode string                `json:"destination_postal_code"`
+	Strategy              string                `json:"strategy"`
+	Items                 []ShipmentItemRequest `json:"items"`
+}
+
+// ShippingDocumentQuery represents the query parameters of a shipping document download
+type ShippingDocumentQuery struct {
+	Format string `form:"format"`
+}
+
+// OrderEventMessage is a status change of a shipment of an order on an event stream, or a heartbeat on a WebSocket
+type OrderEventMessage struct {
+	Type       string                    `json:"type"`
+	OrderID    int64                     `json:"order_id"`
+	ShipmentID int64                     `json:"shipment_id,omitempty"`
+	Event      *shipmentPb.ShipmentEvent `json:"event,omitempty"`
+}
+
+func newOrderEventMessage(update *shipmentPb.WatchUpdate) *OrderEventMessage {
+	if update.Heartbeat {
+		return &OrderEventMessage{Type: eventTypeHeartbeat, OrderID: update.OrderId}
+	}
+	return &OrderEventMessage{Type: eventTypeShipment, OrderID: update.OrderId, ShipmentID: update.ShipmentId, Event: update.Event}
+}
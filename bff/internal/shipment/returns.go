@@ -0,0 +1,132 @@
+package shipment
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+// returnActionFunc is a shipment service call moving a return to its next status
+type returnActionFunc func(client shipmentPb.ShipmentServiceClient, ctx context.Context, in *shipmentPb.ReturnActionRequest, opts ...grpc.CallOption) (*shipmentPb.ReturnResponse, error)
+
+// RequestReturn handles HTTP request to open a return for items of a shipment
+func (h *Handler) RequestReturn(ctx *gin.Context) {
+	shipmentID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid shipment id"))
+		return
+	}
+
+	var request RequestReturnRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.RequestReturn(ctx, &shipmentPb.RequestReturnRequest{
+		ShipmentId: shipmentID,
+		Reason:     request.Reason,
+		Items:      toProtoItems(request.Items),
+	})
+	writeReturnResponse(ctx, http.StatusCreated, protoResp, err)
+}
+
+// GetReturn handles HTTP request to get a return with its items
+func (h *Handler) GetReturn(ctx *gin.Context) {
+	returnID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid return id"))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.GetReturn(ctx, &shipmentPb.GetReturnRequest{ReturnId: returnID})
+	writeReturnResponse(ctx, http.StatusOK, protoResp, err)
+}
+
+// ApproveReturn handles HTTP request to approve a return
+func (h *Handler) ApproveReturn(ctx *gin.Context) {
+	h.returnAction(ctx, shipmentPb.ShipmentServiceClient.ApproveReturn)
+}
+
+// RejectReturn handles HTTP request to reject a return
+func (h *Handler) RejectReturn(ctx *gin.Context) {
+	h.returnAction(ctx, shipmentPb.ShipmentServiceClient.RejectReturn)
+}
+
+// ReceiveReturn handles HTTP request to receive the items of a return, which credits their invoiced amount
+func (h *Handler) ReceiveReturn(ctx *gin.Context) {
+	h.returnAction(ctx, shipmentPb.ShipmentServiceClient.ReceiveReturn)
+}
+
+// InspectReturn handles HTTP request to record the inspection of a return
+func (h *Handler) InspectReturn(ctx *gin.Context) {
+	h.returnAction(ctx, shipmentPb.ShipmentServiceClient.InspectReturn)
+}
+
+// returnAction moves the return of the request to its next status with the given call
+func (h *Handler) returnAction(ctx *gin.Context, call returnActionFunc) {
+	returnID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid return id"))
+		return
+	}
+
+	// The note is optional, so is the body
+	var request ReturnActionRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(common.BindError(err))
+			return
+		}
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := call(shipmentClient, ctx, &shipmentPb.ReturnActionRequest{ReturnId: returnID, Note: request.Note})
+	writeReturnResponse(ctx, http.StatusOK, protoResp, err)
+}
+
+// client returns the shipment service client, it writes the error response when there is none
+func (h *Handler) client(ctx *gin.Context) (shipmentPb.ShipmentServiceClient, bool) {
+	client, _, err := h.ShipmentConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		ctx.Error(common.ServiceUnavailable("shipment"))
+		return nil, false
+	}
+	return client.(shipmentPb.ShipmentServiceClient), true
+}
+
+// writeReturnResponse writes the return of a shipment service response or its error
+func writeReturnResponse(ctx *gin.Context, code int, protoResp *shipmentPb.ReturnResponse, err error) {
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(code, &ShipmentResponse{
+		Code:    code,
+		Message: "success",
+		Data:    protoResp.Data,
+	})
+}
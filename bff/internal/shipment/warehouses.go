@@ -0,0 +1,159 @@
+package shipment
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/internal/common"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+// UpsertWarehouse handles HTTP request to create a warehouse or replace the warehouse with the same code
+func (h *Handler) UpsertWarehouse(ctx *gin.Context) {
+	var request WarehouseRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.UpsertWarehouse(ctx, &shipmentPb.UpsertWarehouseRequest{
+		Warehouse: &shipmentPb.Warehouse{
+			Code:       request.Code,
+			Name:       request.Name,
+			PostalCode: request.PostalCode,
+			Priority:   request.Priority,
+			Active:     request.Active,
+		},
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp.Warehouse,
+	})
+}
+
+// ListWarehouses handles HTTP request to list every warehouse
+func (h *Handler) ListWarehouses(ctx *gin.Context) {
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.ListWarehouses(ctx, &shipmentPb.ListWarehousesRequest{})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp.Warehouses,
+	})
+}
+
+// UpsertWarehouseStock handles HTTP request to set the quantity of SKUs on hand in a warehouse
+func (h *Handler) UpsertWarehouseStock(ctx *gin.Context) {
+	warehouseID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid warehouse id"))
+		return
+	}
+
+	var request WarehouseStockRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(common.BindError(err))
+		return
+	}
+
+	stock := make([]*shipmentPb.WarehouseStock, len(request.Stock))
+	for i, item := range request.Stock {
+		stock[i] = &shipmentPb.WarehouseStock{
+			WarehouseId: warehouseID,
+			Sku:         item.Sku,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.UpsertWarehouseStock(ctx, &shipmentPb.UpsertWarehouseStockRequest{Stock: stock})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    gin.H{"updated": protoResp.Updated},
+	})
+}
+
+// AllocateShipments handles HTTP request to propose a split of an order into per-warehouse shipments.
+// The plan can be sent back as is to the shipment creation route.
+func (h *Handler) AllocateShipments(ctx *gin.Context) {
+	orderID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid order id"))
+		return
+	}
+
+	// Every field is optional, so is the body
+	var request AllocationRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(common.BindError(err))
+			return
+		}
+	}
+
+	shipmentClient, ok := h.client(ctx)
+	if !ok {
+		return
+	}
+
+	protoResp, err := shipmentClient.AllocateShipments(ctx, &shipmentPb.AllocateShipmentsRequest{
+		OrderId:               orderID,
+		DestinationPostalCode: request.DestinationPostalCode,
+		Strategy:              request.Strategy,
+		Items:                 toProtoItems(request.Items),
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &ShipmentResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    protoResp,
+	})
+}
+
+// toProtoItems converts requested items to their protobuf form
+func toProtoItems(items []ShipmentItemRequest) []*shipmentPb.ShipmentItemRequest {
+	protoItems := make([]*shipmentPb.ShipmentItemRequest, len(items))
+	for i, item := range items {
+		protoItems[i] = &shipmentPb.ShipmentItemRequest{
+			Sku:      item.Sku,
+			Quantity: item.Quantity,
+		}
+	}
+	return protoItems
+}
@@ -0,0 +1,150 @@
+package summary
+
+import (
+	billingPb "billing-system/billing_service/proto"
+)
+
+// buildSummary combines the responses of the calls into the view of the order.
+// Quantities of a section that failed are left null rather than reported as zero.
+func buildSummary(r results, unavailable []UnavailableSection) OrderSummaryResponse {
+	order := r.order
+	summary := OrderSummaryResponse{
+		Order: OrderInfo{
+			ID:          order.Id,
+			CustomerID:  order.CustomerId,
+			Status:      order.Status.String(),
+			TotalAmount: order.TotalAmount,
+			PaymentTerm: order.PaymentTerm,
+			CreatedAt:   order.CreatedAt,
+			UpdatedAt:   order.UpdatedAt,
+		},
+		Items:       summarizeItems(order.Items),
+		Shipments:   []ShipmentSummary{},
+		Invoices:    []InvoiceSummary{},
+		Payments:    make([]PaymentInfo, len(order.Payments)),
+		Degraded:    len(r.failed) > 0,
+		Unavailable: unavailable,
+	}
+	for i, payment := range order.Payments {
+		summary.Payments[i] = PaymentInfo{ID: payment.Id, Method: payment.Method, Amount: payment.Amount}
+	}
+
+	if available(unavailable, SectionQuantities) {
+		quantities := make(map[int64]*billingPb.ShippableQuantity, len(r.quantities))
+		for _, quantity := range r.quantities {
+			quantities[quantity.ItemId] = quantity
+		}
+		for i := range summary.Items {
+			item := &summary.Items[i]
+			invoiced, remaining := 0, item.Ordered
+			if quantity, ok := quantities[item.ItemID]; ok {
+				invoiced, remaining = int(quantity.Invoiced), int(quantity.Remaining)
+			}
+			item.Invoiced, item.Remaining = &invoiced, &remaining
+		}
+	}
+
+	if available(unavailable, SectionShipments) {
+		shipped := make(map[string]int)
+		for _, shipment := range r.shipments {
+			view := ShipmentSummary{
+				ID:             shipment.ShipmentId,
+				Status:         shipment.Status,
+				CarrierCode:    shipment.CarrierCode,
+				TrackingNumber: shipment.TrackingNumber,
+				Items:          make([]ShipmentItemQty, len(shipment.Items)),
+				CreatedAt:      shipment.CreatedAt,
+				UpdatedAt:      shipment.UpdatedAt,
+			}
+			for i, item := range shipment.Items {
+				view.Items[i] = ShipmentItemQty{Sku: item.Sku, Quantity: int(item.Quantity)}
+				if shippedStatuses[shipment.Status] {
+					shipped[item.Sku] += int(item.Quantity)
+				}
+			}
+			summary.Shipments = append(summary.Shipments, view)
+		}
+		for i := range summary.Items {
+			quantity := shipped[summary.Items[i].Sku]
+			summary.Items[i].Shipped = &quantity
+		}
+	}
+
+	summary.PaymentStatus = PaymentStatusUnknown
+	if available(unavailable, SectionInvoices) {
+		for _, invoice := range r.invoices {
+			summary.Invoices = append(summary.Invoices, InvoiceSummary{
+				ID:                invoice.Id,
+				ShipmentID:        invoice.ShipmentId,
+				TotalAmount:       invoice.TotalAmount,
+				PaidAmount:        invoice.PaidAmount,
+				CreditedAmount:    invoice.CreditedAmount,
+				OutstandingAmount: invoice.OutstandingAmount,
+				PaymentStatus:     invoice.PaymentStatus,
+				DueDate:           invoice.DueDate,
+			})
+			summary.OutstandingAmount += max(invoice.OutstandingAmount, 0)
+		}
+		summary.PaymentStatus = paymentStatus(r.invoices)
+	}
+
+	return summary
+}
+
+// summarizeItems returns an item per ordered SKU, an item ordered on several lines is reported once
+func summarizeItems(orderItems []*billingPb.OrderItem) []ItemSummary {
+	items := []ItemSummary{}
+	index := make(map[int64]int)
+	for _, orderItem := range orderItems {
+		if i, ok := index[orderItem.ItemId]; ok {
+			items[i].Ordered += int(orderItem.Quantity)
+			continue
+		}
+		index[orderItem.ItemId] = len(items)
+		items = append(items, ItemSummary{
+			ItemID:  orderItem.ItemId,
+			Sku:     orderItem.Sku,
+			Name:    orderItem.Name,
+			Ordered: int(orderItem.Quantity),
+		})
+	}
+	return items
+}
+
+// available reports whether the section was not left out
+func available(unavailable []UnavailableSection, name string) bool {
+	for _, section := range unavailable {
+		if section.Section == name {
+			return false
+		}
+	}
+	return true
+}
+
+// paymentStatus returns the payment status of an order from the statuses of its invoices.
+// An overdue invoice makes the order overdue, and it is paid once every invoice is.
+func paymentStatus(invoices []*billingPb.Invoice) string {
+	if len(invoices) == 0 {
+		return PaymentStatusNotInvoiced
+	}
+	paid, started := 0, false
+	for _, invoice := range invoices {
+		switch invoice.PaymentStatus {
+		case "OVERDUE":
+			return "OVERDUE"
+		case "PAID":
+			paid++
+			started = true
+		case "PARTIALLY_PAID":
+			started = true
+		}
+	}
+	switch {
+	case paid == len(invoices):
+		return "PAID"
+	case started:
+		return "PARTIALLY_PAID"
+	default:
+		return "UNPAID"
+	}
+}
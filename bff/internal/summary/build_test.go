@@ -0,0 +1,57 @@
+package summary
+
+import (
+	"errors"
+	"testing"
+
+	billingPb "billing-system/billing_service/proto"
+)
+
+func TestPaymentStatus(t *testing.T) {
+	invoices := func(statuses ...string) []*billingPb.Invoice {
+		var result []*billingPb.Invoice
+		for _, status := range statuses {
+			result = append(result, &billingPb.Invoice{PaymentStatus: status})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		invoices []*billingPb.Invoice
+		want     string
+	}{
+		{"no invoice yet", nil, PaymentStatusNotInvoiced},
+		{"nothing paid", invoices("UNPAID", "UNPAID"), "UNPAID"},
+		{"one invoice paid", invoices("PAID", "UNPAID"), "PARTIALLY_PAID"},
+		{"one invoice partly paid", invoices("PARTIALLY_PAID"), "PARTIALLY_PAID"},
+		{"every invoice paid", invoices("PAID", "PAID"), "PAID"},
+		{"one invoice overdue", invoices("PAID", "OVERDUE"), "OVERDUE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paymentStatus(tt.invoices); got != tt.want {
+				t.Errorf("paymentStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSummaryLeavesUnavailableQuantitiesNull(t *testing.T) {
+	r := results{
+		order: &billingPb.Order{Id: 1, Items: []*billingPb.OrderItem{
+			{ItemId: 7, Sku: "SKU-1", Quantity: 2},
+			{ItemId: 7, Sku: "SKU-1", Quantity: 1},
+		}},
+		failed: map[string]error{SectionQuantities: errors.New("unavailable")},
+	}
+	view := buildSummary(r, []UnavailableSection{{Section: SectionQuantities}})
+
+	if !view.Degraded || len(view.Items) != 1 {
+		t.Fatalf("degraded = %v, items = %+v", view.Degraded, view.Items)
+	}
+	item := view.Items[0]
+	if item.Ordered != 3 || item.Invoiced != nil || item.Remaining != nil || item.Shipped == nil || *item.Shipped != 0 {
+		t.Errorf("item = %+v, want 3 ordered, nothing shipped and unknown invoiced and remaining", item)
+	}
+}
@@ -0,0 +1,206 @@
+// Package summary serves views of an order aggregated from the billing and shipment services.
+// The services are called concurrently, each call with its own deadline, and a failed call leaves
+// its section out of the view instead of failing the request. Only the order itself is required.
+package summary
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"billing-system/bff/config"
+	"billing-system/bff/internal/billing"
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/shipment"
+	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+// defaultCallTimeout is the deadline of each service call when the configuration sets none
+const defaultCallTimeout = 2 * time.Second
+
+// Sections of a summary that may be left out, the order itself is required
+const (
+	sectionOrder      = "order"
+	SectionQuantities = "quantities"
+	SectionInvoices   = "invoices"
+	SectionShipments  = "shipments"
+)
+
+// Payment statuses of an order, the statuses of its invoices add UNPAID, PARTIALLY_PAID, PAID and OVERDUE
+const (
+	PaymentStatusNotInvoiced = "NOT_INVOICED"
+	PaymentStatusUnknown     = "UNKNOWN"
+)
+
+// listShipmentsPageSize is the largest page the shipment service returns
+const listShipmentsPageSize = 200
+
+// shippedStatuses are the statuses of shipments that left the warehouse and did not come back
+var shippedStatuses = map[string]bool{
+	"IN_TRANSIT":       true,
+	"OUT_FOR_DELIVERY": true,
+	"DELIVERED":        true,
+	"FAILED_DELIVERY":  true,
+}
+
+// Handler serves the aggregated views, it shares the connections of the billing and shipment handlers
+type Handler struct {
+	BillingConnection  *billing.BillingConnectionAdapter
+	ShipmentConnection *shipment.ShipmentConnectionAdapter
+}
+
+// NewHandler creates a summary handler calling the services over the given connections
+func NewHandler(billingConnection *billing.BillingConnectionAdapter, shipmentConnection *shipment.ShipmentConnectionAdapter) *Handler {
+	return &Handler{
+		BillingConnection:  billingConnection,
+		ShipmentConnection: shipmentConnection,
+	}
+}
+
+// results holds the responses of the calls of a summary, the error of a call is kept with its section
+type results struct {
+	order      *billingPb.Order
+	quantities []*billingPb.ShippableQuantity
+	invoices   []*billingPb.Invoice
+	shipments  []*shipmentPb.ShipmentData
+	failed     map[string]error
+}
+
+// GetOrderSummary returns an order with its items, shipments, invoices and payment status.
+// Customers only get their own orders. They cannot list shipments, so their summaries have no shipments section.
+func (h *Handler) GetOrderSummary(ctx *gin.Context) {
+	orderID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(common.BadRequest("invalid order id"))
+		return
+	}
+
+	client, _, err := h.BillingConnection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		ctx.Error(common.ServiceUnavailable("billing"))
+		return
+	}
+	billingClient := client.(billingPb.BillingServiceClient)
+
+	// Customers cannot list shipments, the shipment service is not called for them
+	var shipmentClient shipmentPb.ShipmentServiceClient
+	var unavailable []UnavailableSection
+	r := results{failed: make(map[string]error)}
+	if principal, ok := auth.FromContext(ctx); ok && !principal.Can(auth.PermissionShipmentsRead) {
+		unavailable = append(unavailable, UnavailableSection{
+			Section: SectionShipments,
+			Reason:  "PERMISSION_DENIED",
+			Message: "the caller may not read shipments",
+		})
+	} else if client, _, err := h.ShipmentConnection.NewClient(); err != nil {
+		log.Println("Error connecting to shipment service:", err)
+		r.failed[SectionShipments] = common.ServiceUnavailable("shipment")
+	} else {
+		shipmentClient = client.(shipmentPb.ShipmentServiceClient)
+	}
+
+	// Each call runs with its own deadline and records its result in its own field of r
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	call := func(section string, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			callCtx, cancel := context.WithTimeout(ctx, callTimeout())
+			defer cancel()
+			if err := fn(callCtx); err != nil {
+				mu.Lock()
+				r.failed[section] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	call(sectionOrder, func(ctx context.Context) error {
+		resp, err := billingClient.GetOrder(ctx, &billingPb.GetOrderRequest{OrderId: orderID})
+		r.order = resp.GetOrder()
+		return err
+	})
+	call(SectionQuantities, func(ctx context.Context) error {
+		resp, err := billingClient.GetShippableQuantities(ctx, &billingPb.GetShippableQuantitiesRequest{OrderId: orderID})
+		r.quantities = resp.GetQuantities()
+		return err
+	})
+	call(SectionInvoices, func(ctx context.Context) error {
+		resp, err := billingClient.ListOrderInvoices(ctx, &billingPb.ListOrderInvoicesRequest{OrderId: orderID})
+		r.invoices = resp.GetInvoices()
+		return err
+	})
+	if shipmentClient != nil {
+		call(SectionShipments, func(ctx context.Context) error {
+			shipments, err := listOrderShipments(ctx, shipmentClient, orderID)
+			r.shipments = shipments
+			return err
+		})
+	}
+
+	wg.Wait()
+
+	// The other sections are not shown without the order, which also hides them from customers of other orders
+	if err, ok := r.failed[sectionOrder]; ok {
+		ctx.Error(err)
+		return
+	}
+
+	for _, section := range []string{SectionQuantities, SectionInvoices, SectionShipments} {
+		err, ok := r.failed[section]
+		if !ok {
+			continue
+		}
+		log.Printf("Order %d summary is missing %s: %v", orderID, section, err)
+		apiErr := toAPIError(err)
+		unavailable = append(unavailable, UnavailableSection{Section: section, Reason: apiErr.Reason, Message: apiErr.Message})
+	}
+
+	ctx.JSON(http.StatusOK, common.SuccessResponse(buildSummary(r, unavailable)))
+}
+
+// listOrderShipments returns every shipment of an order, following the pages of the shipment service
+func listOrderShipments(ctx context.Context, client shipmentPb.ShipmentServiceClient, orderID int64) ([]*shipmentPb.ShipmentData, error) {
+	var shipments []*shipmentPb.ShipmentData
+	cursor := ""
+	for {
+		resp, err := client.ListShipments(ctx, &shipmentPb.ListShipmentsRequest{
+			OrderId:  orderID,
+			PageSize: listShipmentsPageSize,
+			Cursor:   cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, resp.Shipments...)
+		if resp.NextCursor == "" {
+			return shipments, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// callTimeout returns the configured deadline of each service call
+func callTimeout() time.Duration {
+	if timeout := config.Service.Summary.CallTimeout; timeout > 0 {
+		return timeout
+	}
+	return defaultCallTimeout
+}
+
+// toAPIError converts the error of a call, which is an APIError when the BFF could not connect
+func toAPIError(err error) *common.APIError {
+	if apiErr, ok := err.(*common.APIError); ok {
+		return apiErr
+	}
+	return common.FromGRPC(err)
+}
@@ -0,0 +1,80 @@
+package summary
+
+// OrderSummaryResponse is the view of an order with what was shipped, invoiced and paid of it.
+// Sections a service could not provide are listed in Unavailable, and Degraded is set when a call failed.
+type OrderSummaryResponse struct {
+	Order             OrderInfo            `json:"order"`
+	Items             []ItemSummary        `json:"items"`
+	Shipments         []ShipmentSummary    `json:"shipments"`
+	Invoices          []InvoiceSummary     `json:"invoices"`
+	Payments          []PaymentInfo        `json:"payments"`
+	PaymentStatus     string               `json:"payment_status"`
+	OutstandingAmount float64              `json:"outstanding_amount"`
+	Degraded          bool                 `json:"degraded"`
+	Unavailable       []UnavailableSection `json:"unavailable,omitempty"`
+}
+
+// OrderInfo is the order a summary is about
+type OrderInfo struct {
+	ID          int64   `json:"id"`
+	CustomerID  string  `json:"customer_id"`
+	Status      string  `json:"status"`
+	TotalAmount float64 `json:"total_amount"`
+	PaymentTerm string  `json:"payment_term,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// ItemSummary is an ordered item, quantities of an unavailable section are null
+type ItemSummary struct {
+	ItemID    int64  `json:"item_id"`
+	Sku       string `json:"sku"`
+	Name      string `json:"name"`
+	Ordered   int    `json:"ordered"`
+	Shipped   *int   `json:"shipped"`
+	Invoiced  *int   `json:"invoiced"`
+	Remaining *int   `json:"remaining"`
+}
+
+// ShipmentSummary is a shipment of the order with its status
+type ShipmentSummary struct {
+	ID             int64             `json:"id"`
+	Status         string            `json:"status"`
+	CarrierCode    string            `json:"carrier_code,omitempty"`
+	TrackingNumber string            `json:"tracking_number,omitempty"`
+	Items          []ShipmentItemQty `json:"items"`
+	CreatedAt      string            `json:"created_at"`
+	UpdatedAt      string            `json:"updated_at"`
+}
+
+// ShipmentItemQty is the quantity of a SKU in a shipment
+type ShipmentItemQty struct {
+	Sku      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// InvoiceSummary is an invoice of the order with its payment status
+type InvoiceSummary struct {
+	ID                int64   `json:"id"`
+	ShipmentID        int64   `json:"shipment_id"`
+	TotalAmount       float64 `json:"total_amount"`
+	PaidAmount        float64 `json:"paid_amount"`
+	CreditedAmount    float64 `json:"credited_amount"`
+	OutstandingAmount float64 `json:"outstanding_amount"`
+	PaymentStatus     string  `json:"payment_status"`
+	DueDate           string  `json:"due_date"`
+}
+
+// PaymentInfo is a payment method of the order
+type PaymentInfo struct {
+	ID     int64   `json:"id"`
+	Method string  `json:"method"`
+	Amount float64 `json:"amount"`
+}
+
+// UnavailableSection is a section of the summary left out, with the reason of the failed call
+type UnavailableSection struct {
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
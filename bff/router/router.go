@@ -1,36 +1,180 @@
 package router
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	"billing-system/bff/config"
+	"billing-system/bff/internal/apikey"
 	billing "billing-system/bff/internal/billing"
+	"billing-system/bff/internal/bulk"
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/middleware"
+	"billing-system/bff/internal/openapi"
+	"billing-system/bff/internal/ratelimit"
 	shipment "billing-system/bff/internal/shipment"
+	"billing-system/bff/internal/summary"
+	"billing-system/pkg/auth"
 )
 
 func Start() {
-	// Create a default gin router
+	router, err := New()
+	if err != nil {
+		log.Fatal("Failed to create router", zap.Error(err))
+	}
+
+	// Start HTTP server
+	serverAddress := config.Service.Server.Address
+
+	if err := router.Run(serverAddress); err != nil {
+		log.Fatal("Failed to start server", zap.Error(err))
+	}
+}
+
+// New creates the router of every BFF route with the configuration of config.Service
+func New() (*gin.Engine, error) {
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a default gin router, handlers pass the gin context to gRPC calls and the token is read from its request
 	router := gin.Default()
+	router.ContextWithFallback = true
+	if err := router.SetTrustedProxies(config.Service.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	if config.Service.Server.Mode == config.ModeDev {
+		router.Use(middleware.ValidateResponses(spec))
+	}
+	router.Use(middleware.ErrorHandler())
+	common.UseJSONFieldNames()
+
+	verifier, err := auth.NewVerifier(config.Service.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+	if verifier == nil {
+		log.Println("Authentication is disabled, every client may call every route")
+	}
+	limiter, addressLimiter, err := newRateLimiters(config.Service.RateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure rate limiting: %w", err)
+	}
+
+	// The specification of the API and a page to browse it
+	router.GET("/openapi.json", openapi.ServeSpec(spec))
+	router.GET("/docs", openapi.ServeSwaggerUI("/openapi.json"))
 
 	// Initialize billing handler
 	billingHandler := billing.NewHandler()
 	shipmentHandler := shipment.NewHandler()
+	summaryHandler := summary.NewHandler(billingHandler.BillingConnection, shipmentHandler.ShipmentConnection)
+	bulkHandler := bulk.NewHandler(billingHandler.BillingConnection)
+
+	// Server-to-server clients authenticate with API keys the billing service verifies
+	apiKeys, err := apikey.New(config.Service.APIKeys, config.Service.Auth, billingHandler.VerifyAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure api keys: %w", err)
+	}
+
+	// Carriers sign their webhooks instead of sending a token
+	publicRoutes := router.Group("/api/v1", middleware.RateLimit(limiter), middleware.ValidateRequests(spec))
+	{
+		publicRoutes.POST("/carriers/:code/webhook", shipmentHandler.CarrierWebhook)
+	}
 
 	// Set up billing API routes
-	billingRoutes := router.Group("/api/v1")
+	// Each address is limited before credentials are verified, then clients are rate limited once authenticated,
+	// so each user has its own buckets
+	billingRoutes := router.Group("/api/v1",
+		middleware.RateLimitByAddress(addressLimiter),
+		middleware.Authenticate(verifier, apiKeys),
+		middleware.RateLimit(limiter),
+		middleware.ValidateRequests(spec),
+	)
 	{
 		// Order endpoints
-		billingRoutes.POST("/orders", billingHandler.CreateOrder)
-		billingRoutes.POST("/shipments", shipmentHandler.CreateShipment)
+		billingRoutes.POST("/orders", middleware.Require(auth.PermissionOrdersWrite), billingHandler.CreateOrder)
+		billingRoutes.POST("/orders/quote", middleware.Require(auth.PermissionOrdersWrite), billingHandler.QuoteOrder)
+		billingRoutes.POST("/orders/bulk", middleware.Require(auth.PermissionOrdersWrite), bulkHandler.ImportOrders)
+		billingRoutes.GET("/jobs/:id", middleware.Require(auth.PermissionOrdersRead), bulkHandler.GetJob)
+		billingRoutes.GET("/jobs/:id/failed-rows", middleware.Require(auth.PermissionOrdersRead), bulkHandler.DownloadFailedRows)
+		billingRoutes.GET("/orders/:id", middleware.Require(auth.PermissionOrdersRead), billingHandler.GetOrder)
+		billingRoutes.GET("/orders/:id/summary", middleware.Require(auth.PermissionOrdersRead), summaryHandler.GetOrderSummary)
+		billingRoutes.GET("/orders/:id/events", middleware.Require(auth.PermissionOrdersRead), shipmentHandler.StreamOrderEvents)
+		billingRoutes.GET("/orders/:id/events/ws", middleware.Require(auth.PermissionOrdersRead), shipmentHandler.StreamOrderEventsWebSocket)
+		billingRoutes.POST("/orders/:id/allocation", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.AllocateShipments)
+		billingRoutes.POST("/shipments", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.CreateShipment)
+		billingRoutes.POST("/shipments/manifest", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.UploadManifest)
+		billingRoutes.GET("/shipments", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.ListShipments)
+		billingRoutes.GET("/shipments/:id", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.GetShipment)
+		billingRoutes.GET("/shipments/:id/tracking", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.GetTracking)
+		billingRoutes.PUT("/shipments/:id/parcels", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.PackShipment)
+		billingRoutes.GET("/shipments/:id/documents/:type", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.DownloadShippingDocument)
+		billingRoutes.GET("/shipments/:id/delivery-proof", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.GetDeliveryProof)
+		billingRoutes.GET("/shipments/:id/delivery-proof/files/:file_id", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.DownloadDeliveryFile)
+		billingRoutes.POST("/shipments/:id/returns", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.RequestReturn)
+		billingRoutes.GET("/returns/:id", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.GetReturn)
+		billingRoutes.POST("/returns/:id/approve", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.ApproveReturn)
+		billingRoutes.POST("/returns/:id/reject", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.RejectReturn)
+		billingRoutes.POST("/returns/:id/receive", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.ReceiveReturn)
+		billingRoutes.POST("/returns/:id/inspect", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.InspectReturn)
+		billingRoutes.GET("/warehouses", middleware.Require(auth.PermissionShipmentsRead), shipmentHandler.ListWarehouses)
+		billingRoutes.PUT("/warehouses", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.UpsertWarehouse)
+		billingRoutes.PUT("/warehouses/:id/stock", middleware.Require(auth.PermissionShipmentsWrite), shipmentHandler.UpsertWarehouseStock)
+
+		// API keys of server-to-server clients
+		billingRoutes.POST("/api-keys", middleware.Require(auth.PermissionAdmin), billingHandler.IssueAPIKey)
+		billingRoutes.GET("/api-keys", middleware.Require(auth.PermissionAdmin), billingHandler.ListAPIKeys)
+		billingRoutes.POST("/api-keys/:id/rotate", middleware.Require(auth.PermissionAdmin), billingHandler.RotateAPIKey)
+		billingRoutes.DELETE("/api-keys/:id", middleware.Require(auth.PermissionAdmin), billingHandler.RevokeAPIKey)
 	}
 
-	// Start HTTP server
-	serverAddress := config.Service.Server.Address
+	return router, nil
+}
 
-	if err := router.Run(serverAddress); err != nil {
-		log.Fatal("Failed to start server", zap.Error(err))
+// newRateLimiters returns the limiter of clients and the limiter of addresses of the configuration,
+// nil when rate limiting or the limit per address is disabled
+func newRateLimiters(cfg config.RateLimitConfig) (*ratelimit.Limiter, *ratelimit.Limiter, error) {
+	if !cfg.Enabled {
+		return nil, nil, nil
 	}
+
+	var store ratelimit.Store
+	switch cfg.Store {
+	case config.RateLimitStoreMemory, "":
+		store = ratelimit.NewMemoryStore()
+	case config.RateLimitStoreRedis:
+		client := redis.NewClient(&redis.Options{Addr: cfg.Redis.Address, Password: cfg.Redis.Password, DB: cfg.Redis.DB})
+		store = ratelimit.NewRedisStore(client, cfg.Redis.KeyPrefix)
+	default:
+		return nil, nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+
+	rules := make([]ratelimit.Rule, len(cfg.Routes))
+	for i, route := range cfg.Routes {
+		rules[i] = ratelimit.Rule{Method: route.Method, Path: route.Path, Limit: limit(route.LimitConfig)}
+	}
+	limiter, err := ratelimit.NewLimiter(store, limit(cfg.Default), rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.PerAddress.Requests == 0 {
+		return limiter, nil, nil
+	}
+	addressLimiter, err := ratelimit.NewLimiter(store, limit(cfg.PerAddress), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("limit per address: %w", err)
+	}
+	return limiter, addressLimiter, nil
+}
+
+func limit(cfg config.LimitConfig) ratelimit.Limit {
+	return ratelimit.Limit{Requests: cfg.Requests, Per: cfg.Per, Burst: cfg.Burst}
 }
@@ -0,0 +1,688 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"billing-system/bff/config"
+	"billing-system/bff/internal/common"
+	"billing-system/bff/internal/openapi"
+	"billing-system/bff/internal/shipment"
+	"billing-system/bff/internal/summary"
+	billingPb "billing-system/billing_service/proto"
+	shipmentPb "billing-system/shipment_service/proto"
+)
+
+// fakeBilling answers the billing calls of the BFF with fully populated messages, order 404 does not exist
+type fakeBilling struct {
+	billingPb.UnimplementedBillingServiceServer
+}
+
+func testOrder(id int64) *billingPb.Order {
+	return &billingPb.Order{
+		Id: id, CustomerId: "CUST001", TotalAmount: 30, Status: billingPb.OrderStatus_SUCCESS,
+		Items:     []*billingPb.OrderItem{{Id: 1, OrderId: id, ItemId: 7, Quantity: 3, Sku: "SKU-1", Name: "Widget"}},
+		Payments:  []*billingPb.Payment{{Id: 1, OrderId: id, Method: "card", Amount: 30}},
+		CreatedAt: "2026-01-02T10:00:00Z", UpdatedAt: "2026-01-02T10:00:00Z",
+	}
+}
+
+func (fakeBilling) CreateOrder(context.Context, *billingPb.CreateOrderRequest) (*billingPb.CreateOrderResponse, error) {
+	return &billingPb.CreateOrderResponse{Order: testOrder(1)}, nil
+}
+
+func (fakeBilling) GetOrder(_ context.Context, req *billingPb.GetOrderRequest) (*billingPb.GetOrderResponse, error) {
+	if req.OrderId == 404 {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &billingPb.GetOrderResponse{Order: testOrder(req.OrderId)}, nil
+}
+
+func (fakeBilling) QuoteOrder(context.Context, *billingPb.QuoteOrderRequest) (*billingPb.QuoteOrderResponse, error) {
+	return &billingPb.QuoteOrderResponse{
+		CustomerId:  "CUST001",
+		Lines:       []*billingPb.QuoteLine{{Sku: "SKU-1", ItemId: 7, Quantity: 3, UnitPrice: 10, LineTotal: 30, PriceListCode: "VIP", PriceTier: 1}},
+		TotalAmount: 30,
+		QuoteToken:  "token",
+		ExpiresAt:   "2026-01-02T10:30:00Z",
+	}, nil
+}
+
+func (fakeBilling) GetShippableQuantities(context.Context, *billingPb.GetShippableQuantitiesRequest) (*billingPb.GetShippableQuantitiesResponse, error) {
+	return &billingPb.GetShippableQuantitiesResponse{Quantities: []*billingPb.ShippableQuantity{{Sku: "SKU-1", ItemId: 7, Ordered: 3, Invoiced: 3}}}, nil
+}
+
+func (fakeBilling) ListOrderInvoices(_ context.Context, req *billingPb.ListOrderInvoicesRequest) (*billingPb.ListOrderInvoicesResponse, error) {
+	return &billingPb.ListOrderInvoicesResponse{Invoices: []*billingPb.Invoice{{
+		Id: 1, ShipmentId: 1, OrderId: req.OrderId, TotalAmount: 30, PaidAmount: 10, OutstandingAmount: 20,
+		PaymentStatus: "PARTIALLY_PAID", DueDate: "2026-02-01T10:00:00Z",
+	}}}, nil
+}
+
+func testAPIKey(id int64) *billingPb.APIKey {
+	return &billingPb.APIKey{
+		Id: id, KeyId: "0a1b2c3d4e5f", Name: "ERP", CustomerId: "CUST001", Roles: []string{"customer"},
+		Scopes: []string{"orders:write"}, ExpiresAt: "2027-01-02T10:00:00Z", LastUsedAt: "2026-01-02T10:00:00Z",
+		RotatedFromId: 1, CreatedBy: "admin-1", CreatedAt: "2026-01-02T10:00:00Z",
+	}
+}
+
+func (fakeBilling) IssueAPIKey(context.Context, *billingPb.IssueAPIKeyRequest) (*billingPb.APIKeySecretResponse, error) {
+	return &billingPb.APIKeySecretResponse{ApiKey: testAPIKey(1), Secret: "bsk_0a1b2c3d4e5f_secret"}, nil
+}
+
+func (fakeBilling) ListAPIKeys(context.Context, *billingPb.ListAPIKeysRequest) (*billingPb.ListAPIKeysResponse, error) {
+	return &billingPb.ListAPIKeysResponse{ApiKeys: []*billingPb.APIKey{testAPIKey(1)}}, nil
+}
+
+func (fakeBilling) RotateAPIKey(_ context.Context, req *billingPb.RotateAPIKeyRequest) (*billingPb.APIKeySecretResponse, error) {
+	if req.Id == 404 {
+		return nil, status.Error(codes.NotFound, "api key not found")
+	}
+	return &billingPb.APIKeySecretResponse{ApiKey: testAPIKey(req.Id + 1), Secret: "bsk_0a1b2c3d4e5f_secret"}, nil
+}
+
+func (fakeBilling) RevokeAPIKey(_ context.Context, req *billingPb.APIKeyRequest) (*billingPb.APIKeyResponse, error) {
+	key := testAPIKey(req.Id)
+	key.RevokedAt = "2026-01-03T10:00:00Z"
+	return &billingPb.APIKeyResponse{ApiKey: key}, nil
+}
+
+// fakeShipment answers the shipment calls of the BFF with fully populated messages
+type fakeShipment struct {
+	shipmentPb.UnimplementedShipmentServiceServer
+}
+
+func testShipment() *shipmentPb.ShipmentData {
+	items := []*shipmentPb.ShipmentItem{{Sku: "SKU-1", Quantity: 3}}
+	return &shipmentPb.ShipmentData{
+		ShipmentId: 1, OrderId: 1, Status: "DELIVERED", Items: items,
+		CreatedAt: "2026-01-02T10:00:00Z", UpdatedAt: "2026-01-03T10:00:00Z",
+		CarrierCode: "fake", TrackingNumber: "TRK1", DestinationPostalCode: "10115", ShippingZone: "DE",
+		ChargeableWeightKg: 2.5, ShippingFee: 4.9, WarehouseId: 1,
+		Parcels: []*shipmentPb.Parcel{{
+			ParcelId: 1, Sequence: 1, WeightKg: 2, LengthCm: 30, WidthCm: 20, HeightCm: 10,
+			DimensionalWeightKg: 1.2, ChargeableWeightKg: 2, Items: items,
+		}},
+		ShipTo: &shipmentPb.Address{Name: "Jane", Line1: "Main St 1", City: "Berlin", PostalCode: "10115", Country: "DE"},
+		DeliveryProof: &shipmentPb.DeliveryProof{
+			RecipientName: "Jane", Latitude: 52.5, Longitude: 13.4, DeliveredAt: "2026-01-03T10:00:00Z",
+			Files: []*shipmentPb.DeliveryFile{{FileId: 1, Kind: "SIGNATURE", ContentType: "image/png", SizeBytes: 4, Sha256: "ab"}},
+		},
+	}
+}
+
+func testReturn() *shipmentPb.ReturnResponse {
+	return &shipmentPb.ReturnResponse{Data: &shipmentPb.ReturnData{
+		ReturnId: 1, ShipmentId: 1, OrderId: 1, Status: "APPROVED", Reason: "damaged", Note: "ok",
+		Items: []*shipmentPb.ShipmentItem{{Sku: "SKU-1", Quantity: 1}}, CreditNoteId: 2, CreditedAmount: 10,
+		ReceivedAt: "2026-01-04T10:00:00Z", CreatedAt: "2026-01-03T10:00:00Z", UpdatedAt: "2026-01-04T10:00:00Z",
+	}}
+}
+
+func testPlan() []*shipmentPb.PlannedShipment {
+	return []*shipmentPb.PlannedShipment{{WarehouseId: 1, WarehouseCode: "BER", Items: []*shipmentPb.ShipmentItemRequest{{Sku: "SKU-1", Quantity: 3}}}}
+}
+
+func (fakeShipment) CreateShipment(_ context.Context, req *shipmentPb.CreateShipmentRequest) (*shipmentPb.CreateShipmentResponse, error) {
+	if len(req.Plan) > 0 {
+		return &shipmentPb.CreateShipmentResponse{Code: 1, Message: "success", Shipments: []*shipmentPb.ShipmentData{testShipment()}}, nil
+	}
+	return &shipmentPb.CreateShipmentResponse{Code: 1, Message: "success", Data: testShipment()}, nil
+}
+
+// CreateShipments creates the shipment of every line but those of order 409, which is out of stock
+func (fakeShipment) CreateShipments(stream grpc.ClientStreamingServer[shipmentPb.CreateShipmentsRequest, shipmentPb.CreateShipmentsResponse]) error {
+	response := &shipmentPb.CreateShipmentsResponse{Reasons: map[string]int32{}}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(response)
+		}
+		if err != nil {
+			return err
+		}
+		result := &shipmentPb.ShipmentLineResult{Line: req.Line, Reference: req.Reference}
+		if req.Shipment.OrderId == 409 {
+			result.Reason, result.Message = "OUT_OF_STOCK", "not enough stock in warehouse"
+			response.Failed = append(response.Failed, result)
+			response.Reasons[result.Reason]++
+			continue
+		}
+		result.Shipment = testShipment()
+		response.Confirmed = append(response.Confirmed, result)
+	}
+}
+
+func (fakeShipment) GetShipment(context.Context, *shipmentPb.GetShipmentRequest) (*shipmentPb.GetShipmentResponse, error) {
+	return &shipmentPb.GetShipmentResponse{Shipment: testShipment()}, nil
+}
+
+// ListShipments returns two pages of the test shipment
+func (fakeShipment) ListShipments(_ context.Context, req *shipmentPb.ListShipmentsRequest) (*shipmentPb.ListShipmentsResponse, error) {
+	if req.Cursor != "" {
+		return &shipmentPb.ListShipmentsResponse{Shipments: []*shipmentPb.ShipmentData{testShipment()}}, nil
+	}
+	return &shipmentPb.ListShipmentsResponse{Shipments: []*shipmentPb.ShipmentData{testShipment()}, NextCursor: "Mg"}, nil
+}
+
+func (fakeShipment) GetTrackingHistory(context.Context, *shipmentPb.GetTrackingHistoryRequest) (*shipmentPb.GetTrackingHistoryResponse, error) {
+	return &shipmentPb.GetTrackingHistoryResponse{Shipment: testShipment(), Events: []*shipmentPb.ShipmentEvent{{
+		Id: 1, Status: "DELIVERED", PreviousStatus: "OUT_FOR_DELIVERY", Timestamp: "2026-01-03T10:00:00Z",
+		Location: "Berlin", Actor: "carrier:fake", Note: "left at door",
+	}}}, nil
+}
+
+func (fakeShipment) PackShipment(context.Context, *shipmentPb.PackShipmentRequest) (*shipmentPb.PackShipmentResponse, error) {
+	return &shipmentPb.PackShipmentResponse{Shipment: testShipment()}, nil
+}
+
+func (fakeShipment) GetShippingDocument(context.Context, *shipmentPb.GetShippingDocumentRequest) (*shipmentPb.ShippingDocument, error) {
+	return &shipmentPb.ShippingDocument{Filename: "label-1.zpl", ContentType: "application/zpl", Content: []byte("^XA^XZ")}, nil
+}
+
+func (fakeShipment) GetDeliveryFile(_ *shipmentPb.GetDeliveryFileRequest, stream grpc.ServerStreamingServer[shipmentPb.DeliveryFileChunk]) error {
+	return stream.Send(&shipmentPb.DeliveryFileChunk{Kind: "SIGNATURE", ContentType: "image/png", Data: []byte("\x89PNG")})
+}
+
+// WatchOrder opens with a heartbeat and sends the events of order 1 after after_event_id, then ends the stream
+func (fakeShipment) WatchOrder(req *shipmentPb.WatchOrderRequest, stream grpc.ServerStreamingServer[shipmentPb.WatchUpdate]) error {
+	if req.OrderId == 404 {
+		return status.Error(codes.NotFound, "order not found")
+	}
+	if err := stream.Send(&shipmentPb.WatchUpdate{OrderId: req.OrderId, Heartbeat: true}); err != nil {
+		return err
+	}
+	for id := req.AfterEventId + 1; id <= 3; id++ {
+		update := &shipmentPb.WatchUpdate{OrderId: req.OrderId, ShipmentId: 1, Event: &shipmentPb.ShipmentEvent{
+			Id: id, Status: "IN_TRANSIT", PreviousStatus: "PACKED", Timestamp: "2026-01-02T12:00:00Z", Actor: "carrier",
+		}}
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (fakeShipment) HandleCarrierWebhook(context.Context, *shipmentPb.CarrierWebhookRequest) (*shipmentPb.CarrierWebhookResponse, error) {
+	return &shipmentPb.CarrierWebhookResponse{Applied: 1}, nil
+}
+
+func (fakeShipment) RequestReturn(context.Context, *shipmentPb.RequestReturnRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) GetReturn(context.Context, *shipmentPb.GetReturnRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) ApproveReturn(context.Context, *shipmentPb.ReturnActionRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) RejectReturn(context.Context, *shipmentPb.ReturnActionRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) ReceiveReturn(context.Context, *shipmentPb.ReturnActionRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) InspectReturn(context.Context, *shipmentPb.ReturnActionRequest) (*shipmentPb.ReturnResponse, error) {
+	return testReturn(), nil
+}
+
+func (fakeShipment) UpsertWarehouse(_ context.Context, req *shipmentPb.UpsertWarehouseRequest) (*shipmentPb.UpsertWarehouseResponse, error) {
+	warehouse := req.Warehouse
+	warehouse.Id = 1
+	return &shipmentPb.UpsertWarehouseResponse{Warehouse: warehouse}, nil
+}
+
+func (fakeShipment) ListWarehouses(context.Context, *shipmentPb.ListWarehousesRequest) (*shipmentPb.ListWarehousesResponse, error) {
+	return &shipmentPb.ListWarehousesResponse{Warehouses: []*shipmentPb.Warehouse{{Id: 1, Code: "BER", Name: "Berlin", PostalCode: "10115", Priority: 1, Active: true}}}, nil
+}
+
+func (fakeShipment) UpsertWarehouseStock(_ context.Context, req *shipmentPb.UpsertWarehouseStockRequest) (*shipmentPb.UpsertWarehouseStockResponse, error) {
+	return &shipmentPb.UpsertWarehouseStockResponse{Updated: int32(len(req.Stock))}, nil
+}
+
+func (fakeShipment) AllocateShipments(context.Context, *shipmentPb.AllocateShipmentsRequest) (*shipmentPb.AllocateShipmentsResponse, error) {
+	return &shipmentPb.AllocateShipmentsResponse{
+		Strategy: "NEAREST", Shipments: testPlan(), Unallocated: []*shipmentPb.ShipmentItemRequest{{Sku: "SKU-2", Quantity: 1}},
+	}, nil
+}
+
+// serve starts a gRPC server for the test and returns its address
+func serve(t *testing.T, register func(*grpc.Server)) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// newTestRouter returns the router of the BFF calling fake services, without authentication
+func newTestRouter(t *testing.T) (*gin.Engine, *openapi.Spec) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	billingAddress := serve(t, func(s *grpc.Server) { billingPb.RegisterBillingServiceServer(s, fakeBilling{}) })
+	shipmentAddress := serve(t, func(s *grpc.Server) { shipmentPb.RegisterShipmentServiceServer(s, fakeShipment{}) })
+	previous := config.Service
+	config.Service = config.Config{
+		Server:             config.ServerConfig{Mode: config.ModeDev},
+		BillingConnection:  config.AdapterConnectionAddress{Address: billingAddress},
+		ShipmentConnection: config.AdapterConnectionAddress{Address: shipmentAddress},
+	}
+	t.Cleanup(func() { config.Service = previous })
+
+	router, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router, spec
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	router, spec := newTestRouter(t)
+
+	documented := make(map[string]bool)
+	for _, route := range spec.Routes() {
+		documented[route.Method+" "+route.Path] = true
+	}
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		if route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+		if !documented[key] {
+			t.Errorf("route %s is missing from the specification", key)
+		}
+		delete(documented, key)
+	}
+	for key := range documented {
+		t.Errorf("specification documents %s, which has no route", key)
+	}
+}
+
+// TestHandlersMatchSpec calls every documented operation and fails when a response drifts from the specification
+func TestHandlersMatchSpec(t *testing.T) {
+	router, spec := newTestRouter(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodPost, "/api/v1/orders", `{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":3}],"payments":[{"method":"card","amount":30}]}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/orders/quote", `{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":3}],"lock_minutes":30}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/orders/1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/orders/404", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/orders/1/summary", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/orders/404/summary", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/orders/1/events?last_event_id=1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/orders/404/events", "", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/orders/1/events/ws", "", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/orders/1/allocation", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/orders/1/allocation", `{"destination_postal_code":"10115","strategy":"PRIORITY"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/shipments", `{"order_id":1,"items":[{"sku":"SKU-1","quantity":3}],"ship_to":{"name":"Jane"}}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/shipments", `{"order_id":1,"plan":[{"warehouse_id":1,"items":[{"sku":"SKU-1","quantity":3}]}]}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments?order_id=1&page_size=10&created_from=2026-01-01T00:00:00Z", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments/1", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments/1/tracking", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/shipments/1/parcels", `{"parcels":[{"weight_kg":2,"items":[{"sku":"SKU-1","quantity":3}]}]}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments/1/documents/label?format=zpl", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments/1/delivery-proof", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/shipments/1/delivery-proof/files/1", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/shipments/1/returns", `{"reason":"damaged","items":[{"sku":"SKU-1","quantity":1}]}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/returns/1", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/returns/1/approve", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/returns/1/reject", `{"note":"worn"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/returns/1/receive", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/returns/1/inspect", `{"note":"resellable"}`, http.StatusOK},
+		{http.MethodGet, "/api/v1/warehouses", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/warehouses", `{"code":"BER","name":"Berlin","postal_code":"10115","priority":1,"active":true}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/warehouses/1/stock", `{"stock":[{"sku":"SKU-1","quantity":10}]}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/api-keys", `{"name":"ERP","customer_id":"CUST001","roles":["customer"],"scopes":["orders:write"]}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/api-keys?customer_id=CUST001", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/api-keys/1/rotate", "", http.StatusCreated},
+		{http.MethodPost, "/api/v1/api-keys/1/rotate", `{"overlap_minutes":60}`, http.StatusCreated},
+		{http.MethodPost, "/api/v1/api-keys/404/rotate", "", http.StatusNotFound},
+		{http.MethodDelete, "/api/v1/api-keys/1", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/carriers/fake/webhook", `{"tracking_number":"TRK1","status":"DELIVERED"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/orders", `{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":0}],"payments":[]}`, http.StatusBadRequest},
+	}
+
+	called := make(map[string]bool)
+	check := func(t *testing.T, method, path, contentType, body string, want int) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+		}
+
+		route := strings.SplitN(path, "?", 2)[0]
+		var op *openapi.Operation
+		for _, documented := range spec.Routes() {
+			if documented.Method == method && matches(documented.Path, route) {
+				op = documented.Operation
+				called[documented.Method+" "+documented.Path] = true
+			}
+		}
+		if op == nil {
+			t.Fatalf("no documented operation for %s %s", method, route)
+		}
+		for _, violation := range spec.ValidateResponse(op, rec.Code, rec.Header(), rec.Body.Bytes()) {
+			t.Errorf("response does not match the specification: %s", violation)
+		}
+		return rec
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			check(t, tt.method, tt.path, "application/json", tt.body, tt.status)
+		})
+	}
+
+	// Jobs get random ids, they are looked up by the id their import returned
+	t.Run("bulk import", func(t *testing.T) {
+		csvFile := "reference,customer_id,items,payments\nA-1,CUST001,SKU-1:3,card:30\nA-2,CUST001,SKU-1:zero,card:30\n"
+		rec := check(t, http.MethodPost, "/api/v1/orders/bulk", "text/csv", csvFile, http.StatusAccepted)
+		jobURL := rec.Header().Get("Location")
+
+		check(t, http.MethodPost, "/api/v1/orders/bulk", "application/x-ndjson", `{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":3}],"payments":[{"method":"card","amount":30}]}`, http.StatusAccepted)
+		check(t, http.MethodPost, "/api/v1/orders/bulk", "application/pdf", "%PDF", http.StatusUnsupportedMediaType)
+		check(t, http.MethodGet, jobURL, "", "", http.StatusOK)
+		check(t, http.MethodGet, "/api/v1/jobs/unknown", "", "", http.StatusNotFound)
+		if rec := check(t, http.MethodGet, jobURL+"/failed-rows", "", "", http.StatusOK); !strings.Contains(rec.Body.String(), "A-2,CUST001,SKU-1:zero,card:30,,3,INVALID_REQUEST") {
+			t.Errorf("failed rows = %q, want the invalid row", rec.Body.String())
+		}
+	})
+
+	t.Run("shipment manifest", func(t *testing.T) {
+		csvFile := "reference,order_id,items,ship_to_name,ship_to_city\nM-1,1,SKU-1:3,Jane,Berlin\nM-2,409,SKU-1:1,,\nM-3,abc,SKU-1:1,,\n"
+		rec := check(t, http.MethodPost, "/api/v1/shipments/manifest", "text/csv", csvFile, http.StatusOK)
+		var body struct {
+			Data shipment.ManifestResponse `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		manifest := body.Data
+		if manifest.Total != 3 || len(manifest.Confirmed) != 1 || manifest.Confirmed[0].Line != 2 || len(manifest.Failed) != 2 {
+			t.Fatalf("manifest = %+v, want line 2 confirmed and lines 3 and 4 failed", manifest)
+		}
+		if manifest.Failed[0].Line != 3 || manifest.Failed[1].Line != 4 || manifest.Failed[1].Reference != "M-3" ||
+			manifest.Reasons["OUT_OF_STOCK"] != 1 || manifest.Reasons["INVALID_REQUEST"] != 1 {
+			t.Errorf("failed = %+v, reasons = %v, want the stock and the invalid line in order", manifest.Failed, manifest.Reasons)
+		}
+
+		check(t, http.MethodPost, "/api/v1/shipments/manifest", "application/json", "{}", http.StatusUnsupportedMediaType)
+		check(t, http.MethodPost, "/api/v1/shipments/manifest", "text/csv", "reference,items\nM-1,SKU-1:1\n", http.StatusBadRequest)
+	})
+
+	for _, route := range spec.Routes() {
+		if !called[route.Method+" "+route.Path] {
+			t.Errorf("documented operation %s %s is not exercised", route.Method, route.Path)
+		}
+	}
+}
+
+// matches reports whether a request path matches a gin route path
+func matches(routePath, path string) bool {
+	routeSegments, segments := strings.Split(routePath, "/"), strings.Split(path, "/")
+	if len(routeSegments) != len(segments) {
+		return false
+	}
+	for i, segment := range routeSegments {
+		if !strings.HasPrefix(segment, ":") && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// slowShipment answers shipment listings only once the deadline of the call has passed
+type slowShipment struct {
+	fakeShipment
+}
+
+func (slowShipment) ListShipments(ctx context.Context, _ *shipmentPb.ListShipmentsRequest) (*shipmentPb.ListShipmentsResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestOrderSummary(t *testing.T) {
+	getSummary := func(t *testing.T, router *gin.Engine) summary.OrderSummaryResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/summary", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		var envelope struct {
+			Data summary.OrderSummaryResponse `json:"data"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+			t.Fatal(err)
+		}
+		return envelope.Data
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		view := getSummary(t, router)
+
+		if view.Degraded || len(view.Unavailable) != 0 {
+			t.Fatalf("degraded = %v, unavailable = %+v, want a complete summary", view.Degraded, view.Unavailable)
+		}
+		if len(view.Items) != 1 || len(view.Shipments) != 2 || len(view.Invoices) != 1 {
+			t.Fatalf("items = %d, shipments = %d, invoices = %d, want 1, 2 and 1", len(view.Items), len(view.Shipments), len(view.Invoices))
+		}
+		item := view.Items[0]
+		if item.Sku != "SKU-1" || item.Name != "Widget" || item.Ordered != 3 || *item.Shipped != 6 || *item.Invoiced != 3 || *item.Remaining != 0 {
+			t.Errorf("item = %+v, shipped %d, invoiced %d, remaining %d", item, *item.Shipped, *item.Invoiced, *item.Remaining)
+		}
+		if view.PaymentStatus != "PARTIALLY_PAID" || view.OutstandingAmount != 20 {
+			t.Errorf("payment status = %s, outstanding = %v, want PARTIALLY_PAID and 20", view.PaymentStatus, view.OutstandingAmount)
+		}
+	})
+
+	t.Run("shipment service down", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Service.ShipmentConnection.Address = lis.Addr().String()
+		lis.Close()
+
+		view := getSummary(t, router)
+		if !view.Degraded || len(view.Unavailable) != 1 || view.Unavailable[0].Section != summary.SectionShipments {
+			t.Fatalf("degraded = %v, unavailable = %+v, want the shipments left out", view.Degraded, view.Unavailable)
+		}
+		if item := view.Items[0]; item.Shipped != nil || item.Invoiced == nil || len(view.Invoices) != 1 {
+			t.Errorf("item = %+v, invoices = %d, want shipped unknown and the billing sections present", item, len(view.Invoices))
+		}
+	})
+
+	t.Run("shipment service too slow", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		config.Service.ShipmentConnection.Address = serve(t, func(s *grpc.Server) { shipmentPb.RegisterShipmentServiceServer(s, slowShipment{}) })
+		config.Service.Summary.CallTimeout = 50 * time.Millisecond
+
+		start := time.Now()
+		view := getSummary(t, router)
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("summary took %v, want it bounded by the call timeout", elapsed)
+		}
+		if !view.Degraded || len(view.Unavailable) != 1 || view.Unavailable[0].Reason != "DEADLINE_EXCEEDED" {
+			t.Fatalf("degraded = %v, unavailable = %+v, want the shipments timed out", view.Degraded, view.Unavailable)
+		}
+	})
+}
+
+func TestOrderEvents(t *testing.T) {
+	t.Run("server-sent events resume after Last-Event-ID", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/events", nil)
+		req.Header.Set("Last-Event-ID", "1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("status = %d, content type = %q, want an event stream: %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+		}
+		frames := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+		if len(frames) != 4 || frames[0] != "retry: 3000" || frames[1] != ": heartbeat" {
+			t.Fatalf("frames = %q, want the retry, a heartbeat and events 2 and 3", frames)
+		}
+		for i, frame := range frames[2:] {
+			lines := strings.Split(frame, "\n")
+			if len(lines) != 3 || lines[0] != fmt.Sprintf("id: %d", i+2) || lines[1] != "event: shipment" {
+				t.Fatalf("frame = %q, want event %d", frame, i+2)
+			}
+			var message shipment.OrderEventMessage
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &message); err != nil {
+				t.Fatal(err)
+			}
+			if message.Type != "shipment" || message.OrderID != 1 || message.ShipmentID != 1 || message.Event.Id != int64(i+2) {
+				t.Errorf("message = %+v, want event %d of shipment 1", message, i+2)
+			}
+		}
+	})
+
+	t.Run("invalid last event id", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1/events", nil)
+		req.Header.Set("Last-Event-ID", "latest")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		router, _ := newTestRouter(t)
+		server := httptest.NewServer(router)
+		defer server.Close()
+
+		conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/v1/orders/1/events/ws?last_event_id=2", "", server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var heartbeat, event shipment.OrderEventMessage
+		if err := websocket.JSON.Receive(conn, &heartbeat); err != nil {
+			t.Fatal(err)
+		}
+		if err := websocket.JSON.Receive(conn, &event); err != nil {
+			t.Fatal(err)
+		}
+		if heartbeat.Type != "heartbeat" || event.Type != "shipment" || event.Event == nil || event.Event.Id != 3 {
+			t.Fatalf("messages = %+v and %+v, want a heartbeat and event 3", heartbeat, event)
+		}
+		var more shipment.OrderEventMessage
+		if err := websocket.JSON.Receive(conn, &more); err == nil {
+			t.Errorf("received %+v after the watch ended, want the socket closed", more)
+		}
+	})
+}
+
+func TestRequestsAreValidated(t *testing.T) {
+	router, _ := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		field  string
+		reason string
+	}{
+		{"quantity below minimum", http.MethodPost, "/api/v1/orders", `{"customer_id":"CUST001","items":[{"sku":"SKU-1","quantity":0}],"payments":[]}`, "items[0].quantity", openapi.ReasonMin},
+		{"missing customer", http.MethodPost, "/api/v1/orders/quote", `{"items":[{"sku":"SKU-1","quantity":1}]}`, "customer_id", openapi.ReasonRequired},
+		{"wrong type", http.MethodPut, "/api/v1/warehouses", `{"code":"BER","postal_code":10115}`, "postal_code", openapi.ReasonInvalidType},
+		{"malformed body", http.MethodPut, "/api/v1/warehouses", `{"code":`, "", openapi.ReasonInvalidJSON},
+		{"missing body", http.MethodPut, "/api/v1/warehouses/1/stock", "", "", openapi.ReasonRequired},
+		{"id not a number", http.MethodGet, "/api/v1/orders/abc", "", "id", openapi.ReasonInvalidType},
+		{"page size above maximum", http.MethodGet, "/api/v1/shipments?page_size=500", "", "page_size", openapi.ReasonMax},
+		{"date not RFC 3339", http.MethodGet, "/api/v1/shipments?created_from=yesterday", "", "created_from", openapi.ReasonFormat},
+		{"unknown document type", http.MethodGet, "/api/v1/shipments/1/documents/invoice", "", "type", openapi.ReasonEnum},
+		{"unknown strategy", http.MethodPost, "/api/v1/orders/1/allocation", `{"strategy":"CHEAPEST"}`, "strategy", openapi.ReasonEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400: %s", rec.Code, rec.Body.String())
+			}
+			var envelope common.ErrorEnvelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatal(err)
+			}
+			for _, violation := range envelope.Error.FieldViolations {
+				if violation.Field == tt.field && violation.Reason == tt.reason {
+					return
+				}
+			}
+			t.Errorf("violations = %+v, want %s on %q", envelope.Error.FieldViolations, tt.reason, tt.field)
+		})
+	}
+}
+
+func TestServesSpec(t *testing.T) {
+	router, spec := newTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("X-API-Version") != spec.Info.Version {
+		t.Fatalf("status = %d, version = %q", rec.Code, rec.Header().Get("X-API-Version"))
+	}
+	if _, err := openapi.Parse(rec.Body.Bytes()); err != nil {
+		t.Fatalf("served specification does not parse: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"/openapi.json"`) {
+		t.Fatalf("Swagger UI page not served: %d", rec.Code)
+	}
+}
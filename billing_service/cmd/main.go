@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"billing-system/billing_service/config"
 	billing_handler "billing-system/billing_service/internal/handler"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/notifier"
 	"billing-system/billing_service/internal/repository"
 	"billing-system/billing_service/internal/service"
 	"billing-system/billing_service/pkg/db"
 	billing_pb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -37,13 +44,60 @@ func main() {
 	itemRepo := repository.NewItemRepository(gormDB)
 	orderRepo := repository.NewOrderRepository(gormDB)
 	invoiceRepo := repository.NewInvoiceRepository(gormDB)
+	customerRepo := repository.NewCustomerRepository(gormDB)
+	planRepo := repository.NewPlanRepository(gormDB)
+	subscriptionRepo := repository.NewSubscriptionRepository(gormDB)
+	meterRepo := repository.NewMeterRepository(gormDB)
+	usageRepo := repository.NewUsageRepository(gormDB)
+	priceListRepo := repository.NewPriceListRepository(gormDB)
+	creditNoteRepo := repository.NewCreditNoteRepository(gormDB)
+	apiKeyRepo := repository.NewAPIKeyRepository(gormDB)
 
 	// Initialize services
-	orderService := service.NewOrderService(orderRepo, itemRepo)
-	invoiceService := service.NewInvoiceService(invoiceRepo, orderRepo, itemRepo)
+	dunningConfig := config.Service.Dunning
+	pricingService := service.NewPricingService(itemRepo, priceListRepo, customerRepo)
+	orderService := service.NewOrderService(orderRepo, pricingService, customerRepo, model.PaymentTerm(dunningConfig.DefaultPaymentTerm),
+		quoteSecret(config.Service.Quotes.Secret), config.Service.Quotes.MaxLock)
+	invoiceService := service.NewInvoiceService(invoiceRepo, orderRepo, itemRepo, customerRepo)
+	creditNoteService := service.NewCreditNoteService(creditNoteRepo, invoiceRepo, orderRepo, customerRepo)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, planRepo, itemRepo, orderService, invoiceService, config.Service.Subscriptions.Lease)
+	usageService := service.NewUsageService(meterRepo, usageRepo, itemRepo, orderService, invoiceService, config.Service.Usage.GracePeriod, config.Service.Usage.Lease)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, config.Service.APIKeys.RotationOverlap, config.Service.APIKeys.MaxRotationOverlap)
+
+	// Start the dunning worker
+	if dunningConfig.Enabled {
+		dunningService := service.NewDunningService(invoiceRepo, customerRepo, newNotifier(dunningConfig.Notifier), service.DunningPolicy{
+			ReminderOffsetDays: dunningConfig.ReminderOffsetDays,
+			EscalateAfterDays:  dunningConfig.EscalateAfterDays,
+		})
+
+		interval := dunningConfig.Interval
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		go dunningService.Start(context.Background(), interval)
+	}
+
+	// Start the subscription billing scheduler, every replica can run it
+	if config.Service.Subscriptions.Enabled {
+		interval := config.Service.Subscriptions.Interval
+		if interval <= 0 {
+			interval = 5 * time.Minute
+		}
+		go subscriptionService.Start(context.Background(), interval)
+	}
+
+	// Start the usage aggregation job
+	if config.Service.Usage.Enabled {
+		interval := config.Service.Usage.Interval
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		go usageService.Start(context.Background(), interval)
+	}
 
 	// Initialize  handlers
-	orderHandler := billing_handler.NewOrderHandler(orderService, invoiceService)
+	orderHandler := billing_handler.NewOrderHandler(orderService, invoiceService, subscriptionService, usageService, pricingService, creditNoteService, apiKeyService)
 
 	// server's address
 	address := fmt.Sprintf("%s:%s", config.Service.GRPCServer.Host, config.Service.GRPCServer.Port)
@@ -52,8 +106,30 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", address, err)
 	}
 
+	// Callers are authorized by the token the BFF forwards
+	verifier, err := auth.NewVerifier(config.Service.Auth)
+	if err != nil {
+		log.Fatalf("Failed to configure authentication: %v", err)
+	}
+	if verifier == nil {
+		log.Println("Authentication is disabled, every caller may call every method")
+	}
+
+	// Callers must present a certificate of the CA with an allowed identity when TLS is enabled
+	creds, err := mtls.ServerCredentials(config.Service.TLS)
+	if err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
+	if !config.Service.TLS.Enabled {
+		log.Println("TLS is disabled, connections are in plaintext")
+	}
+
 	// start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(verifier, billing_handler.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(verifier, billing_handler.MethodPermissions)),
+	)
 	billing_pb.RegisterBillingServiceServer(grpcServer, orderHandler)
 	reflection.Register(grpcServer)
 
@@ -62,3 +138,28 @@ func main() {
 	}
 
 }
+
+// newNotifier creates the notifier selected in the configuration
+func newNotifier(kind string) notifier.Notifier {
+	switch kind {
+	case "memory":
+		return notifier.NewInMemoryNotifier()
+	default:
+		return notifier.NewLogNotifier()
+	}
+}
+
+// quoteSecret returns the configured quote signing secret or a random one.
+// Tokens signed with a random secret stop working on restart and are not accepted by other replicas.
+func quoteSecret(configured string) []byte {
+	if configured != "" {
+		return []byte(configured)
+	}
+
+	log.Println("No quote secret configured, quote tokens are only valid on this instance until it restarts")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate quote secret: %v", err)
+	}
+	return secret
+}
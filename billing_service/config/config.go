@@ -2,13 +2,24 @@ package config
 
 import (
 	"os"
+	"time"
+
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Database   DatabaseConfig   `yaml:"database"`
-	GRPCServer GRPCServerConfig `yaml:"grpc_server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	GRPCServer    GRPCServerConfig    `yaml:"grpc_server"`
+	Dunning       DunningConfig       `yaml:"dunning"`
+	Subscriptions SubscriptionsConfig `yaml:"subscriptions"`
+	Usage         UsageConfig         `yaml:"usage"`
+	Quotes        QuotesConfig        `yaml:"quotes"`
+	APIKeys       APIKeysConfig       `yaml:"api_keys"`
+	Auth          auth.Config         `yaml:"auth"`
+	TLS           mtls.Config         `yaml:"tls"`
 }
 
 type DatabaseConfig struct {
@@ -24,6 +35,48 @@ type GRPCServerConfig struct {
 	Port string `yaml:"port"`
 }
 
+type DunningConfig struct {
+	Enabled            bool          `yaml:"enabled"`
+	Interval           time.Duration `yaml:"interval"`
+	DefaultPaymentTerm string        `yaml:"default_payment_term"`
+	// ReminderOffsetDays are the days after the due date at which reminders are sent
+	ReminderOffsetDays []int `yaml:"reminder_offset_days"`
+	// EscalateAfterDays is the number of days overdue after which the customer is put on hold
+	EscalateAfterDays int `yaml:"escalate_after_days"`
+	// Notifier selects the notifier implementation: "log" or "memory"
+	Notifier string `yaml:"notifier"`
+}
+
+type SubscriptionsConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+	// Lease is how long a replica owns a subscription while billing it
+	Lease time.Duration `yaml:"lease"`
+}
+
+type UsageConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"`
+	// GracePeriod is how long after its end a usage period waits for late events before it is billed
+	GracePeriod time.Duration `yaml:"grace_period"`
+	// Lease is how long a replica owns a usage period or adjustment while invoicing it
+	Lease time.Duration `yaml:"lease"`
+}
+
+type QuotesConfig struct {
+	// Secret signs quote tokens, a random secret is generated at startup when empty
+	Secret string `yaml:"secret"`
+	// MaxLock is the longest a quote can lock its prices, zero disables locking
+	MaxLock time.Duration `yaml:"max_lock"`
+}
+
+type APIKeysConfig struct {
+	// RotationOverlap is how long a rotated key stays valid when the rotation does not ask for another overlap
+	RotationOverlap time.Duration `yaml:"rotation_overlap"`
+	// MaxRotationOverlap is the longest overlap a rotation can ask for
+	MaxRotationOverlap time.Duration `yaml:"max_rotation_overlap"`
+}
+
 var Service Config
 
 func LoadConfig() error {
@@ -1,6 +1,16 @@
 package dto
 
+import "billing-system/billing_service/internal/model"
+
 type InvoiceItemRequest struct {
 	Sku      string
 	Quantity int
 }
+
+// InvoiceChargeRequest represents a request to bill a charge such as a shipping fee on an invoice
+type InvoiceChargeRequest struct {
+	Type        model.ChargeType
+	Description string
+	Amount      float64
+	TaxCategory string
+}
@@ -6,6 +6,8 @@ import "billing-system/billing_service/internal/model"
 type ItemRequest struct {
 	Sku      string `json:"skus"`
 	Quantity int    `json:"quantity"`
+	// UnitPrice overrides the catalog price, used internally for recurring and prorated charges
+	UnitPrice *float64 `json:"-"`
 }
 
 // PaymentRequest represents a request to add a payment to an order
@@ -0,0 +1,9 @@
+package dto
+
+import "billing-system/billing_service/internal/model"
+
+// PriceListEntryRequest represents a request to price an item in a price list
+type PriceListEntryRequest struct {
+	Sku   string           `json:"sku"`
+	Tiers model.PriceTiers `json:"tiers"`
+}
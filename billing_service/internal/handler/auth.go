@@ -0,0 +1,41 @@
+package billing_handler
+
+import (
+	pb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	"context"
+)
+
+// MethodPermissions are the permissions the methods of the billing service require of their callers
+var MethodPermissions = auth.Policy{
+	pb.BillingService_CreateOrder_FullMethodName:            auth.PermissionOrdersWrite,
+	pb.BillingService_QuoteOrder_FullMethodName:             auth.PermissionOrdersWrite,
+	pb.BillingService_GetOrder_FullMethodName:               auth.PermissionOrdersRead,
+	pb.BillingService_CreateInvoice_FullMethodName:          auth.PermissionInvoicesWrite,
+	pb.BillingService_PayInvoice_FullMethodName:             auth.PermissionInvoicesWrite,
+	pb.BillingService_GetShippableQuantities_FullMethodName: auth.PermissionOrdersRead,
+	pb.BillingService_ListOrderInvoices_FullMethodName:      auth.PermissionOrdersRead,
+	pb.BillingService_CreateCreditNote_FullMethodName:       auth.PermissionInvoicesWrite,
+	pb.BillingService_CreatePlan_FullMethodName:             auth.PermissionCatalogWrite,
+	pb.BillingService_CreateSubscription_FullMethodName:     auth.PermissionSubscriptionsWrite,
+	pb.BillingService_ChangeSubscriptionPlan_FullMethodName: auth.PermissionSubscriptionsWrite,
+	pb.BillingService_PauseSubscription_FullMethodName:      auth.PermissionSubscriptionsWrite,
+	pb.BillingService_ResumeSubscription_FullMethodName:     auth.PermissionSubscriptionsWrite,
+	pb.BillingService_CancelSubscription_FullMethodName:     auth.PermissionSubscriptionsWrite,
+	pb.BillingService_CreateMeter_FullMethodName:            auth.PermissionCatalogWrite,
+	pb.BillingService_RecordUsage_FullMethodName:            auth.PermissionUsageWrite,
+	pb.BillingService_CreatePriceList_FullMethodName:        auth.PermissionCatalogWrite,
+	pb.BillingService_IssueAPIKey_FullMethodName:            auth.PermissionAdmin,
+	pb.BillingService_ListAPIKeys_FullMethodName:            auth.PermissionAdmin,
+	pb.BillingService_RevokeAPIKey_FullMethodName:           auth.PermissionAdmin,
+	pb.BillingService_RotateAPIKey_FullMethodName:           auth.PermissionAdmin,
+	// The BFF verifies API keys before it has a token to forward, the secret is the credential.
+	// Only the BFF reaches the service when mutual TLS restricts its clients.
+	pb.BillingService_VerifyAPIKey_FullMethodName: auth.PermissionPublic,
+}.With(auth.ReflectionMethods)
+
+// canActFor reports whether the caller may act for the customer, every caller may when authentication is disabled
+func canActFor(ctx context.Context, customerID string) bool {
+	principal, ok := auth.FromContext(ctx)
+	return !ok || principal.CanActFor(customerID)
+}
@@ -0,0 +1,53 @@
+package billing_handler
+
+import (
+	"billing-system/billing_service/internal/service"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the statuses returned by the billing service
+const errorDomain = "billing.billing-system"
+
+// reasonInternal is the reason of errors that are not domain errors
+const reasonInternal = "INTERNAL"
+
+// mapErrorToGRPCStatus maps service errors to gRPC statuses with an ErrorInfo detail carrying their reason.
+// Wrapped domain errors keep their code, anything else is Internal and its message is not exposed.
+func mapErrorToGRPCStatus(err error) *status.Status {
+	var domainErr *service.Error
+	if !errors.As(err, &domainErr) {
+		return withErrorInfo(status.New(codes.Internal, "internal server error"), reasonInternal)
+	}
+
+	code := codes.Internal
+	switch domainErr.Kind {
+	case service.KindInvalid:
+		code = codes.InvalidArgument
+	case service.KindNotFound:
+		code = codes.NotFound
+	case service.KindConflict:
+		code = codes.FailedPrecondition
+	case service.KindUnauthenticated:
+		code = codes.Unauthenticated
+	}
+	return withErrorInfo(status.New(code, err.Error()), domainErr.Reason)
+}
+
+// invalidArgument returns the status of a request the handler cannot convert, wrapping the service error with the detail
+func invalidArgument(err *service.Error, detail string) error {
+	return mapErrorToGRPCStatus(fmt.Errorf("%w: %s", err, detail)).Err()
+}
+
+// withErrorInfo adds an ErrorInfo detail with the reason, the status is returned without it when it cannot be encoded
+func withErrorInfo(st *status.Status, reason string) *status.Status {
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
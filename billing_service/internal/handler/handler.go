@@ -1,11 +1,17 @@
 package billing_handler
 
 import (
+	"billing-system/billing_service/internal/model"
 	"billing-system/billing_service/internal/service"
 	"billing-system/billing_service/pkg/utils"
 	pb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,15 +20,33 @@ import (
 // OrderHandler handles gRPC requestsW related to orders
 type OrderHandler struct {
 	pb.UnimplementedBillingServiceServer
-	orderService   service.OrderService
-	invoiceService service.InvoiceService
+	orderService        service.OrderService
+	invoiceService      service.InvoiceService
+	subscriptionService service.SubscriptionService
+	usageService        service.UsageService
+	pricingService      service.PricingService
+	creditNoteService   service.CreditNoteService
+	apiKeyService       service.APIKeyService
 }
 
 // NewOrderHandler creates a new OrderHandler
-func NewOrderHandler(orderService service.OrderService, invoiceService service.InvoiceService) *OrderHandler {
+func NewOrderHandler(
+	orderService service.OrderService,
+	invoiceService service.InvoiceService,
+	subscriptionService service.SubscriptionService,
+	usageService service.UsageService,
+	pricingService service.PricingService,
+	creditNoteService service.CreditNoteService,
+	apiKeyService service.APIKeyService,
+) *OrderHandler {
 	return &OrderHandler{
-		orderService:   orderService,
-		invoiceService: invoiceService,
+		orderService:        orderService,
+		invoiceService:      invoiceService,
+		subscriptionService: subscriptionService,
+		usageService:        usageService,
+		pricingService:      pricingService,
+		creditNoteService:   creditNoteService,
+		apiKeyService:       apiKeyService,
 	}
 }
 
@@ -32,8 +56,21 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 	items := utils.ProtoItemRequestsToDTO(req.Items)
 	payments := utils.ProtoPaymentRequestsToDTO(req.Payments)
 
-	// Call the service layer
-	order, err := h.orderService.CreateOrder(ctx, req.CustomerId, items, payments)
+	if !canActFor(ctx, req.CustomerId) {
+		return nil, auth.ForeignCustomer()
+	}
+
+	// Call the service layer, a quote token fixes the items and their prices
+	var order *model.Order
+	var err error
+	if req.QuoteToken != "" {
+		if len(items) > 0 {
+			return nil, invalidArgument(service.ErrInvalidQuote, "items cannot be set together with a quote token")
+		}
+		order, err = h.orderService.CreateOrderFromQuote(ctx, req.CustomerId, req.QuoteToken, payments)
+	} else {
+		order, err = h.orderService.CreateOrder(ctx, req.CustomerId, items, payments)
+	}
 	if err != nil {
 		log.Println("Failed to create order:", err)
 		return nil, mapErrorToGRPCStatus(err).Err()
@@ -47,17 +84,61 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderReque
 	}, nil
 }
 
+// QuoteOrder handles the gRPC request to price a cart without creating an order
+func (h *OrderHandler) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteOrderResponse, error) {
+	if !canActFor(ctx, req.CustomerId) {
+		return nil, auth.ForeignCustomer()
+	}
+
+	items := utils.ProtoItemRequestsToDTO(req.Items)
+	lock := time.Duration(req.LockMinutes) * time.Minute
+
+	quote, err := h.orderService.QuoteOrder(ctx, req.CustomerId, items, lock)
+	if err != nil {
+		log.Println("Failed to quote order:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return utils.QuoteToProto(quote), nil
+}
+
+// GetOrder handles the gRPC request to get an order.
+// The orders of other customers are not found for customers, so they cannot tell which ids exist.
+func (h *OrderHandler) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	order, err := h.getOwnOrder(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetOrderResponse{
+		Order: utils.OrderToProto(order),
+	}, nil
+}
+
+// getOwnOrder returns the order when the caller may act for its customer, the status of the error otherwise
+func (h *OrderHandler) getOwnOrder(ctx context.Context, orderID int64) (*model.Order, error) {
+	order, err := h.orderService.GetOrderByID(ctx, orderID)
+	if err != nil {
+		log.Println("Failed to get order:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+	if !canActFor(ctx, order.CustomerID) {
+		return nil, mapErrorToGRPCStatus(fmt.Errorf("%w: %d", service.ErrOrderNotFound, orderID)).Err()
+	}
+	return order, nil
+}
+
 func (h *OrderHandler) CreateInvoice(ctx context.Context, req *pb.CreateInvoiceRequest) (*pb.CreateInvoiceResponse, error) {
 	// Convert proto items to DTO
 	items := utils.ProtoInvoiceItemRequestsToDTO(req.Items)
 
+	charges := utils.ProtoShippingFeeToDTO(req.ShippingFee)
+
 	// Call service
-	invoice, err := h.invoiceService.CreateInvoice(ctx, req.ShipmentId, req.OrderId, items)
+	invoice, err := h.invoiceService.CreateInvoice(ctx, req.ShipmentId, req.OrderId, items, charges)
 	if err != nil {
-		return &pb.CreateInvoiceResponse{
-			Code:    "ERROR",
-			Message: err.Error(),
-		}, nil
+		log.Println("Failed to create invoice:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
 	}
 
 	// Convert domain model to proto
@@ -70,14 +151,301 @@ func (h *OrderHandler) CreateInvoice(ctx context.Context, req *pb.CreateInvoiceR
 	}, nil
 }
 
-// mapErrorToGRPCStatus maps service errors to gRPC status errors
-func mapErrorToGRPCStatus(err error) *status.Status {
-	switch err {
-	case service.ErrItemNotFound, service.ErrOrderNotFound:
-		return status.New(codes.NotFound, err.Error())
-	case service.ErrInvalidQuantity, service.ErrInvalidAmount, service.ErrInsufficientPayment:
-		return status.New(codes.InvalidArgument, err.Error())
-	default:
-		return status.New(codes.Internal, "internal server error")
+// PayInvoice handles the gRPC request to record a payment against an invoice
+func (h *OrderHandler) PayInvoice(ctx context.Context, req *pb.PayInvoiceRequest) (*pb.PayInvoiceResponse, error) {
+	invoice, err := h.invoiceService.PayInvoice(ctx, req.InvoiceId, req.Amount)
+	if err != nil {
+		log.Println("Failed to pay invoice:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.PayInvoiceResponse{
+		Invoice: utils.InvoiceToProto(invoice),
+	}, nil
+}
+
+// GetShippableQuantities handles the gRPC request to get what is left to ship of an order
+func (h *OrderHandler) GetShippableQuantities(ctx context.Context, req *pb.GetShippableQuantitiesRequest) (*pb.GetShippableQuantitiesResponse, error) {
+	// Only customers are limited to their own orders, staff get any order without looking it up
+	if principal, ok := auth.FromContext(ctx); ok && !principal.IsStaff() {
+		if _, err := h.getOwnOrder(ctx, req.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
+	quantities, err := h.invoiceService.GetShippableQuantities(ctx, req.OrderId)
+	if err != nil {
+		log.Println("Failed to get shippable quantities:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.GetShippableQuantitiesResponse{
+		Quantities: utils.ShippableQuantitiesToProto(quantities),
+	}, nil
+}
+
+// ListOrderInvoices handles the gRPC request to list the invoices of an order
+func (h *OrderHandler) ListOrderInvoices(ctx context.Context, req *pb.ListOrderInvoicesRequest) (*pb.ListOrderInvoicesResponse, error) {
+	// Only customers are limited to their own orders, staff get any order without looking it up
+	if principal, ok := auth.FromContext(ctx); ok && !principal.IsStaff() {
+		if _, err := h.getOwnOrder(ctx, req.OrderId); err != nil {
+			return nil, err
+		}
+	}
+
+	invoices, err := h.invoiceService.ListOrderInvoices(ctx, req.OrderId)
+	if err != nil {
+		log.Println("Failed to list order invoices:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
 	}
+
+	protoInvoices := make([]*pb.Invoice, len(invoices))
+	for i := range invoices {
+		protoInvoices[i] = utils.InvoiceToProto(&invoices[i])
+	}
+	return &pb.ListOrderInvoicesResponse{
+		Invoices: protoInvoices,
+	}, nil
+}
+
+// CreateCreditNote handles the gRPC request to reverse items of a shipment's invoice
+func (h *OrderHandler) CreateCreditNote(ctx context.Context, req *pb.CreateCreditNoteRequest) (*pb.CreateCreditNoteResponse, error) {
+	items := utils.ProtoInvoiceItemRequestsToDTO(req.Items)
+
+	creditNote, err := h.creditNoteService.CreateCreditNote(ctx, req.ShipmentId, req.Reference, req.Reason, items)
+	if err != nil {
+		log.Println("Failed to create credit note:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.CreateCreditNoteResponse{
+		Code:       "SUCCESS",
+		Message:    "Credit note created successfully",
+		CreditNote: utils.CreditNoteToProto(creditNote),
+	}, nil
+}
+
+// CreatePlan handles the gRPC request to create a recurring plan
+func (h *OrderHandler) CreatePlan(ctx context.Context, req *pb.CreatePlanRequest) (*pb.CreatePlanResponse, error) {
+	plan, err := h.subscriptionService.CreatePlan(ctx, utils.ProtoCreatePlanRequestToModel(req))
+	if err != nil {
+		log.Println("Failed to create plan:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.CreatePlanResponse{
+		Plan: utils.PlanToProto(plan),
+	}, nil
+}
+
+// CreateSubscription handles the gRPC request to subscribe a customer to a plan
+func (h *OrderHandler) CreateSubscription(ctx context.Context, req *pb.CreateSubscriptionRequest) (*pb.SubscriptionResponse, error) {
+	subscription, err := h.subscriptionService.CreateSubscription(ctx, req.CustomerId, req.PlanCode, model.PaymentMethod(req.PaymentMethod))
+	if err != nil {
+		log.Println("Failed to create subscription:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: utils.SubscriptionToProto(subscription),
+	}, nil
+}
+
+// ChangeSubscriptionPlan handles the gRPC request to move a subscription to another plan
+func (h *OrderHandler) ChangeSubscriptionPlan(ctx context.Context, req *pb.ChangeSubscriptionPlanRequest) (*pb.SubscriptionResponse, error) {
+	subscription, err := h.subscriptionService.ChangePlan(ctx, req.SubscriptionId, req.PlanCode)
+	if err != nil {
+		log.Println("Failed to change subscription plan:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: utils.SubscriptionToProto(subscription),
+	}, nil
+}
+
+// PauseSubscription handles the gRPC request to pause a subscription
+func (h *OrderHandler) PauseSubscription(ctx context.Context, req *pb.SubscriptionRequest) (*pb.SubscriptionResponse, error) {
+	subscription, err := h.subscriptionService.PauseSubscription(ctx, req.SubscriptionId)
+	if err != nil {
+		log.Println("Failed to pause subscription:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: utils.SubscriptionToProto(subscription),
+	}, nil
+}
+
+// ResumeSubscription handles the gRPC request to resume a paused subscription
+func (h *OrderHandler) ResumeSubscription(ctx context.Context, req *pb.SubscriptionRequest) (*pb.SubscriptionResponse, error) {
+	subscription, err := h.subscriptionService.ResumeSubscription(ctx, req.SubscriptionId)
+	if err != nil {
+		log.Println("Failed to resume subscription:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: utils.SubscriptionToProto(subscription),
+	}, nil
+}
+
+// CancelSubscription handles the gRPC request to cancel a subscription at the end of its period
+func (h *OrderHandler) CancelSubscription(ctx context.Context, req *pb.SubscriptionRequest) (*pb.SubscriptionResponse, error) {
+	subscription, err := h.subscriptionService.CancelSubscription(ctx, req.SubscriptionId)
+	if err != nil {
+		log.Println("Failed to cancel subscription:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: utils.SubscriptionToProto(subscription),
+	}, nil
+}
+
+// CreateMeter handles the gRPC request to create a usage meter
+func (h *OrderHandler) CreateMeter(ctx context.Context, req *pb.CreateMeterRequest) (*pb.CreateMeterResponse, error) {
+	meter, err := h.usageService.CreateMeter(ctx, utils.ProtoCreateMeterRequestToModel(req))
+	if err != nil {
+		log.Println("Failed to create meter:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.CreateMeterResponse{
+		Meter: utils.MeterToProto(meter),
+	}, nil
+}
+
+// RecordUsage handles the gRPC stream of usage events.
+// Invalid events are reported back as rejected without aborting the stream,
+// other failures abort it and the client can resend, duplicates are ignored.
+func (h *OrderHandler) RecordUsage(stream pb.BillingService_RecordUsageServer) error {
+	response := &pb.RecordUsageResponse{}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(response)
+		}
+		if err != nil {
+			return err
+		}
+
+		record, err := utils.ProtoUsageEventToModel(event)
+		if err == nil {
+			var created bool
+			created, err = h.usageService.RecordUsage(stream.Context(), record)
+			if created {
+				response.Accepted++
+			} else if err == nil {
+				response.Duplicates++
+			}
+		}
+		if err != nil {
+			if record != nil && !errors.Is(err, service.ErrInvalidUsage) && !errors.Is(err, service.ErrMeterNotFound) {
+				log.Println("Failed to record usage:", err)
+				return status.Errorf(codes.Internal, "failed to record usage event %s: %v", event.IdempotencyKey, err)
+			}
+			response.Rejected = append(response.Rejected, &pb.RejectedUsageEvent{
+				IdempotencyKey: event.IdempotencyKey,
+				Reason:         err.Error(),
+			})
+		}
+	}
+}
+
+// CreatePriceList handles the gRPC request to create a price list
+func (h *OrderHandler) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.CreatePriceListResponse, error) {
+	priceList, entries, err := utils.ProtoCreatePriceListRequestToModel(req)
+	if err != nil {
+		return nil, invalidArgument(service.ErrInvalidPriceList, err.Error())
+	}
+
+	priceList, err = h.pricingService.CreatePriceList(ctx, priceList, entries)
+	if err != nil {
+		log.Println("Failed to create price list:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.CreatePriceListResponse{
+		PriceList: utils.PriceListToProto(priceList),
+	}, nil
+}
+
+// IssueAPIKey handles the gRPC request to issue an API key
+func (h *OrderHandler) IssueAPIKey(ctx context.Context, req *pb.IssueAPIKeyRequest) (*pb.APIKeySecretResponse, error) {
+	key, err := utils.ProtoIssueAPIKeyRequestToModel(req)
+	if err != nil {
+		return nil, invalidArgument(service.ErrInvalidAPIKey, err.Error())
+	}
+
+	key, secret, err := h.apiKeyService.IssueAPIKey(ctx, key)
+	if err != nil {
+		log.Println("Failed to issue API key:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.APIKeySecretResponse{
+		ApiKey: utils.APIKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+// ListAPIKeys handles the gRPC request to list API keys
+func (h *OrderHandler) ListAPIKeys(ctx context.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	keys, err := h.apiKeyService.ListAPIKeys(ctx, req.CustomerId)
+	if err != nil {
+		log.Println("Failed to list API keys:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	protoKeys := make([]*pb.APIKey, len(keys))
+	for i := range keys {
+		protoKeys[i] = utils.APIKeyToProto(&keys[i])
+	}
+	return &pb.ListAPIKeysResponse{
+		ApiKeys: protoKeys,
+	}, nil
+}
+
+// RevokeAPIKey handles the gRPC request to revoke an API key
+func (h *OrderHandler) RevokeAPIKey(ctx context.Context, req *pb.APIKeyRequest) (*pb.APIKeyResponse, error) {
+	key, err := h.apiKeyService.RevokeAPIKey(ctx, req.Id)
+	if err != nil {
+		log.Println("Failed to revoke API key:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.APIKeyResponse{
+		ApiKey: utils.APIKeyToProto(key),
+	}, nil
+}
+
+// RotateAPIKey handles the gRPC request to replace an API key
+func (h *OrderHandler) RotateAPIKey(ctx context.Context, req *pb.RotateAPIKeyRequest) (*pb.APIKeySecretResponse, error) {
+	overlap := time.Duration(req.OverlapMinutes) * time.Minute
+
+	key, secret, err := h.apiKeyService.RotateAPIKey(ctx, req.Id, overlap)
+	if err != nil {
+		log.Println("Failed to rotate API key:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.APIKeySecretResponse{
+		ApiKey: utils.APIKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+// VerifyAPIKey handles the gRPC request of the BFF to authenticate a client by its API key
+func (h *OrderHandler) VerifyAPIKey(ctx context.Context, req *pb.VerifyAPIKeyRequest) (*pb.APIKeyResponse, error) {
+	key, err := h.apiKeyService.VerifyAPIKey(ctx, req.Secret)
+	if err != nil {
+		log.Println("Failed to verify API key:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.APIKeyResponse{
+		ApiKey: utils.APIKeyToProto(key),
+	}, nil
 }
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// APIKey is a credential of a server-to-server client such as an ERP.
+// Only the SHA-256 hash of its secret is stored, the key is shown once when it is issued.
+// KeyID is the public part of the key, it names the key in logs and on the orders and shipments it creates.
+type APIKey struct {
+	Base
+	KeyID      string   `json:"key_id" gorm:"uniqueIndex"`
+	Name       string   `json:"name"`
+	CustomerID string   `json:"customer_id,omitempty" gorm:"index"`
+	Roles      []string `json:"roles" gorm:"serializer:json"`
+	// Scopes narrow the permissions of the roles, the roles apply in full when empty
+	Scopes     []string   `json:"scopes,omitempty" gorm:"serializer:json"`
+	SecretHash string     `json:"-"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// RotatedFromID is the key this key replaced, it stays valid until its expiry so clients can switch over
+	RotatedFromID *int64 `json:"rotated_from_id,omitempty"`
+	// CreatedBy is the subject of the admin who issued the key
+	CreatedBy string `json:"created_by"`
+}
+
+// IsActive reports whether the key may still be used at the time
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -0,0 +1,24 @@
+package model
+
+// CreditNote reverses part of an invoice, such as the items of a return.
+// Reference identifies the request that issued it so retries return the same note.
+type CreditNote struct {
+	Base
+	InvoiceID int64            `json:"invoice_id" gorm:"index"`
+	OrderID   int64            `json:"order_id" gorm:"index"`
+	Reference string           `json:"reference" gorm:"uniqueIndex"`
+	Reason    string           `json:"reason,omitempty"`
+	Amount    float64          `json:"amount"`
+	Items     []CreditNoteItem `json:"items" gorm:"foreignKey:CreditNoteID"`
+}
+
+// CreditNoteItem is the credited quantity of an invoiced item at the price it was invoiced at
+type CreditNoteItem struct {
+	Base
+	CreditNoteID int64   `json:"credit_note_id" gorm:"index"`
+	ItemID       int64   `json:"item_id"`
+	Sku          string  `json:"sku"`
+	Quantity     int     `json:"quantity"`
+	UnitPrice    float64 `json:"unit_price"`
+	Amount       float64 `json:"amount"`
+}
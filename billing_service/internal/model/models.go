@@ -21,6 +21,37 @@ const (
 	VNPAY PaymentMethod = "VN_PAY"
 )
 
+// PaymentTerm defines how many days a customer has to pay an invoice
+type PaymentTerm string
+
+const (
+	Net7  PaymentTerm = "NET_7"
+	Net15 PaymentTerm = "NET_15"
+	Net30 PaymentTerm = "NET_30"
+
+	// DefaultPaymentTerm applies when neither the customer nor the configuration sets a term
+	DefaultPaymentTerm = Net30
+)
+
+// Days returns the number of days granted by the payment term
+func (t PaymentTerm) Days() int {
+	switch t {
+	case Net7:
+		return 7
+	case Net15:
+		return 15
+	case Net30:
+		return 30
+	default:
+		return 0
+	}
+}
+
+// IsValid reports whether the payment term is one of the supported terms
+func (t PaymentTerm) IsValid() bool {
+	return t.Days() > 0
+}
+
 type Base struct {
 	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
@@ -28,15 +59,27 @@ type Base struct {
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// Customer holds billing settings for a customer
+type Customer struct {
+	Base
+	CustomerID  string          `json:"customer_id" gorm:"uniqueIndex"`
+	PaymentTerm PaymentTerm     `json:"payment_term"`
+	OnHold      bool            `json:"on_hold"`
+	Segment     CustomerSegment `json:"segment"`
+}
+
 // Order represents an order in the system
 type Order struct {
 	Base
 	CustomerID  string      `json:"customer_id"`
 	TotalAmount float64     `json:"total_amount"`
 	Status      OrderStatus `json:"status"`
-	Items       []OrderItem `json:"items,omitempty" gorm:"foreignKey:OrderID"`
-	Payments    []Payment   `json:"payments,omitempty" gorm:"foreignKey:OrderID"`
-	Invoices    []Invoice   `json:"invoices,omitempty" gorm:"foreignKey:OrderID"`
+	PaymentTerm PaymentTerm `json:"payment_term"`
+	// APIKeyID is the public id of the API key the order was created with, empty for users
+	APIKeyID string      `json:"api_key_id,omitempty" gorm:"index"`
+	Items    []OrderItem `json:"items,omitempty" gorm:"foreignKey:OrderID"`
+	Payments []Payment   `json:"payments,omitempty" gorm:"foreignKey:OrderID"`
+	Invoices []Invoice   `json:"invoices,omitempty" gorm:"foreignKey:OrderID"`
 }
 
 type Item struct {
@@ -46,13 +89,28 @@ type Item struct {
 	Price float64 `json:"price"`
 }
 
-// OrderItem represents an item in an order
+// OrderItem represents an item in an order.
+// PriceListCode and PriceTier record where the unit price came from, they are empty for catalog prices.
 type OrderItem struct {
 	Base
-	OrderID  int64 `json:"order_id" gorm:"index"`
-	Quantity int   `json:"quantity"`
-	ItemID   int64 `json:"item_id" gorm:"foreignKey:ID;references:ID"`
-	Item     Item  `json:"item" gorm:"foreignKey:ItemID"`
+	OrderID   int64   `json:"order_id" gorm:"index"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	// PriceRecorded is set on lines whose unit price was recorded when they were ordered, a price of 0 included
+	PriceRecorded bool   `json:"-"`
+	PriceListCode string `json:"price_list_code,omitempty"`
+	PriceTier     int    `json:"price_tier,omitempty"`
+	ItemID        int64  `json:"item_id" gorm:"foreignKey:ID;references:ID"`
+	Item          Item   `json:"item" gorm:"foreignKey:ItemID"`
+}
+
+// Price returns the unit price recorded on the line. Lines of orders created before unit prices were recorded
+// have none and use the catalog price, a line recorded before the flag existed has a non-zero price.
+func (i *OrderItem) Price(catalogPrice float64) float64 {
+	if i.PriceRecorded || i.UnitPrice != 0 {
+		return i.UnitPrice
+	}
+	return catalogPrice
 }
 
 // Payment represents a payment for an order
@@ -66,10 +124,63 @@ type Payment struct {
 // Invoice represents an invoice for a shipment
 type Invoice struct {
 	Base
-	OrderID     int64         `json:"order_id" gorm:"index"`
-	ShipmentID  int64         `json:"shipment_id" gorm:"uniqueIndex"`
-	TotalAmount float64       `json:"total_amount"`
-	Items       []InvoiceItem `json:"items" gorm:"foreignKey:InvoiceID"`
+	OrderID     int64     `json:"order_id" gorm:"index"`
+	ShipmentID  int64     `json:"shipment_id" gorm:"uniqueIndex:idx_invoices_shipment_id,where:shipment_id <> 0"`
+	TotalAmount float64   `json:"total_amount"`
+	DueDate     time.Time `json:"due_date" gorm:"index"`
+	PaidAmount  float64   `json:"paid_amount"`
+	// CreditedAmount is the total of the credit notes issued against the invoice
+	CreditedAmount float64         `json:"credited_amount"`
+	DunningLevel   int             `json:"dunning_level"`
+	LastReminderAt *time.Time      `json:"last_reminder_at,omitempty"`
+	Items          []InvoiceItem   `json:"items" gorm:"foreignKey:InvoiceID"`
+	Charges        []InvoiceCharge `json:"charges,omitempty" gorm:"foreignKey:InvoiceID"`
+	Order          *Order          `json:"order,omitempty" gorm:"foreignKey:OrderID"`
+}
+
+// OutstandingAmount returns the amount still to be paid on the invoice.
+// It is negative when credit notes leave more paid than owed, the difference is due back to the customer.
+func (i *Invoice) OutstandingAmount() float64 {
+	return i.TotalAmount - i.PaidAmount - i.CreditedAmount
+}
+
+// IsPaid reports whether the invoice has been fully paid
+func (i *Invoice) IsPaid() bool {
+	return i.OutstandingAmount() <= 0
+}
+
+// InvoicePaymentStatus is how far an invoice has been paid
+type InvoicePaymentStatus string
+
+const (
+	InvoiceUnpaid        InvoicePaymentStatus = "UNPAID"
+	InvoicePartiallyPaid InvoicePaymentStatus = "PARTIALLY_PAID"
+	InvoicePaid          InvoicePaymentStatus = "PAID"
+	// InvoiceOverdue is an invoice not fully paid after its due date
+	InvoiceOverdue InvoicePaymentStatus = "OVERDUE"
+)
+
+// PaymentStatus returns the payment status of the invoice at the given time
+func (i *Invoice) PaymentStatus(now time.Time) InvoicePaymentStatus {
+	switch {
+	case i.IsPaid():
+		return InvoicePaid
+	case !i.DueDate.IsZero() && now.After(i.DueDate):
+		return InvoiceOverdue
+	case i.PaidAmount > 0 || i.CreditedAmount > 0:
+		return InvoicePartiallyPaid
+	default:
+		return InvoiceUnpaid
+	}
+}
+
+// ShippableQuantity is how much of an ordered SKU has been invoiced and how much is left to ship
+type ShippableQuantity struct {
+	Sku       string `json:"sku"`
+	ItemID    int64  `json:"item_id"`
+	Ordered   int    `json:"ordered"`
+	Invoiced  int    `json:"invoiced"`
+	Remaining int    `json:"remaining"`
 }
 
 // InvoiceItem represents an item in an invoice
@@ -80,3 +191,113 @@ type InvoiceItem struct {
 	ItemID    int64 `json:"item_id" gorm:"foreignKey:ID;references:ID"`
 	Item      Item  `json:"item" gorm:"foreignKey:ItemID"`
 }
+
+// ChargeType defines the kind of an invoice line that is not an ordered item
+type ChargeType string
+
+const (
+	ChargeShipping ChargeType = "SHIPPING"
+)
+
+// DefaultShippingTaxCategory is the tax category of shipping charges that do not name one
+const DefaultShippingTaxCategory = "SHIPPING"
+
+// InvoiceCharge is an invoice line billed on top of the ordered items, such as a shipping fee.
+// TaxCategory is recorded so charges can be taxed apart from the items.
+type InvoiceCharge struct {
+	Base
+	InvoiceID   int64      `json:"invoice_id" gorm:"index"`
+	Type        ChargeType `json:"type"`
+	Description string     `json:"description"`
+	Amount      float64    `json:"amount"`
+	TaxCategory string     `json:"tax_category"`
+}
+
+// BillingInterval defines the length unit of a subscription billing period
+type BillingInterval string
+
+const (
+	IntervalMonth BillingInterval = "MONTH"
+	IntervalYear  BillingInterval = "YEAR"
+)
+
+// SubscriptionStatus defines the status of a subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionTrialing SubscriptionStatus = "TRIALING"
+	SubscriptionActive   SubscriptionStatus = "ACTIVE"
+	SubscriptionPaused   SubscriptionStatus = "PAUSED"
+	SubscriptionCanceled SubscriptionStatus = "CANCELED"
+)
+
+// Plan represents a recurring plan that customers can subscribe to.
+// Each period is billed as one unit of the item identified by Sku at Price.
+type Plan struct {
+	Base
+	Code          string          `json:"code" gorm:"uniqueIndex"`
+	Name          string          `json:"name"`
+	Sku           string          `json:"sku"`
+	Price         float64         `json:"price"`
+	Interval      BillingInterval `json:"interval"`
+	IntervalCount int             `json:"interval_count"`
+	TrialDays     int             `json:"trial_days"`
+}
+
+// PeriodEnd returns the end of a billing period starting at start
+func (p *Plan) PeriodEnd(start time.Time) time.Time {
+	count := p.IntervalCount
+	if count <= 0 {
+		count = 1
+	}
+	if p.Interval == IntervalYear {
+		return start.AddDate(count, 0, 0)
+	}
+	return start.AddDate(0, count, 0)
+}
+
+// Subscription represents a customer's subscription to a plan
+type Subscription struct {
+	Base
+	CustomerID         string             `json:"customer_id" gorm:"index"`
+	PlanID             int64              `json:"plan_id"`
+	Plan               Plan               `json:"plan" gorm:"foreignKey:PlanID"`
+	Status             SubscriptionStatus `json:"status"`
+	PaymentMethod      PaymentMethod      `json:"payment_method"`
+	TrialEnd           *time.Time         `json:"trial_end,omitempty"`
+	CurrentPeriodStart time.Time          `json:"current_period_start"`
+	CurrentPeriodEnd   time.Time          `json:"current_period_end"`
+	NextBillingAt      time.Time          `json:"next_billing_at" gorm:"index"`
+	CancelAtPeriodEnd  bool               `json:"cancel_at_period_end"`
+	CanceledAt         *time.Time         `json:"canceled_at,omitempty"`
+	// CreditBalance holds proration credit applied to upcoming periods
+	CreditBalance float64 `json:"credit_balance"`
+	// LockedUntil is the lease taken by the scheduler replica billing the subscription
+	LockedUntil *time.Time `json:"-"`
+}
+
+// SubscriptionPeriod records the billing of one subscription period.
+// The unique index on (subscription_id, period_start) prevents billing a period twice.
+type SubscriptionPeriod struct {
+	Base
+	SubscriptionID int64     `json:"subscription_id" gorm:"uniqueIndex:idx_subscription_period"`
+	PeriodStart    time.Time `json:"period_start" gorm:"uniqueIndex:idx_subscription_period"`
+	PeriodEnd      time.Time `json:"period_end"`
+	Amount         float64   `json:"amount"`
+	OrderID        int64     `json:"order_id"`
+	InvoiceID      int64     `json:"invoice_id"`
+}
+
+// SubscriptionPlanChange records the proration charge of a plan change until it is applied or voided.
+// A subscription has at most one pending change, so a retried change is charged once.
+type SubscriptionPlanChange struct {
+	Base
+	SubscriptionID int64      `json:"subscription_id" gorm:"uniqueIndex:idx_subscription_open_plan_change,where:applied_at IS NULL AND voided_at IS NULL"`
+	FromPlanID     int64      `json:"from_plan_id"`
+	ToPlanID       int64      `json:"to_plan_id"`
+	Amount         float64    `json:"amount"`
+	OrderID        int64      `json:"order_id"`
+	InvoiceID      int64      `json:"invoice_id"`
+	AppliedAt      *time.Time `json:"applied_at"`
+	VoidedAt       *time.Time `json:"voided_at,omitempty"`
+}
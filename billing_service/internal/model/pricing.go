@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// CustomerSegment groups customers that share a price list
+type CustomerSegment string
+
+const (
+	SegmentRetail    CustomerSegment = "RETAIL"
+	SegmentWholesale CustomerSegment = "WHOLESALE"
+)
+
+// PriceListKind defines who a price list applies to
+type PriceListKind string
+
+const (
+	// PriceListRetail applies to every customer without a more specific price
+	PriceListRetail PriceListKind = "RETAIL"
+	// PriceListWholesale applies to customers in the wholesale segment
+	PriceListWholesale PriceListKind = "WHOLESALE"
+	// PriceListContract applies to a single customer
+	PriceListContract PriceListKind = "CONTRACT"
+)
+
+// PriceList is a set of item prices effective between ValidFrom and ValidTo.
+// Contract price lists belong to the customer identified by CustomerID.
+type PriceList struct {
+	Base
+	Code       string           `json:"code" gorm:"uniqueIndex"`
+	Name       string           `json:"name"`
+	Kind       PriceListKind    `json:"kind" gorm:"index"`
+	CustomerID string           `json:"customer_id,omitempty" gorm:"index"`
+	ValidFrom  time.Time        `json:"valid_from"`
+	ValidTo    *time.Time       `json:"valid_to,omitempty"`
+	Entries    []PriceListEntry `json:"entries,omitempty" gorm:"foreignKey:PriceListID"`
+}
+
+// IsEffective reports whether the price list applies at the given time
+func (p *PriceList) IsEffective(at time.Time) bool {
+	return !at.Before(p.ValidFrom) && (p.ValidTo == nil || at.Before(*p.ValidTo))
+}
+
+// PriceListEntry is the price of one item in a price list.
+// The unit price is taken from the tier the ordered quantity falls into.
+type PriceListEntry struct {
+	Base
+	PriceListID int64      `json:"price_list_id" gorm:"uniqueIndex:idx_price_list_item"`
+	ItemID      int64      `json:"item_id" gorm:"uniqueIndex:idx_price_list_item"`
+	Tiers       PriceTiers `json:"tiers" gorm:"serializer:json"`
+	Item        *Item      `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	PriceList   *PriceList `json:"-" gorm:"foreignKey:PriceListID"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// QuoteLine is the price of one item of a quoted cart
+type QuoteLine struct {
+	Sku           string  `json:"sku"`
+	ItemID        int64   `json:"item_id"`
+	Quantity      int     `json:"quantity"`
+	UnitPrice     float64 `json:"unit_price"`
+	LineTotal     float64 `json:"line_total"`
+	PriceListCode string  `json:"price_list_code,omitempty"`
+	PriceTier     int     `json:"price_tier,omitempty"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+// Quote is the price of a cart computed the same way as an order, it is never stored.
+// A locked quote carries a signed token that keeps its prices until ExpiresAt.
+// TaxAmount is the tax of the lines on top of TotalAmount, zero until taxes are computed.
+type Quote struct {
+	CustomerID  string      `json:"customer_id"`
+	Lines       []QuoteLine `json:"lines"`
+	TotalAmount float64     `json:"total_amount"`
+	TaxAmount   float64     `json:"tax_amount"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
+	Token       string      `json:"-"`
+}
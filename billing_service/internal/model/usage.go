@@ -0,0 +1,209 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// UsageAggregation defines how the usage records of a period are rolled up
+type UsageAggregation string
+
+const (
+	AggregationSum  UsageAggregation = "SUM"
+	AggregationMax  UsageAggregation = "MAX"
+	AggregationLast UsageAggregation = "LAST"
+)
+
+// PricingModel defines how price tiers are applied to a quantity
+type PricingModel string
+
+const (
+	// PricingTiered prices each unit at the tier it falls into
+	PricingTiered PricingModel = "TIERED"
+	// PricingVolume prices every unit at the tier the total quantity falls into
+	PricingVolume PricingModel = "VOLUME"
+)
+
+// PriceTier is one step of a tiered price.
+// UpTo is the inclusive upper bound of the tier, zero means unbounded.
+type PriceTier struct {
+	UpTo      float64 `json:"up_to"`
+	UnitPrice float64 `json:"unit_price"`
+	FlatFee   float64 `json:"flat_fee"`
+}
+
+// PriceTiers is a list of tiers ordered by UpTo, the last one may be unbounded
+type PriceTiers []PriceTier
+
+// Graduated prices each unit at the tier it falls into.
+// The flat fee of a tier is charged once any unit falls into it.
+func (t PriceTiers) Graduated(quantity float64) float64 {
+	var amount, lower float64
+	for _, tier := range t {
+		if quantity <= lower {
+			break
+		}
+
+		upper := quantity
+		if tier.UpTo > 0 && tier.UpTo < quantity {
+			upper = tier.UpTo
+		}
+
+		amount += (upper-lower)*tier.UnitPrice + tier.FlatFee
+		lower = upper
+	}
+	return amount
+}
+
+// Find returns the tier the quantity falls into along with its 1-based position.
+// Quantities above the last bounded tier use the last tier, no tiers returns position 0.
+func (t PriceTiers) Find(quantity float64) (int, PriceTier) {
+	if len(t) == 0 {
+		return 0, PriceTier{}
+	}
+	for i, tier := range t {
+		if tier.UpTo == 0 || quantity <= tier.UpTo {
+			return i + 1, tier
+		}
+	}
+	return len(t), t[len(t)-1]
+}
+
+// Volume prices every unit at the tier the whole quantity falls into
+func (t PriceTiers) Volume(quantity float64) float64 {
+	if quantity <= 0 {
+		return 0
+	}
+	_, tier := t.Find(quantity)
+	return quantity*tier.UnitPrice + tier.FlatFee
+}
+
+// Meter defines a usage metric that customers are billed for.
+// Each period is billed as one unit of the item identified by Sku at the priced amount.
+type Meter struct {
+	Base
+	Code         string           `json:"code" gorm:"uniqueIndex"`
+	Name         string           `json:"name"`
+	Sku          string           `json:"sku"`
+	Aggregation  UsageAggregation `json:"aggregation"`
+	PricingModel PricingModel     `json:"pricing_model"`
+	Tiers        PriceTiers       `json:"tiers" gorm:"serializer:json"`
+}
+
+// Aggregate rolls up the records of one period. Records must be ordered by timestamp.
+func (m *Meter) Aggregate(records []UsageRecord) float64 {
+	var quantity float64
+	for i, record := range records {
+		switch m.Aggregation {
+		case AggregationMax:
+			if i == 0 || record.Quantity > quantity {
+				quantity = record.Quantity
+			}
+		case AggregationLast:
+			quantity = record.Quantity
+		default:
+			quantity += record.Quantity
+		}
+	}
+	return quantity
+}
+
+// Price returns the amount owed for quantity, rounded to cents
+func (m *Meter) Price(quantity float64) float64 {
+	var amount float64
+	if m.PricingModel == PricingVolume {
+		amount = m.Tiers.Volume(quantity)
+	} else {
+		amount = m.Tiers.Graduated(quantity)
+	}
+	return math.Round(amount*100) / 100
+}
+
+// UsageRecord is a single usage event reported for a customer.
+// The idempotency key makes reporting the same event twice harmless, it is unique per customer and meter.
+type UsageRecord struct {
+	Base
+	CustomerID     string    `json:"customer_id" gorm:"index:idx_usage_records_period;uniqueIndex:idx_usage_records_idempotency"`
+	MeterCode      string    `json:"meter_code" gorm:"index:idx_usage_records_period;uniqueIndex:idx_usage_records_idempotency"`
+	Quantity       float64   `json:"quantity"`
+	Timestamp      time.Time `json:"timestamp"`
+	IdempotencyKey string    `json:"idempotency_key" gorm:"uniqueIndex:idx_usage_records_idempotency"`
+	PeriodStart    time.Time `json:"period_start" gorm:"index:idx_usage_records_period"`
+	PeriodEnd      time.Time `json:"period_end"`
+	// UsagePeriodID is set once the record has been billed
+	UsagePeriodID *int64 `json:"usage_period_id,omitempty" gorm:"index"`
+}
+
+// UsagePeriodBounds returns the calendar month in UTC that contains t
+func UsagePeriodBounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// UsageKey identifies the usage of one customer for one meter in one period
+type UsageKey struct {
+	CustomerID  string
+	MeterCode   string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// UsagePeriodStatus defines the status of a usage period
+type UsagePeriodStatus string
+
+const (
+	UsagePeriodOpen   UsagePeriodStatus = "OPEN"
+	UsagePeriodClosed UsagePeriodStatus = "CLOSED"
+)
+
+// UsagePeriod records the billing of a customer's usage of a meter for one period.
+// Quantity and Amount are billed when the period closes, late usage is billed through adjustments.
+type UsagePeriod struct {
+	Base
+	CustomerID  string            `json:"customer_id" gorm:"uniqueIndex:idx_usage_period"`
+	MeterCode   string            `json:"meter_code" gorm:"uniqueIndex:idx_usage_period"`
+	PeriodStart time.Time         `json:"period_start" gorm:"uniqueIndex:idx_usage_period"`
+	PeriodEnd   time.Time         `json:"period_end"`
+	Quantity    float64           `json:"quantity"`
+	Amount      float64           `json:"amount"`
+	Status      UsagePeriodStatus `json:"status"`
+	OrderID     int64             `json:"order_id"`
+	InvoiceID   int64             `json:"invoice_id"`
+	// LockedUntil is the lease taken by the replica invoicing the period
+	LockedUntil *time.Time        `json:"-"`
+	Adjustments []UsageAdjustment `json:"adjustments,omitempty" gorm:"foreignKey:UsagePeriodID"`
+}
+
+// BilledQuantity returns the quantity billed for the period including adjustments
+func (p *UsagePeriod) BilledQuantity() float64 {
+	quantity := p.Quantity
+	for _, adjustment := range p.Adjustments {
+		quantity += adjustment.Quantity
+	}
+	return quantity
+}
+
+// BilledAmount returns the amount billed for the period including adjustments
+func (p *UsagePeriod) BilledAmount() float64 {
+	amount := p.Amount
+	for _, adjustment := range p.Adjustments {
+		amount += adjustment.Amount
+	}
+	return amount
+}
+
+// UsageAdjustment records usage that arrived after its period was closed.
+// A positive amount is invoiced, a negative amount is owed to the customer.
+type UsageAdjustment struct {
+	Base
+	UsagePeriodID int64   `json:"usage_period_id" gorm:"index"`
+	CustomerID    string  `json:"customer_id"`
+	MeterCode     string  `json:"meter_code"`
+	Quantity      float64 `json:"quantity"`
+	Amount        float64 `json:"amount"`
+	OrderID       int64   `json:"order_id"`
+	InvoiceID     int64   `json:"invoice_id"`
+	// LockedUntil is the lease taken by the replica invoicing the adjustment
+	LockedUntil *time.Time `json:"-"`
+}
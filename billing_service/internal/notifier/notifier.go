@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// NotificationKind defines the type of a dunning notification
+type NotificationKind string
+
+const (
+	PaymentReminder NotificationKind = "PAYMENT_REMINDER"
+	CustomerOnHold  NotificationKind = "CUSTOMER_ON_HOLD"
+)
+
+// Notification is a message sent to a customer about an invoice
+type Notification struct {
+	Kind              NotificationKind
+	CustomerID        string
+	InvoiceID         int64
+	OrderID           int64
+	OutstandingAmount float64
+	DueDate           time.Time
+	DaysOverdue       int
+	Level             int
+}
+
+// Notifier delivers notifications to customers
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// LogNotifier writes notifications to the standard logger
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new LogNotifier
+func NewLogNotifier() Notifier {
+	return &LogNotifier{}
+}
+
+// Notify logs the notification
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	log.Printf(
+		"[%s] customer=%s invoice=%d order=%d outstanding=%.2f due=%s overdue=%dd level=%d",
+		notification.Kind,
+		notification.CustomerID,
+		notification.InvoiceID,
+		notification.OrderID,
+		notification.OutstandingAmount,
+		notification.DueDate.Format(time.DateOnly),
+		notification.DaysOverdue,
+		notification.Level,
+	)
+	return nil
+}
+
+// InMemoryNotifier keeps notifications in memory, useful for local runs and tests
+type InMemoryNotifier struct {
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+// NewInMemoryNotifier creates a new InMemoryNotifier
+func NewInMemoryNotifier() *InMemoryNotifier {
+	return &InMemoryNotifier{}
+}
+
+// Notify stores the notification
+func (n *InMemoryNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+// Notifications returns a copy of the stored notifications
+func (n *InMemoryNotifier) Notifications() []Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Notification(nil), n.notifications...)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepositoryImpl implements the APIKeyRepository interface
+type APIKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepositoryImpl
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &APIKeyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new API key
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *model.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// GetByID retrieves an API key by its id
+func (r *APIKeyRepositoryImpl) GetByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.WithContext(ctx).First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByKeyID retrieves an API key by the public id its clients present
+func (r *APIKeyRepositoryImpl) GetByKeyID(ctx context.Context, keyID string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.WithContext(ctx).Where("key_id = ?", keyID).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List retrieves the API keys of a customer, or every key when customerID is empty, newest first
+func (r *APIKeyRepositoryImpl) List(ctx context.Context, customerID string) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	query := r.db.WithContext(ctx).Order("id DESC")
+	if customerID != "" {
+		query = query.Where("customer_id = ?", customerID)
+	}
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rotate stores the replacement key and shortens the expiry of the previous key in one transaction
+func (r *APIKeyRepositoryImpl) Rotate(ctx context.Context, replacement *model.APIKey, previous *model.APIKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.APIKey{}).
+			Where("id = ?", previous.ID).
+			Update("expires_at", previous.ExpiresAt).Error
+	})
+}
+
+// Revoke marks the key as revoked at the time, a key revoked earlier keeps its time
+func (r *APIKeyRepositoryImpl) Revoke(ctx context.Context, id int64, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastUsed records that the key was used at the time, unless it was already recorded as used after before.
+// It only updates the column, so verifying a key does not write a full row on every request.
+func (r *APIKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id int64, at time.Time, before time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, before).
+		UpdateColumn("last_used_at", at).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// CreditNoteRepositoryImpl implements the CreditNoteRepository interface
+type CreditNoteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCreditNoteRepository creates a new instance of CreditNoteRepositoryImpl
+func NewCreditNoteRepository(db *gorm.DB) CreditNoteRepository {
+	return &CreditNoteRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores the credit note with its items and adds its amount to the credited amount of its invoice
+func (r *CreditNoteRepositoryImpl) Create(ctx context.Context, creditNote *model.CreditNote) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(creditNote).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Invoice{}).
+			Where("id = ?", creditNote.InvoiceID).
+			UpdateColumn("credited_amount", gorm.Expr("credited_amount + ?", creditNote.Amount)).Error
+	})
+}
+
+// GetByReference retrieves the credit note issued for a reference along with its items
+func (r *CreditNoteRepositoryImpl) GetByReference(ctx context.Context, reference string) (*model.CreditNote, error) {
+	var creditNote model.CreditNote
+
+	result := r.db.WithContext(ctx).
+		Where("reference = ?", reference).
+		Preload("Items").
+		First(&creditNote)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &creditNote, nil
+}
+
+// ListByInvoiceID retrieves the credit notes of an invoice along with their items
+func (r *CreditNoteRepositoryImpl) ListByInvoiceID(ctx context.Context, invoiceID int64) ([]model.CreditNote, error) {
+	var creditNotes []model.CreditNote
+
+	result := r.db.WithContext(ctx).
+		Where("invoice_id = ?", invoiceID).
+		Preload("Items").
+		Order("id").
+		Find(&creditNotes)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return creditNotes, nil
+}
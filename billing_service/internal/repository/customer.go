@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CustomerRepositoryImpl implements the CustomerRepository interface
+type CustomerRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCustomerRepository creates a new instance of CustomerRepositoryImpl
+func NewCustomerRepository(db *gorm.DB) CustomerRepository {
+	return &CustomerRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetByCustomerID retrieves the billing settings of a customer.
+// Returns gorm.ErrRecordNotFound if the customer has no settings yet.
+func (r *CustomerRepositoryImpl) GetByCustomerID(ctx context.Context, customerID string) (*model.Customer, error) {
+	var customer model.Customer
+	err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).First(&customer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// SetOnHold marks a customer as on hold (or releases the hold).
+// The customer row is created if it does not exist yet.
+func (r *CustomerRepositoryImpl) SetOnHold(ctx context.Context, customerID string, onHold bool) error {
+	customer := model.Customer{
+		CustomerID: customerID,
+		OnHold:     onHold,
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "customer_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"on_hold", "updated_at"}),
+		}).
+		Create(&customer).Error
+}
+
+// ListOnHold retrieves the customers on hold
+func (r *CustomerRepositoryImpl) ListOnHold(ctx context.Context) ([]model.Customer, error) {
+	var customers []model.Customer
+	err := r.db.WithContext(ctx).Where("on_hold = ?", true).Order("customer_id").Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
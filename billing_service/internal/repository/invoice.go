@@ -3,6 +3,7 @@ package repository
 import (
 	"billing-system/billing_service/internal/model"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -35,6 +36,7 @@ func (r *InvoiceRepositoryImpl) GetByOrderID(ctx context.Context, orderID int64)
 	result := r.db.WithContext(ctx).
 		Where("order_id = ?", orderID).
 		Preload("Items").
+		Preload("Charges").
 		Find(&invoices)
 
 	if result.Error != nil {
@@ -43,3 +45,93 @@ func (r *InvoiceRepositoryImpl) GetByOrderID(ctx context.Context, orderID int64)
 
 	return invoices, nil
 }
+
+// GetByID retrieves an invoice by its ID along with its items
+func (r *InvoiceRepositoryImpl) GetByID(ctx context.Context, id int64) (*model.Invoice, error) {
+	var invoice model.Invoice
+
+	result := r.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Charges").
+		First(&invoice, id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &invoice, nil
+}
+
+// ListUnpaidDueBefore retrieves unpaid invoices whose due date is before the given time.
+// The owning order is preloaded so callers can reach the customer.
+func (r *InvoiceRepositoryImpl) ListUnpaidDueBefore(ctx context.Context, before time.Time) ([]model.Invoice, error) {
+	var invoices []model.Invoice
+
+	result := r.db.WithContext(ctx).
+		Where("due_date < ? AND paid_amount + credited_amount < total_amount", before).
+		Preload("Order").
+		Order("due_date").
+		Find(&invoices)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return invoices, nil
+}
+
+// HasUnpaidDueBefore reports whether the customer has an unpaid invoice whose due date is before the given time
+func (r *InvoiceRepositoryImpl) HasUnpaidDueBefore(ctx context.Context, customerID string, before time.Time) (bool, error) {
+	var count int64
+
+	result := r.db.WithContext(ctx).Model(&model.Invoice{}).
+		Joins("JOIN orders ON orders.id = invoices.order_id").
+		Where("orders.customer_id = ? AND invoices.due_date < ? AND invoices.paid_amount + invoices.credited_amount < invoices.total_amount",
+			customerID, before).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// GetByShipmentID retrieves the invoice of a shipment along with its items
+func (r *InvoiceRepositoryImpl) GetByShipmentID(ctx context.Context, shipmentID int64) (*model.Invoice, error) {
+	var invoice model.Invoice
+
+	result := r.db.WithContext(ctx).
+		Where("shipment_id = ?", shipmentID).
+		Preload("Items").
+		Preload("Charges").
+		First(&invoice)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &invoice, nil
+}
+
+// AddPayment adds a payment to the paid amount of an invoice in a single statement, unless it exceeds what is outstanding.
+// It reports false when nothing was added because payments or credit notes committed meanwhile left less outstanding.
+func (r *InvoiceRepositoryImpl) AddPayment(ctx context.Context, id int64, amount float64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("id = ? AND total_amount - paid_amount - credited_amount >= ?", id, amount).
+		UpdateColumn("paid_amount", gorm.Expr("paid_amount + ?", amount))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateDunning saves the dunning level and last reminder of an invoice, its amounts are left untouched
+func (r *InvoiceRepositoryImpl) UpdateDunning(ctx context.Context, invoice *model.Invoice) error {
+	return r.db.WithContext(ctx).Model(&model.Invoice{}).
+		Where("id = ?", invoice.ID).
+		UpdateColumns(map[string]interface{}{
+			"dunning_level":    invoice.DunningLevel,
+			"last_reminder_at": invoice.LastReminderAt,
+		}).Error
+}
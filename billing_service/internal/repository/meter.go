@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// MeterRepositoryImpl implements the MeterRepository interface
+type MeterRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMeterRepository creates a new instance of MeterRepositoryImpl
+func NewMeterRepository(db *gorm.DB) MeterRepository {
+	return &MeterRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create a new meter in the database
+func (r *MeterRepositoryImpl) Create(ctx context.Context, meter *model.Meter) error {
+	return r.db.WithContext(ctx).Create(meter).Error
+}
+
+// GetByCode retrieves a meter by its code
+func (r *MeterRepositoryImpl) GetByCode(ctx context.Context, code string) (*model.Meter, error) {
+	var meter model.Meter
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&meter).Error
+	if err != nil {
+		return nil, err
+	}
+	return &meter, nil
+}
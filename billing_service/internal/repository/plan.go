@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PlanRepositoryImpl implements the PlanRepository interface
+type PlanRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPlanRepository creates a new instance of PlanRepositoryImpl
+func NewPlanRepository(db *gorm.DB) PlanRepository {
+	return &PlanRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create a new plan in the database
+func (r *PlanRepositoryImpl) Create(ctx context.Context, plan *model.Plan) error {
+	return r.db.WithContext(ctx).Create(plan).Error
+}
+
+// GetByCode retrieves a plan by its code
+func (r *PlanRepositoryImpl) GetByCode(ctx context.Context, code string) (*model.Plan, error) {
+	var plan model.Plan
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&plan).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
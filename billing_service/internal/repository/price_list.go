@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PriceListRepositoryImpl implements the PriceListRepository interface
+type PriceListRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPriceListRepository creates a new instance of PriceListRepositoryImpl
+func NewPriceListRepository(db *gorm.DB) PriceListRepository {
+	return &PriceListRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create a new price list along with its entries, the items themselves are left untouched
+func (r *PriceListRepositoryImpl) Create(ctx context.Context, priceList *model.PriceList) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(priceList).Error; err != nil {
+			return err
+		}
+		if len(priceList.Entries) == 0 {
+			return nil
+		}
+
+		for i := range priceList.Entries {
+			priceList.Entries[i].PriceListID = priceList.ID
+		}
+		return tx.Omit(clause.Associations).Create(&priceList.Entries).Error
+	})
+}
+
+// ListEffectiveEntries returns the entries for an item in every price list effective at the given time
+// that may apply to the customer, i.e. retail and wholesale lists and the customer's own contracts.
+// Each entry comes with its price list.
+func (r *PriceListRepositoryImpl) ListEffectiveEntries(ctx context.Context, itemID int64, customerID string, at time.Time) ([]model.PriceListEntry, error) {
+	var entries []model.PriceListEntry
+	err := r.db.WithContext(ctx).
+		Joins("PriceList").
+		Where("price_list_entries.item_id = ?", itemID).
+		Where(`"PriceList".valid_from <= ? AND ("PriceList".valid_to IS NULL OR "PriceList".valid_to > ?)`, at, at).
+		Where(`"PriceList".kind <> ? OR "PriceList".customer_id = ?`, model.PriceListContract, customerID).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"billing-system/billing_service/internal/model"
 	"context"
+	"time"
 )
 
 type ItemRepository interface {
@@ -19,4 +20,82 @@ type OrderRepository interface {
 type InvoiceRepository interface {
 	Create(ctx context.Context, invoice *model.Invoice) error
 	GetByOrderID(ctx context.Context, orderID int64) ([]model.Invoice, error)
+	GetByID(ctx context.Context, id int64) (*model.Invoice, error)
+	GetByShipmentID(ctx context.Context, shipmentID int64) (*model.Invoice, error)
+	ListUnpaidDueBefore(ctx context.Context, before time.Time) ([]model.Invoice, error)
+	HasUnpaidDueBefore(ctx context.Context, customerID string, before time.Time) (bool, error)
+	AddPayment(ctx context.Context, id int64, amount float64) (bool, error)
+	UpdateDunning(ctx context.Context, invoice *model.Invoice) error
+}
+
+// CreditNoteRepository defines the interface for credit note operations
+type CreditNoteRepository interface {
+	Create(ctx context.Context, creditNote *model.CreditNote) error
+	GetByReference(ctx context.Context, reference string) (*model.CreditNote, error)
+	ListByInvoiceID(ctx context.Context, invoiceID int64) ([]model.CreditNote, error)
+}
+
+// CustomerRepository defines the interface for customer billing settings
+type CustomerRepository interface {
+	GetByCustomerID(ctx context.Context, customerID string) (*model.Customer, error)
+	SetOnHold(ctx context.Context, customerID string, onHold bool) error
+	ListOnHold(ctx context.Context) ([]model.Customer, error)
+}
+
+// PlanRepository defines the interface for subscription plan operations
+type PlanRepository interface {
+	Create(ctx context.Context, plan *model.Plan) error
+	GetByCode(ctx context.Context, code string) (*model.Plan, error)
+}
+
+// SubscriptionRepository defines the interface for subscription operations
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *model.Subscription) error
+	GetByID(ctx context.Context, id int64) (*model.Subscription, error)
+	Update(ctx context.Context, subscription *model.Subscription, from model.SubscriptionStatus, columns ...string) (bool, error)
+	AdvancePeriod(ctx context.Context, subscription *model.Subscription, creditUsed float64) error
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.Subscription, error)
+	GetOrCreatePeriod(ctx context.Context, period *model.SubscriptionPeriod) (*model.SubscriptionPeriod, error)
+	UpdatePeriod(ctx context.Context, period *model.SubscriptionPeriod) error
+	GetOrCreatePendingPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (*model.SubscriptionPlanChange, error)
+	UpdatePlanChange(ctx context.Context, change *model.SubscriptionPlanChange) error
+	VoidPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (bool, error)
+	ApplyPlanChange(ctx context.Context, change *model.SubscriptionPlanChange, from model.SubscriptionStatus) (bool, error)
+}
+
+// MeterRepository defines the interface for usage meter operations
+type MeterRepository interface {
+	Create(ctx context.Context, meter *model.Meter) error
+	GetByCode(ctx context.Context, code string) (*model.Meter, error)
+}
+
+// UsageRepository defines the interface for usage records and their billing
+type UsageRepository interface {
+	CreateRecord(ctx context.Context, record *model.UsageRecord) (bool, error)
+	ListPendingKeys(ctx context.Context, closedBefore time.Time) ([]model.UsageKey, error)
+	ListRecords(ctx context.Context, key model.UsageKey) ([]model.UsageRecord, error)
+	GetOrCreatePeriod(ctx context.Context, key model.UsageKey) (*model.UsagePeriod, error)
+	ClosePeriod(ctx context.Context, period *model.UsagePeriod, recordIDs []int64) (bool, error)
+	AddAdjustment(ctx context.Context, adjustment *model.UsageAdjustment, recordIDs []int64) (bool, error)
+	ClaimUninvoicedPeriod(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsagePeriod, error)
+	ClaimUninvoicedAdjustment(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsageAdjustment, error)
+	UpdatePeriod(ctx context.Context, period *model.UsagePeriod) error
+	UpdateAdjustment(ctx context.Context, adjustment *model.UsageAdjustment) error
+}
+
+// PriceListRepository defines the interface for price list operations
+type PriceListRepository interface {
+	Create(ctx context.Context, priceList *model.PriceList) error
+	ListEffectiveEntries(ctx context.Context, itemID int64, customerID string, at time.Time) ([]model.PriceListEntry, error)
+}
+
+// APIKeyRepository defines the interface for API key operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	GetByID(ctx context.Context, id int64) (*model.APIKey, error)
+	GetByKeyID(ctx context.Context, keyID string) (*model.APIKey, error)
+	List(ctx context.Context, customerID string) ([]model.APIKey, error)
+	Rotate(ctx context.Context, replacement *model.APIKey, previous *model.APIKey) error
+	Revoke(ctx context.Context, id int64, at time.Time) error
+	TouchLastUsed(ctx context.Context, id int64, at time.Time, before time.Time) error
 }
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SubscriptionRepositoryImpl implements the SubscriptionRepository interface
+type SubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionRepository creates a new instance of SubscriptionRepositoryImpl
+func NewSubscriptionRepository(db *gorm.DB) SubscriptionRepository {
+	return &SubscriptionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create a new subscription in the database
+func (r *SubscriptionRepositoryImpl) Create(ctx context.Context, subscription *model.Subscription) error {
+	return r.db.WithContext(ctx).Omit("Plan").Create(subscription).Error
+}
+
+// GetByID retrieves a subscription by its ID along with its plan
+func (r *SubscriptionRepositoryImpl) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	var subscription model.Subscription
+
+	result := r.db.WithContext(ctx).
+		Preload("Plan").
+		First(&subscription, id)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &subscription, nil
+}
+
+// Update writes the given columns of the subscription if it is still in the from status.
+// Returns false when the status changed since the subscription was read.
+func (r *SubscriptionRepositoryImpl) Update(ctx context.Context, subscription *model.Subscription, from model.SubscriptionStatus, columns ...string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(subscription).
+		Where("status = ?", from).
+		Select(columns).
+		Updates(subscription)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// AdvancePeriod moves the subscription to its billed period and releases its lease.
+// The credit used by the period is subtracted in the same statement, and only a trial
+// becomes active, so changes made while the period was billed are kept.
+func (r *SubscriptionRepositoryImpl) AdvancePeriod(ctx context.Context, subscription *model.Subscription, creditUsed float64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.Subscription{}).
+		Where("id = ?", subscription.ID).
+		UpdateColumns(map[string]interface{}{
+			"status":               gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", model.SubscriptionTrialing, model.SubscriptionActive),
+			"credit_balance":       gorm.Expr("GREATEST(credit_balance - ?, 0)", creditUsed),
+			"current_period_start": subscription.CurrentPeriodStart,
+			"current_period_end":   subscription.CurrentPeriodEnd,
+			"next_billing_at":      subscription.NextBillingAt,
+			"locked_until":         nil,
+		}).Error
+}
+
+// ClaimDue takes a lease on one subscription that is due for billing at now.
+// Rows locked by another transaction or leased by another replica are skipped,
+// so several scheduler replicas can run concurrently.
+// Returns nil and no error when there is nothing to bill.
+func (r *SubscriptionRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.Subscription, error) {
+	var claimed *model.Subscription
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var subscription model.Subscription
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_billing_at <= ?",
+				[]model.SubscriptionStatus{model.SubscriptionTrialing, model.SubscriptionActive}, now).
+			Where("locked_until IS NULL OR locked_until < ?", now).
+			Order("next_billing_at").
+			First(&subscription).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		err = tx.Model(&subscription).Update("locked_until", leaseUntil).Error
+		if err != nil {
+			return err
+		}
+
+		claimed = &subscription
+		return nil
+	})
+	if err != nil || claimed == nil {
+		return nil, err
+	}
+
+	// Load the plan outside the locking transaction
+	if err := r.db.WithContext(ctx).First(&claimed.Plan, claimed.PlanID).Error; err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// GetOrCreatePeriod returns the billing record of a subscription period, creating it if needed
+func (r *SubscriptionRepositoryImpl) GetOrCreatePeriod(ctx context.Context, period *model.SubscriptionPeriod) (*model.SubscriptionPeriod, error) {
+	var existing model.SubscriptionPeriod
+
+	err := r.db.WithContext(ctx).
+		Where(model.SubscriptionPeriod{SubscriptionID: period.SubscriptionID, PeriodStart: period.PeriodStart}).
+		Attrs(model.SubscriptionPeriod{PeriodEnd: period.PeriodEnd, Amount: period.Amount}).
+		FirstOrCreate(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &existing, nil
+}
+
+// UpdatePeriod saves a subscription period billing record
+func (r *SubscriptionRepositoryImpl) UpdatePeriod(ctx context.Context, period *model.SubscriptionPeriod) error {
+	return r.db.WithContext(ctx).Save(period).Error
+}
+
+// GetOrCreatePendingPlanChange returns the plan change of a subscription that is neither applied nor voided,
+// recording the given one if there is none
+func (r *SubscriptionRepositoryImpl) GetOrCreatePendingPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (*model.SubscriptionPlanChange, error) {
+	var existing model.SubscriptionPlanChange
+
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ? AND applied_at IS NULL AND voided_at IS NULL", change.SubscriptionID).
+		Attrs(model.SubscriptionPlanChange{
+			SubscriptionID: change.SubscriptionID,
+			FromPlanID:     change.FromPlanID,
+			ToPlanID:       change.ToPlanID,
+			Amount:         change.Amount,
+		}).
+		FirstOrCreate(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &existing, nil
+}
+
+// UpdatePlanChange saves the order and invoice charged for a plan change, leaving a concurrent void in place
+func (r *SubscriptionRepositoryImpl) UpdatePlanChange(ctx context.Context, change *model.SubscriptionPlanChange) error {
+	return r.db.WithContext(ctx).Model(change).Select("order_id", "invoice_id").Updates(change).Error
+}
+
+// VoidPlanChange marks a pending plan change voided, so another change can be made.
+// Returns false when the change was applied or voided concurrently.
+func (r *SubscriptionRepositoryImpl) VoidPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&model.SubscriptionPlanChange{}).
+		Where("id = ? AND applied_at IS NULL AND voided_at IS NULL", change.ID).
+		Update("voided_at", &now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	change.VoidedAt = &now
+	return true, nil
+}
+
+// errChangeVoided rolls back a plan change voided while it was charged
+var errChangeVoided = errors.New("plan change voided")
+
+// ApplyPlanChange moves the subscription to the new plan, adds the credit of a downgrade and
+// marks the change applied, in one transaction. Returns false without applying anything when
+// the subscription left the from status, its plan changed since it was read or the change was voided.
+func (r *SubscriptionRepositoryImpl) ApplyPlanChange(ctx context.Context, change *model.SubscriptionPlanChange, from model.SubscriptionStatus) (bool, error) {
+	applied := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		credit := 0.0
+		if change.Amount < 0 {
+			credit = -change.Amount
+		}
+
+		result := tx.Model(&model.Subscription{}).
+			Where("id = ? AND status = ? AND plan_id = ?", change.SubscriptionID, from, change.FromPlanID).
+			Updates(map[string]interface{}{
+				"plan_id":        change.ToPlanID,
+				"credit_balance": gorm.Expr("credit_balance + ?", credit),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		result = tx.Model(&model.SubscriptionPlanChange{}).
+			Where("id = ? AND voided_at IS NULL", change.ID).
+			Update("applied_at", &now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errChangeVoided
+		}
+
+		change.AppliedAt = &now
+		applied = true
+		return nil
+	})
+	if errors.Is(err, errChangeVoided) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCustomerRepositoryGetByCustomerID(t *testing.T) {
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name             string
+		customerID       string
+		mockSetup        func(mock sqlmock.Sqlmock)
+		expectedCustomer *model.Customer
+		expectedError    error
+	}{
+		{
+			name:       "Success - Customer found",
+			customerID: "CUST123",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(CustomerColumns()).
+					AddRow(1, time.Now(), time.Now(), nil, "CUST123", model.Net15, true, model.SegmentWholesale)
+				mock.ExpectQuery(`SELECT (.+) FROM "customers"`).
+					WithArgs("CUST123", 1). // GORM adds LIMIT 1 for First()
+					WillReturnRows(rows)
+			},
+			expectedCustomer: &model.Customer{
+				Base:        model.Base{ID: 1},
+				CustomerID:  "CUST123",
+				PaymentTerm: model.Net15,
+				OnHold:      true,
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Error - Customer not found",
+			customerID: "NONEXISTENT",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT (.+) FROM "customers"`).
+					WithArgs("NONEXISTENT", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedCustomer: nil,
+			expectedError:    gorm.ErrRecordNotFound,
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database for each test case
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Configure the mock according to the test case
+			tc.mockSetup(mockDB.Mock)
+
+			// Create a new customer repository with the mock database
+			customerRepo := repository.NewCustomerRepository(mockDB.DB)
+
+			// Call the method being tested
+			customer, err := customerRepo.GetByCustomerID(context.Background(), tc.customerID)
+
+			// Check the results
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Nil(t, customer)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, customer)
+				assert.Equal(t, tc.expectedCustomer.ID, customer.ID)
+				assert.Equal(t, tc.expectedCustomer.CustomerID, customer.CustomerID)
+				assert.Equal(t, tc.expectedCustomer.PaymentTerm, customer.PaymentTerm)
+				assert.Equal(t, tc.expectedCustomer.OnHold, customer.OnHold)
+			}
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCustomerRepositorySetOnHold(t *testing.T) {
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name          string
+		customerID    string
+		onHold        bool
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name:       "Success - Upsert customer on hold",
+			customerID: "CUST123",
+			onHold:     true,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "customers" (.+) ON CONFLICT \("customer_id"\) DO UPDATE SET "on_hold"="excluded"."on_hold","updated_at"="excluded"."updated_at"`).
+					WithArgs(
+						AnyTime(), AnyTime(), nil, // Base fields
+						"CUST123", "", true, "", // Customer fields (customer_id, payment_term, on_hold, segment)
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Error - Database error",
+			customerID: "CUST456",
+			onHold:     false,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "customers"`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database for each test case
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Configure the mock according to the test case
+			tc.mockSetup(mockDB.Mock)
+
+			// Create a new customer repository with the mock database
+			customerRepo := repository.NewCustomerRepository(mockDB.DB)
+
+			// Call the method being tested
+			err = customerRepo.SetOnHold(context.Background(), tc.customerID, tc.onHold)
+
+			// Check the results
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
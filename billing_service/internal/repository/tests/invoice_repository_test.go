@@ -42,6 +42,7 @@ func TestInvoiceRepositoryCreate(t *testing.T) {
 					WithArgs(
 						AnyTime(), AnyTime(), nil, // Base fields
 						1, 100, 99.99, // Invoice fields (order_id, shipment_id, total_amount)
+						AnyTime(), 0.0, 0.0, 0, nil, // Dunning fields (due_date, paid_amount, credited_amount, dunning_level, last_reminder_at)
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -75,6 +76,7 @@ func TestInvoiceRepositoryCreate(t *testing.T) {
 					WithArgs(
 						AnyTime(), AnyTime(), nil, // Base fields
 						2, 200, 199.99, // Invoice fields
+						AnyTime(), 0.0, 0.0, 0, nil, // Dunning fields
 					).
 					WillReturnError(errors.New("database error"))
 
@@ -131,29 +133,29 @@ func TestInvoiceRepositoryGetByOrderID(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock) {
 				// Invoice rows
 				invoiceRows := sqlmock.NewRows(InvoiceColumns()).
-					AddRow(1, time.Now(), time.Now(), nil, 1, 100, 99.99).
-					AddRow(2, time.Now(), time.Now(), nil, 1, 101, 49.99)
+					AddRow(1, time.Now(), time.Now(), nil, 1, 100, 99.99, time.Now(), 0.0, 0.0, 0, nil).
+					AddRow(2, time.Now(), time.Now(), nil, 1, 101, 49.99, time.Now(), 0.0, 0.0, 0, nil)
 
 				mock.ExpectQuery(`SELECT (.+) FROM "invoices"`).
 					WithArgs(1).
 					WillReturnRows(invoiceRows)
 
-				// Items for invoice 1
-				itemRows1 := sqlmock.NewRows(InvoiceItemColumns()).
-					AddRow(1, time.Now(), time.Now(), nil, 1, 1, 1).
-					AddRow(2, time.Now(), time.Now(), nil, 1, 2, 2)
+				// Charges and items of both invoices are preloaded in a single query each
+				chargeRows := sqlmock.NewRows(InvoiceChargeColumns()).
+					AddRow(1, time.Now(), time.Now(), nil, 2, "SHIPPING", "Shipping", 7.5, "SHIPPING")
 
-				mock.ExpectQuery(`SELECT (.+) FROM "invoice_items"`).
-					WithArgs(1).
-					WillReturnRows(itemRows1)
+				mock.ExpectQuery(`SELECT (.+) FROM "invoice_charges"`).
+					WithArgs(1, 2).
+					WillReturnRows(chargeRows)
 
-				// Items for invoice 2
-				itemRows2 := sqlmock.NewRows(InvoiceItemColumns()).
+				itemRows := sqlmock.NewRows(InvoiceItemColumns()).
+					AddRow(1, time.Now(), time.Now(), nil, 1, 1, 1).
+					AddRow(2, time.Now(), time.Now(), nil, 1, 2, 2).
 					AddRow(3, time.Now(), time.Now(), nil, 2, 1, 3)
 
 				mock.ExpectQuery(`SELECT (.+) FROM "invoice_items"`).
-					WithArgs(2).
-					WillReturnRows(itemRows2)
+					WithArgs(1, 2).
+					WillReturnRows(itemRows)
 			},
 			expectedInvoices: []model.Invoice{
 				{
@@ -174,6 +176,9 @@ func TestInvoiceRepositoryGetByOrderID(t *testing.T) {
 					Items: []model.InvoiceItem{
 						{Base: model.Base{ID: 3}, InvoiceID: 2, Quantity: 1, ItemID: 3},
 					},
+					Charges: []model.InvoiceCharge{
+						{Base: model.Base{ID: 1}, InvoiceID: 2, Type: model.ChargeShipping, Amount: 7.5},
+					},
 				},
 			},
 			expectedError: nil,
@@ -244,6 +249,14 @@ func TestInvoiceRepositoryGetByOrderID(t *testing.T) {
 						assert.Equal(t, expectedItem.Quantity, invoices[i].Items[j].Quantity)
 						assert.Equal(t, expectedItem.ItemID, invoices[i].Items[j].ItemID)
 					}
+
+					// Check invoice charges
+					assert.Equal(t, len(expectedInvoice.Charges), len(invoices[i].Charges))
+					for j, expectedCharge := range expectedInvoice.Charges {
+						assert.Equal(t, expectedCharge.InvoiceID, invoices[i].Charges[j].InvoiceID)
+						assert.Equal(t, expectedCharge.Type, invoices[i].Charges[j].Type)
+						assert.Equal(t, expectedCharge.Amount, invoices[i].Charges[j].Amount)
+					}
 				}
 			}
 
@@ -252,3 +265,115 @@ func TestInvoiceRepositoryGetByOrderID(t *testing.T) {
 		})
 	}
 }
+
+func TestInvoiceRepositoryAddPayment(t *testing.T) {
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name            string
+		mockSetup       func(mock sqlmock.Sqlmock)
+		expectedApplied bool
+		expectedError   error
+	}{
+		{
+			name: "Success - Payment within the outstanding amount",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "invoices" SET "paid_amount"=paid_amount \+ \$1 WHERE id = \$2 AND total_amount - paid_amount - credited_amount >= \$3`).
+					WithArgs(60.0, 1, 60.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedApplied: true,
+		},
+		{
+			// A credit note committed between the read of the invoice and the payment left less outstanding,
+			// the conditional update adds nothing instead of overwriting the credited amount
+			name: "Success - Payment raced by a credit note",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "invoices" SET "paid_amount"=paid_amount \+ \$1 WHERE id = \$2 AND total_amount - paid_amount - credited_amount >= \$3`).
+					WithArgs(60.0, 1, 60.0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectedApplied: false,
+		},
+		{
+			name: "Error - Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "invoices"`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			tc.mockSetup(mockDB.Mock)
+
+			repo := repository.NewInvoiceRepository(mockDB.DB)
+			applied, err := repo.AddPayment(context.Background(), 1, 60)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedApplied, applied)
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInvoiceRepositoryUpdateDunning(t *testing.T) {
+	mockDB, err := NewMockDB()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	remindedAt := time.Now()
+	// Only the dunning columns are written, a stale copy of the invoice cannot put back its amounts
+	mockDB.Mock.ExpectBegin()
+	mockDB.Mock.ExpectExec(`UPDATE "invoices" SET "dunning_level"=\$1,"last_reminder_at"=\$2 WHERE id = \$3`).
+		WithArgs(2, remindedAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.Mock.ExpectCommit()
+
+	repo := repository.NewInvoiceRepository(mockDB.DB)
+	err = repo.UpdateDunning(context.Background(), &model.Invoice{
+		Base:           model.Base{ID: 1},
+		PaidAmount:     10,
+		DunningLevel:   2,
+		LastReminderAt: &remindedAt,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestInvoiceRepositoryHasUnpaidDueBefore(t *testing.T) {
+	before := time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	mockDB, err := NewMockDB()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	mockDB.Mock.ExpectQuery(`SELECT count\(\*\) FROM "invoices" JOIN orders ON orders.id = invoices.order_id WHERE orders.customer_id = (.+) AND invoices.due_date < (.+) AND invoices.paid_amount \+ invoices.credited_amount < invoices.total_amount`).
+		WithArgs("CUST123", before).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	invoiceRepo := repository.NewInvoiceRepository(mockDB.DB)
+	overdue, err := invoiceRepo.HasUnpaidDueBefore(context.Background(), "CUST123", before)
+
+	assert.NoError(t, err)
+	assert.True(t, overdue)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
@@ -67,12 +67,16 @@ func ItemColumns() []string {
 	return []string{"id", "created_at", "updated_at", "deleted_at", "name", "sku", "price"}
 }
 
+func CustomerColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at", "customer_id", "payment_term", "on_hold", "segment"}
+}
+
 func OrderColumns() []string {
-	return []string{"id", "created_at", "updated_at", "deleted_at", "customer_id", "total_amount", "status"}
+	return []string{"id", "created_at", "updated_at", "deleted_at", "customer_id", "total_amount", "status", "payment_term"}
 }
 
 func OrderItemColumns() []string {
-	return []string{"id", "created_at", "updated_at", "deleted_at", "order_id", "quantity", "item_id"}
+	return []string{"id", "created_at", "updated_at", "deleted_at", "order_id", "quantity", "unit_price", "price_recorded", "price_list_code", "price_tier", "item_id"}
 }
 
 func PaymentColumns() []string {
@@ -80,13 +84,31 @@ func PaymentColumns() []string {
 }
 
 func InvoiceColumns() []string {
-	return []string{"id", "created_at", "updated_at", "deleted_at", "order_id", "shipment_id", "total_amount"}
+	return []string{"id", "created_at", "updated_at", "deleted_at", "order_id", "shipment_id", "total_amount", "due_date", "paid_amount", "credited_amount", "dunning_level", "last_reminder_at"}
 }
 
 func InvoiceItemColumns() []string {
 	return []string{"id", "created_at", "updated_at", "deleted_at", "invoice_id", "quantity", "item_id"}
 }
 
+func InvoiceChargeColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at", "invoice_id", "type", "description", "amount", "tax_category"}
+}
+
+func PlanColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at", "code", "name", "sku", "price", "interval", "interval_count", "trial_days"}
+}
+
+func SubscriptionColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at", "customer_id", "plan_id", "status", "payment_method", "trial_end",
+		"current_period_start", "current_period_end", "next_billing_at", "cancel_at_period_end", "canceled_at", "credit_balance", "locked_until"}
+}
+
+func UsagePeriodColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at", "customer_id", "meter_code", "period_start", "period_end",
+		"quantity", "amount", "status", "order_id", "invoice_id", "locked_until"}
+}
+
 // Helper to convert Go time to SQL format
 func AnyTime() sqlmock.Argument {
 	return sqlmock.AnyArg()
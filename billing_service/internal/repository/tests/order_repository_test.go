@@ -46,7 +46,7 @@ func TestOrderRepositoryCreate(t *testing.T) {
 				mock.ExpectQuery(`INSERT INTO "orders"`).
 					WithArgs(
 						AnyTime(), AnyTime(), nil, // Base fields
-						"CUST123", 199.98, model.OrderPending, // Order fields
+						"CUST123", 199.98, model.OrderPending, "", "", // Order fields (customer_id, total_amount, status, payment_term, api_key_id)
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -54,7 +54,7 @@ func TestOrderRepositoryCreate(t *testing.T) {
 				mock.ExpectQuery(`INSERT INTO "order_items"`).
 					WithArgs(
 						AnyTime(), AnyTime(), nil, // Base fields
-						1, 2, 1, // OrderItem fields (order_id, quantity, item_id)
+						1, 2, 0.0, false, "", 0, 1, // OrderItem fields (order_id, quantity, unit_price, price_recorded, price_list_code, price_tier, item_id)
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -86,7 +86,7 @@ func TestOrderRepositoryCreate(t *testing.T) {
 				mock.ExpectQuery(`INSERT INTO "orders"`).
 					WithArgs(
 						AnyTime(), AnyTime(), nil, // Base fields
-						"CUST456", 99.99, model.OrderPending, // Order fields
+						"CUST456", 99.99, model.OrderPending, "", "", // Order fields
 					).
 					WillReturnError(errors.New("database error"))
 
@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionRepositoryClaimDue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(5 * time.Minute)
+
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name                 string
+		mockSetup            func(mock sqlmock.Sqlmock)
+		expectedSubscription *model.Subscription
+		expectedError        error
+	}{
+		{
+			name: "Success - Lease due subscription",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows(SubscriptionColumns()).
+					AddRow(7, now, now, nil, "CUST123", 1, model.SubscriptionActive, model.COD, nil,
+						now.AddDate(0, -1, 0), now, now, false, nil, 0.0, nil)
+				mock.ExpectQuery(`SELECT (.+) FROM "subscriptions" WHERE (.+)locked_until IS NULL OR locked_until < (.+) FOR UPDATE SKIP LOCKED`).
+					WithArgs(model.SubscriptionTrialing, model.SubscriptionActive, now, now, 1).
+					WillReturnRows(rows)
+				mock.ExpectExec(`UPDATE "subscriptions" SET "locked_until"=(.+)`).
+					WithArgs(leaseUntil, AnyTime(), 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT (.+) FROM "plans"`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(PlanColumns()).
+						AddRow(1, now, now, nil, "basic", "Basic", "BASIC", 10.0, model.IntervalMonth, 1, 0))
+			},
+			expectedSubscription: &model.Subscription{
+				Base:       model.Base{ID: 7},
+				CustomerID: "CUST123",
+				PlanID:     1,
+				Plan:       model.Plan{Base: model.Base{ID: 1}, Code: "basic"},
+			},
+		},
+		{
+			name: "Success - Nothing due",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT (.+) FROM "subscriptions"`).
+					WillReturnRows(sqlmock.NewRows(SubscriptionColumns()))
+				mock.ExpectCommit()
+			},
+			expectedSubscription: nil,
+		},
+		{
+			name: "Error - Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT (.+) FROM "subscriptions"`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database for each test case
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Configure the mock according to the test case
+			tc.mockSetup(mockDB.Mock)
+
+			// Create a new subscription repository with the mock database
+			subscriptionRepo := repository.NewSubscriptionRepository(mockDB.DB)
+
+			// Call the method being tested
+			subscription, err := subscriptionRepo.ClaimDue(context.Background(), now, leaseUntil)
+
+			// Check the results
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+				assert.Nil(t, subscription)
+			} else if tc.expectedSubscription == nil {
+				assert.NoError(t, err)
+				assert.Nil(t, subscription)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, subscription)
+				assert.Equal(t, tc.expectedSubscription.ID, subscription.ID)
+				assert.Equal(t, tc.expectedSubscription.CustomerID, subscription.CustomerID)
+				assert.Equal(t, tc.expectedSubscription.Plan.Code, subscription.Plan.Code)
+			}
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSubscriptionRepositoryUpdate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rowsAffected    int64
+		expectedUpdated bool
+	}{
+		{
+			name:            "Success - Status unchanged since read",
+			rowsAffected:    1,
+			expectedUpdated: true,
+		},
+		{
+			name:            "Success - Status changed concurrently",
+			rowsAffected:    0,
+			expectedUpdated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Only the given columns are written, and only while the status is the one read
+			mockDB.Mock.ExpectBegin()
+			mockDB.Mock.ExpectExec(`UPDATE "subscriptions" SET "updated_at"=\$1,"status"=\$2 WHERE status = \$3 AND "id" = \$4`).
+				WithArgs(AnyTime(), model.SubscriptionPaused, model.SubscriptionActive, 7).
+				WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+			mockDB.Mock.ExpectCommit()
+
+			repo := repository.NewSubscriptionRepository(mockDB.DB)
+			updated, err := repo.Update(context.Background(), &model.Subscription{
+				Base:          model.Base{ID: 7},
+				Status:        model.SubscriptionPaused,
+				CreditBalance: 5,
+			}, model.SubscriptionActive, "status")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUpdated, updated)
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSubscriptionRepositoryAdvancePeriod(t *testing.T) {
+	mockDB, err := NewMockDB()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	// The status is not written from the stale copy, so a pause or cancel made while billing is kept
+	mockDB.Mock.ExpectBegin()
+	mockDB.Mock.ExpectExec(`UPDATE "subscriptions" SET "credit_balance"=GREATEST\(credit_balance - \$1, 0\),"current_period_end"=\$2,"current_period_start"=\$3,"locked_until"=\$4,"next_billing_at"=\$5,"status"=CASE WHEN status = \$6 THEN \$7 ELSE status END WHERE id = \$8`).
+		WithArgs(2.5, end, start, nil, end, model.SubscriptionTrialing, model.SubscriptionActive, 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.Mock.ExpectCommit()
+
+	repo := repository.NewSubscriptionRepository(mockDB.DB)
+	err = repo.AdvancePeriod(context.Background(), &model.Subscription{
+		Base:               model.Base{ID: 7},
+		Status:             model.SubscriptionActive,
+		CurrentPeriodStart: start,
+		CurrentPeriodEnd:   end,
+		NextBillingAt:      end,
+	}, 2.5)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryVoidPlanChange(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rowsAffected   int64
+		expectedVoided bool
+	}{
+		{
+			name:           "Success - Change still pending",
+			rowsAffected:   1,
+			expectedVoided: true,
+		},
+		{
+			name:           "Success - Change applied concurrently",
+			rowsAffected:   0,
+			expectedVoided: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			mockDB.Mock.ExpectBegin()
+			mockDB.Mock.ExpectExec(`UPDATE "subscription_plan_changes" SET "voided_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND applied_at IS NULL AND voided_at IS NULL`).
+				WithArgs(AnyTime(), AnyTime(), 4).
+				WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+			mockDB.Mock.ExpectCommit()
+
+			repo := repository.NewSubscriptionRepository(mockDB.DB)
+			change := &model.SubscriptionPlanChange{Base: model.Base{ID: 4}}
+			voided, err := repo.VoidPlanChange(context.Background(), change)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVoided, voided)
+			assert.Equal(t, tc.expectedVoided, change.VoidedAt != nil)
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSubscriptionRepositoryApplyPlanChangeVoided(t *testing.T) {
+	mockDB, err := NewMockDB()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	// A change voided while it was charged is not applied, and the plan update is rolled back
+	mockDB.Mock.ExpectBegin()
+	mockDB.Mock.ExpectExec(`UPDATE "subscriptions" SET "credit_balance"=credit_balance \+ \$1,"plan_id"=\$2,"updated_at"=\$3 WHERE id = \$4 AND status = \$5 AND plan_id = \$6`).
+		WithArgs(0.0, 2, AnyTime(), 7, model.SubscriptionActive, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockDB.Mock.ExpectExec(`UPDATE "subscription_plan_changes" SET "applied_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND voided_at IS NULL`).
+		WithArgs(AnyTime(), AnyTime(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockDB.Mock.ExpectRollback()
+
+	repo := repository.NewSubscriptionRepository(mockDB.DB)
+	change := &model.SubscriptionPlanChange{Base: model.Base{ID: 4}, SubscriptionID: 7, FromPlanID: 1, ToPlanID: 2, Amount: 10}
+	applied, err := repo.ApplyPlanChange(context.Background(), change, model.SubscriptionActive)
+
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	assert.Nil(t, change.AppliedAt)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
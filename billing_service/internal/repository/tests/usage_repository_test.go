@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageRepositoryCreateRecord(t *testing.T) {
+	periodStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name            string
+		mockSetup       func(mock sqlmock.Sqlmock)
+		expectedCreated bool
+		expectedError   error
+	}{
+		{
+			name: "Success - Record stored",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_records" (.+) ON CONFLICT \("customer_id","meter_code","idempotency_key"\) DO NOTHING`).
+					WithArgs(
+						AnyTime(), AnyTime(), nil, // Base fields
+						"CUST123", "api_calls", 5.0, AnyTime(), "evt-1", AnyTime(), AnyTime(), nil, // UsageRecord fields
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "Success - Duplicate idempotency key",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_records" (.+) ON CONFLICT \("customer_id","meter_code","idempotency_key"\) DO NOTHING`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+			expectedCreated: false,
+		},
+		{
+			name: "Error - Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_records"`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database for each test case
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Configure the mock according to the test case
+			tc.mockSetup(mockDB.Mock)
+
+			// Create a new usage repository with the mock database
+			usageRepo := repository.NewUsageRepository(mockDB.DB)
+
+			// Call the method being tested
+			created, err := usageRepo.CreateRecord(context.Background(), &model.UsageRecord{
+				CustomerID:     "CUST123",
+				MeterCode:      "api_calls",
+				Quantity:       5,
+				Timestamp:      periodStart.Add(time.Hour),
+				IdempotencyKey: "evt-1",
+				PeriodStart:    periodStart,
+				PeriodEnd:      periodStart.AddDate(0, 1, 0),
+			})
+
+			// Check the results
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCreated, created)
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUsageRepositoryListPendingKeys(t *testing.T) {
+	closedBefore := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockDB, err := NewMockDB()
+	assert.NoError(t, err)
+	defer mockDB.Close()
+
+	rows := sqlmock.NewRows([]string{"customer_id", "meter_code", "period_start", "period_end"}).
+		AddRow("CUST123", "api_calls", periodStart, periodStart.AddDate(0, 1, 0))
+	mockDB.Mock.ExpectQuery(`SELECT customer_id, meter_code, period_start, period_end FROM "usage_records" WHERE usage_period_id IS NULL AND period_end <= (.+) GROUP BY customer_id, meter_code, period_start, period_end`).
+		WithArgs(closedBefore).
+		WillReturnRows(rows)
+
+	usageRepo := repository.NewUsageRepository(mockDB.DB)
+	keys, err := usageRepo.ListPendingKeys(context.Background(), closedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.UsageKey{{
+		CustomerID:  "CUST123",
+		MeterCode:   "api_calls",
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, 0),
+	}}, keys)
+	assert.NoError(t, mockDB.ExpectationsWereMet())
+}
+
+func TestUsageRepositoryAddAdjustment(t *testing.T) {
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name          string
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedAdded bool
+		expectedError error
+	}{
+		{
+			name: "Success - Adjustment stored and records billed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_adjustments"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(`UPDATE "usage_records" SET "usage_period_id"=(.+) WHERE id IN \((.+)\) AND usage_period_id IS NULL`).
+					WithArgs(int64(1), AnyTime(), int64(3), int64(4)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedAdded: true,
+		},
+		{
+			name: "Success - Records billed by another run",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_adjustments"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(`UPDATE "usage_records" SET "usage_period_id"=(.+) WHERE id IN \((.+)\) AND usage_period_id IS NULL`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			expectedAdded: false,
+		},
+		{
+			name: "Error - Database error",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "usage_adjustments"`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Setup mock expectations
+			tc.mockSetup(mockDB.Mock)
+
+			usageRepo := repository.NewUsageRepository(mockDB.DB)
+
+			// Call the method being tested
+			added, err := usageRepo.AddAdjustment(context.Background(), &model.UsageAdjustment{
+				UsagePeriodID: 1, CustomerID: "CUST123", MeterCode: "api_calls", Quantity: 30, Amount: 15,
+			}, []int64{3, 4})
+
+			// Check the results
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedAdded, added)
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUsageRepositoryClaimUninvoicedPeriod(t *testing.T) {
+	now := time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(5 * time.Minute)
+	periodStart := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test cases for table-driven tests
+	testCases := []struct {
+		name           string
+		mockSetup      func(mock sqlmock.Sqlmock)
+		expectedPeriod *model.UsagePeriod
+	}{
+		{
+			name: "Success - Lease uninvoiced period",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows(UsagePeriodColumns()).
+					AddRow(3, now, now, nil, "CUST123", "api_calls", periodStart, periodStart.AddDate(0, 1, 0),
+						150.0, 125.0, model.UsagePeriodClosed, 0, 0, nil)
+				mock.ExpectQuery(`SELECT (.+) FROM "usage_periods" WHERE (.+)invoice_id = 0(.+)locked_until IS NULL OR locked_until < (.+) FOR UPDATE SKIP LOCKED`).
+					WithArgs(model.UsagePeriodClosed, now, 1).
+					WillReturnRows(rows)
+				mock.ExpectExec(`UPDATE "usage_periods" SET "locked_until"=(.+)`).
+					WithArgs(leaseUntil, AnyTime(), 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedPeriod: &model.UsagePeriod{Base: model.Base{ID: 3}, CustomerID: "CUST123", Amount: 125},
+		},
+		{
+			name: "Success - Nothing to invoice",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT (.+) FROM "usage_periods"`).
+					WillReturnRows(sqlmock.NewRows(UsagePeriodColumns()))
+				mock.ExpectCommit()
+			},
+			expectedPeriod: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Create a new mock database
+			mockDB, err := NewMockDB()
+			assert.NoError(t, err)
+			defer mockDB.Close()
+
+			// Setup mock expectations
+			tc.mockSetup(mockDB.Mock)
+
+			usageRepo := repository.NewUsageRepository(mockDB.DB)
+
+			// Call the method being tested
+			period, err := usageRepo.ClaimUninvoicedPeriod(context.Background(), now, leaseUntil)
+
+			// Check the results
+			assert.NoError(t, err)
+			if tc.expectedPeriod == nil {
+				assert.Nil(t, period)
+			} else {
+				assert.NotNil(t, period)
+				assert.Equal(t, tc.expectedPeriod.ID, period.ID)
+				assert.Equal(t, tc.expectedPeriod.CustomerID, period.CustomerID)
+				assert.Equal(t, tc.expectedPeriod.Amount, period.Amount)
+			}
+
+			// Verify that all expectations were met
+			assert.NoError(t, mockDB.ExpectationsWereMet())
+		})
+	}
+}
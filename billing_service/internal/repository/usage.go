@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"billing-system/billing_service/internal/model"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UsageRepositoryImpl implements the UsageRepository interface
+type UsageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new instance of UsageRepositoryImpl
+func NewUsageRepository(db *gorm.DB) UsageRepository {
+	return &UsageRepositoryImpl{
+		db: db,
+	}
+}
+
+// CreateRecord stores a usage record.
+// Returns false without an error when the customer already reported a record with the same idempotency key for the meter.
+func (r *UsageRepositoryImpl) CreateRecord(ctx context.Context, record *model.UsageRecord) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "customer_id"}, {Name: "meter_code"}, {Name: "idempotency_key"}},
+			DoNothing: true,
+		}).
+		Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListPendingKeys returns the periods ended by closedBefore that have records not billed yet
+func (r *UsageRepositoryImpl) ListPendingKeys(ctx context.Context, closedBefore time.Time) ([]model.UsageKey, error) {
+	var keys []model.UsageKey
+	err := r.db.WithContext(ctx).
+		Model(&model.UsageRecord{}).
+		Select("customer_id, meter_code, period_start, period_end").
+		Where("usage_period_id IS NULL AND period_end <= ?", closedBefore).
+		Group("customer_id, meter_code, period_start, period_end").
+		Order("period_start").
+		Scan(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ListRecords returns every record of a period, billed or not, ordered by timestamp
+func (r *UsageRepositoryImpl) ListRecords(ctx context.Context, key model.UsageKey) ([]model.UsageRecord, error) {
+	var records []model.UsageRecord
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ? AND meter_code = ? AND period_start = ?", key.CustomerID, key.MeterCode, key.PeriodStart).
+		Order("timestamp, id").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetOrCreatePeriod returns the billing record of a usage period along with its adjustments,
+// creating an open one if needed
+func (r *UsageRepositoryImpl) GetOrCreatePeriod(ctx context.Context, key model.UsageKey) (*model.UsagePeriod, error) {
+	var period model.UsagePeriod
+
+	err := r.db.WithContext(ctx).
+		Preload("Adjustments").
+		Where(model.UsagePeriod{CustomerID: key.CustomerID, MeterCode: key.MeterCode, PeriodStart: key.PeriodStart}).
+		Attrs(model.UsagePeriod{PeriodEnd: key.PeriodEnd, Status: model.UsagePeriodOpen}).
+		FirstOrCreate(&period).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+// errAlreadyBilled rolls back a roll up whose records were billed by another run in the meantime
+var errAlreadyBilled = errors.New("usage records already billed")
+
+// ClosePeriod saves a closed period and marks its records as billed in one transaction.
+// Returns false and saves nothing when another run billed any of the records first.
+func (r *UsageRepositoryImpl) ClosePeriod(ctx context.Context, period *model.UsagePeriod, recordIDs []int64) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(period).Error; err != nil {
+			return err
+		}
+		return markBilled(tx, recordIDs, period.ID)
+	})
+	return rolledUp(err)
+}
+
+// AddAdjustment stores an adjustment for late records and marks them as billed in one transaction.
+// Returns false and stores nothing when another run billed any of the records first.
+func (r *UsageRepositoryImpl) AddAdjustment(ctx context.Context, adjustment *model.UsageAdjustment, recordIDs []int64) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(adjustment).Error; err != nil {
+			return err
+		}
+		return markBilled(tx, recordIDs, adjustment.UsagePeriodID)
+	})
+	return rolledUp(err)
+}
+
+// ClaimUninvoicedPeriod takes a lease on one closed period with an amount due that has not been invoiced yet.
+// Rows locked by another transaction or leased by another replica are skipped, like in ClaimDue.
+// Returns nil and no error when there is nothing to invoice.
+func (r *UsageRepositoryImpl) ClaimUninvoicedPeriod(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsagePeriod, error) {
+	var period model.UsagePeriod
+	claimed, err := claimUninvoiced(r.db.WithContext(ctx), &period, now, leaseUntil,
+		"status = ? AND amount > 0 AND invoice_id = 0", model.UsagePeriodClosed)
+	if err != nil || !claimed {
+		return nil, err
+	}
+	return &period, nil
+}
+
+// ClaimUninvoicedAdjustment takes a lease on one adjustment with an amount due that has not been invoiced yet.
+// Returns nil and no error when there is nothing to invoice.
+func (r *UsageRepositoryImpl) ClaimUninvoicedAdjustment(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsageAdjustment, error) {
+	var adjustment model.UsageAdjustment
+	claimed, err := claimUninvoiced(r.db.WithContext(ctx), &adjustment, now, leaseUntil, "amount > 0 AND invoice_id = 0")
+	if err != nil || !claimed {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// UpdatePeriod saves a usage period (its adjustments are left untouched)
+func (r *UsageRepositoryImpl) UpdatePeriod(ctx context.Context, period *model.UsagePeriod) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(period).Error
+}
+
+// UpdateAdjustment saves a usage adjustment
+func (r *UsageRepositoryImpl) UpdateAdjustment(ctx context.Context, adjustment *model.UsageAdjustment) error {
+	return r.db.WithContext(ctx).Save(adjustment).Error
+}
+
+// markBilled assigns records not billed yet to a period.
+// Concurrent runs block on the rows, so the one committing last sees them billed and fails with errAlreadyBilled.
+func markBilled(tx *gorm.DB, recordIDs []int64, periodID int64) error {
+	if len(recordIDs) == 0 {
+		return nil
+	}
+	result := tx.Model(&model.UsageRecord{}).
+		Where("id IN ? AND usage_period_id IS NULL", recordIDs).
+		Update("usage_period_id", periodID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(recordIDs)) {
+		return errAlreadyBilled
+	}
+	return nil
+}
+
+func rolledUp(err error) (bool, error) {
+	if errors.Is(err, errAlreadyBilled) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// claimUninvoiced leases the first row matching query into row, skipping rows locked or leased by other replicas
+func claimUninvoiced(db *gorm.DB, row any, now time.Time, leaseUntil time.Time, query string, args ...any) (bool, error) {
+	claimed := false
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(query, args...).
+			Where("locked_until IS NULL OR locked_until < ?", now).
+			Order("id").
+			First(row).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := tx.Model(row).Update("locked_until", leaseUntil).Error; err != nil {
+			return err
+		}
+
+		claimed = true
+		return nil
+	})
+
+	return claimed, err
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"billing-system/pkg/auth"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyPrefix starts every API key, so leaked keys are easy to search for
+const apiKeyPrefix = "bsk"
+
+// lastUsedResolution is how stale the last use of a key may be, verifying a key writes at most once per resolution
+const lastUsedResolution = time.Minute
+
+// APIKeyServiceImpl implements APIKeyService
+type APIKeyServiceImpl struct {
+	apiKeyRepo     repository.APIKeyRepository
+	defaultOverlap time.Duration
+	maxOverlap     time.Duration
+}
+
+// NewAPIKeyService creates a new APIKeyServiceImpl.
+// A rotated key stays valid for defaultOverlap unless the rotation asks for another overlap, at most maxOverlap.
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, defaultOverlap, maxOverlap time.Duration) APIKeyService {
+	if maxOverlap < defaultOverlap {
+		maxOverlap = defaultOverlap
+	}
+	return &APIKeyServiceImpl{
+		apiKeyRepo:     apiKeyRepo,
+		defaultOverlap: defaultOverlap,
+		maxOverlap:     maxOverlap,
+	}
+}
+
+// IssueAPIKey stores a new key with the name, customer, roles, scopes and expiry of key.
+// It returns the stored key and the secret its client presents, which cannot be recovered later.
+func (s *APIKeyServiceImpl) IssueAPIKey(ctx context.Context, key *model.APIKey) (*model.APIKey, string, error) {
+	if err := validateAPIKey(key, time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	issued := &model.APIKey{
+		Name:       key.Name,
+		CustomerID: key.CustomerID,
+		Roles:      key.Roles,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		CreatedBy:  subjectFromContext(ctx),
+	}
+	secret, err := generateAPIKey(issued)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, issued); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	return issued, secret, nil
+}
+
+// ListAPIKeys returns the keys of a customer, or every key when customerID is empty, including revoked and expired keys
+func (s *APIKeyServiceImpl) ListAPIKeys(ctx context.Context, customerID string) ([]model.APIKey, error) {
+	keys, err := s.apiKeyRepo.List(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key at once, revoking a revoked key returns it unchanged
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, id int64) (*model.APIKey, error) {
+	key, err := s.getAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	if err := s.apiKeyRepo.Revoke(ctx, id, now); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key %d: %w", id, err)
+	}
+	key.RevokedAt = &now
+	return key, nil
+}
+
+// RotateAPIKey issues a replacement of an active key with the same customer, roles, scopes and lifetime.
+// The previous key expires after the overlap, so clients can switch to the new key without downtime.
+// A zero overlap uses the default overlap.
+func (s *APIKeyServiceImpl) RotateAPIKey(ctx context.Context, id int64, overlap time.Duration) (*model.APIKey, string, error) {
+	if overlap < 0 || overlap > s.maxOverlap {
+		return nil, "", fmt.Errorf("%w: overlap must be between 0 and %s", ErrInvalidAPIKey, s.maxOverlap)
+	}
+	if overlap == 0 {
+		overlap = s.defaultOverlap
+	}
+
+	previous, err := s.getAPIKey(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if !previous.IsActive(now) {
+		return nil, "", fmt.Errorf("%w: key %s", ErrAPIKeyInactive, previous.KeyID)
+	}
+
+	replacement := &model.APIKey{
+		Name:          previous.Name,
+		CustomerID:    previous.CustomerID,
+		Roles:         previous.Roles,
+		Scopes:        previous.Scopes,
+		RotatedFromID: &previous.ID,
+		CreatedBy:     subjectFromContext(ctx),
+	}
+	if previous.ExpiresAt != nil {
+		expiresAt := now.Add(previous.ExpiresAt.Sub(previous.CreatedAt))
+		replacement.ExpiresAt = &expiresAt
+	}
+	secret, err := generateAPIKey(replacement)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The previous key never outlives its own expiry
+	overlapEnd := now.Add(overlap)
+	if previous.ExpiresAt == nil || overlapEnd.Before(*previous.ExpiresAt) {
+		previous.ExpiresAt = &overlapEnd
+	}
+
+	if err := s.apiKeyRepo.Rotate(ctx, replacement, previous); err != nil {
+		return nil, "", fmt.Errorf("failed to rotate API key %d: %w", id, err)
+	}
+	return replacement, secret, nil
+}
+
+// VerifyAPIKey returns the active key a client presented and records its use
+func (s *APIKeyServiceImpl) VerifyAPIKey(ctx context.Context, secret string) (*model.APIKey, error) {
+	keyID, ok := parseAPIKey(secret)
+	if !ok {
+		return nil, ErrUnknownAPIKey
+	}
+
+	key, err := s.apiKeyRepo.GetByKeyID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUnknownAPIKey
+		}
+		return nil, fmt.Errorf("failed to get API key %s: %w", keyID, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, ErrUnknownAPIKey
+	}
+
+	now := time.Now()
+	switch {
+	case key.RevokedAt != nil:
+		return nil, ErrAPIKeyRevoked
+	case !key.IsActive(now):
+		return nil, ErrAPIKeyExpired
+	}
+
+	// Failing to record the use does not fail the request of the client
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, now, now.Add(-lastUsedResolution)); err != nil {
+			log.Printf("Failed to record the use of API key %s: %v", key.KeyID, err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+	return key, nil
+}
+
+// getAPIKey returns the key with the id, ErrAPIKeyNotFound when there is none
+func (s *APIKeyServiceImpl) getAPIKey(ctx context.Context, id int64) (*model.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get API key %d: %w", id, err)
+	}
+	return key, nil
+}
+
+// validateAPIKey checks the fields of a key to issue.
+// Customer keys act for their customer only, so they need one, and staff keys cannot have one.
+// Scopes are completed with the scopes they imply, see auth.Permission.Implies.
+func validateAPIKey(key *model.APIKey, now time.Time) error {
+	if strings.TrimSpace(key.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+	if len(key.Roles) == 0 {
+		return fmt.Errorf("%w: at least one role is required", ErrInvalidAPIKey)
+	}
+
+	customer := false
+	for _, role := range key.Roles {
+		if !auth.Role(role).IsKnown() {
+			return fmt.Errorf("%w: unknown role %q", ErrInvalidAPIKey, role)
+		}
+		customer = customer || auth.Role(role) == auth.RoleCustomer
+	}
+	if customer != (key.CustomerID != "") {
+		return fmt.Errorf("%w: customer_id is required for and only allowed on keys with the customer role", ErrInvalidAPIKey)
+	}
+
+	for _, scope := range key.Scopes {
+		if !auth.Permission(scope).IsScope() {
+			return fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKey, scope)
+		}
+		for _, implied := range auth.Permission(scope).Implies() {
+			if !slices.Contains(key.Scopes, string(implied)) {
+				key.Scopes = append(key.Scopes, string(implied))
+			}
+		}
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expiry must be in the future", ErrInvalidAPIKey)
+	}
+	return nil
+}
+
+// generateAPIKey sets a random key id and the hash of a random secret on the key and returns the secret.
+// Keys look like bsk_<key id>_<secret>, the key id finds the key and the whole key is hashed.
+func generateAPIKey(key *model.APIKey) (string, error) {
+	random := make([]byte, 6+32)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key.KeyID = hex.EncodeToString(random[:6])
+	secret := apiKeyPrefix + "_" + key.KeyID + "_" + hex.EncodeToString(random[6:])
+	key.SecretHash = hashAPIKey(secret)
+	return secret, nil
+}
+
+// parseAPIKey returns the key id of a key, false when it is not shaped like a key
+func parseAPIKey(secret string) (string, bool) {
+	parts := strings.Split(secret, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// hashAPIKey returns the SHA-256 of a key in hex.
+// Keys are random, so a plain hash cannot be reversed and verifying needs no slow password hash.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// subjectFromContext returns the subject of the caller, empty when authentication is disabled
+func subjectFromContext(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// apiKeyIDFromContext returns the public id of the API key of the caller, empty for users
+func apiKeyIDFromContext(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.APIKeyID
+	}
+	return ""
+}
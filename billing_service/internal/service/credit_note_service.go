@@ -0,0 +1,172 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CreditNoteServiceImpl implements CreditNoteService
+type CreditNoteServiceImpl struct {
+	creditNoteRepo repository.CreditNoteRepository
+	invoiceRepo    repository.InvoiceRepository
+	orderRepo      repository.OrderRepository
+	holdReleaser   holdReleaser
+}
+
+// NewCreditNoteService creates a new CreditNoteServiceImpl
+func NewCreditNoteService(
+	creditNoteRepo repository.CreditNoteRepository,
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.OrderRepository,
+	customerRepo repository.CustomerRepository,
+) CreditNoteService {
+	return &CreditNoteServiceImpl{
+		creditNoteRepo: creditNoteRepo,
+		invoiceRepo:    invoiceRepo,
+		orderRepo:      orderRepo,
+		holdReleaser:   holdReleaser{invoiceRepo: invoiceRepo, customerRepo: customerRepo},
+	}
+}
+
+// CreateCreditNote reverses items of a shipment's invoice at the price they were invoiced at.
+// Quantities cannot exceed what was invoiced less what earlier credit notes reversed.
+// A reference that already has a credit note returns that note, so callers can retry safely.
+// Charges such as the shipping fee are not reversed.
+func (s *CreditNoteServiceImpl) CreateCreditNote(
+	ctx context.Context,
+	shipmentID int64,
+	reference string,
+	reason string,
+	itemRequests []dto.InvoiceItemRequest,
+) (*model.CreditNote, error) {
+	if shipmentID <= 0 || reference == "" {
+		return nil, fmt.Errorf("%w: shipment and reference are required", ErrInvalidCreditNote)
+	}
+	if len(itemRequests) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidCreditNote)
+	}
+
+	invoice, err := s.invoiceRepo.GetByShipmentID(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice of shipment %d: %w", shipmentID, err)
+	}
+
+	if existing, err := s.getByReference(ctx, reference); err != nil || existing != nil {
+		return s.replayed(existing, invoice, err)
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, invoice.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %d: %w", invoice.OrderID, err)
+	}
+
+	// Items are credited at the price they were invoiced at, see InvoiceServiceImpl.CreateInvoice
+	orderLines := make(map[string][]model.OrderItem, len(order.Items))
+	for _, orderItem := range order.Items {
+		orderLines[orderItem.Item.Sku] = append(orderLines[orderItem.Item.Sku], orderItem)
+	}
+
+	invoiced := make(map[int64]int)
+	for _, item := range invoice.Items {
+		invoiced[item.ItemID] += item.Quantity
+	}
+
+	previous, err := s.creditNoteRepo.ListByInvoiceID(ctx, invoice.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit notes of invoice %d: %w", invoice.ID, err)
+	}
+	credited := make(map[int64]int)
+	for _, creditNote := range previous {
+		for _, item := range creditNote.Items {
+			credited[item.ItemID] += item.Quantity
+		}
+	}
+
+	creditNote := &model.CreditNote{
+		InvoiceID: invoice.ID,
+		OrderID:   invoice.OrderID,
+		Reference: reference,
+		Reason:    reason,
+	}
+	for _, itemReq := range itemRequests {
+		if itemReq.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity for item %s must be greater than 0", ErrInvalidCreditNote, itemReq.Sku)
+		}
+
+		lines, ok := orderLines[itemReq.Sku]
+		if !ok || invoiced[lines[0].ItemID] == 0 {
+			return nil, fmt.Errorf("%w: item %s is not on invoice %d", ErrInvalidCreditNote, itemReq.Sku, invoice.ID)
+		}
+		orderItem := lines[0]
+
+		remaining := invoiced[orderItem.ItemID] - credited[orderItem.ItemID]
+		if itemReq.Quantity > remaining {
+			return nil, fmt.Errorf("%w: quantity %d for item %s exceeds the %d left to credit on invoice %d",
+				ErrInvalidCreditNote, itemReq.Quantity, itemReq.Sku, remaining, invoice.ID)
+		}
+		credited[orderItem.ItemID] += itemReq.Quantity
+
+		unitPrice := orderedUnitPrice(lines, orderItem.Item.Price)
+		amount := math.Round(unitPrice*float64(itemReq.Quantity)*100) / 100
+
+		creditNote.Items = append(creditNote.Items, model.CreditNoteItem{
+			ItemID:    orderItem.ItemID,
+			Sku:       itemReq.Sku,
+			Quantity:  itemReq.Quantity,
+			UnitPrice: unitPrice,
+			Amount:    amount,
+		})
+		creditNote.Amount += amount
+	}
+	creditNote.Amount = math.Round(creditNote.Amount*100) / 100
+
+	if err := s.creditNoteRepo.Create(ctx, creditNote); err != nil {
+		// A concurrent retry may have issued the note first
+		if existing, getErr := s.getByReference(ctx, reference); getErr == nil && existing != nil {
+			return s.replayed(existing, invoice, nil)
+		}
+		return nil, fmt.Errorf("failed to create credit note: %w", err)
+	}
+
+	// A credit note settling the last overdue invoice lifts the customer's hold, like a payment does
+	if err := s.holdReleaser.release(ctx, order.CustomerID, time.Now()); err != nil {
+		log.Printf("Hold not released after credit note %s: %v", creditNote.Reference, err)
+	}
+
+	return creditNote, nil
+}
+
+// getByReference returns the credit note issued for a reference, nil when there is none
+func (s *CreditNoteServiceImpl) getByReference(ctx context.Context, reference string) (*model.CreditNote, error) {
+	creditNote, err := s.creditNoteRepo.GetByReference(ctx, reference)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get credit note %s: %w", reference, err)
+	}
+	return creditNote, nil
+}
+
+// replayed returns the credit note already issued for a reference, which must belong to the same invoice
+func (s *CreditNoteServiceImpl) replayed(existing *model.CreditNote, invoice *model.Invoice, err error) (*model.CreditNote, error) {
+	if err != nil {
+		return nil, err
+	}
+	if existing.InvoiceID != invoice.ID {
+		return nil, fmt.Errorf("%w: reference %s was used for invoice %d", ErrInvalidCreditNote, existing.Reference, existing.InvoiceID)
+	}
+	return existing, nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/notifier"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DunningPolicy configures when reminders are sent and when customers are escalated
+type DunningPolicy struct {
+	// ReminderOffsetDays are the days relative to the due date at which reminders are sent.
+	// Negative values send a reminder before the invoice is due.
+	ReminderOffsetDays []int
+	// EscalateAfterDays is the number of days overdue after which the customer is put on hold.
+	// Zero disables escalation.
+	EscalateAfterDays int
+}
+
+// DunningServiceImpl implements DunningService
+type DunningServiceImpl struct {
+	invoiceRepo  repository.InvoiceRepository
+	customerRepo repository.CustomerRepository
+	notifier     notifier.Notifier
+	policy       DunningPolicy
+}
+
+// NewDunningService creates a new DunningServiceImpl
+func NewDunningService(
+	invoiceRepo repository.InvoiceRepository,
+	customerRepo repository.CustomerRepository,
+	notifier notifier.Notifier,
+	policy DunningPolicy,
+) DunningService {
+	offsets := append([]int(nil), policy.ReminderOffsetDays...)
+	sort.Ints(offsets)
+	policy.ReminderOffsetDays = offsets
+
+	return &DunningServiceImpl{
+		invoiceRepo:  invoiceRepo,
+		customerRepo: customerRepo,
+		notifier:     notifier,
+		policy:       policy,
+	}
+}
+
+// Start runs ProcessUnpaidInvoices immediately and then on every interval until ctx is cancelled
+func (s *DunningServiceImpl) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessUnpaidInvoices(ctx, time.Now()); err != nil {
+			log.Println("Dunning run finished with errors:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessUnpaidInvoices sends the reminders that are due at the given time and escalates
+// customers whose invoices are overdue for too long. Fully paid invoices are skipped.
+// A failure on one invoice does not stop the others from being processed.
+func (s *DunningServiceImpl) ProcessUnpaidInvoices(ctx context.Context, now time.Time) error {
+	// Look ahead far enough to catch reminders scheduled before the due date
+	lookAhead := 0
+	if len(s.policy.ReminderOffsetDays) > 0 && s.policy.ReminderOffsetDays[0] < 0 {
+		lookAhead = -s.policy.ReminderOffsetDays[0]
+	}
+
+	invoices, err := s.invoiceRepo.ListUnpaidDueBefore(ctx, now.AddDate(0, 0, lookAhead))
+	if err != nil {
+		return fmt.Errorf("failed to list unpaid invoices: %w", err)
+	}
+
+	var errs []error
+	for i := range invoices {
+		if err := s.processInvoice(ctx, &invoices[i], now); err != nil {
+			errs = append(errs, fmt.Errorf("invoice %d: %w", invoices[i].ID, err))
+		}
+	}
+
+	// Holds are lifted when a payment or credit note settles the last overdue invoice,
+	// the ones whose release failed then are lifted here
+	if err := s.releaseHolds(ctx, now); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// releaseHolds lifts the hold of every customer without an overdue unpaid invoice left
+func (s *DunningServiceImpl) releaseHolds(ctx context.Context, now time.Time) error {
+	customers, err := s.customerRepo.ListOnHold(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list customers on hold: %w", err)
+	}
+
+	releaser := holdReleaser{invoiceRepo: s.invoiceRepo, customerRepo: s.customerRepo}
+	var errs []error
+	for _, customer := range customers {
+		if err := releaser.lift(ctx, customer.CustomerID, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// processInvoice sends the next reminder for an invoice and escalates the customer if needed
+func (s *DunningServiceImpl) processInvoice(ctx context.Context, invoice *model.Invoice, now time.Time) error {
+	if invoice.IsPaid() {
+		return nil
+	}
+	if invoice.Order == nil {
+		return fmt.Errorf("order %d not loaded", invoice.OrderID)
+	}
+
+	customerID := invoice.Order.CustomerID
+	daysOverdue := int(math.Floor(now.Sub(invoice.DueDate).Hours() / 24))
+
+	// Number of reminders that should have been sent by now
+	level := 0
+	for _, offset := range s.policy.ReminderOffsetDays {
+		if daysOverdue >= offset {
+			level++
+		}
+	}
+
+	// Only the latest reached reminder is sent, missed ones are not replayed
+	if level > invoice.DunningLevel {
+		err := s.notifier.Notify(ctx, newNotification(notifier.PaymentReminder, customerID, invoice, daysOverdue, level))
+		if err != nil {
+			return fmt.Errorf("failed to send reminder: %w", err)
+		}
+
+		invoice.DunningLevel = level
+		invoice.LastReminderAt = &now
+		if err := s.invoiceRepo.UpdateDunning(ctx, invoice); err != nil {
+			return fmt.Errorf("failed to update dunning level: %w", err)
+		}
+	}
+
+	if s.policy.EscalateAfterDays > 0 && daysOverdue >= s.policy.EscalateAfterDays {
+		return s.escalate(ctx, customerID, invoice, daysOverdue)
+	}
+
+	return nil
+}
+
+// escalate puts the customer on hold unless they already are
+func (s *DunningServiceImpl) escalate(ctx context.Context, customerID string, invoice *model.Invoice, daysOverdue int) error {
+	customer, err := s.customerRepo.GetByCustomerID(ctx, customerID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get customer %s: %w", customerID, err)
+	}
+	if customer != nil && customer.OnHold {
+		return nil
+	}
+
+	if err := s.customerRepo.SetOnHold(ctx, customerID, true); err != nil {
+		return fmt.Errorf("failed to put customer %s on hold: %w", customerID, err)
+	}
+
+	err = s.notifier.Notify(ctx, newNotification(notifier.CustomerOnHold, customerID, invoice, daysOverdue, invoice.DunningLevel))
+	if err != nil {
+		return fmt.Errorf("failed to send escalation notice: %w", err)
+	}
+
+	return nil
+}
+
+// holdReleaser lifts the hold dunning put a customer on once none of their invoices is overdue and unpaid
+type holdReleaser struct {
+	invoiceRepo  repository.InvoiceRepository
+	customerRepo repository.CustomerRepository
+}
+
+// release lifts the hold of the customer, if they are on hold, once they have no overdue unpaid invoice left
+func (r holdReleaser) release(ctx context.Context, customerID string, now time.Time) error {
+	customer, err := r.customerRepo.GetByCustomerID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get customer %s: %w", customerID, err)
+	}
+	if !customer.OnHold {
+		return nil
+	}
+
+	return r.lift(ctx, customerID, now)
+}
+
+// lift releases the hold of a customer on hold unless one of their invoices is still overdue and unpaid
+func (r holdReleaser) lift(ctx context.Context, customerID string, now time.Time) error {
+	overdue, err := r.invoiceRepo.HasUnpaidDueBefore(ctx, customerID, now)
+	if err != nil {
+		return fmt.Errorf("failed to check the invoices of customer %s: %w", customerID, err)
+	}
+	if overdue {
+		return nil
+	}
+
+	if err := r.customerRepo.SetOnHold(ctx, customerID, false); err != nil {
+		return fmt.Errorf("failed to release the hold of customer %s: %w", customerID, err)
+	}
+
+	return nil
+}
+
+func newNotification(kind notifier.NotificationKind, customerID string, invoice *model.Invoice, daysOverdue int, level int) notifier.Notification {
+	return notifier.Notification{
+		Kind:              kind,
+		CustomerID:        customerID,
+		InvoiceID:         invoice.ID,
+		OrderID:           invoice.OrderID,
+		OutstandingAmount: invoice.OutstandingAmount(),
+		DueDate:           invoice.DueDate,
+		DaysOverdue:       daysOverdue,
+		Level:             level,
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrorKind classifies domain errors by what the caller can do about them
+type ErrorKind int
+
+const (
+	// KindInvalid is a request the caller has to correct
+	KindInvalid ErrorKind = iota + 1
+	// KindNotFound is a request for something that does not exist
+	KindNotFound
+	// KindConflict is a request the current state of the customer, invoice or subscription does not allow
+	KindConflict
+	// KindUnauthenticated is a credential that does not identify a caller
+	KindUnauthenticated
+)
+
+// Error is a domain error. The errors of this package are compared with errors.Is,
+// wrapping them with fmt.Errorf and %w adds detail to the message without losing the kind.
+type Error struct {
+	Kind ErrorKind
+	// Reason is the machine-readable cause, such as ORDER_NOT_FOUND
+	Reason  string
+	message string
+}
+
+func newError(kind ErrorKind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// itemLookupError wraps the error of getting an item by SKU, an unknown SKU is ErrItemNotFound
+func itemLookupError(sku string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: SKU %s", ErrItemNotFound, sku)
+	}
+	return fmt.Errorf("failed to get item with SKU %s: %w", sku, err)
+}
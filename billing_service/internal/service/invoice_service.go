@@ -5,36 +5,60 @@ import (
 	"billing-system/billing_service/internal/model"
 	"billing-system/billing_service/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type InvoiceServiceImpl struct {
-	invoiceRepo repository.InvoiceRepository
-	orderRepo   repository.OrderRepository
-	itemRepo    repository.ItemRepository
+	invoiceRepo  repository.InvoiceRepository
+	orderRepo    repository.OrderRepository
+	itemRepo     repository.ItemRepository
+	holdReleaser holdReleaser
 }
 
-func NewInvoiceService(invoiceRepo repository.InvoiceRepository, orderRepo repository.OrderRepository, itemRepo repository.ItemRepository) InvoiceService {
+func NewInvoiceService(
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.OrderRepository,
+	itemRepo repository.ItemRepository,
+	customerRepo repository.CustomerRepository,
+) InvoiceService {
 	return &InvoiceServiceImpl{
-		invoiceRepo: invoiceRepo,
-		orderRepo:   orderRepo,
-		itemRepo:    itemRepo,
+		invoiceRepo:  invoiceRepo,
+		orderRepo:    orderRepo,
+		itemRepo:     itemRepo,
+		holdReleaser: holdReleaser{invoiceRepo: invoiceRepo, customerRepo: customerRepo},
 	}
 }
 
-func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64, orderId int64, itemRequest []dto.InvoiceItemRequest) (*model.Invoice, error) {
+// CreateInvoice invoices items of an order along with charges such as the shipping fee.
+// Item quantities cannot exceed what is left to invoice on the order.
+func (s *InvoiceServiceImpl) CreateInvoice(
+	ctx context.Context,
+	shipmentId int64,
+	orderId int64,
+	itemRequest []dto.InvoiceItemRequest,
+	chargeRequests []dto.InvoiceChargeRequest,
+) (*model.Invoice, error) {
 	// Validate order exists and get order details
 	order, err := s.orderRepo.GetByID(ctx, orderId)
 	if err != nil {
-		return nil, fmt.Errorf("order not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrOrderNotFound, orderId)
+		}
+		return nil, fmt.Errorf("failed to get order %d: %w", orderId, err)
 	}
 
-	// Create a map of items in the order with quantities
+	// An item ordered on several lines is invoiced against their combined quantity, as GetShippableQuantities reports it
 	orderItemMap := make(map[int64]int)
-	skuToItemID := make(map[string]int64)
+	orderLines := make(map[int64][]model.OrderItem)
 	for _, orderItem := range order.Items {
-		orderItemMap[orderItem.ItemID] = orderItem.Quantity
-		skuToItemID[orderItem.Item.Sku] = orderItem.ItemID
+		orderItemMap[orderItem.ItemID] += orderItem.Quantity
+		orderLines[orderItem.ItemID] = append(orderLines[orderItem.ItemID], orderItem)
 	}
 
 	// Get existing invoices for this order
@@ -58,29 +82,30 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64
 
 	for _, itemReq := range itemRequest {
 		if itemReq.Quantity <= 0 {
-			return nil, fmt.Errorf("invalid quantity for item %s: %d", itemReq.Sku, itemReq.Quantity)
+			return nil, fmt.Errorf("%w for item %s: %d", ErrInvalidQuantity, itemReq.Sku, itemReq.Quantity)
 		}
 
 		// Get item by SKU
 		item, err := s.itemRepo.GetBySku(ctx, itemReq.Sku)
 		if err != nil {
-			return nil, fmt.Errorf("item with SKU %s not found: %w", itemReq.Sku, err)
+			return nil, itemLookupError(itemReq.Sku, err)
 		}
 
 		// Check if item exists in original order
 		orderQty, exists := orderItemMap[item.ID]
 		if !exists {
-			return nil, fmt.Errorf("item %s not found in original order", itemReq.Sku)
+			return nil, fmt.Errorf("%w: item %s not found in original order", ErrInvalidQuantity, itemReq.Sku)
 		}
 
-		// Check if the total quantity exceeds the order quantity
-		totalRequestedQty := itemReq.Quantity + consumedQuantities[item.ID]
-		if totalRequestedQty > orderQty {
+		// Check if the total quantity exceeds the order quantity, counting the earlier lines of this request
+		available := orderQty - consumedQuantities[item.ID] - requestedQuantities[item.ID]
+		if itemReq.Quantity > available {
 			return nil, fmt.Errorf(
-				"requested quantity %d for item %s exceeds available quantity %d (consumed: %d, ordered: %d)",
+				"%w: requested quantity %d for item %s exceeds available quantity %d (consumed: %d, ordered: %d)",
+				ErrInvalidQuantity,
 				itemReq.Quantity,
 				itemReq.Sku,
-				orderQty-consumedQuantities[item.ID],
+				available,
 				consumedQuantities[item.ID],
 				orderQty,
 			)
@@ -89,7 +114,8 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64
 		// Track requested quantities for this invoice
 		requestedQuantities[item.ID] += itemReq.Quantity
 
-		itemTotal := item.Price * float64(itemReq.Quantity)
+		unitPrice := orderedUnitPrice(orderLines[item.ID], item.Price)
+		itemTotal := unitPrice * float64(itemReq.Quantity)
 		totalAmount += itemTotal
 
 		invoiceItems = append(invoiceItems, model.InvoiceItem{
@@ -98,11 +124,21 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64
 		})
 	}
 
-	// Double-check for duplicates in the current request
-	for itemID, qty := range requestedQuantities {
-		if qty > orderItemMap[itemID] {
-			return nil, fmt.Errorf("duplicate items in request exceed original order quantity")
+	// Charges are billed in full on top of the items
+	charges := make([]model.InvoiceCharge, 0, len(chargeRequests))
+	for _, chargeReq := range chargeRequests {
+		charge, err := newInvoiceCharge(chargeReq)
+		if err != nil {
+			return nil, err
 		}
+		totalAmount += charge.Amount
+		charges = append(charges, charge)
+	}
+
+	// The due date follows the payment term recorded on the order
+	paymentTerm := order.PaymentTerm
+	if !paymentTerm.IsValid() {
+		paymentTerm = model.DefaultPaymentTerm
 	}
 
 	// Create invoice
@@ -110,7 +146,9 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64
 		OrderID:     orderId,
 		ShipmentID:  shipmentId,
 		TotalAmount: totalAmount,
+		DueDate:     time.Now().AddDate(0, 0, paymentTerm.Days()),
 		Items:       invoiceItems,
+		Charges:     charges,
 	}
 
 	if err := s.invoiceRepo.Create(ctx, invoice); err != nil {
@@ -119,3 +157,169 @@ func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, shipmentId int64
 
 	return invoice, nil
 }
+
+// orderedUnitPrice returns the price of an item on the lines of an order, weighted by their quantities when it
+// was ordered on several lines at different prices, so the invoices of every unit add up to what was ordered.
+// Lines of orders created before unit prices were recorded use the catalog price, a recorded price of 0 is kept.
+func orderedUnitPrice(lines []model.OrderItem, catalogPrice float64) float64 {
+	var amount float64
+	var quantity int
+	for _, line := range lines {
+		amount += line.Price(catalogPrice) * float64(line.Quantity)
+		quantity += line.Quantity
+	}
+	if quantity == 0 {
+		return catalogPrice
+	}
+	return amount / float64(quantity)
+}
+
+// newInvoiceCharge validates a charge request, shipping charges default to the shipping tax category
+func newInvoiceCharge(req dto.InvoiceChargeRequest) (model.InvoiceCharge, error) {
+	if req.Amount < 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return model.InvoiceCharge{}, fmt.Errorf("%w: charge amount must be a non-negative number", ErrInvalidAmount)
+	}
+
+	charge := model.InvoiceCharge{
+		Type:        req.Type,
+		Description: req.Description,
+		Amount:      req.Amount,
+		TaxCategory: req.TaxCategory,
+	}
+
+	switch req.Type {
+	case model.ChargeShipping:
+		if charge.TaxCategory == "" {
+			charge.TaxCategory = model.DefaultShippingTaxCategory
+		}
+		if charge.Description == "" {
+			charge.Description = "Shipping"
+		}
+	default:
+		return model.InvoiceCharge{}, fmt.Errorf("%w: unsupported charge type %q", ErrInvalidCharge, req.Type)
+	}
+
+	return charge, nil
+}
+
+// GetShippableQuantities returns the ordered, invoiced and remaining quantity of each SKU of an order,
+// in the order the items were ordered. Shipments are invoiced as they are created, so what is left to
+// invoice is what is left to ship.
+func (s *InvoiceServiceImpl) GetShippableQuantities(ctx context.Context, orderID int64) ([]model.ShippableQuantity, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order %d: %w", orderID, err)
+	}
+
+	invoices, err := s.invoiceRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing invoices: %w", err)
+	}
+
+	invoiced := make(map[int64]int)
+	for _, invoice := range invoices {
+		for _, item := range invoice.Items {
+			invoiced[item.ItemID] += item.Quantity
+		}
+	}
+
+	// An item ordered on several lines is reported once
+	var quantities []model.ShippableQuantity
+	index := make(map[int64]int)
+	for _, orderItem := range order.Items {
+		if i, ok := index[orderItem.ItemID]; ok {
+			quantities[i].Ordered += orderItem.Quantity
+			continue
+		}
+		index[orderItem.ItemID] = len(quantities)
+		quantities = append(quantities, model.ShippableQuantity{
+			Sku:     orderItem.Item.Sku,
+			ItemID:  orderItem.ItemID,
+			Ordered: orderItem.Quantity,
+		})
+	}
+
+	for i := range quantities {
+		quantities[i].Invoiced = invoiced[quantities[i].ItemID]
+		quantities[i].Remaining = max(quantities[i].Ordered-quantities[i].Invoiced, 0)
+	}
+
+	return quantities, nil
+}
+
+// ListOrderInvoices returns the invoices of an order with their items and charges
+func (s *InvoiceServiceImpl) ListOrderInvoices(ctx context.Context, orderID int64) ([]model.Invoice, error) {
+	if _, err := s.orderRepo.GetByID(ctx, orderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order %d: %w", orderID, err)
+	}
+
+	invoices, err := s.invoiceRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve invoices of order %d: %w", orderID, err)
+	}
+	return invoices, nil
+}
+
+// PayInvoice records a payment against an invoice.
+// The amount must be positive and cannot exceed the outstanding amount.
+func (s *InvoiceServiceImpl) PayInvoice(ctx context.Context, invoiceID int64, amount float64) (*model.Invoice, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to get invoice %d: %w", invoiceID, err)
+	}
+
+	if invoice.IsPaid() {
+		return nil, ErrInvoiceAlreadyPaid
+	}
+
+	if amount > invoice.OutstandingAmount() {
+		return nil, fmt.Errorf("%w: payment %f exceeds outstanding amount %f",
+			ErrInvalidAmount, amount, invoice.OutstandingAmount())
+	}
+
+	// The outstanding amount is checked again by the update, a payment or credit note may have committed since the read
+	applied, err := s.invoiceRepo.AddPayment(ctx, invoiceID, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+	if !applied {
+		return nil, fmt.Errorf("%w: payment %f exceeds the outstanding amount", ErrInvalidAmount, amount)
+	}
+
+	invoice, err = s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice %d: %w", invoiceID, err)
+	}
+
+	// Paying the last overdue invoice lifts the customer's hold. The payment stands if that fails,
+	// the next dunning run lifts the hold instead.
+	if invoice.IsPaid() {
+		if err := s.releaseHold(ctx, invoice.OrderID); err != nil {
+			log.Printf("Hold not released after paying invoice %d: %v", invoiceID, err)
+		}
+	}
+
+	return invoice, nil
+}
+
+// releaseHold lifts the hold of the customer of an order if none of their invoices is overdue anymore
+func (s *InvoiceServiceImpl) releaseHold(ctx context.Context, orderID int64) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order %d: %w", orderID, err)
+	}
+	return s.holdReleaser.release(ctx, order.CustomerID, time.Now())
+}
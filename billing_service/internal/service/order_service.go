@@ -5,23 +5,44 @@ import (
 	"billing-system/billing_service/internal/model"
 	"billing-system/billing_service/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // OrderServiceImpl implements OrderService
 type OrderServiceImpl struct {
-	orderRepo repository.OrderRepository
-	itemRepo  repository.ItemRepository
+	orderRepo          repository.OrderRepository
+	pricingService     PricingService
+	customerRepo       repository.CustomerRepository
+	defaultPaymentTerm model.PaymentTerm
+	quoteSigner        quoteSigner
+	maxQuoteLock       time.Duration
 }
 
-// NewOrderService creates a new OrderServiceImpl
+// NewOrderService creates a new OrderServiceImpl.
+// defaultPaymentTerm is used for customers without a payment term of their own.
+// quoteSecret signs quote tokens, which lock prices for at most maxQuoteLock. Zero disables locking.
 func NewOrderService(
 	orderRepo repository.OrderRepository,
-	itemRepo repository.ItemRepository,
+	pricingService PricingService,
+	customerRepo repository.CustomerRepository,
+	defaultPaymentTerm model.PaymentTerm,
+	quoteSecret []byte,
+	maxQuoteLock time.Duration,
 ) OrderService {
+	if !defaultPaymentTerm.IsValid() {
+		defaultPaymentTerm = model.DefaultPaymentTerm
+	}
 	return &OrderServiceImpl{
-		orderRepo: orderRepo,
-		itemRepo:  itemRepo,
+		orderRepo:          orderRepo,
+		pricingService:     pricingService,
+		customerRepo:       customerRepo,
+		defaultPaymentTerm: defaultPaymentTerm,
+		quoteSigner:        quoteSigner{secret: quoteSecret},
+		maxQuoteLock:       maxQuoteLock,
 	}
 }
 
@@ -32,23 +53,148 @@ func (s *OrderServiceImpl) CreateOrder(
 	itemRequests []dto.ItemRequest,
 	paymentRequests []dto.PaymentRequest,
 ) (*model.Order, error) {
-	// Calculate total amount from items
-	var totalAmount float64
-	orderItems := make([]model.OrderItem, 0, len(itemRequests))
-
-	// Process items and calculate totals
-	for _, req := range itemRequests {
-		// Fetch item details from repository
-		item, err := s.itemRepo.GetBySku(ctx, req.Sku)
-		if err != nil {
-			return nil, fmt.Errorf("item with SKU %s not found: %w", req.Sku, err)
+	// Resolve the customer's payment term and make sure they are allowed to order
+	paymentTerm, err := s.resolvePaymentTerm(ctx, customerID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the price the customer pays for each item
+	quote, err := s.priceCart(ctx, customerID, itemRequests, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.placeOrder(ctx, quote, paymentTerm, paymentRequests)
+}
+
+// CreateBillingOrder creates an order for usage or a subscription period the customer already owes.
+// Unlike CreateOrder it accepts customers on hold: the hold stops new purchases,
+// not the billing of what was already consumed.
+func (s *OrderServiceImpl) CreateBillingOrder(
+	ctx context.Context,
+	customerID string,
+	itemRequests []dto.ItemRequest,
+	paymentRequests []dto.PaymentRequest,
+) (*model.Order, error) {
+	paymentTerm, err := s.resolvePaymentTerm(ctx, customerID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.priceCart(ctx, customerID, itemRequests, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.placeOrder(ctx, quote, paymentTerm, paymentRequests)
+}
+
+// CreateOrderFromQuote creates an order for the items of a locked quote at the quoted prices
+func (s *OrderServiceImpl) CreateOrderFromQuote(
+	ctx context.Context,
+	customerID string,
+	quoteToken string,
+	paymentRequests []dto.PaymentRequest,
+) (*model.Order, error) {
+	quote, err := s.quoteSigner.verify(quoteToken, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if quote.CustomerID != customerID {
+		return nil, fmt.Errorf("%w: quote was issued to another customer", ErrInvalidQuote)
+	}
+
+	// Customers put on hold after the quote was issued are still not allowed to order
+	paymentTerm, err := s.resolvePaymentTerm(ctx, customerID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.placeOrder(ctx, quote, paymentTerm, paymentRequests)
+}
+
+// QuoteOrder prices a cart the same way CreateOrder does without storing anything.
+// A positive lock returns a token that creates the order at the quoted prices until it expires.
+func (s *OrderServiceImpl) QuoteOrder(
+	ctx context.Context,
+	customerID string,
+	itemRequests []dto.ItemRequest,
+	lock time.Duration,
+) (*model.Quote, error) {
+	if lock < 0 || lock > s.maxQuoteLock {
+		return nil, fmt.Errorf("%w: prices can be locked for at most %s", ErrInvalidQuote, s.maxQuoteLock)
+	}
+
+	// Customers that cannot order are not quoted either
+	if _, err := s.resolvePaymentTerm(ctx, customerID, false); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	quote, err := s.priceCart(ctx, customerID, itemRequests, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if lock > 0 {
+		expiresAt := now.Add(lock)
+		quote.ExpiresAt = &expiresAt
+		if quote.Token, err = s.quoteSigner.sign(quote); err != nil {
+			return nil, err
 		}
+	}
+
+	return quote, nil
+}
+
+// priceCart prices each item for the customer at the given time and totals the cart.
+// Taxes are not computed yet, so the tax amounts stay zero.
+func (s *OrderServiceImpl) priceCart(ctx context.Context, customerID string, itemRequests []dto.ItemRequest, at time.Time) (*model.Quote, error) {
+	pricedItems, err := s.pricingService.PriceItems(ctx, customerID, itemRequests, at)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &model.Quote{
+		CustomerID: customerID,
+		Lines:      make([]model.QuoteLine, 0, len(pricedItems)),
+	}
 
-		totalAmount += float64(req.Quantity) * item.Price
+	for _, priced := range pricedItems {
+		lineTotal := float64(priced.Quantity) * priced.UnitPrice
+		quote.TotalAmount += lineTotal
 
+		quote.Lines = append(quote.Lines, model.QuoteLine{
+			Sku:           priced.Item.Sku,
+			ItemID:        priced.Item.ID,
+			Quantity:      priced.Quantity,
+			UnitPrice:     priced.UnitPrice,
+			LineTotal:     lineTotal,
+			PriceListCode: priced.PriceListCode,
+			PriceTier:     priced.PriceTier,
+		})
+	}
+
+	return quote, nil
+}
+
+// placeOrder stores an order for the priced cart once the payments cover its total
+func (s *OrderServiceImpl) placeOrder(
+	ctx context.Context,
+	quote *model.Quote,
+	paymentTerm model.PaymentTerm,
+	paymentRequests []dto.PaymentRequest,
+) (*model.Order, error) {
+	orderItems := make([]model.OrderItem, 0, len(quote.Lines))
+	for _, line := range quote.Lines {
 		orderItems = append(orderItems, model.OrderItem{
-			ItemID:   item.ID,
-			Quantity: req.Quantity,
+			ItemID:        line.ItemID,
+			Quantity:      line.Quantity,
+			UnitPrice:     line.UnitPrice,
+			PriceRecorded: true,
+			PriceListCode: line.PriceListCode,
+			PriceTier:     line.PriceTier,
 		})
 	}
 
@@ -65,16 +211,18 @@ func (s *OrderServiceImpl) CreateOrder(
 	}
 
 	// Validate that total payment equals total amount
-	if totalPayment != totalAmount {
+	if totalPayment != quote.TotalAmount {
 		return nil, fmt.Errorf("%w: payment total %f does not match order total %f",
-			ErrInvalidAmount, totalPayment, totalAmount)
+			ErrInvalidAmount, totalPayment, quote.TotalAmount)
 	}
 
-	// Create order
+	// Create order, recording the API key of server-to-server callers
 	order := &model.Order{
-		CustomerID:  customerID,
-		TotalAmount: totalAmount,
+		CustomerID:  quote.CustomerID,
+		TotalAmount: quote.TotalAmount,
 		Status:      model.OrderPending,
+		PaymentTerm: paymentTerm,
+		APIKeyID:    apiKeyIDFromContext(ctx),
 		Items:       orderItems,
 		Payments:    payments,
 	}
@@ -88,12 +236,35 @@ func (s *OrderServiceImpl) CreateOrder(
 	return order, nil
 }
 
+// resolvePaymentTerm returns the payment term to record on a new order for the customer.
+// Customers on hold are not allowed to place new orders unless allowOnHold is set.
+func (s *OrderServiceImpl) resolvePaymentTerm(ctx context.Context, customerID string, allowOnHold bool) (model.PaymentTerm, error) {
+	customer, err := s.customerRepo.GetByCustomerID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.defaultPaymentTerm, nil
+		}
+		return "", fmt.Errorf("failed to get customer %s: %w", customerID, err)
+	}
+
+	if customer.OnHold && !allowOnHold {
+		return "", ErrCustomerOnHold
+	}
+
+	if !customer.PaymentTerm.IsValid() {
+		return s.defaultPaymentTerm, nil
+	}
+	return customer.PaymentTerm, nil
+}
+
 // GetOrderByID retrieves an order by its ID
 func (s *OrderServiceImpl) GetOrderByID(ctx context.Context, id int64) (*model.Order, error) {
 	// Retrieve the order from the repository
 	order, err := s.orderRepo.GetByID(ctx, id)
 	if err != nil {
-		// Handle the case where the order is not found
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrOrderNotFound, id)
+		}
 		return nil, fmt.Errorf("failed to get order with ID %d: %w", id, err)
 	}
 
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PricedItem is an item request with the unit price the customer pays for it.
+// PriceListCode and PriceTier are empty when the catalog price or an override is used.
+type PricedItem struct {
+	Item          *model.Item
+	Quantity      int
+	UnitPrice     float64
+	PriceListCode string
+	PriceTier     int
+}
+
+// PricingServiceImpl implements PricingService
+type PricingServiceImpl struct {
+	itemRepo      repository.ItemRepository
+	priceListRepo repository.PriceListRepository
+	customerRepo  repository.CustomerRepository
+}
+
+// NewPricingService creates a new PricingServiceImpl
+func NewPricingService(
+	itemRepo repository.ItemRepository,
+	priceListRepo repository.PriceListRepository,
+	customerRepo repository.CustomerRepository,
+) PricingService {
+	return &PricingServiceImpl{
+		itemRepo:      itemRepo,
+		priceListRepo: priceListRepo,
+		customerRepo:  customerRepo,
+	}
+}
+
+// CreatePriceList validates and stores a price list with its entries
+func (s *PricingServiceImpl) CreatePriceList(ctx context.Context, priceList *model.PriceList, entries []dto.PriceListEntryRequest) (*model.PriceList, error) {
+	if priceList.Code == "" {
+		return nil, fmt.Errorf("%w: code is required", ErrInvalidPriceList)
+	}
+
+	switch priceList.Kind {
+	case model.PriceListRetail, model.PriceListWholesale:
+		if priceList.CustomerID != "" {
+			return nil, fmt.Errorf("%w: only contract price lists belong to a customer", ErrInvalidPriceList)
+		}
+	case model.PriceListContract:
+		if priceList.CustomerID == "" {
+			return nil, fmt.Errorf("%w: contract price lists require a customer", ErrInvalidPriceList)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported kind %q", ErrInvalidPriceList, priceList.Kind)
+	}
+
+	if priceList.ValidFrom.IsZero() {
+		priceList.ValidFrom = time.Now()
+	}
+	if priceList.ValidTo != nil && !priceList.ValidTo.After(priceList.ValidFrom) {
+		return nil, fmt.Errorf("%w: valid to must be after valid from", ErrInvalidPriceList)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: at least one entry is required", ErrInvalidPriceList)
+	}
+
+	seen := make(map[int64]bool, len(entries))
+	priceList.Entries = make([]model.PriceListEntry, 0, len(entries))
+	for _, entry := range entries {
+		item, err := s.itemRepo.GetBySku(ctx, entry.Sku)
+		if err != nil {
+			return nil, itemLookupError(entry.Sku, err)
+		}
+		if seen[item.ID] {
+			return nil, fmt.Errorf("%w: item %s is listed twice", ErrInvalidPriceList, entry.Sku)
+		}
+		seen[item.ID] = true
+
+		if err := validateTiers(entry.Tiers, ErrInvalidPriceList); err != nil {
+			return nil, fmt.Errorf("item %s: %w", entry.Sku, err)
+		}
+
+		priceList.Entries = append(priceList.Entries, model.PriceListEntry{
+			ItemID: item.ID,
+			Tiers:  entry.Tiers,
+			Item:   item,
+		})
+	}
+
+	if err := s.priceListRepo.Create(ctx, priceList); err != nil {
+		return nil, fmt.Errorf("failed to create price list: %w", err)
+	}
+
+	return priceList, nil
+}
+
+// PriceItems resolves the unit price of each item for the customer at the given time.
+// A customer's contract wins over their segment's price list, which wins over the retail list.
+// Among lists of the same kind the one that became effective last wins.
+// Items on no effective list use the catalog price, and an item's UnitPrice override is used as is.
+func (s *PricingServiceImpl) PriceItems(ctx context.Context, customerID string, items []dto.ItemRequest, at time.Time) ([]PricedItem, error) {
+	segment, err := s.customerSegment(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	priced := make([]PricedItem, 0, len(items))
+	for _, req := range items {
+		item, err := s.itemRepo.GetBySku(ctx, req.Sku)
+		if err != nil {
+			return nil, itemLookupError(req.Sku, err)
+		}
+
+		pricedItem := PricedItem{
+			Item:      item,
+			Quantity:  req.Quantity,
+			UnitPrice: item.Price,
+		}
+
+		if req.UnitPrice != nil {
+			pricedItem.UnitPrice = *req.UnitPrice
+			priced = append(priced, pricedItem)
+			continue
+		}
+
+		entries, err := s.priceListRepo.ListEffectiveEntries(ctx, item.ID, customerID, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list prices of item %s: %w", req.Sku, err)
+		}
+
+		if entry := bestEntry(entries, segment); entry != nil {
+			position, tier := entry.Tiers.Find(float64(req.Quantity))
+			if position > 0 {
+				pricedItem.UnitPrice = tier.UnitPrice
+				pricedItem.PriceListCode = entry.PriceList.Code
+				pricedItem.PriceTier = position
+			}
+		}
+
+		priced = append(priced, pricedItem)
+	}
+
+	return priced, nil
+}
+
+// customerSegment returns the customer's segment, customers without settings are retail
+func (s *PricingServiceImpl) customerSegment(ctx context.Context, customerID string) (model.CustomerSegment, error) {
+	customer, err := s.customerRepo.GetByCustomerID(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.SegmentRetail, nil
+		}
+		return "", fmt.Errorf("failed to get customer %s: %w", customerID, err)
+	}
+	if customer.Segment == "" {
+		return model.SegmentRetail, nil
+	}
+	return customer.Segment, nil
+}
+
+// bestEntry picks the entry of the most specific price list that applies to the segment
+func bestEntry(entries []model.PriceListEntry, segment model.CustomerSegment) *model.PriceListEntry {
+	rank := func(kind model.PriceListKind) int {
+		switch {
+		case kind == model.PriceListContract:
+			return 3
+		case kind == model.PriceListWholesale && segment == model.SegmentWholesale:
+			return 2
+		case kind == model.PriceListRetail:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	var best *model.PriceListEntry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.PriceList == nil || rank(entry.PriceList.Kind) == 0 {
+			continue
+		}
+		if best == nil ||
+			rank(entry.PriceList.Kind) > rank(best.PriceList.Kind) ||
+			rank(entry.PriceList.Kind) == rank(best.PriceList.Kind) && entry.PriceList.ValidFrom.After(best.PriceList.ValidFrom) {
+			best = entry
+		}
+	}
+	return best
+}
+
+// validateTiers checks that tiers are ordered, not negative and only the last one is unbounded.
+// errInvalid is the error wrapped in the returned error.
+func validateTiers(tiers model.PriceTiers, errInvalid error) error {
+	if len(tiers) == 0 {
+		return fmt.Errorf("%w: at least one price tier is required", errInvalid)
+	}
+
+	var previous float64
+	for i, tier := range tiers {
+		if tier.UnitPrice < 0 || tier.FlatFee < 0 {
+			return fmt.Errorf("%w: tier %d has a negative price", errInvalid, i+1)
+		}
+		last := i == len(tiers)-1
+		if tier.UpTo == 0 && !last {
+			return fmt.Errorf("%w: only the last tier can be unbounded", errInvalid)
+		}
+		if tier.UpTo != 0 && tier.UpTo <= previous {
+			return fmt.Errorf("%w: tier %d must end after the previous tier", errInvalid, i+1)
+		}
+		if tier.UpTo != 0 && last {
+			return fmt.Errorf("%w: the last tier must be unbounded", errInvalid)
+		}
+		previous = tier.UpTo
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/model"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// quoteSigner issues and verifies quote tokens.
+// A token is the quote encoded as JSON followed by its HMAC-SHA256, so locked prices need no storage.
+type quoteSigner struct {
+	secret []byte
+}
+
+// sign returns the token of a locked quote
+func (s quoteSigner) sign(quote *model.Quote) (string, error) {
+	payload, err := json.Marshal(quote)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode quote: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), nil
+}
+
+// verify returns the quote of a token that was signed with the same secret and has not expired at now
+func (s quoteSigner) verify(token string, now time.Time) (*model.Quote, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidQuote)
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidQuote)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidQuote)
+	}
+
+	var quote model.Quote
+	if err := json.Unmarshal(payload, &quote); err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidQuote)
+	}
+
+	if quote.ExpiresAt == nil || !now.Before(*quote.ExpiresAt) {
+		return nil, ErrQuoteExpired
+	}
+
+	return &quote, nil
+}
+
+func (s quoteSigner) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
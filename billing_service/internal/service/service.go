@@ -5,25 +5,104 @@ import (
 	"billing-system/billing_service/internal/model"
 	"context"
 	"errors"
+	"time"
 )
 
 var (
-	ErrItemNotFound        = errors.New("item not found")
-	ErrOrderNotFound       = errors.New("order not found")
-	ErrInvoiceNotFound     = errors.New("invoice not found")
-	ErrPaymentNotFound     = errors.New("payment not found")
-	ErrInvalidQuantity     = errors.New("invalid quantity")
-	ErrInvalidAmount       = errors.New("invalid amount")
-	ErrInsufficientPayment = errors.New("insufficient payment")
+	ErrItemNotFound        = newError(KindNotFound, "ITEM_NOT_FOUND", "item not found")
+	ErrOrderNotFound       = newError(KindNotFound, "ORDER_NOT_FOUND", "order not found")
+	ErrInvoiceNotFound     = newError(KindNotFound, "INVOICE_NOT_FOUND", "invoice not found")
+	ErrPaymentNotFound     = newError(KindNotFound, "PAYMENT_NOT_FOUND", "payment not found")
+	ErrInvalidQuantity     = newError(KindInvalid, "INVALID_QUANTITY", "invalid quantity")
+	ErrInvalidAmount       = newError(KindInvalid, "INVALID_AMOUNT", "invalid amount")
+	ErrInvalidCharge       = newError(KindInvalid, "INVALID_CHARGE", "invalid charge")
+	ErrInsufficientPayment = newError(KindInvalid, "INSUFFICIENT_PAYMENT", "insufficient payment")
 	ErrDatabaseError       = errors.New("database error")
+	ErrCustomerOnHold      = newError(KindConflict, "CUSTOMER_ON_HOLD", "customer is on hold")
+	ErrInvoiceAlreadyPaid  = newError(KindConflict, "INVOICE_ALREADY_PAID", "invoice already paid")
+
+	ErrPlanNotFound             = newError(KindNotFound, "PLAN_NOT_FOUND", "plan not found")
+	ErrInvalidPlan              = newError(KindInvalid, "INVALID_PLAN", "invalid plan")
+	ErrSubscriptionNotFound     = newError(KindNotFound, "SUBSCRIPTION_NOT_FOUND", "subscription not found")
+	ErrInvalidSubscriptionState = newError(KindConflict, "INVALID_SUBSCRIPTION_STATE", "invalid subscription state")
+
+	ErrMeterNotFound = newError(KindNotFound, "METER_NOT_FOUND", "meter not found")
+	ErrInvalidMeter  = newError(KindInvalid, "INVALID_METER", "invalid meter")
+	ErrInvalidUsage  = newError(KindInvalid, "INVALID_USAGE", "invalid usage record")
+
+	ErrInvalidPriceList = newError(KindInvalid, "INVALID_PRICE_LIST", "invalid price list")
+
+	ErrInvalidQuote = newError(KindInvalid, "INVALID_QUOTE", "invalid quote")
+	ErrQuoteExpired = newError(KindConflict, "QUOTE_EXPIRED", "quote expired")
+
+	ErrInvalidCreditNote = newError(KindInvalid, "INVALID_CREDIT_NOTE", "invalid credit note")
+
+	ErrAPIKeyNotFound = newError(KindNotFound, "API_KEY_NOT_FOUND", "API key not found")
+	ErrInvalidAPIKey  = newError(KindInvalid, "INVALID_API_KEY", "invalid API key")
+	ErrAPIKeyInactive = newError(KindConflict, "API_KEY_INACTIVE", "API key expired or revoked")
+	ErrUnknownAPIKey  = newError(KindUnauthenticated, "UNKNOWN_API_KEY", "unknown API key")
+	ErrAPIKeyExpired  = newError(KindUnauthenticated, "API_KEY_EXPIRED", "API key expired")
+	ErrAPIKeyRevoked  = newError(KindUnauthenticated, "API_KEY_REVOKED", "API key revoked")
 )
 
 // OrderService defines the interface for order-related business logic
 type OrderService interface {
 	CreateOrder(ctx context.Context, customerID string, items []dto.ItemRequest, payments []dto.PaymentRequest) (*model.Order, error)
+	CreateOrderFromQuote(ctx context.Context, customerID string, quoteToken string, payments []dto.PaymentRequest) (*model.Order, error)
+	CreateBillingOrder(ctx context.Context, customerID string, items []dto.ItemRequest, payments []dto.PaymentRequest) (*model.Order, error)
+	QuoteOrder(ctx context.Context, customerID string, items []dto.ItemRequest, lock time.Duration) (*model.Quote, error)
 	GetOrderByID(ctx context.Context, id int64) (*model.Order, error)
 }
 
 type InvoiceService interface {
-	CreateInvoice(ctx context.Context, shipmentId int64, orderId int64, itemRequest []dto.InvoiceItemRequest) (*model.Invoice, error)
+	CreateInvoice(ctx context.Context, shipmentId int64, orderId int64, itemRequest []dto.InvoiceItemRequest, chargeRequests []dto.InvoiceChargeRequest) (*model.Invoice, error)
+	PayInvoice(ctx context.Context, invoiceID int64, amount float64) (*model.Invoice, error)
+	GetShippableQuantities(ctx context.Context, orderID int64) ([]model.ShippableQuantity, error)
+	ListOrderInvoices(ctx context.Context, orderID int64) ([]model.Invoice, error)
+}
+
+// CreditNoteService defines the interface for reversing invoiced items
+type CreditNoteService interface {
+	CreateCreditNote(ctx context.Context, shipmentID int64, reference string, reason string, items []dto.InvoiceItemRequest) (*model.CreditNote, error)
+}
+
+// DunningService defines the interface for following up on unpaid invoices
+type DunningService interface {
+	ProcessUnpaidInvoices(ctx context.Context, now time.Time) error
+	Start(ctx context.Context, interval time.Duration)
+}
+
+// SubscriptionService defines the interface for recurring billing
+type SubscriptionService interface {
+	CreatePlan(ctx context.Context, plan *model.Plan) (*model.Plan, error)
+	CreateSubscription(ctx context.Context, customerID string, planCode string, paymentMethod model.PaymentMethod) (*model.Subscription, error)
+	ChangePlan(ctx context.Context, subscriptionID int64, planCode string) (*model.Subscription, error)
+	PauseSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error)
+	ResumeSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error)
+	CancelSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error)
+	BillDueSubscriptions(ctx context.Context, now time.Time) error
+	Start(ctx context.Context, interval time.Duration)
+}
+
+// PricingService defines the interface for resolving the price customers pay for items
+type PricingService interface {
+	CreatePriceList(ctx context.Context, priceList *model.PriceList, entries []dto.PriceListEntryRequest) (*model.PriceList, error)
+	PriceItems(ctx context.Context, customerID string, items []dto.ItemRequest, at time.Time) ([]PricedItem, error)
+}
+
+// UsageService defines the interface for metered billing
+type UsageService interface {
+	CreateMeter(ctx context.Context, meter *model.Meter) (*model.Meter, error)
+	RecordUsage(ctx context.Context, record *model.UsageRecord) (bool, error)
+	CloseUsagePeriods(ctx context.Context, now time.Time) error
+	Start(ctx context.Context, interval time.Duration)
+}
+
+// APIKeyService defines the interface for the API keys of server-to-server clients
+type APIKeyService interface {
+	IssueAPIKey(ctx context.Context, key *model.APIKey) (*model.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, customerID string) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) (*model.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int64, overlap time.Duration) (*model.APIKey, string, error)
+	VerifyAPIKey(ctx context.Context, secret string) (*model.APIKey, error)
 }
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SubscriptionServiceImpl implements SubscriptionService
+type SubscriptionServiceImpl struct {
+	subscriptionRepo repository.SubscriptionRepository
+	planRepo         repository.PlanRepository
+	itemRepo         repository.ItemRepository
+	orderService     OrderService
+	invoiceService   InvoiceService
+	lease            time.Duration
+}
+
+// NewSubscriptionService creates a new SubscriptionServiceImpl.
+// lease is how long a scheduler replica owns a subscription while billing it.
+func NewSubscriptionService(
+	subscriptionRepo repository.SubscriptionRepository,
+	planRepo repository.PlanRepository,
+	itemRepo repository.ItemRepository,
+	orderService OrderService,
+	invoiceService InvoiceService,
+	lease time.Duration,
+) SubscriptionService {
+	if lease <= 0 {
+		lease = 5 * time.Minute
+	}
+	return &SubscriptionServiceImpl{
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+		itemRepo:         itemRepo,
+		orderService:     orderService,
+		invoiceService:   invoiceService,
+		lease:            lease,
+	}
+}
+
+// CreatePlan validates and stores a new plan
+func (s *SubscriptionServiceImpl) CreatePlan(ctx context.Context, plan *model.Plan) (*model.Plan, error) {
+	if plan.Code == "" || plan.Sku == "" {
+		return nil, fmt.Errorf("%w: code and sku are required", ErrInvalidPlan)
+	}
+	if plan.Price < 0 || plan.IntervalCount < 0 || plan.TrialDays < 0 {
+		return nil, fmt.Errorf("%w: price, interval count and trial days cannot be negative", ErrInvalidPlan)
+	}
+	if plan.Interval != model.IntervalMonth && plan.Interval != model.IntervalYear {
+		return nil, fmt.Errorf("%w: unsupported interval %q", ErrInvalidPlan, plan.Interval)
+	}
+	if plan.IntervalCount == 0 {
+		plan.IntervalCount = 1
+	}
+
+	// Periods are billed as one unit of the plan's item
+	if _, err := s.itemRepo.GetBySku(ctx, plan.Sku); err != nil {
+		return nil, itemLookupError(plan.Sku, err)
+	}
+
+	if err := s.planRepo.Create(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// CreateSubscription subscribes a customer to a plan.
+// Plans with a trial start in TRIALING and are first billed when the trial ends,
+// otherwise the first period is billed by the scheduler on its next run.
+func (s *SubscriptionServiceImpl) CreateSubscription(ctx context.Context, customerID string, planCode string, paymentMethod model.PaymentMethod) (*model.Subscription, error) {
+	plan, err := s.getPlan(ctx, planCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentMethod == "" {
+		paymentMethod = model.COD
+	}
+
+	now := time.Now()
+	subscription := &model.Subscription{
+		CustomerID:         customerID,
+		PlanID:             plan.ID,
+		Plan:               *plan,
+		Status:             model.SubscriptionActive,
+		PaymentMethod:      paymentMethod,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now,
+		NextBillingAt:      now,
+	}
+
+	if plan.TrialDays > 0 {
+		trialEnd := now.AddDate(0, 0, plan.TrialDays)
+		subscription.Status = model.SubscriptionTrialing
+		subscription.TrialEnd = &trialEnd
+		subscription.CurrentPeriodEnd = trialEnd
+		subscription.NextBillingAt = trialEnd
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// ChangePlan moves a subscription to another plan immediately.
+// For the rest of the current period an upgrade is charged right away and a downgrade
+// is kept as credit for the next periods. Plan changes during a trial are free.
+// The change is recorded before charging, so a retry after a failure is not charged again.
+// A pending change to another plan is voided when nothing was charged for it or the subscription left its plan,
+// otherwise it has to be completed first.
+func (s *SubscriptionServiceImpl) ChangePlan(ctx context.Context, subscriptionID int64, planCode string) (*model.Subscription, error) {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != model.SubscriptionActive && subscription.Status != model.SubscriptionTrialing {
+		return nil, fmt.Errorf("%w: cannot change plan of a %s subscription", ErrInvalidSubscriptionState, subscription.Status)
+	}
+
+	newPlan, err := s.getPlan(ctx, planCode)
+	if err != nil {
+		return nil, err
+	}
+	if newPlan.ID == subscription.PlanID {
+		return subscription, nil
+	}
+
+	amount := 0.0
+	if subscription.Status == model.SubscriptionActive {
+		amount = Prorate(subscription.Plan.Price, newPlan.Price, subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd, time.Now())
+	}
+
+	change, err := s.pendingPlanChange(ctx, &model.SubscriptionPlanChange{
+		SubscriptionID: subscription.ID,
+		FromPlanID:     subscription.PlanID,
+		ToPlanID:       newPlan.ID,
+		Amount:         amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Amount > 0 {
+		// An upgrade is a new purchase, so customers on hold cannot make one
+		err := s.chargeOnce(ctx, s.orderService.CreateOrder, subscription, newPlan.Sku, change.Amount, &change.OrderID, &change.InvoiceID, func() error {
+			if err := s.subscriptionRepo.UpdatePlanChange(ctx, change); err != nil {
+				return fmt.Errorf("failed to update plan change: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to charge plan upgrade: %w", err)
+		}
+	}
+
+	applied, err := s.subscriptionRepo.ApplyPlanChange(ctx, change, subscription.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	if !applied {
+		return nil, fmt.Errorf("%w: subscription changed concurrently", ErrInvalidSubscriptionState)
+	}
+
+	if change.Amount < 0 {
+		subscription.CreditBalance += -change.Amount
+	}
+	subscription.PlanID = newPlan.ID
+	subscription.Plan = *newPlan
+
+	return subscription, nil
+}
+
+// pendingPlanChange records the plan change unless one is pending, voiding a pending change to another plan
+// that was not charged or whose from plan the subscription left
+func (s *SubscriptionServiceImpl) pendingPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (*model.SubscriptionPlanChange, error) {
+	pending, err := s.subscriptionRepo.GetOrCreatePendingPlanChange(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record plan change: %w", err)
+	}
+	if pending.FromPlanID == change.FromPlanID && pending.ToPlanID == change.ToPlanID {
+		return pending, nil
+	}
+	if pending.OrderID != 0 && pending.FromPlanID == change.FromPlanID {
+		return nil, fmt.Errorf("%w: a change to plan %d is pending", ErrInvalidSubscriptionState, pending.ToPlanID)
+	}
+
+	// The order of a change whose from plan was left stays on the voided change, to be refunded with a credit note
+	if _, err := s.subscriptionRepo.VoidPlanChange(ctx, pending); err != nil {
+		return nil, fmt.Errorf("failed to void plan change: %w", err)
+	}
+	pending, err = s.subscriptionRepo.GetOrCreatePendingPlanChange(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record plan change: %w", err)
+	}
+	if pending.FromPlanID != change.FromPlanID || pending.ToPlanID != change.ToPlanID {
+		return nil, fmt.Errorf("%w: a change to plan %d is pending", ErrInvalidSubscriptionState, pending.ToPlanID)
+	}
+	return pending, nil
+}
+
+// PauseSubscription stops billing until the subscription is resumed
+func (s *SubscriptionServiceImpl) PauseSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error) {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != model.SubscriptionActive && subscription.Status != model.SubscriptionTrialing {
+		return nil, fmt.Errorf("%w: cannot pause a %s subscription", ErrInvalidSubscriptionState, subscription.Status)
+	}
+
+	from := subscription.Status
+	subscription.Status = model.SubscriptionPaused
+
+	if err := s.update(ctx, subscription, from, "status"); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// ResumeSubscription restarts billing of a paused subscription.
+// Periods that fell due while paused are not billed, billing restarts from now.
+func (s *SubscriptionServiceImpl) ResumeSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error) {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != model.SubscriptionPaused {
+		return nil, fmt.Errorf("%w: cannot resume a %s subscription", ErrInvalidSubscriptionState, subscription.Status)
+	}
+
+	now := time.Now()
+	from := subscription.Status
+	subscription.Status = model.SubscriptionActive
+	if subscription.TrialEnd != nil && now.Before(*subscription.TrialEnd) {
+		subscription.Status = model.SubscriptionTrialing
+	}
+	if subscription.NextBillingAt.Before(now) {
+		subscription.NextBillingAt = now
+	}
+
+	if err := s.update(ctx, subscription, from, "status", "next_billing_at"); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// CancelSubscription cancels the subscription at the end of the current period.
+// Paused subscriptions are not billed anymore and are canceled immediately.
+func (s *SubscriptionServiceImpl) CancelSubscription(ctx context.Context, subscriptionID int64) (*model.Subscription, error) {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := subscription.Status
+	switch subscription.Status {
+	case model.SubscriptionCanceled:
+		return nil, fmt.Errorf("%w: subscription is already canceled", ErrInvalidSubscriptionState)
+	case model.SubscriptionPaused:
+		now := time.Now()
+		subscription.Status = model.SubscriptionCanceled
+		subscription.CanceledAt = &now
+	default:
+		subscription.CancelAtPeriodEnd = true
+	}
+
+	if err := s.update(ctx, subscription, from, "status", "canceled_at", "cancel_at_period_end"); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Start runs BillDueSubscriptions immediately and then on every interval until ctx is cancelled
+func (s *SubscriptionServiceImpl) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.BillDueSubscriptions(ctx, time.Now()); err != nil {
+			log.Println("Subscription billing run finished with errors:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// BillDueSubscriptions bills every subscription whose next billing date has passed.
+// Each subscription is leased before billing, so replicas never bill the same one concurrently.
+// A subscription that fails keeps its lease until it expires, which delays the retry.
+func (s *SubscriptionServiceImpl) BillDueSubscriptions(ctx context.Context, now time.Time) error {
+	var errs []error
+
+	for {
+		subscription, err := s.subscriptionRepo.ClaimDue(ctx, now, now.Add(s.lease))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to claim subscription: %w", err))
+			break
+		}
+		if subscription == nil {
+			break
+		}
+
+		if err := s.billPeriod(ctx, subscription, now); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %d: %w", subscription.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// billPeriod bills the period starting at the subscription's next billing date and advances it.
+// Every step is recorded on the period so a retry after a partial failure resumes where it stopped.
+func (s *SubscriptionServiceImpl) billPeriod(ctx context.Context, subscription *model.Subscription, now time.Time) error {
+	if subscription.CancelAtPeriodEnd {
+		from := subscription.Status
+		subscription.Status = model.SubscriptionCanceled
+		subscription.CanceledAt = &now
+		subscription.LockedUntil = nil
+		return s.update(ctx, subscription, from, "status", "canceled_at", "locked_until")
+	}
+
+	plan := subscription.Plan
+	start := subscription.NextBillingAt
+	end := plan.PeriodEnd(start)
+	credit := math.Min(subscription.CreditBalance, plan.Price)
+
+	period, err := s.subscriptionRepo.GetOrCreatePeriod(ctx, &model.SubscriptionPeriod{
+		SubscriptionID: subscription.ID,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		Amount:         plan.Price - credit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record period: %w", err)
+	}
+
+	if period.Amount > 0 {
+		// Renewals are billed even when the customer is on hold
+		err := s.chargeOnce(ctx, s.orderService.CreateBillingOrder, subscription, plan.Sku, period.Amount, &period.OrderID, &period.InvoiceID, func() error {
+			if err := s.subscriptionRepo.UpdatePeriod(ctx, period); err != nil {
+				return fmt.Errorf("failed to update period: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	creditUsed := plan.Price - period.Amount
+	subscription.CreditBalance = math.Max(subscription.CreditBalance-creditUsed, 0)
+	if subscription.Status == model.SubscriptionTrialing {
+		subscription.Status = model.SubscriptionActive
+	}
+	subscription.CurrentPeriodStart = start
+	subscription.CurrentPeriodEnd = end
+	subscription.NextBillingAt = end
+	subscription.LockedUntil = nil
+
+	// Only the billing columns are written, a pause or cancel made meanwhile is kept
+	if err := s.subscriptionRepo.AdvancePeriod(ctx, subscription, creditUsed); err != nil {
+		return fmt.Errorf("failed to advance subscription: %w", err)
+	}
+
+	return nil
+}
+
+// orderCreator is OrderService.CreateOrder for purchases or OrderService.CreateBillingOrder for renewals
+type orderCreator func(ctx context.Context, customerID string, items []dto.ItemRequest, payments []dto.PaymentRequest) (*model.Order, error)
+
+// chargeOnce charges amount for one unit of sku unless the order and invoice recorded in orderID
+// and invoiceID already exist. save is called after each step so a retry resumes where it stopped.
+func (s *SubscriptionServiceImpl) chargeOnce(ctx context.Context, createOrder orderCreator, subscription *model.Subscription, sku string, amount float64, orderID *int64, invoiceID *int64, save func() error) error {
+	if *orderID == 0 {
+		order, invoice, err := s.charge(ctx, createOrder, subscription, sku, amount)
+		if order != nil {
+			*orderID = order.ID
+		}
+		if invoice != nil {
+			*invoiceID = invoice.ID
+		}
+		if saveErr := save(); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if *orderID != 0 && *invoiceID == 0 {
+		invoice, err := s.invoiceService.CreateInvoice(ctx, 0, *orderID, []dto.InvoiceItemRequest{{Sku: sku, Quantity: 1}}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create invoice: %w", err)
+		}
+		*invoiceID = invoice.ID
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// update writes columns of the subscription if its status is still from
+func (s *SubscriptionServiceImpl) update(ctx context.Context, subscription *model.Subscription, from model.SubscriptionStatus, columns ...string) error {
+	updated, err := s.subscriptionRepo.Update(ctx, subscription, from, columns...)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+	if !updated {
+		return fmt.Errorf("%w: subscription changed concurrently", ErrInvalidSubscriptionState)
+	}
+	return nil
+}
+
+// charge creates an order and its invoice for one unit of sku at amount.
+// The order is returned even if invoicing fails so callers can record it.
+func (s *SubscriptionServiceImpl) charge(ctx context.Context, createOrder orderCreator, subscription *model.Subscription, sku string, amount float64) (*model.Order, *model.Invoice, error) {
+	order, err := createOrder(ctx, subscription.CustomerID,
+		[]dto.ItemRequest{{Sku: sku, Quantity: 1, UnitPrice: &amount}},
+		[]dto.PaymentRequest{{Method: subscription.PaymentMethod, Amount: amount}},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	invoice, err := s.invoiceService.CreateInvoice(ctx, 0, order.ID, []dto.InvoiceItemRequest{{Sku: sku, Quantity: 1}}, nil)
+	if err != nil {
+		return order, nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	return order, invoice, nil
+}
+
+func (s *SubscriptionServiceImpl) getPlan(ctx context.Context, code string) (*model.Plan, error) {
+	plan, err := s.planRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("failed to get plan %s: %w", code, err)
+	}
+	return plan, nil
+}
+
+func (s *SubscriptionServiceImpl) getSubscription(ctx context.Context, id int64) (*model.Subscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get subscription %d: %w", id, err)
+	}
+	return subscription, nil
+}
+
+// Prorate returns the price difference between two plans for the part of the period
+// [start, end) that remains at the given time, rounded to cents.
+// A positive amount is owed by the customer, a negative amount is owed to them.
+func Prorate(oldPrice float64, newPrice float64, start time.Time, end time.Time, at time.Time) float64 {
+	total := end.Sub(start)
+	if total <= 0 || !at.Before(end) {
+		return 0
+	}
+
+	remaining := end.Sub(at)
+	if remaining > total {
+		remaining = total
+	}
+
+	amount := (newPrice - oldPrice) * float64(remaining) / float64(total)
+	return math.Round(amount*100) / 100
+}
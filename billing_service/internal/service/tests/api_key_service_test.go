@@ -0,0 +1,321 @@
+package tests
+
+import (
+	billing_handler "billing-system/billing_service/internal/handler"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	pb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// adminContext is the context of a request of an admin
+func adminContext() context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: "admin-1", Roles: []auth.Role{auth.RoleAdmin}}, "token")
+}
+
+func TestAPIKeyService_IssueAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		key           *model.APIKey
+		expectedError error
+	}{
+		{
+			name: "Success - Staff key with scopes",
+			key:  &model.APIKey{Name: "ERP", Roles: []string{"ops"}, Scopes: []string{"orders:read", "shipments:write"}},
+		},
+		{
+			name: "Success - Customer key",
+			key:  &model.APIKey{Name: "Shop integration", CustomerID: "CUST001", Roles: []string{"customer"}},
+		},
+		{
+			name:          "Error - Missing name",
+			key:           &model.APIKey{Roles: []string{"ops"}},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Unknown role",
+			key:           &model.APIKey{Name: "ERP", Roles: []string{"superuser"}},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Customer key without customer",
+			key:           &model.APIKey{Name: "Shop integration", Roles: []string{"customer"}},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Staff key with customer",
+			key:           &model.APIKey{Name: "ERP", CustomerID: "CUST001", Roles: []string{"ops"}},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Unknown scope",
+			key:           &model.APIKey{Name: "ERP", Roles: []string{"ops"}, Scopes: []string{"orders:delete"}},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Expiry in the past",
+			key:           &model.APIKey{Name: "ERP", Roles: []string{"ops"}, ExpiresAt: &past},
+			expectedError: service.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+			mockAPIKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.APIKey")).Return(nil).Maybe()
+			apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo, 24*time.Hour, 7*24*time.Hour)
+
+			key, secret, err := apiKeyService.IssueAPIKey(adminContext(), tc.key)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockAPIKeyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(secret, "bsk_"+key.KeyID+"_"), "secret %q does not start with its key id", secret)
+			assert.NotContains(t, key.SecretHash, secret)
+			assert.Equal(t, hashOf(secret), key.SecretHash)
+			assert.Equal(t, "admin-1", key.CreatedBy)
+			assert.Equal(t, tc.key.Scopes, key.Scopes)
+		})
+	}
+}
+
+func TestAPIKeyService_IssueAPIKey_ShipmentScope(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	mockAPIKeyRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.APIKey")).Return(nil)
+	apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo, 24*time.Hour, 7*24*time.Hour)
+
+	key, _, err := apiKeyService.IssueAPIKey(adminContext(), &model.APIKey{Name: "Warehouse", Roles: []string{"ops"}, Scopes: []string{"shipments:write"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"shipments:write", "orders:read", "invoices:write"}, key.Scopes)
+
+	// The principal of the key creates a shipment, which forwards its token to these billing methods
+	principal := &auth.Principal{Subject: key.KeyID, Roles: []auth.Role{auth.RoleOps}, APIKeyID: key.KeyID}
+	for _, scope := range key.Scopes {
+		principal.Scopes = append(principal.Scopes, auth.Permission(scope))
+	}
+	assert.True(t, principal.Can(auth.PermissionShipmentsWrite))
+	for _, method := range []string{
+		pb.BillingService_GetOrder_FullMethodName,
+		pb.BillingService_GetShippableQuantities_FullMethodName,
+		pb.BillingService_CreateInvoice_FullMethodName,
+	} {
+		assert.True(t, principal.Can(billing_handler.MethodPermissions[method]), "key cannot call %s", method)
+	}
+	assert.False(t, principal.Can(auth.PermissionOrdersWrite))
+}
+
+func TestAPIKeyService_VerifyAPIKey(t *testing.T) {
+	// Issue a key to verify
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	mockAPIKeyRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	issued, secret, err := service.NewAPIKeyService(mockAPIKeyRepo, time.Hour, time.Hour).
+		IssueAPIKey(adminContext(), &model.APIKey{Name: "ERP", Roles: []string{"ops"}})
+	require.NoError(t, err)
+	issued.ID = 7
+
+	now := time.Now()
+	recently := now.Add(-10 * time.Second)
+	earlier := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name          string
+		secret        string
+		key           func() *model.APIKey
+		expectTouch   bool
+		expectedError error
+	}{
+		{
+			name:        "Success - Use is recorded",
+			secret:      secret,
+			key:         func() *model.APIKey { k := *issued; return &k },
+			expectTouch: true,
+		},
+		{
+			name:   "Success - Recent use is not recorded again",
+			secret: secret,
+			key:    func() *model.APIKey { k := *issued; k.LastUsedAt = &recently; return &k },
+		},
+		{
+			name:          "Error - Wrong secret",
+			secret:        "bsk_" + issued.KeyID + "_" + strings.Repeat("0", 64),
+			key:           func() *model.APIKey { k := *issued; return &k },
+			expectedError: service.ErrUnknownAPIKey,
+		},
+		{
+			name:          "Error - Not a key",
+			secret:        "not-a-key",
+			expectedError: service.ErrUnknownAPIKey,
+		},
+		{
+			name:          "Error - Revoked",
+			secret:        secret,
+			key:           func() *model.APIKey { k := *issued; k.RevokedAt = &earlier; return &k },
+			expectedError: service.ErrAPIKeyRevoked,
+		},
+		{
+			name:          "Error - Expired",
+			secret:        secret,
+			key:           func() *model.APIKey { k := *issued; k.ExpiresAt = &earlier; return &k },
+			expectedError: service.ErrAPIKeyExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+			if tc.key != nil {
+				mockAPIKeyRepo.On("GetByKeyID", mock.Anything, issued.KeyID).Return(tc.key(), nil)
+			}
+			mockAPIKeyRepo.On("TouchLastUsed", mock.Anything, int64(7), mock.Anything, mock.Anything).Return(nil).Maybe()
+			apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo, time.Hour, time.Hour)
+
+			key, err := apiKeyService.VerifyAPIKey(context.Background(), tc.secret)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, key)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, issued.KeyID, key.KeyID)
+			}
+			if tc.expectTouch {
+				mockAPIKeyRepo.AssertCalled(t, "TouchLastUsed", mock.Anything, int64(7), mock.Anything, mock.Anything)
+			} else {
+				mockAPIKeyRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+
+	t.Run("Error - Unknown key id", func(t *testing.T) {
+		mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+		mockAPIKeyRepo.On("GetByKeyID", mock.Anything, issued.KeyID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := service.NewAPIKeyService(mockAPIKeyRepo, time.Hour, time.Hour).VerifyAPIKey(context.Background(), secret)
+		assert.ErrorIs(t, err, service.ErrUnknownAPIKey)
+	})
+}
+
+func TestAPIKeyService_RotateAPIKey(t *testing.T) {
+	created := time.Now().Add(-24 * time.Hour)
+	inAMonth := time.Now().Add(30 * 24 * time.Hour)
+	inAnHour := time.Now().Add(time.Hour)
+	revoked := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		key           *model.APIKey
+		overlap       time.Duration
+		expectedError error
+		check         func(t *testing.T, replacement, previous *model.APIKey)
+	}{
+		{
+			name:    "Success - Previous key expires after the default overlap",
+			key:     &model.APIKey{Base: model.Base{ID: 3, CreatedAt: created}, KeyID: "aaaaaaaaaaaa", Name: "ERP", Roles: []string{"ops"}, Scopes: []string{"orders:read"}},
+			overlap: 0,
+			check: func(t *testing.T, replacement, previous *model.APIKey) {
+				assert.Equal(t, "ERP", replacement.Name)
+				assert.Equal(t, []string{"orders:read"}, replacement.Scopes)
+				require.NotNil(t, replacement.RotatedFromID)
+				assert.Equal(t, int64(3), *replacement.RotatedFromID)
+				assert.Nil(t, replacement.ExpiresAt)
+				assert.Equal(t, "admin-1", replacement.CreatedBy)
+				require.NotNil(t, previous.ExpiresAt)
+				assert.WithinDuration(t, time.Now().Add(24*time.Hour), *previous.ExpiresAt, time.Minute)
+			},
+		},
+		{
+			name:    "Success - Replacement keeps the lifetime of the previous key",
+			key:     &model.APIKey{Base: model.Base{ID: 3, CreatedAt: created}, KeyID: "aaaaaaaaaaaa", Name: "ERP", Roles: []string{"ops"}, ExpiresAt: &inAMonth},
+			overlap: 2 * time.Hour,
+			check: func(t *testing.T, replacement, previous *model.APIKey) {
+				require.NotNil(t, replacement.ExpiresAt)
+				assert.WithinDuration(t, time.Now().Add(inAMonth.Sub(created)), *replacement.ExpiresAt, time.Minute)
+				assert.WithinDuration(t, time.Now().Add(2*time.Hour), *previous.ExpiresAt, time.Minute)
+			},
+		},
+		{
+			name:    "Success - Overlap never extends the previous key",
+			key:     &model.APIKey{Base: model.Base{ID: 3, CreatedAt: created}, KeyID: "aaaaaaaaaaaa", Name: "ERP", Roles: []string{"ops"}, ExpiresAt: &inAnHour},
+			overlap: 48 * time.Hour,
+			check: func(t *testing.T, replacement, previous *model.APIKey) {
+				assert.Equal(t, inAnHour, *previous.ExpiresAt)
+			},
+		},
+		{
+			name:          "Error - Overlap above the maximum",
+			overlap:       8 * 24 * time.Hour,
+			expectedError: service.ErrInvalidAPIKey,
+		},
+		{
+			name:          "Error - Revoked key",
+			key:           &model.APIKey{Base: model.Base{ID: 3, CreatedAt: created}, KeyID: "aaaaaaaaaaaa", Name: "ERP", Roles: []string{"ops"}, RevokedAt: &revoked},
+			expectedError: service.ErrAPIKeyInactive,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+			if tc.key != nil {
+				mockAPIKeyRepo.On("GetByID", mock.Anything, tc.key.ID).Return(tc.key, nil)
+			}
+			var replacement, previous *model.APIKey
+			mockAPIKeyRepo.On("Rotate", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				replacement = args.Get(1).(*model.APIKey)
+				previous = args.Get(2).(*model.APIKey)
+			}).Maybe()
+			apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo, 24*time.Hour, 7*24*time.Hour)
+
+			key, secret, err := apiKeyService.RotateAPIKey(adminContext(), 3, tc.overlap)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockAPIKeyRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, replacement, key)
+			assert.NotEqual(t, tc.key.KeyID, key.KeyID)
+			assert.Equal(t, hashOf(secret), key.SecretHash)
+			tc.check(t, replacement, previous)
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	mockAPIKeyRepo.On("GetByID", mock.Anything, int64(3)).Return(&model.APIKey{Base: model.Base{ID: 3}, KeyID: "aaaaaaaaaaaa"}, nil)
+	mockAPIKeyRepo.On("GetByID", mock.Anything, int64(4)).Return(nil, gorm.ErrRecordNotFound)
+	mockAPIKeyRepo.On("Revoke", mock.Anything, int64(3), mock.Anything).Return(nil)
+	apiKeyService := service.NewAPIKeyService(mockAPIKeyRepo, time.Hour, time.Hour)
+
+	key, err := apiKeyService.RevokeAPIKey(adminContext(), 3)
+	require.NoError(t, err)
+	assert.NotNil(t, key.RevokedAt)
+
+	_, err = apiKeyService.RevokeAPIKey(adminContext(), 4)
+	assert.ErrorIs(t, err, service.ErrAPIKeyNotFound)
+}
+
+// hashOf returns the hash stored for a key
+func hashOf(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreditNoteService_CreateCreditNote(t *testing.T) {
+	invoice := &model.Invoice{
+		Base:       model.Base{ID: 10},
+		OrderID:    1,
+		ShipmentID: 101,
+		Items: []model.InvoiceItem{
+			{ItemID: 1, Quantity: 3},
+			{ItemID: 2, Quantity: 1},
+			{ItemID: 4, Quantity: 2},
+		},
+	}
+	order := &model.Order{
+		Base: model.Base{ID: 1},
+		Items: []model.OrderItem{
+			{ItemID: 1, Quantity: 3, UnitPrice: 40, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 50}},
+			{ItemID: 2, Quantity: 1, Item: model.Item{Base: model.Base{ID: 2}, Sku: "SKU002", Price: 25.5}},
+			{ItemID: 3, Quantity: 1, Item: model.Item{Base: model.Base{ID: 3}, Sku: "SKU003", Price: 10}},
+			{ItemID: 4, Quantity: 2, UnitPrice: 0, PriceRecorded: true, Item: model.Item{Base: model.Base{ID: 4}, Sku: "SKU004", Price: 30}},
+		},
+	}
+	earlier := model.CreditNote{
+		InvoiceID: 10,
+		Reference: "RMA-1",
+		Items:     []model.CreditNoteItem{{ItemID: 1, Quantity: 2}},
+	}
+
+	testCases := []struct {
+		name          string
+		reference     string
+		items         []dto.InvoiceItemRequest
+		mockSetup     func(*mocks.MockCreditNoteRepository, *mocks.MockInvoiceRepository, *mocks.MockOrderRepository)
+		expectedError error
+		checkNote     func(*testing.T, *model.CreditNote)
+	}{
+		{
+			name:      "Success - Items credited at their invoiced price",
+			reference: "RMA-2",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 1}, {Sku: "SKU002", Quantity: 1}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(invoice, nil)
+				creditNoteRepo.On("GetByReference", mock.Anything, "RMA-2").Return(nil, gorm.ErrRecordNotFound)
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(order, nil)
+				creditNoteRepo.On("ListByInvoiceID", mock.Anything, int64(10)).Return([]model.CreditNote{earlier}, nil)
+				creditNoteRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			checkNote: func(t *testing.T, creditNote *model.CreditNote) {
+				assert.Equal(t, int64(10), creditNote.InvoiceID)
+				assert.Equal(t, "RMA-2", creditNote.Reference)
+				assert.Equal(t, 65.5, creditNote.Amount)
+				require.Len(t, creditNote.Items, 2)
+				assert.Equal(t, 40.0, creditNote.Items[0].UnitPrice)
+				assert.Equal(t, 25.5, creditNote.Items[1].UnitPrice)
+			},
+		},
+		{
+			name:      "Success - Free line credited at zero rather than the catalog price",
+			reference: "RMA-3",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU004", Quantity: 2}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(invoice, nil)
+				creditNoteRepo.On("GetByReference", mock.Anything, "RMA-3").Return(nil, gorm.ErrRecordNotFound)
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(order, nil)
+				creditNoteRepo.On("ListByInvoiceID", mock.Anything, int64(10)).Return([]model.CreditNote{}, nil)
+				creditNoteRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			checkNote: func(t *testing.T, creditNote *model.CreditNote) {
+				assert.Equal(t, 0.0, creditNote.Amount)
+				require.Len(t, creditNote.Items, 1)
+				assert.Equal(t, 0.0, creditNote.Items[0].UnitPrice)
+			},
+		},
+		{
+			name:      "Success - Retried reference returns the issued note",
+			reference: "RMA-1",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 2}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(invoice, nil)
+				creditNoteRepo.On("GetByReference", mock.Anything, "RMA-1").Return(&earlier, nil)
+			},
+			checkNote: func(t *testing.T, creditNote *model.CreditNote) {
+				assert.Equal(t, "RMA-1", creditNote.Reference)
+			},
+		},
+		{
+			name:      "Error - Quantity above what is left to credit",
+			reference: "RMA-2",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 2}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(invoice, nil)
+				creditNoteRepo.On("GetByReference", mock.Anything, "RMA-2").Return(nil, gorm.ErrRecordNotFound)
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(order, nil)
+				creditNoteRepo.On("ListByInvoiceID", mock.Anything, int64(10)).Return([]model.CreditNote{earlier}, nil)
+			},
+			expectedError: service.ErrInvalidCreditNote,
+		},
+		{
+			name:      "Error - Item ordered but not on the invoice",
+			reference: "RMA-2",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU003", Quantity: 1}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(invoice, nil)
+				creditNoteRepo.On("GetByReference", mock.Anything, "RMA-2").Return(nil, gorm.ErrRecordNotFound)
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(order, nil)
+				creditNoteRepo.On("ListByInvoiceID", mock.Anything, int64(10)).Return([]model.CreditNote{}, nil)
+			},
+			expectedError: service.ErrInvalidCreditNote,
+		},
+		{
+			name:      "Error - Shipment was not invoiced",
+			reference: "RMA-2",
+			items:     []dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 1}},
+			mockSetup: func(creditNoteRepo *mocks.MockCreditNoteRepository, invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrInvoiceNotFound,
+		},
+		{
+			name:          "Error - Missing reference",
+			items:         []dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 1}},
+			mockSetup:     func(*mocks.MockCreditNoteRepository, *mocks.MockInvoiceRepository, *mocks.MockOrderRepository) {},
+			expectedError: service.ErrInvalidCreditNote,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creditNoteRepo := new(mocks.MockCreditNoteRepository)
+			invoiceRepo := new(mocks.MockInvoiceRepository)
+			orderRepo := new(mocks.MockOrderRepository)
+			customerRepo := new(mocks.MockCustomerRepository)
+			customerRepo.On("GetByCustomerID", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+			tc.mockSetup(creditNoteRepo, invoiceRepo, orderRepo)
+
+			creditNoteService := service.NewCreditNoteService(creditNoteRepo, invoiceRepo, orderRepo, customerRepo)
+			creditNote, err := creditNoteService.CreateCreditNote(context.Background(), 101, tc.reference, "damaged", tc.items)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, creditNote)
+			} else {
+				require.NoError(t, err)
+				tc.checkNote(t, creditNote)
+			}
+
+			creditNoteRepo.AssertExpectations(t)
+			invoiceRepo.AssertExpectations(t)
+			orderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreditNoteService_CreateCreditNote_ReleasesHold(t *testing.T) {
+	creditNoteRepo := new(mocks.MockCreditNoteRepository)
+	invoiceRepo := new(mocks.MockInvoiceRepository)
+	orderRepo := new(mocks.MockOrderRepository)
+	customerRepo := new(mocks.MockCustomerRepository)
+
+	invoiceRepo.On("GetByShipmentID", mock.Anything, int64(101)).Return(&model.Invoice{
+		Base: model.Base{ID: 10}, OrderID: 1, ShipmentID: 101, Items: []model.InvoiceItem{{ItemID: 1, Quantity: 1}},
+	}, nil)
+	creditNoteRepo.On("GetByReference", mock.Anything, "RMA-1").Return(nil, gorm.ErrRecordNotFound)
+	orderRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Order{
+		Base:       model.Base{ID: 1},
+		CustomerID: "customer-123",
+		Items:      []model.OrderItem{{ItemID: 1, Quantity: 1, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 50}}},
+	}, nil)
+	creditNoteRepo.On("ListByInvoiceID", mock.Anything, int64(10)).Return([]model.CreditNote{}, nil)
+	creditNoteRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	// The credit note settled the customer's only overdue invoice
+	customerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(&model.Customer{CustomerID: "customer-123", OnHold: true}, nil)
+	invoiceRepo.On("HasUnpaidDueBefore", mock.Anything, "customer-123", mock.AnythingOfType("time.Time")).Return(false, nil)
+	customerRepo.On("SetOnHold", mock.Anything, "customer-123", false).Return(nil)
+
+	creditNoteService := service.NewCreditNoteService(creditNoteRepo, invoiceRepo, orderRepo, customerRepo)
+	creditNote, err := creditNoteService.CreateCreditNote(context.Background(), 101, "RMA-1", "damaged",
+		[]dto.InvoiceItemRequest{{Sku: "SKU001", Quantity: 1}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, creditNote.Amount)
+	invoiceRepo.AssertExpectations(t)
+	customerRepo.AssertExpectations(t)
+}
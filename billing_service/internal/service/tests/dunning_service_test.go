@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/notifier"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestDunningService_ProcessUnpaidInvoices(t *testing.T) {
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	policy := service.DunningPolicy{
+		ReminderOffsetDays: []int{7, 0, 3},
+		EscalateAfterDays:  14,
+	}
+
+	newInvoice := func(id int64, daysOverdue int, dunningLevel int, paid float64) model.Invoice {
+		return model.Invoice{
+			Base:         model.Base{ID: id},
+			OrderID:      id * 10,
+			TotalAmount:  100,
+			PaidAmount:   paid,
+			DueDate:      now.AddDate(0, 0, -daysOverdue),
+			DunningLevel: dunningLevel,
+			Order:        &model.Order{Base: model.Base{ID: id * 10}, CustomerID: "customer-123"},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		invoices              []model.Invoice
+		mockSetup             func(*mocks.MockInvoiceRepository, *mocks.MockCustomerRepository)
+		expectedError         string
+		expectedNotifications []notifier.Notification
+	}{
+		{
+			name:     "Success - First reminder on the due date",
+			invoices: []model.Invoice{newInvoice(1, 0, 0, 0)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {
+				invoiceRepo.On("UpdateDunning", mock.Anything, mock.MatchedBy(func(invoice *model.Invoice) bool {
+					return invoice.ID == 1 && invoice.DunningLevel == 1 && invoice.LastReminderAt != nil
+				})).Return(nil)
+			},
+			expectedNotifications: []notifier.Notification{
+				{Kind: notifier.PaymentReminder, CustomerID: "customer-123", InvoiceID: 1, OrderID: 10, OutstandingAmount: 100, DaysOverdue: 0, Level: 1},
+			},
+		},
+		{
+			name:      "Success - Reminder already sent for the current offset",
+			invoices:  []model.Invoice{newInvoice(2, 5, 2, 0)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {},
+		},
+		{
+			name:      "Success - Fully paid invoice is skipped",
+			invoices:  []model.Invoice{newInvoice(3, 20, 0, 100)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {},
+		},
+		{
+			name:     "Success - Escalate customer to on hold",
+			invoices: []model.Invoice{newInvoice(4, 15, 3, 40)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {
+				customerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+				customerRepo.On("SetOnHold", mock.Anything, "customer-123", true).Return(nil)
+			},
+			expectedNotifications: []notifier.Notification{
+				{Kind: notifier.CustomerOnHold, CustomerID: "customer-123", InvoiceID: 4, OrderID: 40, OutstandingAmount: 60, DaysOverdue: 15, Level: 3},
+			},
+		},
+		{
+			name:     "Success - Customer already on hold is not escalated again",
+			invoices: []model.Invoice{newInvoice(5, 30, 3, 0)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {
+				customerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(&model.Customer{CustomerID: "customer-123", OnHold: true}, nil)
+			},
+		},
+		{
+			name:     "Success - Hold lifted once no invoice is overdue",
+			invoices: []model.Invoice{},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {
+				customerRepo.On("ListOnHold", mock.Anything).Return([]model.Customer{
+					{CustomerID: "customer-123", OnHold: true},
+					{CustomerID: "customer-456", OnHold: true},
+				}, nil)
+				invoiceRepo.On("HasUnpaidDueBefore", mock.Anything, "customer-123", now).Return(false, nil)
+				invoiceRepo.On("HasUnpaidDueBefore", mock.Anything, "customer-456", now).Return(true, nil)
+				customerRepo.On("SetOnHold", mock.Anything, "customer-123", false).Return(nil)
+			},
+		},
+		{
+			name:     "Error - Failure on one invoice does not stop the others",
+			invoices: []model.Invoice{newInvoice(6, 3, 1, 0), newInvoice(7, 3, 1, 0)},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, customerRepo *mocks.MockCustomerRepository) {
+				invoiceRepo.On("UpdateDunning", mock.Anything, mock.MatchedBy(func(invoice *model.Invoice) bool {
+					return invoice.ID == 6
+				})).Return(errors.New("database error"))
+				invoiceRepo.On("UpdateDunning", mock.Anything, mock.MatchedBy(func(invoice *model.Invoice) bool {
+					return invoice.ID == 7
+				})).Return(nil)
+			},
+			expectedError: "invoice 6: failed to update dunning level: database error",
+			expectedNotifications: []notifier.Notification{
+				{Kind: notifier.PaymentReminder, CustomerID: "customer-123", InvoiceID: 6, OrderID: 60, OutstandingAmount: 100, DaysOverdue: 3, Level: 2},
+				{Kind: notifier.PaymentReminder, CustomerID: "customer-123", InvoiceID: 7, OrderID: 70, OutstandingAmount: 100, DaysOverdue: 3, Level: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+			mockCustomerRepo := new(mocks.MockCustomerRepository)
+			memoryNotifier := notifier.NewInMemoryNotifier()
+
+			mockInvoiceRepo.On("ListUnpaidDueBefore", mock.Anything, now).Return(tc.invoices, nil)
+			tc.mockSetup(mockInvoiceRepo, mockCustomerRepo)
+			mockCustomerRepo.On("ListOnHold", mock.Anything).Return([]model.Customer{}, nil).Maybe()
+
+			dunningService := service.NewDunningService(mockInvoiceRepo, mockCustomerRepo, memoryNotifier, policy)
+
+			err := dunningService.ProcessUnpaidInvoices(context.Background(), now)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			notifications := memoryNotifier.Notifications()
+			assert.Len(t, notifications, len(tc.expectedNotifications))
+			for i, expected := range tc.expectedNotifications {
+				if i >= len(notifications) {
+					break
+				}
+				expected.DueDate = notifications[i].DueDate
+				assert.Equal(t, expected, notifications[i])
+			}
+
+			mockInvoiceRepo.AssertExpectations(t)
+			mockCustomerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDunningService_ProcessUnpaidInvoices_ReminderBeforeDueDate(t *testing.T) {
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+	mockCustomerRepo := new(mocks.MockCustomerRepository)
+	memoryNotifier := notifier.NewInMemoryNotifier()
+
+	// A reminder 2 days before the due date widens the query window by 2 days
+	mockInvoiceRepo.On("ListUnpaidDueBefore", mock.Anything, now.AddDate(0, 0, 2)).Return([]model.Invoice{
+		{
+			Base:        model.Base{ID: 1},
+			OrderID:     10,
+			TotalAmount: 100,
+			DueDate:     now.AddDate(0, 0, 1),
+			Order:       &model.Order{CustomerID: "customer-123"},
+		},
+	}, nil)
+	mockInvoiceRepo.On("UpdateDunning", mock.Anything, mock.AnythingOfType("*model.Invoice")).Return(nil)
+	mockCustomerRepo.On("ListOnHold", mock.Anything).Return([]model.Customer{}, nil)
+
+	dunningService := service.NewDunningService(mockInvoiceRepo, mockCustomerRepo, memoryNotifier, service.DunningPolicy{
+		ReminderOffsetDays: []int{-2, 0},
+	})
+
+	err := dunningService.ProcessUnpaidInvoices(context.Background(), now)
+
+	assert.NoError(t, err)
+	notifications := memoryNotifier.Notifications()
+	if assert.Len(t, notifications, 1) {
+		assert.Equal(t, notifier.PaymentReminder, notifications[0].Kind)
+		assert.Equal(t, 1, notifications[0].Level)
+		assert.Equal(t, -1, notifications[0].DaysOverdue)
+	}
+	mockInvoiceRepo.AssertExpectations(t)
+}
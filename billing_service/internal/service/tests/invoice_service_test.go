@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestInvoiceService_CreateInvoice(t *testing.T) {
@@ -25,6 +26,7 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 		shipmentID    int64
 		orderID       int64
 		itemRequests  []dto.InvoiceItemRequest
+		charges       []dto.InvoiceChargeRequest
 		mockSetup     func(*mocks.MockInvoiceRepository, *mocks.MockOrderRepository, *mocks.MockItemRepository)
 		expectedError string
 		checkInvoice  func(*testing.T, *model.Invoice)
@@ -193,6 +195,214 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 				assert.Equal(t, int64(1), invoice.Items[0].ItemID)
 			},
 		},
+		{
+			name:       "Success - Shipping fee billed as a charge",
+			shipmentID: 104,
+			orderID:    1,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 2},
+			},
+			charges: []dto.InvoiceChargeRequest{
+				{Type: model.ChargeShipping, Amount: 12.5},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Order{
+					Base: model.Base{ID: 1},
+					Items: []model.OrderItem{
+						{
+							ItemID:   1,
+							Quantity: 2,
+							Item: model.Item{
+								Base:  model.Base{ID: 1},
+								Name:  "Item 1",
+								Sku:   "SKU001",
+								Price: 100,
+							},
+						},
+					},
+				}, nil)
+
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{
+					Base:  model.Base{ID: 1},
+					Name:  "Item 1",
+					Sku:   "SKU001",
+					Price: 100,
+				}, nil)
+
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(1)).Return([]model.Invoice{}, nil)
+
+				invoiceRepo.On("Create", mock.Anything, mock.MatchedBy(func(invoice *model.Invoice) bool {
+					return invoice.TotalAmount == 212.5 && len(invoice.Charges) == 1
+				})).Return(nil)
+			},
+			expectedError: "",
+			checkInvoice: func(t *testing.T, invoice *model.Invoice) {
+				assert.Equal(t, 212.5, invoice.TotalAmount)
+				require.Len(t, invoice.Charges, 1)
+				assert.Equal(t, model.ChargeShipping, invoice.Charges[0].Type)
+				assert.Equal(t, "Shipping", invoice.Charges[0].Description)
+				assert.Equal(t, model.DefaultShippingTaxCategory, invoice.Charges[0].TaxCategory)
+			},
+		},
+		{
+			name:       "Error - Negative shipping fee",
+			shipmentID: 105,
+			orderID:    1,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 2},
+			},
+			charges: []dto.InvoiceChargeRequest{
+				{Type: model.ChargeShipping, Amount: -1},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Order{
+					Base: model.Base{ID: 1},
+					Items: []model.OrderItem{
+						{
+							ItemID:   1,
+							Quantity: 2,
+							Item: model.Item{
+								Base:  model.Base{ID: 1},
+								Name:  "Item 1",
+								Sku:   "SKU001",
+								Price: 100,
+							},
+						},
+					},
+				}, nil)
+
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{
+					Base:  model.Base{ID: 1},
+					Name:  "Item 1",
+					Sku:   "SKU001",
+					Price: 100,
+				}, nil)
+
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(1)).Return([]model.Invoice{}, nil)
+			},
+			expectedError: "charge amount must be a non-negative number",
+			checkInvoice:  nil,
+		},
+		{
+			name:       "Error - Unsupported charge type",
+			shipmentID: 106,
+			orderID:    1,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 2},
+			},
+			charges: []dto.InvoiceChargeRequest{
+				{Type: "HANDLING", Amount: 5},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Order{
+					Base: model.Base{ID: 1},
+					Items: []model.OrderItem{
+						{ItemID: 1, Quantity: 2, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 100}},
+					},
+				}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 100}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(1)).Return([]model.Invoice{}, nil)
+			},
+			expectedError: "invalid charge: unsupported charge type \"HANDLING\"",
+			checkInvoice:  nil,
+		},
+		{
+			name:       "Success - SKU ordered on two lines",
+			shipmentID: 104,
+			orderID:    4,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 3},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				// 2 at 10 and 2 at 20, the combined quantity is shippable as GetShippableQuantities reports it
+				orderRepo.On("GetByID", mock.Anything, int64(4)).Return(&model.Order{
+					Base: model.Base{ID: 4},
+					Items: []model.OrderItem{
+						{ItemID: 1, Quantity: 2, UnitPrice: 10, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 30}},
+						{ItemID: 1, Quantity: 2, UnitPrice: 20, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 30}},
+					},
+				}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 30}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(4)).Return([]model.Invoice{}, nil)
+				invoiceRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Invoice")).Return(nil)
+			},
+			checkInvoice: func(t *testing.T, invoice *model.Invoice) {
+				// Units are invoiced at the quantity-weighted price of the lines
+				assert.Equal(t, 45.0, invoice.TotalAmount)
+				require.Len(t, invoice.Items, 1)
+				assert.Equal(t, 3, invoice.Items[0].Quantity)
+			},
+		},
+		{
+			name:       "Success - Free line invoiced at its recorded price",
+			shipmentID: 106,
+			orderID:    5,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 1},
+				{Sku: "SKU002", Quantity: 1},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				// SKU001 was ordered for free, SKU002 on an order from before prices were recorded
+				orderRepo.On("GetByID", mock.Anything, int64(5)).Return(&model.Order{
+					Base: model.Base{ID: 5},
+					Items: []model.OrderItem{
+						{ItemID: 1, Quantity: 1, UnitPrice: 0, PriceRecorded: true, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+						{ItemID: 2, Quantity: 1, Item: model.Item{Base: model.Base{ID: 2}, Sku: "SKU002"}},
+					},
+				}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 30}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU002").Return(&model.Item{Base: model.Base{ID: 2}, Sku: "SKU002", Price: 12}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(5)).Return([]model.Invoice{}, nil)
+				invoiceRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Invoice")).Return(nil)
+			},
+			checkInvoice: func(t *testing.T, invoice *model.Invoice) {
+				assert.Equal(t, 12.0, invoice.TotalAmount)
+			},
+		},
+		{
+			name:       "Success - Line of a free contract tier invoiced at zero",
+			shipmentID: 107,
+			orderID:    6,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 2},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(6)).Return(&model.Order{
+					Base: model.Base{ID: 6},
+					Items: []model.OrderItem{
+						{ItemID: 1, Quantity: 2, UnitPrice: 0, PriceRecorded: true, PriceListCode: "contract-free", PriceTier: 1,
+							Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+					},
+				}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 30}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(6)).Return([]model.Invoice{}, nil)
+				invoiceRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Invoice")).Return(nil)
+			},
+			checkInvoice: func(t *testing.T, invoice *model.Invoice) {
+				assert.Equal(t, 0.0, invoice.TotalAmount)
+			},
+		},
+		{
+			name:       "Error - SKU requested twice beyond the quantity of its lines",
+			shipmentID: 105,
+			orderID:    4,
+			itemRequests: []dto.InvoiceItemRequest{
+				{Sku: "SKU001", Quantity: 3},
+				{Sku: "SKU001", Quantity: 2},
+			},
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(4)).Return(&model.Order{
+					Base: model.Base{ID: 4},
+					Items: []model.OrderItem{
+						{ItemID: 1, Quantity: 2, UnitPrice: 10, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+						{ItemID: 1, Quantity: 2, UnitPrice: 20, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+					},
+				}, nil)
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(4)).Return([]model.Invoice{}, nil)
+			},
+			expectedError: "requested quantity 2 for item SKU001 exceeds available quantity 1",
+		},
 		{
 			name:       "Error - Order not found",
 			shipmentID: 103,
@@ -344,10 +554,10 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 			tc.mockSetup(mockInvoiceRepo, mockOrderRepo, mockItemRepo)
 
 			// Create service with mocks
-			invoiceService := service.NewInvoiceService(mockInvoiceRepo, mockOrderRepo, mockItemRepo)
+			invoiceService := service.NewInvoiceService(mockInvoiceRepo, mockOrderRepo, mockItemRepo, new(mocks.MockCustomerRepository))
 
 			// Call the method being tested
-			invoice, err := invoiceService.CreateInvoice(context.Background(), tc.shipmentID, tc.orderID, tc.itemRequests)
+			invoice, err := invoiceService.CreateInvoice(context.Background(), tc.shipmentID, tc.orderID, tc.itemRequests, tc.charges)
 
 			// Check errors
 			if tc.expectedError != "" {
@@ -369,3 +579,295 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 		})
 	}
 }
+
+func TestInvoiceService_PayInvoice(t *testing.T) {
+	testCases := []struct {
+		name          string
+		invoiceID     int64
+		amount        float64
+		mockSetup     func(*mocks.MockInvoiceRepository)
+		expectedError error
+		expectedPaid  float64
+	}{
+		{
+			name:      "Success - Partial payment",
+			invoiceID: 1,
+			amount:    40,
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository) {
+				invoiceRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Invoice{
+					Base:        model.Base{ID: 1},
+					TotalAmount: 100,
+					PaidAmount:  10,
+				}, nil).Once()
+				invoiceRepo.On("AddPayment", mock.Anything, int64(1), 40.0).Return(true, nil)
+				invoiceRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Invoice{
+					Base:        model.Base{ID: 1},
+					TotalAmount: 100,
+					PaidAmount:  50,
+				}, nil).Once()
+			},
+			expectedPaid: 50,
+		},
+		{
+			name:      "Error - Credit note committed after the invoice was read",
+			invoiceID: 6,
+			amount:    60,
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository) {
+				// The read sees 60 outstanding, a credit note of 30 commits before the payment is added
+				invoiceRepo.On("GetByID", mock.Anything, int64(6)).Return(&model.Invoice{
+					Base:        model.Base{ID: 6},
+					TotalAmount: 100,
+					PaidAmount:  40,
+				}, nil)
+				invoiceRepo.On("AddPayment", mock.Anything, int64(6), 60.0).Return(false, nil)
+			},
+			expectedError: service.ErrInvalidAmount,
+		},
+		{
+			name:      "Error - Invoice not found",
+			invoiceID: 2,
+			amount:    10,
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository) {
+				invoiceRepo.On("GetByID", mock.Anything, int64(2)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrInvoiceNotFound,
+		},
+		{
+			name:      "Error - Invoice already paid",
+			invoiceID: 3,
+			amount:    10,
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository) {
+				invoiceRepo.On("GetByID", mock.Anything, int64(3)).Return(&model.Invoice{
+					Base:        model.Base{ID: 3},
+					TotalAmount: 100,
+					PaidAmount:  100,
+				}, nil)
+			},
+			expectedError: service.ErrInvoiceAlreadyPaid,
+		},
+		{
+			name:      "Error - Payment exceeds outstanding amount",
+			invoiceID: 4,
+			amount:    80,
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository) {
+				invoiceRepo.On("GetByID", mock.Anything, int64(4)).Return(&model.Invoice{
+					Base:        model.Base{ID: 4},
+					TotalAmount: 100,
+					PaidAmount:  50,
+				}, nil)
+			},
+			expectedError: service.ErrInvalidAmount,
+		},
+		{
+			name:          "Error - Non positive amount",
+			invoiceID:     5,
+			amount:        0,
+			mockSetup:     func(invoiceRepo *mocks.MockInvoiceRepository) {},
+			expectedError: service.ErrInvalidAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+			tc.mockSetup(mockInvoiceRepo)
+
+			invoiceService := service.NewInvoiceService(mockInvoiceRepo, new(mocks.MockOrderRepository), new(mocks.MockItemRepository), new(mocks.MockCustomerRepository))
+
+			invoice, err := invoiceService.PayInvoice(context.Background(), tc.invoiceID, tc.amount)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, invoice)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, invoice)
+				assert.Equal(t, tc.expectedPaid, invoice.PaidAmount)
+			}
+
+			mockInvoiceRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvoiceService_PayInvoice_ReleasesHold(t *testing.T) {
+	testCases := []struct {
+		name         string
+		overdueLeft  bool
+		expectedHold bool
+	}{
+		{name: "Success - Last overdue invoice paid", overdueLeft: false, expectedHold: false},
+		{name: "Success - Another invoice is still overdue", overdueLeft: true, expectedHold: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			mockCustomerRepo := new(mocks.MockCustomerRepository)
+
+			mockInvoiceRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Invoice{
+				Base: model.Base{ID: 1}, OrderID: 10, TotalAmount: 100, PaidAmount: 40,
+			}, nil).Once()
+			mockInvoiceRepo.On("AddPayment", mock.Anything, int64(1), 60.0).Return(true, nil)
+			mockInvoiceRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Invoice{
+				Base: model.Base{ID: 1}, OrderID: 10, TotalAmount: 100, PaidAmount: 100,
+			}, nil).Once()
+			mockOrderRepo.On("GetByID", mock.Anything, int64(10)).Return(&model.Order{Base: model.Base{ID: 10}, CustomerID: "customer-123"}, nil)
+			mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(&model.Customer{CustomerID: "customer-123", OnHold: true}, nil)
+			mockInvoiceRepo.On("HasUnpaidDueBefore", mock.Anything, "customer-123", mock.AnythingOfType("time.Time")).Return(tc.overdueLeft, nil)
+			if !tc.expectedHold {
+				mockCustomerRepo.On("SetOnHold", mock.Anything, "customer-123", false).Return(nil)
+			}
+
+			invoiceService := service.NewInvoiceService(mockInvoiceRepo, mockOrderRepo, new(mocks.MockItemRepository), mockCustomerRepo)
+
+			invoice, err := invoiceService.PayInvoice(context.Background(), 1, 60)
+
+			assert.NoError(t, err)
+			require.NotNil(t, invoice)
+			assert.True(t, invoice.IsPaid())
+			mockInvoiceRepo.AssertExpectations(t)
+			mockCustomerRepo.AssertExpectations(t)
+			if tc.expectedHold {
+				mockCustomerRepo.AssertNotCalled(t, "SetOnHold", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestInvoiceService_GetShippableQuantities(t *testing.T) {
+	order := &model.Order{
+		Base: model.Base{ID: 1},
+		Items: []model.OrderItem{
+			{ItemID: 1, Quantity: 3, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+			{ItemID: 2, Quantity: 1, Item: model.Item{Base: model.Base{ID: 2}, Sku: "SKU002"}},
+			{ItemID: 1, Quantity: 2, Item: model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		mockSetup          func(*mocks.MockInvoiceRepository, *mocks.MockOrderRepository)
+		expectedError      error
+		expectedQuantities []model.ShippableQuantity
+	}{
+		{
+			name: "Success - Remaining quantities after earlier invoices",
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(order, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(1)).Return([]model.Invoice{
+					{Items: []model.InvoiceItem{{ItemID: 1, Quantity: 2}}},
+					{Items: []model.InvoiceItem{{ItemID: 1, Quantity: 1}, {ItemID: 2, Quantity: 1}}},
+				}, nil)
+			},
+			expectedQuantities: []model.ShippableQuantity{
+				{Sku: "SKU001", ItemID: 1, Ordered: 5, Invoiced: 3, Remaining: 2},
+				{Sku: "SKU002", ItemID: 2, Ordered: 1, Invoiced: 1, Remaining: 0},
+			},
+		},
+		{
+			name: "Error - Order not found",
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			tc.mockSetup(mockInvoiceRepo, mockOrderRepo)
+
+			invoiceService := service.NewInvoiceService(mockInvoiceRepo, mockOrderRepo, new(mocks.MockItemRepository), new(mocks.MockCustomerRepository))
+			quantities, err := invoiceService.GetShippableQuantities(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedQuantities, quantities)
+			}
+
+			mockInvoiceRepo.AssertExpectations(t)
+			mockOrderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvoiceService_ListOrderInvoices(t *testing.T) {
+	invoices := []model.Invoice{
+		{Base: model.Base{ID: 10}, OrderID: 1, TotalAmount: 30},
+		{Base: model.Base{ID: 11}, OrderID: 1, TotalAmount: 20, PaidAmount: 20},
+	}
+
+	testCases := []struct {
+		name             string
+		mockSetup        func(*mocks.MockInvoiceRepository, *mocks.MockOrderRepository)
+		expectedError    error
+		expectedInvoices []model.Invoice
+	}{
+		{
+			name: "Success - Invoices of the order",
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(&model.Order{Base: model.Base{ID: 1}}, nil)
+				invoiceRepo.On("GetByOrderID", mock.Anything, int64(1)).Return(invoices, nil)
+			},
+			expectedInvoices: invoices,
+		},
+		{
+			name: "Error - Order not found",
+			mockSetup: func(invoiceRepo *mocks.MockInvoiceRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByID", mock.Anything, int64(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrOrderNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoiceRepo := new(mocks.MockInvoiceRepository)
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			tc.mockSetup(mockInvoiceRepo, mockOrderRepo)
+
+			invoiceService := service.NewInvoiceService(mockInvoiceRepo, mockOrderRepo, new(mocks.MockItemRepository), new(mocks.MockCustomerRepository))
+			result, err := invoiceService.ListOrderInvoices(context.Background(), 1)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedInvoices, result)
+			}
+
+			mockInvoiceRepo.AssertExpectations(t)
+			mockOrderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInvoice_PaymentStatus(t *testing.T) {
+	due := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	before, after := due.Add(-time.Hour), due.Add(time.Hour)
+
+	testCases := []struct {
+		name     string
+		invoice  model.Invoice
+		now      time.Time
+		expected model.InvoicePaymentStatus
+	}{
+		{name: "Nothing paid", invoice: model.Invoice{TotalAmount: 100, DueDate: due}, now: before, expected: model.InvoiceUnpaid},
+		{name: "Partly paid", invoice: model.Invoice{TotalAmount: 100, PaidAmount: 40, DueDate: due}, now: before, expected: model.InvoicePartiallyPaid},
+		{name: "Partly credited", invoice: model.Invoice{TotalAmount: 100, CreditedAmount: 40, DueDate: due}, now: before, expected: model.InvoicePartiallyPaid},
+		{name: "Paid and credited", invoice: model.Invoice{TotalAmount: 100, PaidAmount: 60, CreditedAmount: 40, DueDate: due}, now: after, expected: model.InvoicePaid},
+		{name: "Unpaid after the due date", invoice: model.Invoice{TotalAmount: 100, PaidAmount: 40, DueDate: due}, now: after, expected: model.InvoiceOverdue},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.invoice.PaymentStatus(tc.now))
+		})
+	}
+}
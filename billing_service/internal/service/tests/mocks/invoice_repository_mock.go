@@ -3,6 +3,7 @@ package mocks
 import (
 	"billing-system/billing_service/internal/model"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -23,4 +24,43 @@ func (m *MockInvoiceRepository) GetByOrderID(ctx context.Context, orderID int64)
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]model.Invoice), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (m *MockInvoiceRepository) GetByID(ctx context.Context, id int64) (*model.Invoice, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetByShipmentID(ctx context.Context, shipmentID int64) (*model.Invoice, error) {
+	args := m.Called(ctx, shipmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) ListUnpaidDueBefore(ctx context.Context, before time.Time) ([]model.Invoice, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) HasUnpaidDueBefore(ctx context.Context, customerID string, before time.Time) (bool, error) {
+	args := m.Called(ctx, customerID, before)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) AddPayment(ctx context.Context, id int64, amount float64) (bool, error) {
+	args := m.Called(ctx, id, amount)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) UpdateDunning(ctx context.Context, invoice *model.Invoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"billing-system/billing_service/internal/model"
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -36,4 +37,301 @@ func (m *MockItemRepository) GetBySku(ctx context.Context, sku string) (*model.I
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.Item), args.Error(1)
-}
\ No newline at end of file
+}
+
+// MockCustomerRepository is a mock implementation of repository.CustomerRepository
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) GetByCustomerID(ctx context.Context, customerID string) (*model.Customer, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) SetOnHold(ctx context.Context, customerID string, onHold bool) error {
+	args := m.Called(ctx, customerID, onHold)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) ListOnHold(ctx context.Context) ([]model.Customer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Customer), args.Error(1)
+}
+
+// MockPlanRepository is a mock implementation of repository.PlanRepository
+type MockPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlanRepository) Create(ctx context.Context, plan *model.Plan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) GetByCode(ctx context.Context, code string) (*model.Plan, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Plan), args.Error(1)
+}
+
+// MockSubscriptionRepository is a mock implementation of repository.SubscriptionRepository
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) Create(ctx context.Context, subscription *model.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Update(ctx context.Context, subscription *model.Subscription, from model.SubscriptionStatus, columns ...string) (bool, error) {
+	args := m.Called(ctx, subscription, from, columns)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) AdvancePeriod(ctx context.Context, subscription *model.Subscription, creditUsed float64) error {
+	args := m.Called(ctx, subscription, creditUsed)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.Subscription, error) {
+	args := m.Called(ctx, now, leaseUntil)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetOrCreatePeriod(ctx context.Context, period *model.SubscriptionPeriod) (*model.SubscriptionPeriod, error) {
+	args := m.Called(ctx, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionPeriod), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) UpdatePeriod(ctx context.Context, period *model.SubscriptionPeriod) error {
+	args := m.Called(ctx, period)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) GetOrCreatePendingPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (*model.SubscriptionPlanChange, error) {
+	args := m.Called(ctx, change)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionPlanChange), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) UpdatePlanChange(ctx context.Context, change *model.SubscriptionPlanChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) VoidPlanChange(ctx context.Context, change *model.SubscriptionPlanChange) (bool, error) {
+	args := m.Called(ctx, change)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) ApplyPlanChange(ctx context.Context, change *model.SubscriptionPlanChange, from model.SubscriptionStatus) (bool, error) {
+	args := m.Called(ctx, change, from)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockMeterRepository is a mock implementation of repository.MeterRepository
+type MockMeterRepository struct {
+	mock.Mock
+}
+
+func (m *MockMeterRepository) Create(ctx context.Context, meter *model.Meter) error {
+	args := m.Called(ctx, meter)
+	return args.Error(0)
+}
+
+func (m *MockMeterRepository) GetByCode(ctx context.Context, code string) (*model.Meter, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Meter), args.Error(1)
+}
+
+// MockUsageRepository is a mock implementation of repository.UsageRepository
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockUsageRepository) CreateRecord(ctx context.Context, record *model.UsageRecord) (bool, error) {
+	args := m.Called(ctx, record)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUsageRepository) ListPendingKeys(ctx context.Context, closedBefore time.Time) ([]model.UsageKey, error) {
+	args := m.Called(ctx, closedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UsageKey), args.Error(1)
+}
+
+func (m *MockUsageRepository) ListRecords(ctx context.Context, key model.UsageKey) ([]model.UsageRecord, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UsageRecord), args.Error(1)
+}
+
+func (m *MockUsageRepository) GetOrCreatePeriod(ctx context.Context, key model.UsageKey) (*model.UsagePeriod, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UsagePeriod), args.Error(1)
+}
+
+func (m *MockUsageRepository) ClosePeriod(ctx context.Context, period *model.UsagePeriod, recordIDs []int64) (bool, error) {
+	args := m.Called(ctx, period, recordIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUsageRepository) AddAdjustment(ctx context.Context, adjustment *model.UsageAdjustment, recordIDs []int64) (bool, error) {
+	args := m.Called(ctx, adjustment, recordIDs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUsageRepository) ClaimUninvoicedPeriod(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsagePeriod, error) {
+	args := m.Called(ctx, now, leaseUntil)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UsagePeriod), args.Error(1)
+}
+
+func (m *MockUsageRepository) ClaimUninvoicedAdjustment(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.UsageAdjustment, error) {
+	args := m.Called(ctx, now, leaseUntil)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UsageAdjustment), args.Error(1)
+}
+
+func (m *MockUsageRepository) UpdatePeriod(ctx context.Context, period *model.UsagePeriod) error {
+	args := m.Called(ctx, period)
+	return args.Error(0)
+}
+
+func (m *MockUsageRepository) UpdateAdjustment(ctx context.Context, adjustment *model.UsageAdjustment) error {
+	args := m.Called(ctx, adjustment)
+	return args.Error(0)
+}
+
+// MockPriceListRepository is a mock implementation of repository.PriceListRepository
+type MockPriceListRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceListRepository) Create(ctx context.Context, priceList *model.PriceList) error {
+	args := m.Called(ctx, priceList)
+	return args.Error(0)
+}
+
+func (m *MockPriceListRepository) ListEffectiveEntries(ctx context.Context, itemID int64, customerID string, at time.Time) ([]model.PriceListEntry, error) {
+	args := m.Called(ctx, itemID, customerID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PriceListEntry), args.Error(1)
+}
+
+// MockCreditNoteRepository is a mock implementation of repository.CreditNoteRepository
+type MockCreditNoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditNoteRepository) Create(ctx context.Context, creditNote *model.CreditNote) error {
+	args := m.Called(ctx, creditNote)
+	return args.Error(0)
+}
+
+func (m *MockCreditNoteRepository) GetByReference(ctx context.Context, reference string) (*model.CreditNote, error) {
+	args := m.Called(ctx, reference)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreditNote), args.Error(1)
+}
+
+func (m *MockCreditNoteRepository) ListByInvoiceID(ctx context.Context, invoiceID int64) ([]model.CreditNote, error) {
+	args := m.Called(ctx, invoiceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CreditNote), args.Error(1)
+}
+
+// MockAPIKeyRepository is a mock implementation of repository.APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *model.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByKeyID(ctx context.Context, keyID string) (*model.APIKey, error) {
+	args := m.Called(ctx, keyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context, customerID string) ([]model.APIKey, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Rotate(ctx context.Context, replacement *model.APIKey, previous *model.APIKey) error {
+	args := m.Called(ctx, replacement, previous)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time, before time.Time) error {
+	args := m.Called(ctx, id, at, before)
+	return args.Error(0)
+}
@@ -0,0 +1,92 @@
+package mocks
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderService is a mock implementation of service.OrderService
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) CreateOrder(ctx context.Context, customerID string, items []dto.ItemRequest, payments []dto.PaymentRequest) (*model.Order, error) {
+	args := m.Called(ctx, customerID, items, payments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Order), args.Error(1)
+}
+
+func (m *MockOrderService) CreateOrderFromQuote(ctx context.Context, customerID string, quoteToken string, payments []dto.PaymentRequest) (*model.Order, error) {
+	args := m.Called(ctx, customerID, quoteToken, payments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Order), args.Error(1)
+}
+
+func (m *MockOrderService) CreateBillingOrder(ctx context.Context, customerID string, items []dto.ItemRequest, payments []dto.PaymentRequest) (*model.Order, error) {
+	args := m.Called(ctx, customerID, items, payments)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Order), args.Error(1)
+}
+
+func (m *MockOrderService) QuoteOrder(ctx context.Context, customerID string, items []dto.ItemRequest, lock time.Duration) (*model.Quote, error) {
+	args := m.Called(ctx, customerID, items, lock)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Quote), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrderByID(ctx context.Context, id int64) (*model.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Order), args.Error(1)
+}
+
+// MockInvoiceService is a mock implementation of service.InvoiceService
+type MockInvoiceService struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceService) CreateInvoice(ctx context.Context, shipmentId int64, orderId int64, itemRequest []dto.InvoiceItemRequest, chargeRequests []dto.InvoiceChargeRequest) (*model.Invoice, error) {
+	args := m.Called(ctx, shipmentId, orderId, itemRequest, chargeRequests)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceService) PayInvoice(ctx context.Context, invoiceID int64, amount float64) (*model.Invoice, error) {
+	args := m.Called(ctx, invoiceID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceService) GetShippableQuantities(ctx context.Context, orderID int64) ([]model.ShippableQuantity, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ShippableQuantity), args.Error(1)
+}
+
+func (m *MockInvoiceService) ListOrderInvoices(ctx context.Context, orderID int64) ([]model.Invoice, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Invoice), args.Error(1)
+}
@@ -5,6 +5,7 @@ import (
 	"billing-system/billing_service/internal/model"
 	"billing-system/billing_service/internal/service"
 	"billing-system/billing_service/internal/service/tests/mocks"
+	"billing-system/pkg/auth"
 	"context"
 	"errors"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestOrderService_CreateOrder(t *testing.T) {
@@ -198,12 +200,19 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			// Create mocks
 			mockOrderRepo := new(mocks.MockOrderRepository)
 			mockItemRepo := new(mocks.MockItemRepository)
+			mockCustomerRepo := new(mocks.MockCustomerRepository)
 
 			// Set up mocks
 			tc.mockSetup(mockOrderRepo, mockItemRepo)
+			mockCustomerRepo.On("GetByCustomerID", mock.Anything, tc.customerID).Return(nil, gorm.ErrRecordNotFound)
+
+			// Items are on no price list, so the catalog price applies
+			mockPriceListRepo := new(mocks.MockPriceListRepository)
+			mockPriceListRepo.On("ListEffectiveEntries", mock.Anything, mock.Anything, tc.customerID, mock.Anything).Return([]model.PriceListEntry{}, nil).Maybe()
+			pricingService := service.NewPricingService(mockItemRepo, mockPriceListRepo, mockCustomerRepo)
 
 			// Create service with mocks
-			orderService := service.NewOrderService(mockOrderRepo, mockItemRepo)
+			orderService := service.NewOrderService(mockOrderRepo, pricingService, mockCustomerRepo, model.Net30, nil, 0)
 
 			// Call the method being tested
 			order, err := orderService.CreateOrder(context.Background(), tc.customerID, tc.itemRequests, tc.paymentRequests)
@@ -227,3 +236,265 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderService_CreateOrder_RecordsAPIKey(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockItemRepo := new(mocks.MockItemRepository)
+	mockCustomerRepo := new(mocks.MockCustomerRepository)
+	mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+	mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Order")).Return(nil)
+	pricingService := service.NewPricingService(mockItemRepo, new(mocks.MockPriceListRepository), mockCustomerRepo)
+	orderService := service.NewOrderService(mockOrderRepo, pricingService, mockCustomerRepo, model.Net30, nil, 0)
+
+	// The ERP authenticated with an API key, its token carries the key id
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "apikey:3f9a1c2b7d4e", APIKeyID: "3f9a1c2b7d4e", Roles: []auth.Role{auth.RoleOps}}, "token")
+	order, err := orderService.CreateOrder(ctx, "customer-123", nil, []dto.PaymentRequest{{Method: "COD", Amount: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, "3f9a1c2b7d4e", order.APIKeyID)
+
+	// Orders of users record no key
+	order, err = orderService.CreateOrder(context.Background(), "customer-123", nil, []dto.PaymentRequest{{Method: "COD", Amount: 0}})
+	require.NoError(t, err)
+	assert.Empty(t, order.APIKeyID)
+}
+
+func TestOrderService_CreateOrder_PaymentTerm(t *testing.T) {
+	testCases := []struct {
+		name          string
+		customer      *model.Customer
+		customerErr   error
+		expectedTerm  model.PaymentTerm
+		expectedError error
+	}{
+		{
+			name:         "Success - Customer without settings gets the default term",
+			customerErr:  gorm.ErrRecordNotFound,
+			expectedTerm: model.Net15,
+		},
+		{
+			name:         "Success - Customer term is recorded on the order",
+			customer:     &model.Customer{CustomerID: "customer-123", PaymentTerm: model.Net7},
+			expectedTerm: model.Net7,
+		},
+		{
+			name:          "Error - Customer on hold cannot order",
+			customer:      &model.Customer{CustomerID: "customer-123", PaymentTerm: model.Net7, OnHold: true},
+			expectedError: service.ErrCustomerOnHold,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			mockItemRepo := new(mocks.MockItemRepository)
+			mockCustomerRepo := new(mocks.MockCustomerRepository)
+
+			if tc.customer != nil {
+				mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(tc.customer, nil)
+			} else {
+				mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, tc.customerErr)
+			}
+			if tc.expectedError == nil {
+				mockOrderRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Order")).Return(nil)
+			}
+
+			pricingService := service.NewPricingService(mockItemRepo, new(mocks.MockPriceListRepository), mockCustomerRepo)
+			orderService := service.NewOrderService(mockOrderRepo, pricingService, mockCustomerRepo, model.Net15, nil, 0)
+
+			order, err := orderService.CreateOrder(context.Background(), "customer-123", nil, nil)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				require.NotNil(t, order)
+				assert.Equal(t, tc.expectedTerm, order.PaymentTerm)
+			}
+
+			mockOrderRepo.AssertExpectations(t)
+			mockCustomerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_CreateBillingOrder_CustomerOnHold(t *testing.T) {
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockItemRepo := new(mocks.MockItemRepository)
+	mockCustomerRepo := new(mocks.MockCustomerRepository)
+	mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").
+		Return(&model.Customer{CustomerID: "customer-123", PaymentTerm: model.Net7, OnHold: true}, nil)
+	mockItemRepo.On("GetBySku", mock.Anything, "API").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "API", Price: 1}, nil)
+	mockOrderRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.TotalAmount == 125 && len(order.Items) == 1 && order.Items[0].UnitPrice == 125
+	})).Return(nil)
+
+	pricingService := service.NewPricingService(mockItemRepo, new(mocks.MockPriceListRepository), mockCustomerRepo)
+	orderService := service.NewOrderService(mockOrderRepo, pricingService, mockCustomerRepo, model.Net15, nil, 0)
+	amount := 125.0
+	items := []dto.ItemRequest{{Sku: "API", Quantity: 1, UnitPrice: &amount}}
+	payments := []dto.PaymentRequest{{Method: model.COD, Amount: amount}}
+
+	// The hold stops purchases
+	order, err := orderService.CreateOrder(context.Background(), "customer-123", items, payments)
+	assert.ErrorIs(t, err, service.ErrCustomerOnHold)
+	assert.Nil(t, order)
+
+	// but not the billing of what the customer already owes
+	order, err = orderService.CreateBillingOrder(context.Background(), "customer-123", items, payments)
+	assert.NoError(t, err)
+	require.NotNil(t, order)
+	assert.Equal(t, model.Net7, order.PaymentTerm)
+
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func TestOrderService_QuoteOrder(t *testing.T) {
+	newOrderService := func(orderRepo *mocks.MockOrderRepository, itemRepo *mocks.MockItemRepository, customerRepo *mocks.MockCustomerRepository) service.OrderService {
+		priceListRepo := new(mocks.MockPriceListRepository)
+		priceListRepo.On("ListEffectiveEntries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.PriceListEntry{}, nil).Maybe()
+		pricingService := service.NewPricingService(itemRepo, priceListRepo, customerRepo)
+		return service.NewOrderService(orderRepo, pricingService, customerRepo, model.Net30, []byte("secret"), 30*time.Minute)
+	}
+	items := []dto.ItemRequest{{Sku: "SKU001", Quantity: 3}}
+
+	t.Run("Success - Quote without lock stores nothing", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockItemRepo := new(mocks.MockItemRepository)
+		mockCustomerRepo := new(mocks.MockCustomerRepository)
+		mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+		mockItemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 25}, nil)
+
+		quote, err := newOrderService(mockOrderRepo, mockItemRepo, mockCustomerRepo).QuoteOrder(context.Background(), "customer-123", items, 0)
+
+		assert.NoError(t, err)
+		require.NotNil(t, quote)
+		require.Len(t, quote.Lines, 1)
+		assert.Equal(t, 75.0, quote.Lines[0].LineTotal)
+		assert.Equal(t, 75.0, quote.TotalAmount)
+		assert.Empty(t, quote.Token)
+		assert.Nil(t, quote.ExpiresAt)
+		mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Locked quote keeps its prices", func(t *testing.T) {
+		mockOrderRepo := new(mocks.MockOrderRepository)
+		mockItemRepo := new(mocks.MockItemRepository)
+		mockCustomerRepo := new(mocks.MockCustomerRepository)
+		mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+		mockItemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 25}, nil).Once()
+		mockOrderRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+			return order.TotalAmount == 75 && len(order.Items) == 1 && order.Items[0].UnitPrice == 25
+		})).Return(nil)
+		orderService := newOrderService(mockOrderRepo, mockItemRepo, mockCustomerRepo)
+
+		quote, err := orderService.QuoteOrder(context.Background(), "customer-123", items, 15*time.Minute)
+		require.NoError(t, err)
+		require.NotEmpty(t, quote.Token)
+		require.NotNil(t, quote.ExpiresAt)
+
+		// The catalog is not read again, so a price change after the quote does not apply
+		order, err := orderService.CreateOrderFromQuote(context.Background(), "customer-123", quote.Token,
+			[]dto.PaymentRequest{{Method: model.COD, Amount: 75}})
+
+		assert.NoError(t, err)
+		require.NotNil(t, order)
+		assert.Equal(t, model.Net30, order.PaymentTerm)
+		mockItemRepo.AssertExpectations(t)
+		mockOrderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Lock longer than allowed", func(t *testing.T) {
+		quote, err := newOrderService(new(mocks.MockOrderRepository), new(mocks.MockItemRepository), new(mocks.MockCustomerRepository)).
+			QuoteOrder(context.Background(), "customer-123", items, time.Hour)
+
+		assert.ErrorIs(t, err, service.ErrInvalidQuote)
+		assert.Nil(t, quote)
+	})
+
+	t.Run("Error - Customer on hold is not quoted", func(t *testing.T) {
+		mockCustomerRepo := new(mocks.MockCustomerRepository)
+		mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(&model.Customer{CustomerID: "customer-123", OnHold: true}, nil)
+
+		quote, err := newOrderService(new(mocks.MockOrderRepository), new(mocks.MockItemRepository), mockCustomerRepo).
+			QuoteOrder(context.Background(), "customer-123", items, 0)
+
+		assert.ErrorIs(t, err, service.ErrCustomerOnHold)
+		assert.Nil(t, quote)
+	})
+}
+
+func TestOrderService_CreateOrderFromQuote(t *testing.T) {
+	mockItemRepo := new(mocks.MockItemRepository)
+	mockCustomerRepo := new(mocks.MockCustomerRepository)
+	mockCustomerRepo.On("GetByCustomerID", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	mockItemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 25}, nil)
+	priceListRepo := new(mocks.MockPriceListRepository)
+	priceListRepo.On("ListEffectiveEntries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.PriceListEntry{}, nil)
+	pricingService := service.NewPricingService(mockItemRepo, priceListRepo, mockCustomerRepo)
+
+	issuer := service.NewOrderService(new(mocks.MockOrderRepository), pricingService, mockCustomerRepo, model.Net30, []byte("secret"), 30*time.Minute)
+	quote, err := issuer.QuoteOrder(context.Background(), "customer-123", []dto.ItemRequest{{Sku: "SKU001", Quantity: 1}}, 10*time.Minute)
+	require.NoError(t, err)
+	expired, err := issuer.QuoteOrder(context.Background(), "customer-123", []dto.ItemRequest{{Sku: "SKU001", Quantity: 1}}, time.Nanosecond)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		secret        string
+		customerID    string
+		token         string
+		payments      []dto.PaymentRequest
+		expectedError error
+	}{
+		{
+			name:          "Error - Token signed with another secret",
+			secret:        "other",
+			customerID:    "customer-123",
+			token:         quote.Token,
+			expectedError: service.ErrInvalidQuote,
+		},
+		{
+			name:          "Error - Malformed token",
+			secret:        "secret",
+			customerID:    "customer-123",
+			token:         "not-a-token",
+			expectedError: service.ErrInvalidQuote,
+		},
+		{
+			name:          "Error - Quote of another customer",
+			secret:        "secret",
+			customerID:    "customer-456",
+			token:         quote.Token,
+			expectedError: service.ErrInvalidQuote,
+		},
+		{
+			name:          "Error - Expired quote",
+			secret:        "secret",
+			customerID:    "customer-123",
+			token:         expired.Token,
+			expectedError: service.ErrQuoteExpired,
+		},
+		{
+			name:          "Error - Payments do not cover the quote",
+			secret:        "secret",
+			customerID:    "customer-123",
+			token:         quote.Token,
+			payments:      []dto.PaymentRequest{{Method: model.COD, Amount: 10}},
+			expectedError: service.ErrInvalidAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			orderService := service.NewOrderService(mockOrderRepo, pricingService, mockCustomerRepo, model.Net30, []byte(tc.secret), 30*time.Minute)
+
+			order, err := orderService.CreateOrderFromQuote(context.Background(), tc.customerID, tc.token, tc.payments)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, order)
+			mockOrderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
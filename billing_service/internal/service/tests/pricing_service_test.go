@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPricingService_PriceItems(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	item := &model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 100}
+
+	newEntry := func(code string, kind model.PriceListKind, validFrom time.Time, tiers model.PriceTiers) model.PriceListEntry {
+		return model.PriceListEntry{
+			ItemID: item.ID,
+			Tiers:  tiers,
+			PriceList: &model.PriceList{
+				Code:      code,
+				Kind:      kind,
+				ValidFrom: validFrom,
+			},
+		}
+	}
+
+	retail := newEntry("retail-2025", model.PriceListRetail, at.AddDate(0, -2, 0), model.PriceTiers{{UpTo: 9, UnitPrice: 95}, {UnitPrice: 90}})
+	wholesale := newEntry("wholesale-2025", model.PriceListWholesale, at.AddDate(0, -2, 0), model.PriceTiers{{UnitPrice: 70}})
+	contract := newEntry("contract-acme", model.PriceListContract, at.AddDate(0, -1, 0), model.PriceTiers{{UnitPrice: 60}})
+	newerRetail := newEntry("retail-spring", model.PriceListRetail, at.AddDate(0, 0, -7), model.PriceTiers{{UnitPrice: 85}})
+	freeContract := newEntry("contract-free", model.PriceListContract, at.AddDate(0, -1, 0), model.PriceTiers{{UnitPrice: 0}})
+
+	testCases := []struct {
+		name          string
+		customer      *model.Customer
+		request       dto.ItemRequest
+		entries       []model.PriceListEntry
+		expectedPrice float64
+		expectedList  string
+		expectedTier  int
+	}{
+		{
+			name:          "Success - Catalog price without price lists",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{},
+			expectedPrice: 100,
+		},
+		{
+			name:          "Success - Retail price of the first tier",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 9},
+			entries:       []model.PriceListEntry{retail},
+			expectedPrice: 95,
+			expectedList:  "retail-2025",
+			expectedTier:  1,
+		},
+		{
+			name:          "Success - Retail price of the second tier",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 10},
+			entries:       []model.PriceListEntry{retail},
+			expectedPrice: 90,
+			expectedList:  "retail-2025",
+			expectedTier:  2,
+		},
+		{
+			name:          "Success - Wholesale list ignored for retail customers",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{wholesale, retail},
+			expectedPrice: 95,
+			expectedList:  "retail-2025",
+			expectedTier:  1,
+		},
+		{
+			name:          "Success - Wholesale list for wholesale customers",
+			customer:      &model.Customer{CustomerID: "customer-123", Segment: model.SegmentWholesale},
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{retail, wholesale},
+			expectedPrice: 70,
+			expectedList:  "wholesale-2025",
+			expectedTier:  1,
+		},
+		{
+			name:          "Success - Contract wins over every other list",
+			customer:      &model.Customer{CustomerID: "customer-123", Segment: model.SegmentWholesale},
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{retail, contract, wholesale},
+			expectedPrice: 60,
+			expectedList:  "contract-acme",
+			expectedTier:  1,
+		},
+		{
+			name:          "Success - Most recently effective list of the same kind wins",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{retail, newerRetail},
+			expectedPrice: 85,
+			expectedList:  "retail-spring",
+			expectedTier:  1,
+		},
+		{
+			name:          "Success - Free contract tier is not replaced by the catalog price",
+			request:       dto.ItemRequest{Sku: "SKU001", Quantity: 1},
+			entries:       []model.PriceListEntry{retail, freeContract},
+			expectedPrice: 0,
+			expectedList:  "contract-free",
+			expectedTier:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockItemRepo := new(mocks.MockItemRepository)
+			mockPriceListRepo := new(mocks.MockPriceListRepository)
+			mockCustomerRepo := new(mocks.MockCustomerRepository)
+
+			if tc.customer != nil {
+				mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(tc.customer, nil)
+			} else {
+				mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+			}
+			mockItemRepo.On("GetBySku", mock.Anything, "SKU001").Return(item, nil)
+			mockPriceListRepo.On("ListEffectiveEntries", mock.Anything, item.ID, "customer-123", at).Return(tc.entries, nil)
+
+			pricingService := service.NewPricingService(mockItemRepo, mockPriceListRepo, mockCustomerRepo)
+
+			priced, err := pricingService.PriceItems(context.Background(), "customer-123", []dto.ItemRequest{tc.request}, at)
+
+			assert.NoError(t, err)
+			require.Len(t, priced, 1)
+			assert.Equal(t, tc.expectedPrice, priced[0].UnitPrice)
+			assert.Equal(t, tc.expectedList, priced[0].PriceListCode)
+			assert.Equal(t, tc.expectedTier, priced[0].PriceTier)
+			assert.Equal(t, tc.request.Quantity, priced[0].Quantity)
+
+			mockItemRepo.AssertExpectations(t)
+			mockPriceListRepo.AssertExpectations(t)
+			mockCustomerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPricingService_PriceItems_UnitPriceOverride(t *testing.T) {
+	mockItemRepo := new(mocks.MockItemRepository)
+	mockPriceListRepo := new(mocks.MockPriceListRepository)
+	mockCustomerRepo := new(mocks.MockCustomerRepository)
+
+	mockCustomerRepo.On("GetByCustomerID", mock.Anything, "customer-123").Return(nil, gorm.ErrRecordNotFound)
+	mockItemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001", Price: 100}, nil)
+
+	pricingService := service.NewPricingService(mockItemRepo, mockPriceListRepo, mockCustomerRepo)
+
+	override := 12.5
+	priced, err := pricingService.PriceItems(context.Background(), "customer-123",
+		[]dto.ItemRequest{{Sku: "SKU001", Quantity: 1, UnitPrice: &override}}, time.Now())
+
+	assert.NoError(t, err)
+	require.Len(t, priced, 1)
+	assert.Equal(t, 12.5, priced[0].UnitPrice)
+	assert.Empty(t, priced[0].PriceListCode)
+
+	// Price lists are not consulted for overridden prices
+	mockPriceListRepo.AssertNotCalled(t, "ListEffectiveEntries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPricingService_CreatePriceList(t *testing.T) {
+	validFrom := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	beforeValidFrom := validFrom.AddDate(0, 0, -1)
+	entries := []dto.PriceListEntryRequest{{Sku: "SKU001", Tiers: model.PriceTiers{{UpTo: 10, UnitPrice: 95}, {UnitPrice: 90}}}}
+
+	testCases := []struct {
+		name          string
+		priceList     model.PriceList
+		entries       []dto.PriceListEntryRequest
+		mockSetup     func(*mocks.MockItemRepository, *mocks.MockPriceListRepository)
+		expectedError error
+	}{
+		{
+			name:      "Success - Contract price list",
+			priceList: model.PriceList{Code: "contract-acme", Kind: model.PriceListContract, CustomerID: "customer-123", ValidFrom: validFrom},
+			entries:   entries,
+			mockSetup: func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}, nil)
+				priceListRepo.On("Create", mock.Anything, mock.MatchedBy(func(priceList *model.PriceList) bool {
+					return len(priceList.Entries) == 1 && priceList.Entries[0].ItemID == 1
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "Error - Contract without customer",
+			priceList:     model.PriceList{Code: "contract-acme", Kind: model.PriceListContract, ValidFrom: validFrom},
+			entries:       entries,
+			mockSetup:     func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {},
+			expectedError: service.ErrInvalidPriceList,
+		},
+		{
+			name:          "Error - Retail list with a customer",
+			priceList:     model.PriceList{Code: "retail", Kind: model.PriceListRetail, CustomerID: "customer-123", ValidFrom: validFrom},
+			entries:       entries,
+			mockSetup:     func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {},
+			expectedError: service.ErrInvalidPriceList,
+		},
+		{
+			name:          "Error - Ends before it starts",
+			priceList:     model.PriceList{Code: "retail", Kind: model.PriceListRetail, ValidFrom: validFrom, ValidTo: &beforeValidFrom},
+			entries:       entries,
+			mockSetup:     func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {},
+			expectedError: service.ErrInvalidPriceList,
+		},
+		{
+			name:      "Error - Item listed twice",
+			priceList: model.PriceList{Code: "retail", Kind: model.PriceListRetail, ValidFrom: validFrom},
+			entries:   append(append([]dto.PriceListEntryRequest{}, entries...), entries...),
+			mockSetup: func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}, nil)
+			},
+			expectedError: service.ErrInvalidPriceList,
+		},
+		{
+			name:      "Error - Unknown item",
+			priceList: model.PriceList{Code: "retail", Kind: model.PriceListRetail, ValidFrom: validFrom},
+			entries:   entries,
+			mockSetup: func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrItemNotFound,
+		},
+		{
+			name:      "Error - Database error",
+			priceList: model.PriceList{Code: "retail", Kind: model.PriceListRetail, ValidFrom: validFrom},
+			entries:   entries,
+			mockSetup: func(itemRepo *mocks.MockItemRepository, priceListRepo *mocks.MockPriceListRepository) {
+				itemRepo.On("GetBySku", mock.Anything, "SKU001").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "SKU001"}, nil)
+				priceListRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.PriceList")).Return(errors.New("database error"))
+			},
+			expectedError: errors.New("failed to create price list: database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockItemRepo := new(mocks.MockItemRepository)
+			mockPriceListRepo := new(mocks.MockPriceListRepository)
+			tc.mockSetup(mockItemRepo, mockPriceListRepo)
+
+			pricingService := service.NewPricingService(mockItemRepo, mockPriceListRepo, new(mocks.MockCustomerRepository))
+
+			priceList, err := pricingService.CreatePriceList(context.Background(), &tc.priceList, tc.entries)
+
+			if tc.expectedError != nil {
+				assert.ErrorContains(t, err, tc.expectedError.Error())
+				assert.Nil(t, priceList)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, priceList)
+			}
+
+			mockItemRepo.AssertExpectations(t)
+			mockPriceListRepo.AssertExpectations(t)
+		})
+	}
+}
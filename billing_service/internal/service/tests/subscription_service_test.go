@@ -0,0 +1,601 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type subscriptionMocks struct {
+	subscriptionRepo *mocks.MockSubscriptionRepository
+	planRepo         *mocks.MockPlanRepository
+	itemRepo         *mocks.MockItemRepository
+	orderService     *mocks.MockOrderService
+	invoiceService   *mocks.MockInvoiceService
+}
+
+func newSubscriptionMocks() *subscriptionMocks {
+	return &subscriptionMocks{
+		subscriptionRepo: new(mocks.MockSubscriptionRepository),
+		planRepo:         new(mocks.MockPlanRepository),
+		itemRepo:         new(mocks.MockItemRepository),
+		orderService:     new(mocks.MockOrderService),
+		invoiceService:   new(mocks.MockInvoiceService),
+	}
+}
+
+func (m *subscriptionMocks) service() service.SubscriptionService {
+	return service.NewSubscriptionService(m.subscriptionRepo, m.planRepo, m.itemRepo, m.orderService, m.invoiceService, time.Minute)
+}
+
+func (m *subscriptionMocks) assertExpectations(t *testing.T) {
+	m.subscriptionRepo.AssertExpectations(t)
+	m.planRepo.AssertExpectations(t)
+	m.itemRepo.AssertExpectations(t)
+	m.orderService.AssertExpectations(t)
+	m.invoiceService.AssertExpectations(t)
+}
+
+var (
+	basicPlan = model.Plan{Base: model.Base{ID: 1}, Code: "basic", Sku: "BASIC", Price: 10, Interval: model.IntervalMonth, IntervalCount: 1}
+	proPlan   = model.Plan{Base: model.Base{ID: 2}, Code: "pro", Sku: "PRO", Price: 30, Interval: model.IntervalMonth, IntervalCount: 1}
+)
+
+func TestProrate(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 30)
+
+	testCases := []struct {
+		name     string
+		oldPrice float64
+		newPrice float64
+		at       time.Time
+		expected float64
+	}{
+		{name: "Upgrade halfway through the period", oldPrice: 10, newPrice: 30, at: start.AddDate(0, 0, 15), expected: 10},
+		{name: "Downgrade halfway through the period", oldPrice: 30, newPrice: 10, at: start.AddDate(0, 0, 15), expected: -10},
+		{name: "Change at period start charges the full difference", oldPrice: 10, newPrice: 30, at: start, expected: 20},
+		{name: "Change before period start is capped to the full difference", oldPrice: 10, newPrice: 30, at: start.AddDate(0, 0, -1), expected: 20},
+		{name: "Change at period end costs nothing", oldPrice: 10, newPrice: 30, at: end, expected: 0},
+		{name: "Amount is rounded to cents", oldPrice: 0, newPrice: 10, at: start.AddDate(0, 0, 20), expected: 3.33},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, service.Prorate(tc.oldPrice, tc.newPrice, start, end, tc.at))
+		})
+	}
+}
+
+func TestSubscriptionService_CreateSubscription(t *testing.T) {
+	testCases := []struct {
+		name           string
+		plan           model.Plan
+		mockSetup      func(*subscriptionMocks)
+		expectedStatus model.SubscriptionStatus
+		expectedError  error
+	}{
+		{
+			name: "Success - Active subscription is billed on the next run",
+			plan: basicPlan,
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "basic").Return(&basicPlan, nil)
+				m.subscriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Subscription")).Return(nil)
+			},
+			expectedStatus: model.SubscriptionActive,
+		},
+		{
+			name: "Success - Plan with trial starts trialing",
+			plan: model.Plan{Base: model.Base{ID: 3}, Code: "basic", Sku: "BASIC", Price: 10, Interval: model.IntervalMonth, TrialDays: 14},
+			mockSetup: func(m *subscriptionMocks) {
+				trialPlan := &model.Plan{Base: model.Base{ID: 3}, Code: "basic", Sku: "BASIC", Price: 10, Interval: model.IntervalMonth, TrialDays: 14}
+				m.planRepo.On("GetByCode", mock.Anything, "basic").Return(trialPlan, nil)
+				m.subscriptionRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Subscription")).Return(nil)
+			},
+			expectedStatus: model.SubscriptionTrialing,
+		},
+		{
+			name: "Error - Plan not found",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "basic").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrPlanNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newSubscriptionMocks()
+			tc.mockSetup(m)
+
+			subscription, err := m.service().CreateSubscription(context.Background(), "customer-123", "basic", "")
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, subscription)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, subscription.Status)
+				assert.Equal(t, model.COD, subscription.PaymentMethod)
+				if tc.plan.TrialDays > 0 {
+					assert.NotNil(t, subscription.TrialEnd)
+					assert.Equal(t, *subscription.TrialEnd, subscription.NextBillingAt)
+				}
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_BillDueSubscriptions(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	newSubscription := func() *model.Subscription {
+		return &model.Subscription{
+			Base:          model.Base{ID: 7},
+			CustomerID:    "customer-123",
+			PlanID:        basicPlan.ID,
+			Plan:          basicPlan,
+			Status:        model.SubscriptionActive,
+			PaymentMethod: model.VNPAY,
+			NextBillingAt: now,
+			LockedUntil:   &leaseUntil,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		subscription  func() *model.Subscription
+		mockSetup     func(*subscriptionMocks)
+		expectedError string
+		verify        func(*testing.T, *model.Subscription)
+	}{
+		{
+			name:         "Success - Bill period and advance to the next one",
+			subscription: newSubscription,
+			mockSetup: func(m *subscriptionMocks) {
+				amount := 10.0
+				m.subscriptionRepo.On("GetOrCreatePeriod", mock.Anything, mock.AnythingOfType("*model.SubscriptionPeriod")).Return(
+					&model.SubscriptionPeriod{Base: model.Base{ID: 1}, SubscriptionID: 7, PeriodStart: now, PeriodEnd: nextMonth, Amount: 10}, nil)
+				m.orderService.On("CreateBillingOrder", mock.Anything, "customer-123",
+					[]dto.ItemRequest{{Sku: "BASIC", Quantity: 1, UnitPrice: &amount}},
+					[]dto.PaymentRequest{{Method: model.VNPAY, Amount: 10}},
+				).Return(&model.Order{Base: model.Base{ID: 100}}, nil)
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(100), []dto.InvoiceItemRequest{{Sku: "BASIC", Quantity: 1}}, mock.Anything).
+					Return(&model.Invoice{Base: model.Base{ID: 200}}, nil)
+				m.subscriptionRepo.On("UpdatePeriod", mock.Anything, mock.MatchedBy(func(period *model.SubscriptionPeriod) bool {
+					return period.OrderID == 100 && period.InvoiceID == 200
+				})).Return(nil)
+				m.subscriptionRepo.On("AdvancePeriod", mock.Anything, mock.AnythingOfType("*model.Subscription"), 0.0).Return(nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, model.SubscriptionActive, subscription.Status)
+				assert.Equal(t, now, subscription.CurrentPeriodStart)
+				assert.Equal(t, nextMonth, subscription.CurrentPeriodEnd)
+				assert.Equal(t, nextMonth, subscription.NextBillingAt)
+				assert.Nil(t, subscription.LockedUntil)
+			},
+		},
+		{
+			name: "Success - Credit covers the whole period",
+			subscription: func() *model.Subscription {
+				subscription := newSubscription()
+				subscription.CreditBalance = 15
+				return subscription
+			},
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("GetOrCreatePeriod", mock.Anything, mock.MatchedBy(func(period *model.SubscriptionPeriod) bool {
+					return period.Amount == 0
+				})).Return(&model.SubscriptionPeriod{Base: model.Base{ID: 1}, SubscriptionID: 7, PeriodStart: now, PeriodEnd: nextMonth}, nil)
+				m.subscriptionRepo.On("AdvancePeriod", mock.Anything, mock.AnythingOfType("*model.Subscription"), 10.0).Return(nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, 5.0, subscription.CreditBalance)
+				assert.Equal(t, nextMonth, subscription.NextBillingAt)
+			},
+		},
+		{
+			name:         "Success - Period already billed by another run is not billed again",
+			subscription: newSubscription,
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("GetOrCreatePeriod", mock.Anything, mock.AnythingOfType("*model.SubscriptionPeriod")).Return(
+					&model.SubscriptionPeriod{Base: model.Base{ID: 1}, SubscriptionID: 7, PeriodStart: now, PeriodEnd: nextMonth, Amount: 10, OrderID: 100, InvoiceID: 200}, nil)
+				m.subscriptionRepo.On("AdvancePeriod", mock.Anything, mock.AnythingOfType("*model.Subscription"), 0.0).Return(nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, nextMonth, subscription.NextBillingAt)
+			},
+		},
+		{
+			name: "Success - Subscription canceled at period end",
+			subscription: func() *model.Subscription {
+				subscription := newSubscription()
+				subscription.CancelAtPeriodEnd = true
+				return subscription
+			},
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("Update", mock.Anything, mock.AnythingOfType("*model.Subscription"), model.SubscriptionActive,
+					[]string{"status", "canceled_at", "locked_until"}).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, model.SubscriptionCanceled, subscription.Status)
+				assert.NotNil(t, subscription.CanceledAt)
+			},
+		},
+		{
+			name: "Success - Trial ends and the subscription becomes active",
+			subscription: func() *model.Subscription {
+				subscription := newSubscription()
+				subscription.Status = model.SubscriptionTrialing
+				subscription.CreditBalance = 10
+				return subscription
+			},
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("GetOrCreatePeriod", mock.Anything, mock.AnythingOfType("*model.SubscriptionPeriod")).Return(
+					&model.SubscriptionPeriod{Base: model.Base{ID: 1}, SubscriptionID: 7, PeriodStart: now, PeriodEnd: nextMonth}, nil)
+				m.subscriptionRepo.On("AdvancePeriod", mock.Anything, mock.AnythingOfType("*model.Subscription"), 10.0).Return(nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, model.SubscriptionActive, subscription.Status)
+				assert.Equal(t, 0.0, subscription.CreditBalance)
+			},
+		},
+		{
+			name: "Error - Subscription paused before it was canceled at period end",
+			subscription: func() *model.Subscription {
+				subscription := newSubscription()
+				subscription.CancelAtPeriodEnd = true
+				return subscription
+			},
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("Update", mock.Anything, mock.AnythingOfType("*model.Subscription"), model.SubscriptionActive, mock.Anything).
+					Return(false, nil)
+			},
+			expectedError: "subscription 7: invalid subscription state: subscription changed concurrently",
+			verify:        func(t *testing.T, subscription *model.Subscription) {},
+		},
+		{
+			name:         "Error - Invoice failure is recorded and the subscription is not advanced",
+			subscription: newSubscription,
+			mockSetup: func(m *subscriptionMocks) {
+				m.subscriptionRepo.On("GetOrCreatePeriod", mock.Anything, mock.AnythingOfType("*model.SubscriptionPeriod")).Return(
+					&model.SubscriptionPeriod{Base: model.Base{ID: 1}, SubscriptionID: 7, PeriodStart: now, PeriodEnd: nextMonth, Amount: 10}, nil)
+				m.orderService.On("CreateBillingOrder", mock.Anything, "customer-123", mock.Anything, mock.Anything).
+					Return(&model.Order{Base: model.Base{ID: 100}}, nil)
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(100), mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error"))
+				m.subscriptionRepo.On("UpdatePeriod", mock.Anything, mock.MatchedBy(func(period *model.SubscriptionPeriod) bool {
+					return period.OrderID == 100 && period.InvoiceID == 0
+				})).Return(nil)
+			},
+			expectedError: "subscription 7: failed to create invoice: database error",
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, now, subscription.NextBillingAt)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newSubscriptionMocks()
+			subscription := tc.subscription()
+			m.subscriptionRepo.On("ClaimDue", mock.Anything, now, leaseUntil).Return(subscription, nil).Once()
+			m.subscriptionRepo.On("ClaimDue", mock.Anything, now, leaseUntil).Return(nil, nil).Once()
+			tc.mockSetup(m)
+
+			err := m.service().BillDueSubscriptions(context.Background(), now)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			tc.verify(t, subscription)
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_ChangePlan(t *testing.T) {
+	now := time.Now()
+
+	newSubscription := func(plan model.Plan, status model.SubscriptionStatus) *model.Subscription {
+		return &model.Subscription{
+			Base:               model.Base{ID: 7},
+			CustomerID:         "customer-123",
+			PlanID:             plan.ID,
+			Plan:               plan,
+			Status:             status,
+			PaymentMethod:      model.COD,
+			CurrentPeriodStart: now.Add(-15 * 24 * time.Hour),
+			CurrentPeriodEnd:   now.Add(15 * 24 * time.Hour),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		subscription  *model.Subscription
+		planCode      string
+		mockSetup     func(*subscriptionMocks)
+		expectedError error
+		verify        func(*testing.T, *model.Subscription)
+	}{
+		{
+			name:         "Success - Upgrade charges the prorated difference",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.MatchedBy(func(change *model.SubscriptionPlanChange) bool {
+					return change.FromPlanID == basicPlan.ID && change.ToPlanID == proPlan.ID && change.Amount > 9.99 && change.Amount < 10.01
+				})).Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID, Amount: 10}, nil)
+				m.orderService.On("CreateOrder", mock.Anything, "customer-123", mock.MatchedBy(func(items []dto.ItemRequest) bool {
+					return len(items) == 1 && items[0].Sku == "PRO" && *items[0].UnitPrice == 10
+				}), mock.Anything).Return(&model.Order{Base: model.Base{ID: 100}}, nil)
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(100), mock.Anything, mock.Anything).
+					Return(&model.Invoice{Base: model.Base{ID: 200}}, nil)
+				m.subscriptionRepo.On("UpdatePlanChange", mock.Anything, mock.MatchedBy(func(change *model.SubscriptionPlanChange) bool {
+					return change.OrderID == 100 && change.InvoiceID == 200
+				})).Return(nil)
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionActive).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, proPlan.ID, subscription.PlanID)
+				assert.Equal(t, 0.0, subscription.CreditBalance)
+			},
+		},
+		{
+			name:         "Success - Downgrade is kept as credit",
+			subscription: newSubscription(proPlan, model.SubscriptionActive),
+			planCode:     "basic",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "basic").Return(&basicPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: proPlan.ID, ToPlanID: basicPlan.ID, Amount: -10}, nil)
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionActive).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, basicPlan.ID, subscription.PlanID)
+				assert.InDelta(t, 10.0, subscription.CreditBalance, 0.01)
+			},
+		},
+		{
+			name:         "Success - Change during trial is free",
+			subscription: newSubscription(basicPlan, model.SubscriptionTrialing),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.MatchedBy(func(change *model.SubscriptionPlanChange) bool {
+					return change.Amount == 0
+				})).Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID}, nil)
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionTrialing).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, proPlan.ID, subscription.PlanID)
+			},
+		},
+		{
+			name:         "Success - Retry of a charged change is not charged again",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID, Amount: 10, OrderID: 100, InvoiceID: 200}, nil)
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionActive).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, proPlan.ID, subscription.PlanID)
+			},
+		},
+		{
+			name:         "Error - Change to another plan is pending",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: 3, Amount: 20, OrderID: 100}, nil)
+			},
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+		{
+			name:         "Success - Uncharged change to another plan is voided",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				stuck := &model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: 3, Amount: 20}
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).Return(stuck, nil).Once()
+				m.subscriptionRepo.On("VoidPlanChange", mock.Anything, stuck).Return(true, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 2}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID, Amount: 10, OrderID: 100, InvoiceID: 200}, nil).Once()
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.MatchedBy(func(change *model.SubscriptionPlanChange) bool {
+					return change.ID == 2
+				}), model.SubscriptionActive).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, proPlan.ID, subscription.PlanID)
+			},
+		},
+		{
+			name:         "Success - Change from a plan the subscription left is voided",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				stale := &model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: 3, ToPlanID: basicPlan.ID, Amount: 20, OrderID: 100}
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).Return(stale, nil).Once()
+				m.subscriptionRepo.On("VoidPlanChange", mock.Anything, stale).Return(true, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 2}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID, Amount: 10, OrderID: 101, InvoiceID: 201}, nil).Once()
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionActive).Return(true, nil)
+			},
+			verify: func(t *testing.T, subscription *model.Subscription) {
+				assert.Equal(t, proPlan.ID, subscription.PlanID)
+			},
+		},
+		{
+			name:         "Error - Subscription paused while the change was charged",
+			subscription: newSubscription(basicPlan, model.SubscriptionActive),
+			planCode:     "pro",
+			mockSetup: func(m *subscriptionMocks) {
+				m.planRepo.On("GetByCode", mock.Anything, "pro").Return(&proPlan, nil)
+				m.subscriptionRepo.On("GetOrCreatePendingPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange")).
+					Return(&model.SubscriptionPlanChange{Base: model.Base{ID: 1}, SubscriptionID: 7, FromPlanID: basicPlan.ID, ToPlanID: proPlan.ID, Amount: 10, OrderID: 100, InvoiceID: 200}, nil)
+				m.subscriptionRepo.On("ApplyPlanChange", mock.Anything, mock.AnythingOfType("*model.SubscriptionPlanChange"), model.SubscriptionActive).Return(false, nil)
+			},
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+		{
+			name:          "Error - Paused subscription cannot change plan",
+			subscription:  newSubscription(basicPlan, model.SubscriptionPaused),
+			planCode:      "pro",
+			mockSetup:     func(m *subscriptionMocks) {},
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newSubscriptionMocks()
+			m.subscriptionRepo.On("GetByID", mock.Anything, int64(7)).Return(tc.subscription, nil)
+			tc.mockSetup(m)
+
+			subscription, err := m.service().ChangePlan(context.Background(), 7, tc.planCode)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, subscription)
+			} else {
+				assert.NoError(t, err)
+				tc.verify(t, subscription)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_Lifecycle(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         model.SubscriptionStatus
+		operation      func(service.SubscriptionService) (*model.Subscription, error)
+		expectedStatus model.SubscriptionStatus
+		expectCancel   bool
+		conflict       bool
+		expectedError  error
+	}{
+		{
+			name:   "Success - Pause active subscription",
+			status: model.SubscriptionActive,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.PauseSubscription(context.Background(), 7)
+			},
+			expectedStatus: model.SubscriptionPaused,
+		},
+		{
+			name:   "Success - Resume paused subscription",
+			status: model.SubscriptionPaused,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.ResumeSubscription(context.Background(), 7)
+			},
+			expectedStatus: model.SubscriptionActive,
+		},
+		{
+			name:   "Success - Cancel active subscription at period end",
+			status: model.SubscriptionActive,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.CancelSubscription(context.Background(), 7)
+			},
+			expectedStatus: model.SubscriptionActive,
+			expectCancel:   true,
+		},
+		{
+			name:   "Success - Cancel paused subscription immediately",
+			status: model.SubscriptionPaused,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.CancelSubscription(context.Background(), 7)
+			},
+			expectedStatus: model.SubscriptionCanceled,
+		},
+		{
+			name:   "Error - Pause subscription canceled concurrently",
+			status: model.SubscriptionActive,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.PauseSubscription(context.Background(), 7)
+			},
+			conflict:      true,
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+		{
+			name:   "Error - Resume active subscription",
+			status: model.SubscriptionActive,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.ResumeSubscription(context.Background(), 7)
+			},
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+		{
+			name:   "Error - Cancel canceled subscription",
+			status: model.SubscriptionCanceled,
+			operation: func(s service.SubscriptionService) (*model.Subscription, error) {
+				return s.CancelSubscription(context.Background(), 7)
+			},
+			expectedError: service.ErrInvalidSubscriptionState,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newSubscriptionMocks()
+			m.subscriptionRepo.On("GetByID", mock.Anything, int64(7)).Return(&model.Subscription{
+				Base:          model.Base{ID: 7},
+				Plan:          basicPlan,
+				Status:        tc.status,
+				NextBillingAt: time.Now().AddDate(0, -1, 0),
+			}, nil)
+			if tc.expectedError == nil || tc.conflict {
+				m.subscriptionRepo.On("Update", mock.Anything, mock.AnythingOfType("*model.Subscription"), tc.status, mock.Anything).Return(!tc.conflict, nil)
+			}
+
+			subscription, err := tc.operation(m.service())
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, subscription)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, subscription.Status)
+				assert.Equal(t, tc.expectCancel, subscription.CancelAtPeriodEnd)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionService_GetSubscriptionNotFound(t *testing.T) {
+	m := newSubscriptionMocks()
+	m.subscriptionRepo.On("GetByID", mock.Anything, int64(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	subscription, err := m.service().PauseSubscription(context.Background(), 7)
+
+	assert.ErrorIs(t, err, service.ErrSubscriptionNotFound)
+	assert.Nil(t, subscription)
+	m.assertExpectations(t)
+}
@@ -0,0 +1,424 @@
+package tests
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/service"
+	"billing-system/billing_service/internal/service/tests/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var apiCallsMeter = model.Meter{
+	Base:         model.Base{ID: 1},
+	Code:         "api_calls",
+	Sku:          "API",
+	Aggregation:  model.AggregationSum,
+	PricingModel: model.PricingTiered,
+	Tiers: model.PriceTiers{
+		{UpTo: 100, UnitPrice: 1},
+		{UpTo: 1000, UnitPrice: 0.5},
+		{UnitPrice: 0.1},
+	},
+}
+
+func TestMeter_Price(t *testing.T) {
+	flatFeeTiers := model.PriceTiers{{UpTo: 10, FlatFee: 5}, {UnitPrice: 2}}
+
+	testCases := []struct {
+		name     string
+		model    model.PricingModel
+		tiers    model.PriceTiers
+		quantity float64
+		expected float64
+	}{
+		{name: "Tiered - No usage", model: model.PricingTiered, tiers: apiCallsMeter.Tiers, quantity: 0, expected: 0},
+		{name: "Tiered - Within first tier", model: model.PricingTiered, tiers: apiCallsMeter.Tiers, quantity: 80, expected: 80},
+		{name: "Tiered - Across two tiers", model: model.PricingTiered, tiers: apiCallsMeter.Tiers, quantity: 150, expected: 125},
+		{name: "Tiered - Into unbounded tier", model: model.PricingTiered, tiers: apiCallsMeter.Tiers, quantity: 2000, expected: 650},
+		{name: "Tiered - Flat fee charged once the tier is reached", model: model.PricingTiered, tiers: flatFeeTiers, quantity: 12, expected: 9},
+		{name: "Volume - No usage", model: model.PricingVolume, tiers: apiCallsMeter.Tiers, quantity: 0, expected: 0},
+		{name: "Volume - Upper bound is inclusive", model: model.PricingVolume, tiers: apiCallsMeter.Tiers, quantity: 100, expected: 100},
+		{name: "Volume - Every unit at the second tier", model: model.PricingVolume, tiers: apiCallsMeter.Tiers, quantity: 150, expected: 75},
+		{name: "Volume - Every unit at the unbounded tier", model: model.PricingVolume, tiers: apiCallsMeter.Tiers, quantity: 2000, expected: 200},
+		{name: "Volume - Flat fee of the tier", model: model.PricingVolume, tiers: flatFeeTiers, quantity: 12, expected: 24},
+		{name: "Amount is rounded to cents", model: model.PricingTiered, tiers: model.PriceTiers{{UnitPrice: 0.001}}, quantity: 1234, expected: 1.23},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meter := model.Meter{PricingModel: tc.model, Tiers: tc.tiers}
+			assert.InDelta(t, tc.expected, meter.Price(tc.quantity), 0.0001)
+		})
+	}
+}
+
+func TestMeter_Aggregate(t *testing.T) {
+	records := []model.UsageRecord{{Quantity: 5}, {Quantity: 12}, {Quantity: 3}}
+
+	testCases := []struct {
+		aggregation model.UsageAggregation
+		expected    float64
+	}{
+		{aggregation: model.AggregationSum, expected: 20},
+		{aggregation: model.AggregationMax, expected: 12},
+		{aggregation: model.AggregationLast, expected: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.aggregation), func(t *testing.T) {
+			meter := model.Meter{Aggregation: tc.aggregation}
+			assert.Equal(t, tc.expected, meter.Aggregate(records))
+		})
+	}
+}
+
+type usageMocks struct {
+	meterRepo      *mocks.MockMeterRepository
+	usageRepo      *mocks.MockUsageRepository
+	itemRepo       *mocks.MockItemRepository
+	orderService   *mocks.MockOrderService
+	invoiceService *mocks.MockInvoiceService
+}
+
+func newUsageMocks() *usageMocks {
+	return &usageMocks{
+		meterRepo:      new(mocks.MockMeterRepository),
+		usageRepo:      new(mocks.MockUsageRepository),
+		itemRepo:       new(mocks.MockItemRepository),
+		orderService:   new(mocks.MockOrderService),
+		invoiceService: new(mocks.MockInvoiceService),
+	}
+}
+
+func (m *usageMocks) service() service.UsageService {
+	return service.NewUsageService(m.meterRepo, m.usageRepo, m.itemRepo, m.orderService, m.invoiceService, 24*time.Hour, time.Minute)
+}
+
+func (m *usageMocks) assertExpectations(t *testing.T) {
+	m.meterRepo.AssertExpectations(t)
+	m.usageRepo.AssertExpectations(t)
+	m.itemRepo.AssertExpectations(t)
+	m.orderService.AssertExpectations(t)
+	m.invoiceService.AssertExpectations(t)
+}
+
+func TestUsageService_CreateMeter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		meter         model.Meter
+		mockSetup     func(*usageMocks)
+		expectedError error
+	}{
+		{
+			name:  "Success - Defaults to sum and tiered pricing",
+			meter: model.Meter{Code: "api_calls", Sku: "API", Tiers: model.PriceTiers{{UnitPrice: 1}}},
+			mockSetup: func(m *usageMocks) {
+				m.itemRepo.On("GetBySku", mock.Anything, "API").Return(&model.Item{Base: model.Base{ID: 1}, Sku: "API"}, nil)
+				m.meterRepo.On("Create", mock.Anything, mock.MatchedBy(func(meter *model.Meter) bool {
+					return meter.Aggregation == model.AggregationSum && meter.PricingModel == model.PricingTiered
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "Error - Unsupported aggregation",
+			meter:         model.Meter{Code: "api_calls", Sku: "API", Aggregation: "AVG", Tiers: model.PriceTiers{{UnitPrice: 1}}},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidMeter,
+		},
+		{
+			name:          "Error - Unbounded tier before the last one",
+			meter:         model.Meter{Code: "api_calls", Sku: "API", Tiers: model.PriceTiers{{UnitPrice: 1}, {UpTo: 10, UnitPrice: 2}}},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidMeter,
+		},
+		{
+			name:          "Error - Tiers out of order",
+			meter:         model.Meter{Code: "api_calls", Sku: "API", Tiers: model.PriceTiers{{UpTo: 10, UnitPrice: 1}, {UpTo: 5, UnitPrice: 2}, {UnitPrice: 3}}},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidMeter,
+		},
+		{
+			name:          "Error - No tiers",
+			meter:         model.Meter{Code: "api_calls", Sku: "API"},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidMeter,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newUsageMocks()
+			tc.mockSetup(m)
+
+			meter, err := m.service().CreateMeter(context.Background(), &tc.meter)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, meter)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, meter)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestUsageService_RecordUsage(t *testing.T) {
+	timestamp := time.Date(2025, 3, 14, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		record          model.UsageRecord
+		mockSetup       func(*usageMocks)
+		expectedCreated bool
+		expectedError   error
+	}{
+		{
+			name:   "Success - Record stored in its calendar month",
+			record: model.UsageRecord{CustomerID: "customer-123", MeterCode: "api_calls", Quantity: 5, Timestamp: timestamp, IdempotencyKey: "evt-1"},
+			mockSetup: func(m *usageMocks) {
+				m.meterRepo.On("GetByCode", mock.Anything, "api_calls").Return(&apiCallsMeter, nil)
+				m.usageRepo.On("CreateRecord", mock.Anything, mock.MatchedBy(func(record *model.UsageRecord) bool {
+					return record.PeriodStart.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) &&
+						record.PeriodEnd.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+				})).Return(true, nil)
+			},
+			expectedCreated: true,
+		},
+		{
+			name:   "Success - Duplicate idempotency key is ignored",
+			record: model.UsageRecord{CustomerID: "customer-123", MeterCode: "api_calls", Quantity: 5, Timestamp: timestamp, IdempotencyKey: "evt-1"},
+			mockSetup: func(m *usageMocks) {
+				m.meterRepo.On("GetByCode", mock.Anything, "api_calls").Return(&apiCallsMeter, nil)
+				m.usageRepo.On("CreateRecord", mock.Anything, mock.AnythingOfType("*model.UsageRecord")).Return(false, nil)
+			},
+			expectedCreated: false,
+		},
+		{
+			name:   "Error - Unknown meter",
+			record: model.UsageRecord{CustomerID: "customer-123", MeterCode: "unknown", Quantity: 5, IdempotencyKey: "evt-2"},
+			mockSetup: func(m *usageMocks) {
+				m.meterRepo.On("GetByCode", mock.Anything, "unknown").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedError: service.ErrMeterNotFound,
+		},
+		{
+			name:          "Error - Negative quantity",
+			record:        model.UsageRecord{CustomerID: "customer-123", MeterCode: "api_calls", Quantity: -1, IdempotencyKey: "evt-3"},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidUsage,
+		},
+		{
+			name:          "Error - Missing idempotency key",
+			record:        model.UsageRecord{CustomerID: "customer-123", MeterCode: "api_calls", Quantity: 1},
+			mockSetup:     func(m *usageMocks) {},
+			expectedError: service.ErrInvalidUsage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newUsageMocks()
+			tc.mockSetup(m)
+
+			created, err := m.service().RecordUsage(context.Background(), &tc.record)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCreated, created)
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestUsageService_CloseUsagePeriods(t *testing.T) {
+	now := time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)
+	key := model.UsageKey{
+		CustomerID:  "customer-123",
+		MeterCode:   "api_calls",
+		PeriodStart: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	billed := int64(10)
+
+	// expectClaims leases the given periods and adjustments in turn, then reports nothing left to invoice
+	expectClaims := func(m *usageMocks, periods []*model.UsagePeriod, adjustments []*model.UsageAdjustment) {
+		leaseUntil := now.Add(time.Minute)
+		for _, period := range periods {
+			m.usageRepo.On("ClaimUninvoicedPeriod", mock.Anything, now, leaseUntil).Return(period, nil).Once()
+		}
+		m.usageRepo.On("ClaimUninvoicedPeriod", mock.Anything, now, leaseUntil).Return(nil, nil).Once()
+		for _, adjustment := range adjustments {
+			m.usageRepo.On("ClaimUninvoicedAdjustment", mock.Anything, now, leaseUntil).Return(adjustment, nil).Once()
+		}
+		m.usageRepo.On("ClaimUninvoicedAdjustment", mock.Anything, now, leaseUntil).Return(nil, nil).Once()
+	}
+
+	expectInvoice := func(m *usageMocks, amount float64, orderID int64, invoiceID int64) {
+		m.orderService.On("CreateBillingOrder", mock.Anything, "customer-123",
+			[]dto.ItemRequest{{Sku: "API", Quantity: 1, UnitPrice: &amount}},
+			[]dto.PaymentRequest{{Method: model.COD, Amount: amount}},
+		).Return(&model.Order{Base: model.Base{ID: orderID}}, nil)
+		m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), orderID, []dto.InvoiceItemRequest{{Sku: "API", Quantity: 1}}, mock.Anything).
+			Return(&model.Invoice{Base: model.Base{ID: invoiceID}}, nil)
+	}
+
+	testCases := []struct {
+		name          string
+		mockSetup     func(*usageMocks)
+		expectedError string
+	}{
+		{
+			name: "Success - Close period and invoice it",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{key}, nil)
+				m.usageRepo.On("GetOrCreatePeriod", mock.Anything, key).Return(&model.UsagePeriod{
+					Base: model.Base{ID: 1}, CustomerID: key.CustomerID, MeterCode: key.MeterCode, Status: model.UsagePeriodOpen,
+				}, nil)
+				m.usageRepo.On("ListRecords", mock.Anything, key).Return([]model.UsageRecord{
+					{Base: model.Base{ID: 1}, Quantity: 100},
+					{Base: model.Base{ID: 2}, Quantity: 50},
+				}, nil)
+				m.usageRepo.On("ClosePeriod", mock.Anything, mock.MatchedBy(func(period *model.UsagePeriod) bool {
+					return period.Status == model.UsagePeriodClosed && period.Quantity == 150 && period.Amount == 125
+				}), []int64{1, 2}).Return(true, nil)
+
+				expectClaims(m, []*model.UsagePeriod{
+					{Base: model.Base{ID: 1}, CustomerID: "customer-123", MeterCode: "api_calls", Amount: 125, Status: model.UsagePeriodClosed},
+				}, nil)
+				expectInvoice(m, 125, 100, 200)
+				m.usageRepo.On("UpdatePeriod", mock.Anything, mock.MatchedBy(func(period *model.UsagePeriod) bool {
+					return period.OrderID == 100
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Success - Late usage becomes an invoiced adjustment",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{key}, nil)
+				m.usageRepo.On("GetOrCreatePeriod", mock.Anything, key).Return(&model.UsagePeriod{
+					Base: model.Base{ID: 1}, CustomerID: key.CustomerID, MeterCode: key.MeterCode, Status: model.UsagePeriodClosed,
+					Quantity: 100, Amount: 100, OrderID: 100, InvoiceID: 200,
+					Adjustments: []model.UsageAdjustment{{Quantity: 20, Amount: 10}},
+				}, nil)
+				m.usageRepo.On("ListRecords", mock.Anything, key).Return([]model.UsageRecord{
+					{Base: model.Base{ID: 1}, Quantity: 100, UsagePeriodID: &billed},
+					{Base: model.Base{ID: 2}, Quantity: 20, UsagePeriodID: &billed},
+					{Base: model.Base{ID: 3}, Quantity: 30},
+				}, nil)
+				m.usageRepo.On("AddAdjustment", mock.Anything, mock.MatchedBy(func(adjustment *model.UsageAdjustment) bool {
+					return adjustment.UsagePeriodID == 1 && adjustment.Quantity == 30 && adjustment.Amount == 15
+				}), []int64{3}).Return(true, nil)
+
+				expectClaims(m, nil, []*model.UsageAdjustment{
+					{Base: model.Base{ID: 5}, UsagePeriodID: 1, CustomerID: "customer-123", MeterCode: "api_calls", Quantity: 30, Amount: 15},
+				})
+				expectInvoice(m, 15, 101, 201)
+				m.usageRepo.On("UpdateAdjustment", mock.Anything, mock.MatchedBy(func(adjustment *model.UsageAdjustment) bool {
+					return adjustment.OrderID == 101
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Success - Period with nothing new is skipped",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{key}, nil)
+				m.usageRepo.On("GetOrCreatePeriod", mock.Anything, key).Return(&model.UsagePeriod{
+					Base: model.Base{ID: 1}, Status: model.UsagePeriodClosed,
+				}, nil)
+				m.usageRepo.On("ListRecords", mock.Anything, key).Return([]model.UsageRecord{
+					{Base: model.Base{ID: 1}, Quantity: 100, UsagePeriodID: &billed},
+				}, nil)
+				expectClaims(m, nil, nil)
+			},
+		},
+		{
+			name: "Success - Invoice retried for an order already created",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{}, nil)
+				expectClaims(m, []*model.UsagePeriod{
+					{Base: model.Base{ID: 1}, CustomerID: "customer-123", MeterCode: "api_calls", Amount: 125, Status: model.UsagePeriodClosed, OrderID: 100},
+				}, nil)
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(100), mock.Anything, mock.Anything).
+					Return(&model.Invoice{Base: model.Base{ID: 200}}, nil)
+				m.usageRepo.On("UpdatePeriod", mock.Anything, mock.MatchedBy(func(period *model.UsagePeriod) bool {
+					return period.OrderID == 100 && period.InvoiceID == 200
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Success - Roll up racing another replica is dropped",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{key}, nil)
+				m.usageRepo.On("GetOrCreatePeriod", mock.Anything, key).Return(&model.UsagePeriod{
+					Base: model.Base{ID: 1}, CustomerID: key.CustomerID, MeterCode: key.MeterCode, Status: model.UsagePeriodClosed,
+					Quantity: 100, Amount: 100, OrderID: 100, InvoiceID: 200,
+				}, nil)
+				m.usageRepo.On("ListRecords", mock.Anything, key).Return([]model.UsageRecord{
+					{Base: model.Base{ID: 1}, Quantity: 100, UsagePeriodID: &billed},
+					{Base: model.Base{ID: 3}, Quantity: 30},
+				}, nil)
+				m.usageRepo.On("AddAdjustment", mock.Anything, mock.AnythingOfType("*model.UsageAdjustment"), []int64{3}).Return(false, nil)
+				expectClaims(m, nil, nil)
+			},
+		},
+		{
+			name: "Error - Failed invoice keeps its lease and the next period is invoiced",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{}, nil)
+				expectClaims(m, []*model.UsagePeriod{
+					{Base: model.Base{ID: 1}, CustomerID: "customer-123", MeterCode: "api_calls", Amount: 125, Status: model.UsagePeriodClosed, OrderID: 100},
+					{Base: model.Base{ID: 2}, CustomerID: "customer-456", MeterCode: "api_calls", Amount: 125, Status: model.UsagePeriodClosed, OrderID: 101},
+				}, nil)
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(100), mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error"))
+				m.invoiceService.On("CreateInvoice", mock.Anything, int64(0), int64(101), mock.Anything, mock.Anything).
+					Return(&model.Invoice{Base: model.Base{ID: 201}}, nil)
+				m.usageRepo.On("UpdatePeriod", mock.Anything, mock.MatchedBy(func(period *model.UsagePeriod) bool {
+					return period.ID == 2 && period.InvoiceID == 201
+				})).Return(nil)
+			},
+			expectedError: "usage period 1: failed to create invoice: database error",
+		},
+		{
+			name: "Error - Failure on one period does not stop invoicing",
+			mockSetup: func(m *usageMocks) {
+				m.usageRepo.On("ListPendingKeys", mock.Anything, now.Add(-24*time.Hour)).Return([]model.UsageKey{key}, nil)
+				m.usageRepo.On("GetOrCreatePeriod", mock.Anything, key).Return(nil, errors.New("database error"))
+				expectClaims(m, nil, nil)
+			},
+			expectedError: "usage of api_calls for customer-123 from 2025-03-01: failed to record period: database error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newUsageMocks()
+			m.meterRepo.On("GetByCode", mock.Anything, "api_calls").Return(&apiCallsMeter, nil).Maybe()
+			tc.mockSetup(m)
+
+			err := m.service().CloseUsagePeriods(context.Background(), now)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
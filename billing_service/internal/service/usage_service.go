@@ -0,0 +1,316 @@
+package service
+
+import (
+	"billing-system/billing_service/internal/dto"
+	"billing-system/billing_service/internal/model"
+	"billing-system/billing_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UsageServiceImpl implements UsageService
+type UsageServiceImpl struct {
+	meterRepo      repository.MeterRepository
+	usageRepo      repository.UsageRepository
+	itemRepo       repository.ItemRepository
+	orderService   OrderService
+	invoiceService InvoiceService
+	gracePeriod    time.Duration
+	lease          time.Duration
+}
+
+// NewUsageService creates a new UsageServiceImpl.
+// gracePeriod is how long after its end a period stays open for late usage before it is billed.
+// lease is how long a replica owns a period or adjustment while invoicing it.
+func NewUsageService(
+	meterRepo repository.MeterRepository,
+	usageRepo repository.UsageRepository,
+	itemRepo repository.ItemRepository,
+	orderService OrderService,
+	invoiceService InvoiceService,
+	gracePeriod time.Duration,
+	lease time.Duration,
+) UsageService {
+	if lease <= 0 {
+		lease = 5 * time.Minute
+	}
+
+	return &UsageServiceImpl{
+		meterRepo:      meterRepo,
+		usageRepo:      usageRepo,
+		itemRepo:       itemRepo,
+		orderService:   orderService,
+		invoiceService: invoiceService,
+		gracePeriod:    gracePeriod,
+		lease:          lease,
+	}
+}
+
+// CreateMeter validates and stores a new meter
+func (s *UsageServiceImpl) CreateMeter(ctx context.Context, meter *model.Meter) (*model.Meter, error) {
+	if meter.Code == "" || meter.Sku == "" {
+		return nil, fmt.Errorf("%w: code and sku are required", ErrInvalidMeter)
+	}
+
+	switch meter.Aggregation {
+	case "":
+		meter.Aggregation = model.AggregationSum
+	case model.AggregationSum, model.AggregationMax, model.AggregationLast:
+	default:
+		return nil, fmt.Errorf("%w: unsupported aggregation %q", ErrInvalidMeter, meter.Aggregation)
+	}
+
+	switch meter.PricingModel {
+	case "":
+		meter.PricingModel = model.PricingTiered
+	case model.PricingTiered, model.PricingVolume:
+	default:
+		return nil, fmt.Errorf("%w: unsupported pricing model %q", ErrInvalidMeter, meter.PricingModel)
+	}
+
+	if err := validateTiers(meter.Tiers, ErrInvalidMeter); err != nil {
+		return nil, err
+	}
+
+	// Usage is billed as one unit of the meter's item
+	if _, err := s.itemRepo.GetBySku(ctx, meter.Sku); err != nil {
+		return nil, itemLookupError(meter.Sku, err)
+	}
+
+	if err := s.meterRepo.Create(ctx, meter); err != nil {
+		return nil, fmt.Errorf("failed to create meter: %w", err)
+	}
+
+	return meter, nil
+}
+
+// RecordUsage stores a usage record in the period its timestamp falls into.
+// Returns false when the customer already reported a record with the same idempotency key for the meter.
+func (s *UsageServiceImpl) RecordUsage(ctx context.Context, record *model.UsageRecord) (bool, error) {
+	if record.CustomerID == "" || record.MeterCode == "" || record.IdempotencyKey == "" {
+		return false, fmt.Errorf("%w: customer, metric and idempotency key are required", ErrInvalidUsage)
+	}
+	if record.Quantity < 0 || math.IsNaN(record.Quantity) || math.IsInf(record.Quantity, 0) {
+		return false, fmt.Errorf("%w: quantity must be a non-negative number", ErrInvalidUsage)
+	}
+
+	if _, err := s.getMeter(ctx, record.MeterCode); err != nil {
+		return false, err
+	}
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	record.PeriodStart, record.PeriodEnd = model.UsagePeriodBounds(record.Timestamp)
+
+	created, err := s.usageRepo.CreateRecord(ctx, record)
+	if err != nil {
+		return false, fmt.Errorf("failed to store usage record: %w", err)
+	}
+
+	return created, nil
+}
+
+// Start runs CloseUsagePeriods immediately and then on every interval until ctx is cancelled
+func (s *UsageServiceImpl) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CloseUsagePeriods(ctx, time.Now()); err != nil {
+			log.Println("Usage billing run finished with errors:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CloseUsagePeriods rolls up the usage of every period that ended before now minus the grace period
+// and invoices the amounts due. Usage that arrives after its period was closed is billed as an adjustment.
+// Rolling up and invoicing are separate steps so an invoicing failure is retried on the next run
+// without rolling the usage up again. Several replicas can run concurrently: a roll up racing another one
+// for the same records is dropped, and periods and adjustments are leased before they are invoiced.
+func (s *UsageServiceImpl) CloseUsagePeriods(ctx context.Context, now time.Time) error {
+	keys, err := s.usageRepo.ListPendingKeys(ctx, now.Add(-s.gracePeriod))
+	if err != nil {
+		return fmt.Errorf("failed to list pending usage: %w", err)
+	}
+
+	var errs []error
+	for _, key := range keys {
+		if err := s.rollUp(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("usage of %s for %s from %s: %w",
+				key.MeterCode, key.CustomerID, key.PeriodStart.Format(time.DateOnly), err))
+		}
+	}
+
+	if err := s.invoicePending(ctx, now); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// rollUp aggregates and prices the records of one period.
+// The first roll up closes the period, later ones record the difference as an adjustment.
+func (s *UsageServiceImpl) rollUp(ctx context.Context, key model.UsageKey) error {
+	meter, err := s.getMeter(ctx, key.MeterCode)
+	if err != nil {
+		return err
+	}
+
+	period, err := s.usageRepo.GetOrCreatePeriod(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to record period: %w", err)
+	}
+
+	records, err := s.usageRepo.ListRecords(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to list usage records: %w", err)
+	}
+
+	var pendingIDs []int64
+	for _, record := range records {
+		if record.UsagePeriodID == nil {
+			pendingIDs = append(pendingIDs, record.ID)
+		}
+	}
+	if len(pendingIDs) == 0 {
+		return nil
+	}
+
+	quantity := meter.Aggregate(records)
+	amount := meter.Price(quantity)
+
+	if period.Status != model.UsagePeriodClosed {
+		period.Quantity = quantity
+		period.Amount = amount
+		period.Status = model.UsagePeriodClosed
+		if _, err := s.usageRepo.ClosePeriod(ctx, period, pendingIDs); err != nil {
+			return fmt.Errorf("failed to close period: %w", err)
+		}
+		return nil
+	}
+
+	adjustment := &model.UsageAdjustment{
+		UsagePeriodID: period.ID,
+		CustomerID:    period.CustomerID,
+		MeterCode:     period.MeterCode,
+		Quantity:      quantity - period.BilledQuantity(),
+		Amount:        math.Round((amount-period.BilledAmount())*100) / 100,
+	}
+	if _, err := s.usageRepo.AddAdjustment(ctx, adjustment, pendingIDs); err != nil {
+		return fmt.Errorf("failed to record adjustment: %w", err)
+	}
+
+	return nil
+}
+
+// invoicePending creates the orders and invoices of closed periods and adjustments not invoiced yet.
+// Negative adjustments are not invoiced, they stay recorded as an amount owed to the customer.
+// A period or adjustment that fails keeps its lease until it expires, which delays the retry.
+func (s *UsageServiceImpl) invoicePending(ctx context.Context, now time.Time) error {
+	var errs []error
+
+	for {
+		period, err := s.usageRepo.ClaimUninvoicedPeriod(ctx, now, now.Add(s.lease))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to claim usage period: %w", err))
+			break
+		}
+		if period == nil {
+			break
+		}
+
+		err = s.invoiceUsage(ctx, period.CustomerID, period.MeterCode, period.Amount, &period.OrderID, &period.InvoiceID, func() error {
+			return s.usageRepo.UpdatePeriod(ctx, period)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("usage period %d: %w", period.ID, err))
+		}
+	}
+
+	for {
+		adjustment, err := s.usageRepo.ClaimUninvoicedAdjustment(ctx, now, now.Add(s.lease))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to claim usage adjustment: %w", err))
+			break
+		}
+		if adjustment == nil {
+			break
+		}
+
+		err = s.invoiceUsage(ctx, adjustment.CustomerID, adjustment.MeterCode, adjustment.Amount, &adjustment.OrderID, &adjustment.InvoiceID, func() error {
+			return s.usageRepo.UpdateAdjustment(ctx, adjustment)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("usage adjustment %d: %w", adjustment.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// invoiceUsage bills amount as one unit of the meter's item through an order and its invoice.
+// Usage is billed even when the customer is on hold.
+// Steps already recorded in orderID and invoiceID are skipped, save persists them after each step.
+func (s *UsageServiceImpl) invoiceUsage(
+	ctx context.Context,
+	customerID string,
+	meterCode string,
+	amount float64,
+	orderID *int64,
+	invoiceID *int64,
+	save func() error,
+) error {
+	meter, err := s.getMeter(ctx, meterCode)
+	if err != nil {
+		return err
+	}
+
+	if *orderID == 0 {
+		order, err := s.orderService.CreateBillingOrder(ctx, customerID,
+			[]dto.ItemRequest{{Sku: meter.Sku, Quantity: 1, UnitPrice: &amount}},
+			[]dto.PaymentRequest{{Method: model.COD, Amount: amount}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		*orderID = order.ID
+		if err := save(); err != nil {
+			return fmt.Errorf("failed to record order: %w", err)
+		}
+	}
+
+	invoice, err := s.invoiceService.CreateInvoice(ctx, 0, *orderID, []dto.InvoiceItemRequest{{Sku: meter.Sku, Quantity: 1}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+	*invoiceID = invoice.ID
+	if err := save(); err != nil {
+		return fmt.Errorf("failed to record invoice: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UsageServiceImpl) getMeter(ctx context.Context, code string) (*model.Meter, error) {
+	meter, err := s.meterRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMeterNotFound
+		}
+		return nil, fmt.Errorf("failed to get meter %s: %w", code, err)
+	}
+	return meter, nil
+}
@@ -13,16 +13,45 @@ func MigrateDB(db *gorm.DB) error {
 
 	err := db.AutoMigrate(
 		&model.Item{},
+		&model.Customer{},
 		&model.Order{},
 		&model.OrderItem{},
 		&model.Payment{},
 		&model.Invoice{},
 		&model.InvoiceItem{},
+		&model.InvoiceCharge{},
+		&model.CreditNote{},
+		&model.CreditNoteItem{},
+		&model.Plan{},
+		&model.Subscription{},
+		&model.SubscriptionPeriod{},
+		&model.SubscriptionPlanChange{},
+		&model.Meter{},
+		&model.UsageRecord{},
+		&model.UsagePeriod{},
+		&model.UsageAdjustment{},
+		&model.PriceList{},
+		&model.PriceListEntry{},
+		&model.APIKey{},
 	)
 	if err != nil {
 		return err
 	}
 
+	// Idempotency keys used to be unique across customers and meters
+	if db.Migrator().HasIndex(&model.UsageRecord{}, "idx_usage_records_idempotency_key") {
+		if err := db.Migrator().DropIndex(&model.UsageRecord{}, "idx_usage_records_idempotency_key"); err != nil {
+			return err
+		}
+	}
+
+	// Pending plan changes used to be unique until applied, voided ones are not pending anymore
+	if db.Migrator().HasIndex(&model.SubscriptionPlanChange{}, "idx_subscription_pending_plan_change") {
+		if err := db.Migrator().DropIndex(&model.SubscriptionPlanChange{}, "idx_subscription_pending_plan_change"); err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
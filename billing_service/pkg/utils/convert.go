@@ -4,6 +4,7 @@ import (
 	"billing-system/billing_service/internal/dto"
 	"billing-system/billing_service/internal/model"
 	pb "billing-system/billing_service/proto"
+	"fmt"
 	"time"
 )
 
@@ -71,6 +72,120 @@ func ProtoInvoiceItemRequestToDTO(protoItem *pb.InvoiceItemRequest) dto.InvoiceI
 	}
 }
 
+// ProtoCreatePlanRequestToModel converts a protocol buffer create plan request to a domain plan
+func ProtoCreatePlanRequestToModel(req *pb.CreatePlanRequest) *model.Plan {
+	return &model.Plan{
+		Code:          req.Code,
+		Name:          req.Name,
+		Sku:           req.Sku,
+		Price:         req.Price,
+		Interval:      model.BillingInterval(req.Interval),
+		IntervalCount: int(req.IntervalCount),
+		TrialDays:     int(req.TrialDays),
+	}
+}
+
+// ProtoCreateMeterRequestToModel converts a protocol buffer create meter request to a domain meter
+func ProtoCreateMeterRequestToModel(req *pb.CreateMeterRequest) *model.Meter {
+	return &model.Meter{
+		Code:         req.Code,
+		Name:         req.Name,
+		Sku:          req.Sku,
+		Aggregation:  model.UsageAggregation(req.Aggregation),
+		PricingModel: model.PricingModel(req.PricingModel),
+		Tiers:        ProtoPriceTiersToModel(req.Tiers),
+	}
+}
+
+// ProtoPriceTiersToModel converts protocol buffer price tiers to domain price tiers
+func ProtoPriceTiersToModel(protoTiers []*pb.PriceTier) model.PriceTiers {
+	tiers := make(model.PriceTiers, 0, len(protoTiers))
+	for _, tier := range protoTiers {
+		tiers = append(tiers, model.PriceTier{
+			UpTo:      tier.UpTo,
+			UnitPrice: tier.UnitPrice,
+			FlatFee:   tier.FlatFee,
+		})
+	}
+	return tiers
+}
+
+// ProtoCreatePriceListRequestToModel converts a protocol buffer create price list request
+// to a domain price list and its entry requests
+func ProtoCreatePriceListRequestToModel(req *pb.CreatePriceListRequest) (*model.PriceList, []dto.PriceListEntryRequest, error) {
+	priceList := &model.PriceList{
+		Code:       req.Code,
+		Name:       req.Name,
+		Kind:       model.PriceListKind(req.Kind),
+		CustomerID: req.CustomerId,
+	}
+
+	if req.ValidFrom != "" {
+		validFrom, err := time.Parse(time.RFC3339, req.ValidFrom)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid valid_from %q: %w", req.ValidFrom, err)
+		}
+		priceList.ValidFrom = validFrom
+	}
+	if req.ValidTo != "" {
+		validTo, err := time.Parse(time.RFC3339, req.ValidTo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid valid_to %q: %w", req.ValidTo, err)
+		}
+		priceList.ValidTo = &validTo
+	}
+
+	entries := make([]dto.PriceListEntryRequest, 0, len(req.Entries))
+	for _, entry := range req.Entries {
+		entries = append(entries, dto.PriceListEntryRequest{
+			Sku:   entry.Sku,
+			Tiers: ProtoPriceTiersToModel(entry.Tiers),
+		})
+	}
+
+	return priceList, entries, nil
+}
+
+// ProtoIssueAPIKeyRequestToModel converts a protocol buffer issue API key request to a domain API key
+func ProtoIssueAPIKeyRequestToModel(req *pb.IssueAPIKeyRequest) (*model.APIKey, error) {
+	key := &model.APIKey{
+		Name:       req.Name,
+		CustomerID: req.CustomerId,
+		Roles:      req.Roles,
+		Scopes:     req.Scopes,
+	}
+
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_at %q: %w", req.ExpiresAt, err)
+		}
+		key.ExpiresAt = &expiresAt
+	}
+
+	return key, nil
+}
+
+// ProtoUsageEventToModel converts a protocol buffer usage event to a domain usage record
+func ProtoUsageEventToModel(event *pb.UsageEvent) (*model.UsageRecord, error) {
+	record := &model.UsageRecord{
+		CustomerID:     event.CustomerId,
+		MeterCode:      event.Metric,
+		Quantity:       event.Quantity,
+		IdempotencyKey: event.IdempotencyKey,
+	}
+
+	if event.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %w", event.Timestamp, err)
+		}
+		record.Timestamp = timestamp
+	}
+
+	return record, nil
+}
+
 // Domain to Proto conversions
 
 // OrderToProto converts a domain order model to a protocol buffer order
@@ -86,6 +201,8 @@ func OrderToProto(order *model.Order) *pb.Order {
 		Status:      OrderStatusToProto(order.Status),
 		CreatedAt:   order.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   order.UpdatedAt.Format(time.RFC3339),
+		PaymentTerm: string(order.PaymentTerm),
+		ApiKeyId:    order.APIKeyID,
 		Items:       OrderItemsToProto(order.Items),
 		Payments:    PaymentsToProto(order.Payments),
 	}
@@ -113,10 +230,15 @@ func OrderItemToProto(item *model.OrderItem) *pb.OrderItem {
 	}
 
 	return &pb.OrderItem{
-		Id:       item.ID,
-		OrderId:  item.OrderID,
-		ItemId:   item.ItemID,
-		Quantity: int32(item.Quantity),
+		Id:            item.ID,
+		OrderId:       item.OrderID,
+		ItemId:        item.ItemID,
+		Quantity:      int32(item.Quantity),
+		UnitPrice:     item.UnitPrice,
+		PriceListCode: item.PriceListCode,
+		PriceTier:     int32(item.PriceTier),
+		Sku:           item.Item.Sku,
+		Name:          item.Item.Name,
 	}
 }
 
@@ -154,13 +276,19 @@ func InvoiceToProto(invoice *model.Invoice) *pb.Invoice {
 	}
 
 	protoInvoice := &pb.Invoice{
-		Id:          invoice.ID,
-		ShipmentId:  invoice.ShipmentID,
-		OrderId:     invoice.OrderID,
-		TotalAmount: invoice.TotalAmount,
-		CreatedAt:   invoice.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   invoice.UpdatedAt.Format(time.RFC3339),
-		Items:       InvoiceItemsToProto(invoice.Items),
+		Id:             invoice.ID,
+		ShipmentId:     invoice.ShipmentID,
+		OrderId:        invoice.OrderID,
+		TotalAmount:    invoice.TotalAmount,
+		CreatedAt:      invoice.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      invoice.UpdatedAt.Format(time.RFC3339),
+		DueDate:        invoice.DueDate.Format(time.RFC3339),
+		PaidAmount:     invoice.PaidAmount,
+		CreditedAmount: invoice.CreditedAmount,
+		// The status is as of the time of the response, an unpaid invoice becomes overdue without being updated
+		OutstandingAmount: invoice.OutstandingAmount(),
+		PaymentStatus:     string(invoice.PaymentStatus(time.Now())),
+		Items:             append(InvoiceItemsToProto(invoice.Items), InvoiceChargesToProto(invoice.Charges)...),
 	}
 
 	return protoInvoice
@@ -193,6 +321,95 @@ func InvoiceItemToProto(item *model.InvoiceItem) *pb.InvoiceItem {
 	}
 }
 
+// InvoiceChargesToProto converts domain invoice charges to protocol buffer invoice lines of their type
+func InvoiceChargesToProto(charges []model.InvoiceCharge) []*pb.InvoiceItem {
+	if charges == nil {
+		return nil
+	}
+
+	protoItems := make([]*pb.InvoiceItem, len(charges))
+	for i, charge := range charges {
+		protoItems[i] = &pb.InvoiceItem{
+			Id:          charge.ID,
+			InvoiceId:   charge.InvoiceID,
+			Quantity:    1,
+			LineType:    ChargeTypeToProto(charge.Type),
+			Description: charge.Description,
+			Amount:      charge.Amount,
+			TaxCategory: charge.TaxCategory,
+		}
+	}
+	return protoItems
+}
+
+// ShippableQuantitiesToProto converts domain shippable quantities to protocol buffer shippable quantities
+func ShippableQuantitiesToProto(quantities []model.ShippableQuantity) []*pb.ShippableQuantity {
+	protoQuantities := make([]*pb.ShippableQuantity, len(quantities))
+	for i, quantity := range quantities {
+		protoQuantities[i] = &pb.ShippableQuantity{
+			Sku:       quantity.Sku,
+			ItemId:    quantity.ItemID,
+			Ordered:   int32(quantity.Ordered),
+			Invoiced:  int32(quantity.Invoiced),
+			Remaining: int32(quantity.Remaining),
+		}
+	}
+	return protoQuantities
+}
+
+// CreditNoteToProto converts a domain credit note to a protocol buffer credit note
+func CreditNoteToProto(creditNote *model.CreditNote) *pb.CreditNote {
+	if creditNote == nil {
+		return nil
+	}
+
+	protoItems := make([]*pb.CreditNoteItem, len(creditNote.Items))
+	for i, item := range creditNote.Items {
+		protoItems[i] = &pb.CreditNoteItem{
+			ItemId:    item.ItemID,
+			Sku:       item.Sku,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Amount,
+		}
+	}
+
+	return &pb.CreditNote{
+		Id:        creditNote.ID,
+		InvoiceId: creditNote.InvoiceID,
+		OrderId:   creditNote.OrderID,
+		Reference: creditNote.Reference,
+		Reason:    creditNote.Reason,
+		Amount:    creditNote.Amount,
+		Items:     protoItems,
+		CreatedAt: creditNote.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ChargeTypeToProto maps a domain ChargeType to a proto InvoiceLineType
+func ChargeTypeToProto(chargeType model.ChargeType) pb.InvoiceLineType {
+	switch chargeType {
+	case model.ChargeShipping:
+		return pb.InvoiceLineType_SHIPPING
+	default:
+		return pb.InvoiceLineType_ITEM
+	}
+}
+
+// ProtoShippingFeeToDTO converts a protocol buffer shipping fee to invoice charge requests, nil returns none
+func ProtoShippingFeeToDTO(fee *pb.ShippingFeeRequest) []dto.InvoiceChargeRequest {
+	if fee == nil {
+		return nil
+	}
+
+	return []dto.InvoiceChargeRequest{{
+		Type:        model.ChargeShipping,
+		Description: fee.Description,
+		Amount:      fee.Amount,
+		TaxCategory: fee.TaxCategory,
+	}}
+}
+
 // OrderStatusToProto maps a domain OrderStatus to a proto OrderStatus
 func OrderStatusToProto(status model.OrderStatus) pb.OrderStatus {
 	switch status {
@@ -206,3 +423,178 @@ func OrderStatusToProto(status model.OrderStatus) pb.OrderStatus {
 		return pb.OrderStatus_PENDING
 	}
 }
+
+// PlanToProto converts a domain plan to a protocol buffer plan
+func PlanToProto(plan *model.Plan) *pb.Plan {
+	if plan == nil {
+		return nil
+	}
+
+	return &pb.Plan{
+		Id:            plan.ID,
+		Code:          plan.Code,
+		Name:          plan.Name,
+		Sku:           plan.Sku,
+		Price:         plan.Price,
+		Interval:      string(plan.Interval),
+		IntervalCount: int32(plan.IntervalCount),
+		TrialDays:     int32(plan.TrialDays),
+	}
+}
+
+// SubscriptionToProto converts a domain subscription to a protocol buffer subscription
+func SubscriptionToProto(subscription *model.Subscription) *pb.Subscription {
+	if subscription == nil {
+		return nil
+	}
+
+	protoSubscription := &pb.Subscription{
+		Id:                 subscription.ID,
+		CustomerId:         subscription.CustomerID,
+		Plan:               PlanToProto(&subscription.Plan),
+		Status:             string(subscription.Status),
+		PaymentMethod:      string(subscription.PaymentMethod),
+		CurrentPeriodStart: subscription.CurrentPeriodStart.Format(time.RFC3339),
+		CurrentPeriodEnd:   subscription.CurrentPeriodEnd.Format(time.RFC3339),
+		NextBillingAt:      subscription.NextBillingAt.Format(time.RFC3339),
+		CancelAtPeriodEnd:  subscription.CancelAtPeriodEnd,
+		CreditBalance:      subscription.CreditBalance,
+		CreatedAt:          subscription.CreatedAt.Format(time.RFC3339),
+	}
+
+	if subscription.TrialEnd != nil {
+		protoSubscription.TrialEnd = subscription.TrialEnd.Format(time.RFC3339)
+	}
+
+	return protoSubscription
+}
+
+// MeterToProto converts a domain meter to a protocol buffer meter
+func MeterToProto(meter *model.Meter) *pb.Meter {
+	if meter == nil {
+		return nil
+	}
+
+	return &pb.Meter{
+		Id:           meter.ID,
+		Code:         meter.Code,
+		Name:         meter.Name,
+		Sku:          meter.Sku,
+		Aggregation:  string(meter.Aggregation),
+		PricingModel: string(meter.PricingModel),
+		Tiers:        PriceTiersToProto(meter.Tiers),
+	}
+}
+
+// PriceTiersToProto converts domain price tiers to protocol buffer price tiers
+func PriceTiersToProto(tiers model.PriceTiers) []*pb.PriceTier {
+	protoTiers := make([]*pb.PriceTier, 0, len(tiers))
+	for _, tier := range tiers {
+		protoTiers = append(protoTiers, &pb.PriceTier{
+			UpTo:      tier.UpTo,
+			UnitPrice: tier.UnitPrice,
+			FlatFee:   tier.FlatFee,
+		})
+	}
+	return protoTiers
+}
+
+// PriceListToProto converts a domain price list to a protocol buffer price list
+func PriceListToProto(priceList *model.PriceList) *pb.PriceList {
+	if priceList == nil {
+		return nil
+	}
+
+	protoPriceList := &pb.PriceList{
+		Id:         priceList.ID,
+		Code:       priceList.Code,
+		Name:       priceList.Name,
+		Kind:       string(priceList.Kind),
+		CustomerId: priceList.CustomerID,
+		ValidFrom:  priceList.ValidFrom.Format(time.RFC3339),
+		Entries:    make([]*pb.PriceListEntry, 0, len(priceList.Entries)),
+	}
+
+	if priceList.ValidTo != nil {
+		protoPriceList.ValidTo = priceList.ValidTo.Format(time.RFC3339)
+	}
+
+	for _, entry := range priceList.Entries {
+		protoEntry := &pb.PriceListEntry{
+			Tiers: PriceTiersToProto(entry.Tiers),
+		}
+		if entry.Item != nil {
+			protoEntry.Sku = entry.Item.Sku
+		}
+		protoPriceList.Entries = append(protoPriceList.Entries, protoEntry)
+	}
+
+	return protoPriceList
+}
+
+// QuoteToProto converts a domain quote to a protocol buffer quote response
+func QuoteToProto(quote *model.Quote) *pb.QuoteOrderResponse {
+	if quote == nil {
+		return nil
+	}
+
+	protoQuote := &pb.QuoteOrderResponse{
+		CustomerId:  quote.CustomerID,
+		Lines:       make([]*pb.QuoteLine, len(quote.Lines)),
+		TotalAmount: quote.TotalAmount,
+		QuoteToken:  quote.Token,
+		TaxAmount:   quote.TaxAmount,
+	}
+
+	if quote.ExpiresAt != nil {
+		protoQuote.ExpiresAt = quote.ExpiresAt.Format(time.RFC3339)
+	}
+
+	for i, line := range quote.Lines {
+		protoQuote.Lines[i] = &pb.QuoteLine{
+			Sku:           line.Sku,
+			ItemId:        line.ItemID,
+			Quantity:      int32(line.Quantity),
+			UnitPrice:     line.UnitPrice,
+			LineTotal:     line.LineTotal,
+			PriceListCode: line.PriceListCode,
+			PriceTier:     int32(line.PriceTier),
+			TaxAmount:     line.TaxAmount,
+		}
+	}
+
+	return protoQuote
+}
+
+// APIKeyToProto converts a domain API key to a protocol buffer API key, its secret hash is left out
+func APIKeyToProto(key *model.APIKey) *pb.APIKey {
+	if key == nil {
+		return nil
+	}
+
+	protoKey := &pb.APIKey{
+		Id:         key.ID,
+		KeyId:      key.KeyID,
+		Name:       key.Name,
+		CustomerId: key.CustomerID,
+		Roles:      key.Roles,
+		Scopes:     key.Scopes,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+	}
+
+	if key.ExpiresAt != nil {
+		protoKey.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if key.LastUsedAt != nil {
+		protoKey.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	if key.RevokedAt != nil {
+		protoKey.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+	}
+	if key.RotatedFromID != nil {
+		protoKey.RotatedFromId = *key.RotatedFromID
+	}
+
+	return protoKey
+}
@@ -200,4 +200,37 @@ func TestOrderStatusToProto(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, result)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestInvoiceToProto(t *testing.T) {
+	invoice := &model.Invoice{
+		Base:        model.Base{ID: 1},
+		OrderID:     10,
+		ShipmentID:  20,
+		TotalAmount: 112.5,
+		Items: []model.InvoiceItem{
+			{Base: model.Base{ID: 1}, InvoiceID: 1, ItemID: 5, Quantity: 1},
+		},
+		Charges: []model.InvoiceCharge{
+			{Base: model.Base{ID: 2}, InvoiceID: 1, Type: model.ChargeShipping, Description: "Shipping", Amount: 12.5, TaxCategory: "SHIPPING"},
+		},
+	}
+
+	result := utils.InvoiceToProto(invoice)
+
+	assert.Len(t, result.Items, 2)
+	assert.Equal(t, pb.InvoiceLineType_ITEM, result.Items[0].LineType)
+	assert.Equal(t, int64(5), result.Items[0].ItemId)
+	assert.Equal(t, pb.InvoiceLineType_SHIPPING, result.Items[1].LineType)
+	assert.Equal(t, int32(1), result.Items[1].Quantity)
+	assert.Equal(t, 12.5, result.Items[1].Amount)
+	assert.Equal(t, "SHIPPING", result.Items[1].TaxCategory)
+}
+
+func TestProtoShippingFeeToDTO(t *testing.T) {
+	assert.Nil(t, utils.ProtoShippingFeeToDTO(nil))
+
+	result := utils.ProtoShippingFeeToDTO(&pb.ShippingFeeRequest{Amount: 9.5, Description: "Express"})
+	assert.Equal(t, []dto.InvoiceChargeRequest{
+		{Type: model.ChargeShipping, Description: "Express", Amount: 9.5},
+	}, result)
+}
@@ -22,6 +22,53 @@ const (
 )
 
 // Order status enum
+// Kind of an invoice line
+type InvoiceLineType int32
+
+const (
+	InvoiceLineType_ITEM     InvoiceLineType = 0
+	InvoiceLineType_SHIPPING InvoiceLineType = 1
+)
+
+// Enum value maps for InvoiceLineType.
+var (
+	InvoiceLineType_name = map[int32]string{
+		0: "ITEM",
+		1: "SHIPPING",
+	}
+	InvoiceLineType_value = map[string]int32{
+		"ITEM":     0,
+		"SHIPPING": 1,
+	}
+)
+
+func (x InvoiceLineType) Enum() *InvoiceLineType {
+	p := new(InvoiceLineType)
+	*p = x
+	return p
+}
+
+func (x InvoiceLineType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvoiceLineType) Descriptor() protoreflect.EnumDescriptor {
+	return file_billing_proto_enumTypes[0].Descriptor()
+}
+
+func (InvoiceLineType) Type() protoreflect.EnumType {
+	return &file_billing_proto_enumTypes[0]
+}
+
+func (x InvoiceLineType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvoiceLineType.Descriptor instead.
+func (InvoiceLineType) EnumDescriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{0}
+}
+
 type OrderStatus int32
 
 const (
@@ -55,11 +102,11 @@ func (x OrderStatus) String() string {
 }
 
 func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_billing_proto_enumTypes[0].Descriptor()
+	return file_billing_proto_enumTypes[1].Descriptor()
 }
 
 func (OrderStatus) Type() protoreflect.EnumType {
-	return &file_billing_proto_enumTypes[0]
+	return &file_billing_proto_enumTypes[1]
 }
 
 func (x OrderStatus) Number() protoreflect.EnumNumber {
@@ -68,7 +115,7 @@ func (x OrderStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderStatus.Descriptor instead.
 func (OrderStatus) EnumDescriptor() ([]byte, []int) {
-	return file_billing_proto_rawDescGZIP(), []int{0}
+	return file_billing_proto_rawDescGZIP(), []int{1}
 }
 
 // Item request for order creation
@@ -191,6 +238,7 @@ type CreateOrderRequest struct {
 	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
 	Items         []*ItemRequest         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	Payments      []*PaymentRequest      `protobuf:"bytes,3,rep,name=payments,proto3" json:"payments,omitempty"`
+	QuoteToken    string                 `protobuf:"bytes,4,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"` // Creates the order for the items of a locked quote, items must be empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -246,6 +294,13 @@ func (x *CreateOrderRequest) GetPayments() []*PaymentRequest {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetQuoteToken() string {
+	if x != nil {
+		return x.QuoteToken
+	}
+	return ""
+}
+
 // Response message for creating an order
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -291,29 +346,28 @@ func (x *CreateOrderResponse) GetOrder() *Order {
 	return nil
 }
 
-// Invoice item for invoice creation
-type InvoiceItemRequest struct {
+// Request message for getting an order
+type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InvoiceItemRequest) Reset() {
-	*x = InvoiceItemRequest{}
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
 	mi := &file_billing_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InvoiceItemRequest) String() string {
+func (x *GetOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceItemRequest) ProtoMessage() {}
+func (*GetOrderRequest) ProtoMessage() {}
 
-func (x *InvoiceItemRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -325,49 +379,40 @@ func (x *InvoiceItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceItemRequest.ProtoReflect.Descriptor instead.
-func (*InvoiceItemRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *InvoiceItemRequest) GetSku() string {
-	if x != nil {
-		return x.Sku
-	}
-	return ""
-}
-
-func (x *InvoiceItemRequest) GetQuantity() int32 {
+func (x *GetOrderRequest) GetOrderId() int64 {
 	if x != nil {
-		return x.Quantity
+		return x.OrderId
 	}
 	return 0
 }
 
-// Request message for creating an invoice
-type CreateInvoiceRequest struct {
+// Response message for getting an order
+type GetOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
-	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         []*InvoiceItemRequest  `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateInvoiceRequest) Reset() {
-	*x = CreateInvoiceRequest{}
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
 	mi := &file_billing_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateInvoiceRequest) String() string {
+func (x *GetOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInvoiceRequest) ProtoMessage() {}
+func (*GetOrderResponse) ProtoMessage() {}
 
-func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -379,56 +424,42 @@ func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CreateInvoiceRequest) GetShipmentId() int64 {
-	if x != nil {
-		return x.ShipmentId
-	}
-	return 0
-}
-
-func (x *CreateInvoiceRequest) GetOrderId() int64 {
-	if x != nil {
-		return x.OrderId
-	}
-	return 0
-}
-
-func (x *CreateInvoiceRequest) GetItems() []*InvoiceItemRequest {
+func (x *GetOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.Items
+		return x.Order
 	}
 	return nil
 }
 
-// Response message for creating an invoice
-type CreateInvoiceResponse struct {
+// Request message for quoting a cart
+type QuoteOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Invoice       *Invoice               `protobuf:"bytes,3,opt,name=invoice,proto3" json:"invoice,omitempty"` // Optional invoice data on success
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Items         []*ItemRequest         `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	LockMinutes   int32                  `protobuf:"varint,3,opt,name=lock_minutes,json=lockMinutes,proto3" json:"lock_minutes,omitempty"` // Locks the quoted prices for this many minutes, zero returns no token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateInvoiceResponse) Reset() {
-	*x = CreateInvoiceResponse{}
+func (x *QuoteOrderRequest) Reset() {
+	*x = QuoteOrderRequest{}
 	mi := &file_billing_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateInvoiceResponse) String() string {
+func (x *QuoteOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInvoiceResponse) ProtoMessage() {}
+func (*QuoteOrderRequest) ProtoMessage() {}
 
-func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+func (x *QuoteOrderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -440,60 +471,61 @@ func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteOrderRequest.ProtoReflect.Descriptor instead.
+func (*QuoteOrderRequest) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateInvoiceResponse) GetCode() string {
+func (x *QuoteOrderRequest) GetCustomerId() string {
 	if x != nil {
-		return x.Code
+		return x.CustomerId
 	}
 	return ""
 }
 
-func (x *CreateInvoiceResponse) GetMessage() string {
+func (x *QuoteOrderRequest) GetItems() []*ItemRequest {
 	if x != nil {
-		return x.Message
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateInvoiceResponse) GetInvoice() *Invoice {
+func (x *QuoteOrderRequest) GetLockMinutes() int32 {
 	if x != nil {
-		return x.Invoice
+		return x.LockMinutes
 	}
-	return nil
+	return 0
 }
 
-// Invoice message representing an invoice
-type Invoice struct {
+// Price of one item of a quoted cart
+type QuoteLine struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ShipmentId    int64                  `protobuf:"varint,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
-	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Items         []*InvoiceItem         `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	ItemId        int64                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LineTotal     float64                `protobuf:"fixed64,5,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"`
+	PriceListCode string                 `protobuf:"bytes,6,opt,name=price_list_code,json=priceListCode,proto3" json:"price_list_code,omitempty"`
+	PriceTier     int32                  `protobuf:"varint,7,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`
+	TaxAmount     float64                `protobuf:"fixed64,8,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"` // Zero until taxes are computed
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Invoice) Reset() {
-	*x = Invoice{}
+func (x *QuoteLine) Reset() {
+	*x = QuoteLine{}
 	mi := &file_billing_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Invoice) String() string {
+func (x *QuoteLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Invoice) ProtoMessage() {}
+func (*QuoteLine) ProtoMessage() {}
 
-func (x *Invoice) ProtoReflect() protoreflect.Message {
+func (x *QuoteLine) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,85 +537,94 @@ func (x *Invoice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
-func (*Invoice) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteLine.ProtoReflect.Descriptor instead.
+func (*QuoteLine) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Invoice) GetId() int64 {
+func (x *QuoteLine) GetSku() string {
 	if x != nil {
-		return x.Id
+		return x.Sku
 	}
-	return 0
+	return ""
 }
 
-func (x *Invoice) GetShipmentId() int64 {
+func (x *QuoteLine) GetItemId() int64 {
 	if x != nil {
-		return x.ShipmentId
+		return x.ItemId
 	}
 	return 0
 }
 
-func (x *Invoice) GetOrderId() int64 {
+func (x *QuoteLine) GetQuantity() int32 {
 	if x != nil {
-		return x.OrderId
+		return x.Quantity
 	}
 	return 0
 }
 
-func (x *Invoice) GetTotalAmount() float64 {
+func (x *QuoteLine) GetUnitPrice() float64 {
 	if x != nil {
-		return x.TotalAmount
+		return x.UnitPrice
 	}
 	return 0
 }
 
-func (x *Invoice) GetItems() []*InvoiceItem {
+func (x *QuoteLine) GetLineTotal() float64 {
 	if x != nil {
-		return x.Items
+		return x.LineTotal
 	}
-	return nil
+	return 0
 }
 
-func (x *Invoice) GetCreatedAt() string {
+func (x *QuoteLine) GetPriceListCode() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.PriceListCode
 	}
 	return ""
 }
 
-func (x *Invoice) GetUpdatedAt() string {
+func (x *QuoteLine) GetPriceTier() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.PriceTier
 	}
-	return ""
+	return 0
 }
 
-// Invoice item detail
-type InvoiceItem struct {
+func (x *QuoteLine) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+// Response message for quoting a cart
+type QuoteOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	InvoiceId     int64                  `protobuf:"varint,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
-	ItemId        int64                  `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Lines         []*QuoteLine           `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	QuoteToken    string                 `protobuf:"bytes,4,opt,name=quote_token,json=quoteToken,proto3" json:"quote_token,omitempty"` // Empty unless prices were locked
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	TaxAmount     float64                `protobuf:"fixed64,6,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"` // Tax of the lines, not included in total_amount
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InvoiceItem) Reset() {
-	*x = InvoiceItem{}
+func (x *QuoteOrderResponse) Reset() {
+	*x = QuoteOrderResponse{}
 	mi := &file_billing_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InvoiceItem) String() string {
+func (x *QuoteOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceItem) ProtoMessage() {}
+func (*QuoteOrderResponse) ProtoMessage() {}
 
-func (x *InvoiceItem) ProtoReflect() protoreflect.Message {
+func (x *QuoteOrderResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -595,68 +636,76 @@ func (x *InvoiceItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceItem.ProtoReflect.Descriptor instead.
-func (*InvoiceItem) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteOrderResponse.ProtoReflect.Descriptor instead.
+func (*QuoteOrderResponse) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *InvoiceItem) GetId() int64 {
+func (x *QuoteOrderResponse) GetCustomerId() string {
 	if x != nil {
-		return x.Id
+		return x.CustomerId
 	}
-	return 0
+	return ""
 }
 
-func (x *InvoiceItem) GetInvoiceId() int64 {
+func (x *QuoteOrderResponse) GetLines() []*QuoteLine {
 	if x != nil {
-		return x.InvoiceId
+		return x.Lines
 	}
-	return 0
+	return nil
 }
 
-func (x *InvoiceItem) GetItemId() int64 {
+func (x *QuoteOrderResponse) GetTotalAmount() float64 {
 	if x != nil {
-		return x.ItemId
+		return x.TotalAmount
 	}
 	return 0
 }
 
-func (x *InvoiceItem) GetQuantity() int32 {
+func (x *QuoteOrderResponse) GetQuoteToken() string {
 	if x != nil {
-		return x.Quantity
+		return x.QuoteToken
+	}
+	return ""
+}
+
+func (x *QuoteOrderResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *QuoteOrderResponse) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
 	}
 	return 0
 }
 
-// Order message representing an order
-type Order struct {
+// Invoice item for invoice creation
+type InvoiceItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=billing.OrderStatus" json:"status,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
-	Payments      []*Payment             `protobuf:"bytes,6,rep,name=payments,proto3" json:"payments,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
+func (x *InvoiceItemRequest) Reset() {
+	*x = InvoiceItemRequest{}
 	mi := &file_billing_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
+func (x *InvoiceItemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Order) ProtoMessage() {}
+func (*InvoiceItemRequest) ProtoMessage() {}
 
-func (x *Order) ProtoReflect() protoreflect.Message {
+func (x *InvoiceItemRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_billing_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -668,93 +717,2987 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
+// Deprecated: Use InvoiceItemRequest.ProtoReflect.Descriptor instead.
+func (*InvoiceItemRequest) Descriptor() ([]byte, []int) {
 	return file_billing_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Order) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Order) GetCustomerId() string {
+func (x *InvoiceItemRequest) GetSku() string {
 	if x != nil {
-		return x.CustomerId
+		return x.Sku
 	}
 	return ""
 }
 
-func (x *Order) GetTotalAmount() float64 {
+func (x *InvoiceItemRequest) GetQuantity() int32 {
 	if x != nil {
-		return x.TotalAmount
+		return x.Quantity
 	}
 	return 0
 }
 
-func (x *Order) GetStatus() OrderStatus {
-	if x != nil {
-		return x.Status
-	}
-	return OrderStatus_PENDING
-}
-
-func (x *Order) GetItems() []*OrderItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
+// Request message for creating an invoice
+type CreateInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*InvoiceItemRequest  `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingFee   *ShippingFeeRequest    `protobuf:"bytes,4,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"` // Optional shipping fee billed with the items
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) GetPayments() []*Payment {
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_billing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateInvoiceRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetItems() []*InvoiceItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetShippingFee() *ShippingFeeRequest {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return nil
+}
+
+// Shipping fee billed on an invoice
+type ShippingFeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	TaxCategory   string                 `protobuf:"bytes,3,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"` // Defaults to SHIPPING
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingFeeRequest) Reset() {
+	*x = ShippingFeeRequest{}
+	mi := &file_billing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingFeeRequest) ProtoMessage() {}
+
+func (x *ShippingFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingFeeRequest.ProtoReflect.Descriptor instead.
+func (*ShippingFeeRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ShippingFeeRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ShippingFeeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ShippingFeeRequest) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+// Response message for creating an invoice
+// Failures are returned as error statuses with an ErrorInfo detail
+type CreateInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // Always SUCCESS, kept for older clients
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invoice       *Invoice               `protobuf:"bytes,3,opt,name=invoice,proto3" json:"invoice,omitempty"` // Optional invoice data on success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	mi := &file_billing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateInvoiceResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+// Request message for paying an invoice
+type PayInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceId     int64                  `protobuf:"varint,1,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayInvoiceRequest) Reset() {
+	*x = PayInvoiceRequest{}
+	mi := &file_billing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayInvoiceRequest) ProtoMessage() {}
+
+func (x *PayInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*PayInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PayInvoiceRequest) GetInvoiceId() int64 {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return 0
+}
+
+func (x *PayInvoiceRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Response message for paying an invoice
+type PayInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayInvoiceResponse) Reset() {
+	*x = PayInvoiceResponse{}
+	mi := &file_billing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayInvoiceResponse) ProtoMessage() {}
+
+func (x *PayInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*PayInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PayInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+// Request message for getting the shippable quantities of an order
+type GetShippableQuantitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippableQuantitiesRequest) Reset() {
+	*x = GetShippableQuantitiesRequest{}
+	mi := &file_billing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippableQuantitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippableQuantitiesRequest) ProtoMessage() {}
+
+func (x *GetShippableQuantitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippableQuantitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetShippableQuantitiesRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetShippableQuantitiesRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// Shippable quantity of an ordered SKU
+type ShippableQuantity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	ItemId        int64                  `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Ordered       int32                  `protobuf:"varint,3,opt,name=ordered,proto3" json:"ordered,omitempty"`
+	Invoiced      int32                  `protobuf:"varint,4,opt,name=invoiced,proto3" json:"invoiced,omitempty"`
+	Remaining     int32                  `protobuf:"varint,5,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippableQuantity) Reset() {
+	*x = ShippableQuantity{}
+	mi := &file_billing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippableQuantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippableQuantity) ProtoMessage() {}
+
+func (x *ShippableQuantity) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippableQuantity.ProtoReflect.Descriptor instead.
+func (*ShippableQuantity) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ShippableQuantity) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ShippableQuantity) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ShippableQuantity) GetOrdered() int32 {
+	if x != nil {
+		return x.Ordered
+	}
+	return 0
+}
+
+func (x *ShippableQuantity) GetInvoiced() int32 {
+	if x != nil {
+		return x.Invoiced
+	}
+	return 0
+}
+
+func (x *ShippableQuantity) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+// Response message for getting the shippable quantities of an order
+type GetShippableQuantitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quantities    []*ShippableQuantity   `protobuf:"bytes,1,rep,name=quantities,proto3" json:"quantities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippableQuantitiesResponse) Reset() {
+	*x = GetShippableQuantitiesResponse{}
+	mi := &file_billing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippableQuantitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippableQuantitiesResponse) ProtoMessage() {}
+
+func (x *GetShippableQuantitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippableQuantitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetShippableQuantitiesResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetShippableQuantitiesResponse) GetQuantities() []*ShippableQuantity {
+	if x != nil {
+		return x.Quantities
+	}
+	return nil
+}
+
+// Request message for listing the invoices of an order
+type ListOrderInvoicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderInvoicesRequest) Reset() {
+	*x = ListOrderInvoicesRequest{}
+	mi := &file_billing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderInvoicesRequest) ProtoMessage() {}
+
+func (x *ListOrderInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListOrderInvoicesRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+// Response message for listing the invoices of an order
+type ListOrderInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderInvoicesResponse) Reset() {
+	*x = ListOrderInvoicesResponse{}
+	mi := &file_billing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderInvoicesResponse) ProtoMessage() {}
+
+func (x *ListOrderInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListOrderInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+// Request message for creating a credit note
+type CreateCreditNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"` // Identifies the request, a retried reference returns the issued note
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*InvoiceItemRequest  `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCreditNoteRequest) Reset() {
+	*x = CreateCreditNoteRequest{}
+	mi := &file_billing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCreditNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCreditNoteRequest) ProtoMessage() {}
+
+func (x *CreateCreditNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCreditNoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateCreditNoteRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateCreditNoteRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *CreateCreditNoteRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CreateCreditNoteRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateCreditNoteRequest) GetItems() []*InvoiceItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Response message for creating a credit note
+// Failures are returned as error statuses with an ErrorInfo detail
+type CreateCreditNoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // Always SUCCESS, kept for older clients
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CreditNote    *CreditNote            `protobuf:"bytes,3,opt,name=credit_note,json=creditNote,proto3" json:"credit_note,omitempty"` // Optional credit note data on success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCreditNoteResponse) Reset() {
+	*x = CreateCreditNoteResponse{}
+	mi := &file_billing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCreditNoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCreditNoteResponse) ProtoMessage() {}
+
+func (x *CreateCreditNoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCreditNoteResponse.ProtoReflect.Descriptor instead.
+func (*CreateCreditNoteResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateCreditNoteResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateCreditNoteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateCreditNoteResponse) GetCreditNote() *CreditNote {
+	if x != nil {
+		return x.CreditNote
+	}
+	return nil
+}
+
+// Credit note detail
+type CreditNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceId     int64                  `protobuf:"varint,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount        float64                `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Items         []*CreditNoteItem      `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditNote) Reset() {
+	*x = CreditNote{}
+	mi := &file_billing_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditNote) ProtoMessage() {}
+
+func (x *CreditNote) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditNote.ProtoReflect.Descriptor instead.
+func (*CreditNote) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreditNote) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CreditNote) GetInvoiceId() int64 {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return 0
+}
+
+func (x *CreditNote) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreditNote) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CreditNote) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreditNote) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreditNote) GetItems() []*CreditNoteItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreditNote) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Credit note item detail
+type CreditNoteItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        int64                  `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditNoteItem) Reset() {
+	*x = CreditNoteItem{}
+	mi := &file_billing_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditNoteItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditNoteItem) ProtoMessage() {}
+
+func (x *CreditNoteItem) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditNoteItem.ProtoReflect.Descriptor instead.
+func (*CreditNoteItem) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreditNoteItem) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *CreditNoteItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreditNoteItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreditNoteItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *CreditNoteItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Request message for creating a plan
+type CreatePlanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Interval      string                 `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"` // MONTH, YEAR
+	IntervalCount int32                  `protobuf:"varint,6,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"`
+	TrialDays     int32                  `protobuf:"varint,7,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlanRequest) Reset() {
+	*x = CreatePlanRequest{}
+	mi := &file_billing_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanRequest) ProtoMessage() {}
+
+func (x *CreatePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanRequest.ProtoReflect.Descriptor instead.
+func (*CreatePlanRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreatePlanRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreatePlanRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CreatePlanRequest) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *CreatePlanRequest) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+// Response message for creating a plan
+type CreatePlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *Plan                  `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePlanResponse) Reset() {
+	*x = CreatePlanResponse{}
+	mi := &file_billing_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePlanResponse) ProtoMessage() {}
+
+func (x *CreatePlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePlanResponse.ProtoReflect.Descriptor instead.
+func (*CreatePlanResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreatePlanResponse) GetPlan() *Plan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// Request message for creating a subscription
+type CreateSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	PlanCode      string                 `protobuf:"bytes,2,opt,name=plan_code,json=planCode,proto3" json:"plan_code,omitempty"`
+	PaymentMethod string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // COD, VN_PAY, etc.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubscriptionRequest) Reset() {
+	*x = CreateSubscriptionRequest{}
+	mi := &file_billing_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateSubscriptionRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetPlanCode() string {
+	if x != nil {
+		return x.PlanCode
+	}
+	return ""
+}
+
+func (x *CreateSubscriptionRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+// Request message for changing the plan of a subscription
+type ChangeSubscriptionPlanRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int64                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	PlanCode       string                 `protobuf:"bytes,2,opt,name=plan_code,json=planCode,proto3" json:"plan_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChangeSubscriptionPlanRequest) Reset() {
+	*x = ChangeSubscriptionPlanRequest{}
+	mi := &file_billing_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeSubscriptionPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeSubscriptionPlanRequest) ProtoMessage() {}
+
+func (x *ChangeSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
+func (*ChangeSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ChangeSubscriptionPlanRequest) GetSubscriptionId() int64 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *ChangeSubscriptionPlanRequest) GetPlanCode() string {
+	if x != nil {
+		return x.PlanCode
+	}
+	return ""
+}
+
+// Request message identifying a subscription
+type SubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int64                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubscriptionRequest) Reset() {
+	*x = SubscriptionRequest{}
+	mi := &file_billing_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionRequest) ProtoMessage() {}
+
+func (x *SubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*SubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SubscriptionRequest) GetSubscriptionId() int64 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+// Response message carrying a subscription
+type SubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *Subscription          `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscriptionResponse) Reset() {
+	*x = SubscriptionResponse{}
+	mi := &file_billing_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionResponse) ProtoMessage() {}
+
+func (x *SubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*SubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+// Invoice message representing an invoice
+type Invoice struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShipmentId        int64                  `protobuf:"varint,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId           int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TotalAmount       float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Items             []*InvoiceItem         `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DueDate           string                 `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	PaidAmount        float64                `protobuf:"fixed64,9,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	CreditedAmount    float64                `protobuf:"fixed64,10,opt,name=credited_amount,json=creditedAmount,proto3" json:"credited_amount,omitempty"`
+	OutstandingAmount float64                `protobuf:"fixed64,11,opt,name=outstanding_amount,json=outstandingAmount,proto3" json:"outstanding_amount,omitempty"` // Negative when credit notes leave more paid than owed
+	PaymentStatus     string                 `protobuf:"bytes,12,opt,name=payment_status,json=paymentStatus,proto3" json:"payment_status,omitempty"`               // UNPAID, PARTIALLY_PAID, PAID, OVERDUE
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_billing_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Invoice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Invoice) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *Invoice) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Invoice) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetItems() []*InvoiceItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Invoice) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Invoice) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Invoice) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaidAmount() float64 {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetCreditedAmount() float64 {
+	if x != nil {
+		return x.CreditedAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetOutstandingAmount() float64 {
+	if x != nil {
+		return x.OutstandingAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetPaymentStatus() string {
+	if x != nil {
+		return x.PaymentStatus
+	}
+	return ""
+}
+
+// Invoice item detail
+type InvoiceItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	InvoiceId     int64                  `protobuf:"varint,2,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	ItemId        int64                  `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"` // Not set on charge lines
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LineType      InvoiceLineType        `protobuf:"varint,5,opt,name=line_type,json=lineType,proto3,enum=billing.InvoiceLineType" json:"line_type,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`                    // Set on charge lines
+	Amount        float64                `protobuf:"fixed64,7,opt,name=amount,proto3" json:"amount,omitempty"`                            // Set on charge lines
+	TaxCategory   string                 `protobuf:"bytes,8,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"` // Set on charge lines
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceItem) Reset() {
+	*x = InvoiceItem{}
+	mi := &file_billing_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceItem) ProtoMessage() {}
+
+func (x *InvoiceItem) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceItem.ProtoReflect.Descriptor instead.
+func (*InvoiceItem) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *InvoiceItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetInvoiceId() int64 {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetLineType() InvoiceLineType {
+	if x != nil {
+		return x.LineType
+	}
+	return InvoiceLineType_ITEM
+}
+
+func (x *InvoiceItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InvoiceItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *InvoiceItem) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+// Order message representing an order
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=billing.OrderStatus" json:"status,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Payments      []*Payment             `protobuf:"bytes,6,rep,name=payments,proto3" json:"payments,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PaymentTerm   string                 `protobuf:"bytes,9,opt,name=payment_term,json=paymentTerm,proto3" json:"payment_term,omitempty"` // NET_7, NET_15, NET_30
+	ApiKeyId      string                 `protobuf:"bytes,10,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`       // Public id of the API key the order was created with, empty for users
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_billing_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Order) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Order) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Order) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_PENDING
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetPaymentTerm() string {
+	if x != nil {
+		return x.PaymentTerm
+	}
+	return ""
+}
+
+func (x *Order) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+// OrderItem message representing an item in an order
+type OrderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ItemId        int64                  `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	PriceListCode string                 `protobuf:"bytes,6,opt,name=price_list_code,json=priceListCode,proto3" json:"price_list_code,omitempty"` // Price list the unit price came from, empty for the catalog price
+	PriceTier     int32                  `protobuf:"varint,7,opt,name=price_tier,json=priceTier,proto3" json:"price_tier,omitempty"`              // 1-based quantity tier of the price list
+	Sku           string                 `protobuf:"bytes,8,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_billing_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OrderItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderItem) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetItemId() int64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPriceListCode() string {
+	if x != nil {
+		return x.PriceListCode
+	}
+	return ""
+}
+
+func (x *OrderItem) GetPriceTier() int32 {
+	if x != nil {
+		return x.PriceTier
+	}
+	return 0
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Payment message representing a payment for an order
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_billing_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Payment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Payment) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Payment) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Plan message representing a recurring plan
+type Plan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Interval      string                 `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	IntervalCount int32                  `protobuf:"varint,7,opt,name=interval_count,json=intervalCount,proto3" json:"interval_count,omitempty"`
+	TrialDays     int32                  `protobuf:"varint,8,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	mi := &file_billing_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Plan) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Plan) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Plan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plan) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Plan) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Plan) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Plan) GetIntervalCount() int32 {
+	if x != nil {
+		return x.IntervalCount
+	}
+	return 0
+}
+
+func (x *Plan) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+// Subscription message representing a customer's subscription
+type Subscription struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId         string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Plan               *Plan                  `protobuf:"bytes,3,opt,name=plan,proto3" json:"plan,omitempty"`
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // TRIALING, ACTIVE, PAUSED, CANCELED
+	PaymentMethod      string                 `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	TrialEnd           string                 `protobuf:"bytes,6,opt,name=trial_end,json=trialEnd,proto3" json:"trial_end,omitempty"`
+	CurrentPeriodStart string                 `protobuf:"bytes,7,opt,name=current_period_start,json=currentPeriodStart,proto3" json:"current_period_start,omitempty"`
+	CurrentPeriodEnd   string                 `protobuf:"bytes,8,opt,name=current_period_end,json=currentPeriodEnd,proto3" json:"current_period_end,omitempty"`
+	NextBillingAt      string                 `protobuf:"bytes,9,opt,name=next_billing_at,json=nextBillingAt,proto3" json:"next_billing_at,omitempty"`
+	CancelAtPeriodEnd  bool                   `protobuf:"varint,10,opt,name=cancel_at_period_end,json=cancelAtPeriodEnd,proto3" json:"cancel_at_period_end,omitempty"`
+	CreditBalance      float64                `protobuf:"fixed64,11,opt,name=credit_balance,json=creditBalance,proto3" json:"credit_balance,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	mi := &file_billing_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Subscription) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subscription) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Subscription) GetPlan() *Plan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *Subscription) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subscription) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *Subscription) GetTrialEnd() string {
+	if x != nil {
+		return x.TrialEnd
+	}
+	return ""
+}
+
+func (x *Subscription) GetCurrentPeriodStart() string {
+	if x != nil {
+		return x.CurrentPeriodStart
+	}
+	return ""
+}
+
+func (x *Subscription) GetCurrentPeriodEnd() string {
+	if x != nil {
+		return x.CurrentPeriodEnd
+	}
+	return ""
+}
+
+func (x *Subscription) GetNextBillingAt() string {
+	if x != nil {
+		return x.NextBillingAt
+	}
+	return ""
+}
+
+func (x *Subscription) GetCancelAtPeriodEnd() bool {
+	if x != nil {
+		return x.CancelAtPeriodEnd
+	}
+	return false
+}
+
+func (x *Subscription) GetCreditBalance() float64 {
+	if x != nil {
+		return x.CreditBalance
+	}
+	return 0
+}
+
+func (x *Subscription) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Price tier of a meter, up_to is inclusive and 0 means unbounded
+type PriceTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpTo          float64                `protobuf:"fixed64,1,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,2,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	FlatFee       float64                `protobuf:"fixed64,3,opt,name=flat_fee,json=flatFee,proto3" json:"flat_fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceTier) Reset() {
+	*x = PriceTier{}
+	mi := &file_billing_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceTier) ProtoMessage() {}
+
+func (x *PriceTier) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceTier.ProtoReflect.Descriptor instead.
+func (*PriceTier) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PriceTier) GetUpTo() float64 {
+	if x != nil {
+		return x.UpTo
+	}
+	return 0
+}
+
+func (x *PriceTier) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *PriceTier) GetFlatFee() float64 {
+	if x != nil {
+		return x.FlatFee
+	}
+	return 0
+}
+
+// Request message for creating a meter
+type CreateMeterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Sku           string                 `protobuf:"bytes,3,opt,name=sku,proto3" json:"sku,omitempty"`
+	Aggregation   string                 `protobuf:"bytes,4,opt,name=aggregation,proto3" json:"aggregation,omitempty"`                       // SUM, MAX or LAST
+	PricingModel  string                 `protobuf:"bytes,5,opt,name=pricing_model,json=pricingModel,proto3" json:"pricing_model,omitempty"` // TIERED or VOLUME
+	Tiers         []*PriceTier           `protobuf:"bytes,6,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMeterRequest) Reset() {
+	*x = CreateMeterRequest{}
+	mi := &file_billing_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMeterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMeterRequest) ProtoMessage() {}
+
+func (x *CreateMeterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMeterRequest.ProtoReflect.Descriptor instead.
+func (*CreateMeterRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateMeterRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateMeterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMeterRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateMeterRequest) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
+}
+
+func (x *CreateMeterRequest) GetPricingModel() string {
+	if x != nil {
+		return x.PricingModel
+	}
+	return ""
+}
+
+func (x *CreateMeterRequest) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// Response message for creating a meter
+type CreateMeterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meter         *Meter                 `protobuf:"bytes,1,opt,name=meter,proto3" json:"meter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMeterResponse) Reset() {
+	*x = CreateMeterResponse{}
+	mi := &file_billing_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMeterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMeterResponse) ProtoMessage() {}
+
+func (x *CreateMeterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMeterResponse.ProtoReflect.Descriptor instead.
+func (*CreateMeterResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CreateMeterResponse) GetMeter() *Meter {
+	if x != nil {
+		return x.Meter
+	}
+	return nil
+}
+
+// Meter message representing a usage metric
+type Meter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Sku           string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	Aggregation   string                 `protobuf:"bytes,5,opt,name=aggregation,proto3" json:"aggregation,omitempty"`
+	PricingModel  string                 `protobuf:"bytes,6,opt,name=pricing_model,json=pricingModel,proto3" json:"pricing_model,omitempty"`
+	Tiers         []*PriceTier           `protobuf:"bytes,7,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Meter) Reset() {
+	*x = Meter{}
+	mi := &file_billing_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meter) ProtoMessage() {}
+
+func (x *Meter) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meter.ProtoReflect.Descriptor instead.
+func (*Meter) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Meter) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Meter) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Meter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Meter) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Meter) GetAggregation() string {
+	if x != nil {
+		return x.Aggregation
+	}
+	return ""
+}
+
+func (x *Meter) GetPricingModel() string {
+	if x != nil {
+		return x.PricingModel
+	}
+	return ""
+}
+
+func (x *Meter) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// Usage event reported for a customer
+type UsageEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId     string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Metric         string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"` // Meter code
+	Quantity       float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Timestamp      string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // RFC3339, defaults to the time it is received
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UsageEvent) Reset() {
+	*x = UsageEvent{}
+	mi := &file_billing_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageEvent) ProtoMessage() {}
+
+func (x *UsageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageEvent.ProtoReflect.Descriptor instead.
+func (*UsageEvent) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UsageEvent) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UsageEvent) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *UsageEvent) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *UsageEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *UsageEvent) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Usage event that could not be stored
+type RejectedUsageEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RejectedUsageEvent) Reset() {
+	*x = RejectedUsageEvent{}
+	mi := &file_billing_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedUsageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedUsageEvent) ProtoMessage() {}
+
+func (x *RejectedUsageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedUsageEvent.ProtoReflect.Descriptor instead.
+func (*RejectedUsageEvent) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RejectedUsageEvent) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RejectedUsageEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Response message for recording usage
+type RecordUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Rejected      []*RejectedUsageEvent  `protobuf:"bytes,3,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordUsageResponse) Reset() {
+	*x = RecordUsageResponse{}
+	mi := &file_billing_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordUsageResponse) ProtoMessage() {}
+
+func (x *RecordUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordUsageResponse.ProtoReflect.Descriptor instead.
+func (*RecordUsageResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RecordUsageResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *RecordUsageResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *RecordUsageResponse) GetRejected() []*RejectedUsageEvent {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+// Price of one item in a price list
+type PriceListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Tiers         []*PriceTier           `protobuf:"bytes,2,rep,name=tiers,proto3" json:"tiers,omitempty"` // Quantity tiers, unit_price applies to every unit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceListEntry) Reset() {
+	*x = PriceListEntry{}
+	mi := &file_billing_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceListEntry) ProtoMessage() {}
+
+func (x *PriceListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceListEntry.ProtoReflect.Descriptor instead.
+func (*PriceListEntry) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PriceListEntry) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *PriceListEntry) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// Request message for creating a price list
+type CreatePriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                               // RETAIL, WHOLESALE or CONTRACT
+	CustomerId    string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Required for CONTRACT price lists
+	ValidFrom     string                 `protobuf:"bytes,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`    // RFC3339, defaults to now
+	ValidTo       string                 `protobuf:"bytes,6,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`          // RFC3339, empty means no end
+	Entries       []*PriceListEntry      `protobuf:"bytes,7,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListRequest) Reset() {
+	*x = CreatePriceListRequest{}
+	mi := &file_billing_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListRequest) ProtoMessage() {}
+
+func (x *CreatePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreatePriceListRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Response message for creating a price list
+type CreatePriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListResponse) Reset() {
+	*x = CreatePriceListResponse{}
+	mi := &file_billing_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListResponse) ProtoMessage() {}
+
+func (x *CreatePriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListResponse.ProtoReflect.Descriptor instead.
+func (*CreatePriceListResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreatePriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+// PriceList message representing a price list
+type PriceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,5,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ValidFrom     string                 `protobuf:"bytes,6,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       string                 `protobuf:"bytes,7,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	Entries       []*PriceListEntry      `protobuf:"bytes,8,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceList) Reset() {
+	*x = PriceList{}
+	mi := &file_billing_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceList) ProtoMessage() {}
+
+func (x *PriceList) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceList.ProtoReflect.Descriptor instead.
+func (*PriceList) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PriceList) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceList) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PriceList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceList) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PriceList) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *PriceList) GetValidFrom() string {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return ""
+}
+
+func (x *PriceList) GetValidTo() string {
+	if x != nil {
+		return x.ValidTo
+	}
+	return ""
+}
+
+func (x *PriceList) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// APIKey message representing the credential of a server-to-server client, without its secret
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyId         string                 `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // Public part of the key, recorded on the orders and shipments it creates
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Set on keys with the customer role only
+	Roles         []string               `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	Scopes        []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`                        // Narrow the permissions of the roles, empty means the roles apply in full
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339, empty means no expiry
+	LastUsedAt    string                 `protobuf:"bytes,8,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     string                 `protobuf:"bytes,9,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	RotatedFromId int64                  `protobuf:"varint,10,opt,name=rotated_from_id,json=rotatedFromId,proto3" json:"rotated_from_id,omitempty"` // Key this key replaced, 0 when it was issued
+	CreatedBy     string                 `protobuf:"bytes,11,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_billing_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *APIKey) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *APIKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *APIKey) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetLastUsedAt() string {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+func (x *APIKey) GetRotatedFromId() int64 {
+	if x != nil {
+		return x.RotatedFromId
+	}
+	return 0
+}
+
+func (x *APIKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *APIKey) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Request message for issuing an API key
+type IssueAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339, empty means no expiry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueAPIKeyRequest) Reset() {
+	*x = IssueAPIKeyRequest{}
+	mi := &file_billing_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueAPIKeyRequest) ProtoMessage() {}
+
+func (x *IssueAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*IssueAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *IssueAPIKeyRequest) GetName() string {
 	if x != nil {
-		return x.Payments
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Order) GetCreatedAt() string {
+func (x *IssueAPIKeyRequest) GetCustomerId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.CustomerId
 	}
 	return ""
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *IssueAPIKeyRequest) GetRoles() []string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *IssueAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *IssueAPIKeyRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return ""
 }
 
-// OrderItem message representing an item in an order
-type OrderItem struct {
+// Response message carrying a new API key and its secret
+type APIKeySecretResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ItemId        int64                  `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ApiKey        *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // Shown once, only its hash is stored
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_billing_proto_msgTypes[10]
+func (x *APIKeySecretResponse) Reset() {
+	*x = APIKeySecretResponse{}
+	mi := &file_billing_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *APIKeySecretResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*APIKeySecretResponse) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_billing_proto_msgTypes[10]
+func (x *APIKeySecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,65 +3708,138 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_billing_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use APIKeySecretResponse.ProtoReflect.Descriptor instead.
+func (*APIKeySecretResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *OrderItem) GetId() int64 {
+func (x *APIKeySecretResponse) GetApiKey() *APIKey {
 	if x != nil {
-		return x.Id
+		return x.ApiKey
 	}
-	return 0
+	return nil
 }
 
-func (x *OrderItem) GetOrderId() int64 {
+func (x *APIKeySecretResponse) GetSecret() string {
 	if x != nil {
-		return x.OrderId
+		return x.Secret
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderItem) GetItemId() int64 {
+// Request message for listing API keys
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Empty lists every key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_billing_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[51]
 	if x != nil {
-		return x.ItemId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListAPIKeysRequest) GetCustomerId() string {
 	if x != nil {
-		return x.Quantity
+		return x.CustomerId
 	}
-	return 0
+	return ""
 }
 
-// Payment message representing a payment for an order
-type Payment struct {
+// Response message for listing API keys
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_billing_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+// Request message naming an API key
+type APIKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
-	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Payment) Reset() {
-	*x = Payment{}
-	mi := &file_billing_proto_msgTypes[11]
+func (x *APIKeyRequest) Reset() {
+	*x = APIKeyRequest{}
+	mi := &file_billing_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Payment) String() string {
+func (x *APIKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Payment) ProtoMessage() {}
+func (*APIKeyRequest) ProtoMessage() {}
 
-func (x *Payment) ProtoReflect() protoreflect.Message {
-	mi := &file_billing_proto_msgTypes[11]
+func (x *APIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,37 +3850,159 @@ func (x *Payment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
-func (*Payment) Descriptor() ([]byte, []int) {
-	return file_billing_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use APIKeyRequest.ProtoReflect.Descriptor instead.
+func (*APIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Payment) GetId() int64 {
+func (x *APIKeyRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Payment) GetOrderId() int64 {
+// Request message for rotating an API key
+type RotateAPIKeyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OverlapMinutes int32                  `protobuf:"varint,2,opt,name=overlap_minutes,json=overlapMinutes,proto3" json:"overlap_minutes,omitempty"` // How long the previous key stays valid, 0 uses the configured overlap
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RotateAPIKeyRequest) Reset() {
+	*x = RotateAPIKeyRequest{}
+	mi := &file_billing_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateAPIKeyRequest) ProtoMessage() {}
+
+func (x *RotateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[54]
 	if x != nil {
-		return x.OrderId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RotateAPIKeyRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
 	return 0
 }
 
-func (x *Payment) GetMethod() string {
+func (x *RotateAPIKeyRequest) GetOverlapMinutes() int32 {
 	if x != nil {
-		return x.Method
+		return x.OverlapMinutes
+	}
+	return 0
+}
+
+// Request message for verifying the secret of an API key
+type VerifyAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyAPIKeyRequest) Reset() {
+	*x = VerifyAPIKeyRequest{}
+	mi := &file_billing_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyAPIKeyRequest) ProtoMessage() {}
+
+func (x *VerifyAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*VerifyAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *VerifyAPIKeyRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
 	}
 	return ""
 }
 
-func (x *Payment) GetAmount() float64 {
+// Response message carrying an API key
+type APIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiKey        *APIKey                `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKeyResponse) Reset() {
+	*x = APIKeyResponse{}
+	mi := &file_billing_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyResponse) ProtoMessage() {}
+
+func (x *APIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_billing_proto_msgTypes[56]
 	if x != nil {
-		return x.Amount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyResponse.ProtoReflect.Descriptor instead.
+func (*APIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_billing_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *APIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
 }
 
 var File_billing_proto protoreflect.FileDescriptor
@@ -878,26 +4016,141 @@ const file_billing_proto_rawDesc = "" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\"@\n" +
 	"\x0ePaymentRequest\x12\x16\n" +
 	"\x06method\x18\x01 \x01(\tR\x06method\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\x96\x01\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xb7\x01\n" +
 	"\x12CreateOrderRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\tR\n" +
 	"customerId\x12*\n" +
 	"\x05items\x18\x02 \x03(\v2\x14.billing.ItemRequestR\x05items\x123\n" +
-	"\bpayments\x18\x03 \x03(\v2\x17.billing.PaymentRequestR\bpayments\";\n" +
+	"\bpayments\x18\x03 \x03(\v2\x17.billing.PaymentRequestR\bpayments\x12\x1f\n" +
+	"\vquote_token\x18\x04 \x01(\tR\n" +
+	"quoteToken\";\n" +
 	"\x13CreateOrderResponse\x12$\n" +
-	"\x05order\x18\x03 \x01(\v2\x0e.billing.OrderR\x05order\"B\n" +
+	"\x05order\x18\x03 \x01(\v2\x0e.billing.OrderR\x05order\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"8\n" +
+	"\x10GetOrderResponse\x12$\n" +
+	"\x05order\x18\x01 \x01(\v2\x0e.billing.OrderR\x05order\"\x83\x01\n" +
+	"\x11QuoteOrderRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12*\n" +
+	"\x05items\x18\x02 \x03(\v2\x14.billing.ItemRequestR\x05items\x12!\n" +
+	"\flock_minutes\x18\x03 \x01(\x05R\vlockMinutes\"\xf6\x01\n" +
+	"\tQuoteLine\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x03R\x06itemId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x1d\n" +
+	"\n" +
+	"line_total\x18\x05 \x01(\x01R\tlineTotal\x12&\n" +
+	"\x0fprice_list_code\x18\x06 \x01(\tR\rpriceListCode\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\a \x01(\x05R\tpriceTier\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\b \x01(\x01R\ttaxAmount\"\xe1\x01\n" +
+	"\x12QuoteOrderResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12(\n" +
+	"\x05lines\x18\x02 \x03(\v2\x12.billing.QuoteLineR\x05lines\x12!\n" +
+	"\ftotal_amount\x18\x03 \x01(\x01R\vtotalAmount\x12\x1f\n" +
+	"\vquote_token\x18\x04 \x01(\tR\n" +
+	"quoteToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\x06 \x01(\x01R\ttaxAmount\"B\n" +
 	"\x12InvoiceItemRequest\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x85\x01\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xc5\x01\n" +
 	"\x14CreateInvoiceRequest\x12\x1f\n" +
 	"\vshipment_id\x18\x01 \x01(\x03R\n" +
 	"shipmentId\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\x03R\aorderId\x121\n" +
-	"\x05items\x18\x03 \x03(\v2\x1b.billing.InvoiceItemRequestR\x05items\"q\n" +
+	"\x05items\x18\x03 \x03(\v2\x1b.billing.InvoiceItemRequestR\x05items\x12>\n" +
+	"\fshipping_fee\x18\x04 \x01(\v2\x1b.billing.ShippingFeeRequestR\vshippingFee\"q\n" +
+	"\x12ShippingFeeRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12!\n" +
+	"\ftax_category\x18\x03 \x01(\tR\vtaxCategory\"q\n" +
 	"\x15CreateInvoiceResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
-	"\ainvoice\x18\x03 \x01(\v2\x10.billing.InvoiceR\ainvoice\"\xe2\x01\n" +
+	"\ainvoice\x18\x03 \x01(\v2\x10.billing.InvoiceR\ainvoice\"J\n" +
+	"\x11PayInvoiceRequest\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x01 \x01(\x03R\tinvoiceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"@\n" +
+	"\x12PayInvoiceResponse\x12*\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x10.billing.InvoiceR\ainvoice\":\n" +
+	"\x1dGetShippableQuantitiesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"\x92\x01\n" +
+	"\x11ShippableQuantity\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x03R\x06itemId\x12\x18\n" +
+	"\aordered\x18\x03 \x01(\x05R\aordered\x12\x1a\n" +
+	"\binvoiced\x18\x04 \x01(\x05R\binvoiced\x12\x1c\n" +
+	"\tremaining\x18\x05 \x01(\x05R\tremaining\"\\\n" +
+	"\x1eGetShippableQuantitiesResponse\x12:\n" +
+	"\n" +
+	"quantities\x18\x01 \x03(\v2\x1a.billing.ShippableQuantityR\n" +
+	"quantities\"5\n" +
+	"\x18ListOrderInvoicesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"I\n" +
+	"\x19ListOrderInvoicesResponse\x12,\n" +
+	"\binvoices\x18\x01 \x03(\v2\x10.billing.InvoiceR\binvoices\"\xa3\x01\n" +
+	"\x17CreateCreditNoteRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x121\n" +
+	"\x05items\x18\x04 \x03(\v2\x1b.billing.InvoiceItemRequestR\x05items\"~\n" +
+	"\x18CreateCreditNoteResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\vcredit_note\x18\x03 \x01(\v2\x13.billing.CreditNoteR\n" +
+	"creditNote\"\xf2\x01\n" +
+	"\n" +
+	"CreditNote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x02 \x01(\x03R\tinvoiceId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x03R\aorderId\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12-\n" +
+	"\x05items\x18\a \x03(\v2\x17.billing.CreditNoteItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"\x8e\x01\n" +
+	"\x0eCreditNoteItem\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x03R\x06itemId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\x01R\tunitPrice\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\"\xc5\x01\n" +
+	"\x11CreatePlanRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
+	"\binterval\x18\x05 \x01(\tR\binterval\x12%\n" +
+	"\x0einterval_count\x18\x06 \x01(\x05R\rintervalCount\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\a \x01(\x05R\ttrialDays\"7\n" +
+	"\x12CreatePlanResponse\x12!\n" +
+	"\x04plan\x18\x01 \x01(\v2\r.billing.PlanR\x04plan\"\x80\x01\n" +
+	"\x19CreateSubscriptionRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1b\n" +
+	"\tplan_code\x18\x02 \x01(\tR\bplanCode\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\"e\n" +
+	"\x1dChangeSubscriptionPlanRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x03R\x0esubscriptionId\x12\x1b\n" +
+	"\tplan_code\x18\x02 \x01(\tR\bplanCode\">\n" +
+	"\x13SubscriptionRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x03R\x0esubscriptionId\"Q\n" +
+	"\x14SubscriptionResponse\x129\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x15.billing.SubscriptionR\fsubscription\"\x9d\x03\n" +
 	"\aInvoice\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vshipment_id\x18\x02 \x01(\x03R\n" +
@@ -908,13 +4161,24 @@ const file_billing_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"q\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x19\n" +
+	"\bdue_date\x18\b \x01(\tR\adueDate\x12\x1f\n" +
+	"\vpaid_amount\x18\t \x01(\x01R\n" +
+	"paidAmount\x12'\n" +
+	"\x0fcredited_amount\x18\n" +
+	" \x01(\x01R\x0ecreditedAmount\x12-\n" +
+	"\x12outstanding_amount\x18\v \x01(\x01R\x11outstandingAmount\x12%\n" +
+	"\x0epayment_status\x18\f \x01(\tR\rpaymentStatus\"\x85\x02\n" +
 	"\vInvoiceItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
 	"\n" +
 	"invoice_id\x18\x02 \x01(\x03R\tinvoiceId\x12\x17\n" +
 	"\aitem_id\x18\x03 \x01(\x03R\x06itemId\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\x9f\x02\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x125\n" +
+	"\tline_type\x18\x05 \x01(\x0e2\x18.billing.InvoiceLineTypeR\blineType\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x01R\x06amount\x12!\n" +
+	"\ftax_category\x18\b \x01(\tR\vtaxCategory\"\xe0\x02\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -926,25 +4190,199 @@ const file_billing_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"k\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12!\n" +
+	"\fpayment_term\x18\t \x01(\tR\vpaymentTerm\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\n" +
+	" \x01(\tR\bapiKeyId\"\xf7\x01\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x17\n" +
 	"\aitem_id\x18\x03 \x01(\x03R\x06itemId\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\"d\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\x01R\tunitPrice\x12&\n" +
+	"\x0fprice_list_code\x18\x06 \x01(\tR\rpriceListCode\x12\x1d\n" +
+	"\n" +
+	"price_tier\x18\a \x01(\x05R\tpriceTier\x12\x10\n" +
+	"\x03sku\x18\b \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\"d\n" +
 	"\aPayment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x16\n" +
 	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount*3\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\"\xc8\x01\n" +
+	"\x04Plan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\binterval\x18\x06 \x01(\tR\binterval\x12%\n" +
+	"\x0einterval_count\x18\a \x01(\x05R\rintervalCount\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\b \x01(\x05R\ttrialDays\"\xbd\x03\n" +
+	"\fSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\x04plan\x18\x03 \x01(\v2\r.billing.PlanR\x04plan\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0epayment_method\x18\x05 \x01(\tR\rpaymentMethod\x12\x1b\n" +
+	"\ttrial_end\x18\x06 \x01(\tR\btrialEnd\x120\n" +
+	"\x14current_period_start\x18\a \x01(\tR\x12currentPeriodStart\x12,\n" +
+	"\x12current_period_end\x18\b \x01(\tR\x10currentPeriodEnd\x12&\n" +
+	"\x0fnext_billing_at\x18\t \x01(\tR\rnextBillingAt\x12/\n" +
+	"\x14cancel_at_period_end\x18\n" +
+	" \x01(\bR\x11cancelAtPeriodEnd\x12%\n" +
+	"\x0ecredit_balance\x18\v \x01(\x01R\rcreditBalance\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"Z\n" +
+	"\tPriceTier\x12\x13\n" +
+	"\x05up_to\x18\x01 \x01(\x01R\x04upTo\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x02 \x01(\x01R\tunitPrice\x12\x19\n" +
+	"\bflat_fee\x18\x03 \x01(\x01R\aflatFee\"\xbf\x01\n" +
+	"\x12CreateMeterRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x03 \x01(\tR\x03sku\x12 \n" +
+	"\vaggregation\x18\x04 \x01(\tR\vaggregation\x12#\n" +
+	"\rpricing_model\x18\x05 \x01(\tR\fpricingModel\x12(\n" +
+	"\x05tiers\x18\x06 \x03(\v2\x12.billing.PriceTierR\x05tiers\";\n" +
+	"\x13CreateMeterResponse\x12$\n" +
+	"\x05meter\x18\x01 \x01(\v2\x0e.billing.MeterR\x05meter\"\xc2\x01\n" +
+	"\x05Meter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12 \n" +
+	"\vaggregation\x18\x05 \x01(\tR\vaggregation\x12#\n" +
+	"\rpricing_model\x18\x06 \x01(\tR\fpricingModel\x12(\n" +
+	"\x05tiers\x18\a \x03(\v2\x12.billing.PriceTierR\x05tiers\"\xa8\x01\n" +
+	"\n" +
+	"UsageEvent\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x16\n" +
+	"\x06metric\x18\x02 \x01(\tR\x06metric\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"U\n" +
+	"\x12RejectedUsageEvent\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x8a\x01\n" +
+	"\x13RecordUsageResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x02 \x01(\x05R\n" +
+	"duplicates\x127\n" +
+	"\brejected\x18\x03 \x03(\v2\x1b.billing.RejectedUsageEventR\brejected\"L\n" +
+	"\x0ePriceListEntry\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12(\n" +
+	"\x05tiers\x18\x02 \x03(\v2\x12.billing.PriceTierR\x05tiers\"\xe2\x01\n" +
+	"\x16CreatePriceListRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vcustomer_id\x18\x04 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\x05 \x01(\tR\tvalidFrom\x12\x19\n" +
+	"\bvalid_to\x18\x06 \x01(\tR\avalidTo\x121\n" +
+	"\aentries\x18\a \x03(\v2\x17.billing.PriceListEntryR\aentries\"L\n" +
+	"\x17CreatePriceListResponse\x121\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x12.billing.PriceListR\tpriceList\"\xe5\x01\n" +
+	"\tPriceList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vcustomer_id\x18\x05 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"valid_from\x18\x06 \x01(\tR\tvalidFrom\x12\x19\n" +
+	"\bvalid_to\x18\a \x01(\tR\avalidTo\x121\n" +
+	"\aentries\x18\b \x03(\v2\x17.billing.PriceListEntryR\aentries\"\xd8\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\tR\x05keyId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcustomer_id\x18\x04 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05roles\x18\x05 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\x12 \n" +
+	"\flast_used_at\x18\b \x01(\tR\n" +
+	"lastUsedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\t \x01(\tR\trevokedAt\x12&\n" +
+	"\x0frotated_from_id\x18\n" +
+	" \x01(\x03R\rrotatedFromId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\v \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"\x96\x01\n" +
+	"\x12IssueAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"X\n" +
+	"\x14APIKeySecretResponse\x12(\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x0f.billing.APIKeyR\x06apiKey\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"5\n" +
+	"\x12ListAPIKeysRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"A\n" +
+	"\x13ListAPIKeysResponse\x12*\n" +
+	"\bapi_keys\x18\x01 \x03(\v2\x0f.billing.APIKeyR\aapiKeys\"\x1f\n" +
+	"\rAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"N\n" +
+	"\x13RotateAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0foverlap_minutes\x18\x02 \x01(\x05R\x0eoverlapMinutes\"-\n" +
+	"\x13VerifyAPIKeyRequest\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\":\n" +
+	"\x0eAPIKeyResponse\x12(\n" +
+	"\aapi_key\x18\x01 \x01(\v2\x0f.billing.APIKeyR\x06apiKey*)\n" +
+	"\x0fInvoiceLineType\x12\b\n" +
+	"\x04ITEM\x10\x00\x12\f\n" +
+	"\bSHIPPING\x10\x01*3\n" +
 	"\vOrderStatus\x12\v\n" +
 	"\aPENDING\x10\x00\x12\v\n" +
 	"\aSUCCESS\x10\x01\x12\n" +
 	"\n" +
-	"\x06FAILED\x10\x022\xae\x01\n" +
+	"\x06FAILED\x10\x022\x8c\x0e\n" +
 	"\x0eBillingService\x12J\n" +
-	"\vCreateOrder\x12\x1b.billing.CreateOrderRequest\x1a\x1c.billing.CreateOrderResponse\"\x00\x12P\n" +
-	"\rCreateInvoice\x12\x1d.billing.CreateInvoiceRequest\x1a\x1e.billing.CreateInvoiceResponse\"\x00B&Z$billing-system/billing_service/protob\x06proto3"
+	"\vCreateOrder\x12\x1b.billing.CreateOrderRequest\x1a\x1c.billing.CreateOrderResponse\"\x00\x12G\n" +
+	"\n" +
+	"QuoteOrder\x12\x1a.billing.QuoteOrderRequest\x1a\x1b.billing.QuoteOrderResponse\"\x00\x12A\n" +
+	"\bGetOrder\x12\x18.billing.GetOrderRequest\x1a\x19.billing.GetOrderResponse\"\x00\x12P\n" +
+	"\rCreateInvoice\x12\x1d.billing.CreateInvoiceRequest\x1a\x1e.billing.CreateInvoiceResponse\"\x00\x12G\n" +
+	"\n" +
+	"PayInvoice\x12\x1a.billing.PayInvoiceRequest\x1a\x1b.billing.PayInvoiceResponse\"\x00\x12k\n" +
+	"\x16GetShippableQuantities\x12&.billing.GetShippableQuantitiesRequest\x1a'.billing.GetShippableQuantitiesResponse\"\x00\x12\\\n" +
+	"\x11ListOrderInvoices\x12!.billing.ListOrderInvoicesRequest\x1a\".billing.ListOrderInvoicesResponse\"\x00\x12Y\n" +
+	"\x10CreateCreditNote\x12 .billing.CreateCreditNoteRequest\x1a!.billing.CreateCreditNoteResponse\"\x00\x12G\n" +
+	"\n" +
+	"CreatePlan\x12\x1a.billing.CreatePlanRequest\x1a\x1b.billing.CreatePlanResponse\"\x00\x12Y\n" +
+	"\x12CreateSubscription\x12\".billing.CreateSubscriptionRequest\x1a\x1d.billing.SubscriptionResponse\"\x00\x12a\n" +
+	"\x16ChangeSubscriptionPlan\x12&.billing.ChangeSubscriptionPlanRequest\x1a\x1d.billing.SubscriptionResponse\"\x00\x12R\n" +
+	"\x11PauseSubscription\x12\x1c.billing.SubscriptionRequest\x1a\x1d.billing.SubscriptionResponse\"\x00\x12S\n" +
+	"\x12ResumeSubscription\x12\x1c.billing.SubscriptionRequest\x1a\x1d.billing.SubscriptionResponse\"\x00\x12S\n" +
+	"\x12CancelSubscription\x12\x1c.billing.SubscriptionRequest\x1a\x1d.billing.SubscriptionResponse\"\x00\x12J\n" +
+	"\vCreateMeter\x12\x1b.billing.CreateMeterRequest\x1a\x1c.billing.CreateMeterResponse\"\x00\x12D\n" +
+	"\vRecordUsage\x12\x13.billing.UsageEvent\x1a\x1c.billing.RecordUsageResponse\"\x00(\x01\x12V\n" +
+	"\x0fCreatePriceList\x12\x1f.billing.CreatePriceListRequest\x1a .billing.CreatePriceListResponse\"\x00\x12K\n" +
+	"\vIssueAPIKey\x12\x1b.billing.IssueAPIKeyRequest\x1a\x1d.billing.APIKeySecretResponse\"\x00\x12J\n" +
+	"\vListAPIKeys\x12\x1b.billing.ListAPIKeysRequest\x1a\x1c.billing.ListAPIKeysResponse\"\x00\x12A\n" +
+	"\fRevokeAPIKey\x12\x16.billing.APIKeyRequest\x1a\x17.billing.APIKeyResponse\"\x00\x12M\n" +
+	"\fRotateAPIKey\x12\x1c.billing.RotateAPIKeyRequest\x1a\x1d.billing.APIKeySecretResponse\"\x00\x12G\n" +
+	"\fVerifyAPIKey\x12\x1c.billing.VerifyAPIKeyRequest\x1a\x17.billing.APIKeyResponse\"\x00B&Z$billing-system/billing_service/protob\x06proto3"
 
 var (
 	file_billing_proto_rawDescOnce sync.Once
@@ -958,42 +4396,153 @@ func file_billing_proto_rawDescGZIP() []byte {
 	return file_billing_proto_rawDescData
 }
 
-var file_billing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_billing_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_billing_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_billing_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_billing_proto_goTypes = []any{
-	(OrderStatus)(0),              // 0: billing.OrderStatus
-	(*ItemRequest)(nil),           // 1: billing.ItemRequest
-	(*PaymentRequest)(nil),        // 2: billing.PaymentRequest
-	(*CreateOrderRequest)(nil),    // 3: billing.CreateOrderRequest
-	(*CreateOrderResponse)(nil),   // 4: billing.CreateOrderResponse
-	(*InvoiceItemRequest)(nil),    // 5: billing.InvoiceItemRequest
-	(*CreateInvoiceRequest)(nil),  // 6: billing.CreateInvoiceRequest
-	(*CreateInvoiceResponse)(nil), // 7: billing.CreateInvoiceResponse
-	(*Invoice)(nil),               // 8: billing.Invoice
-	(*InvoiceItem)(nil),           // 9: billing.InvoiceItem
-	(*Order)(nil),                 // 10: billing.Order
-	(*OrderItem)(nil),             // 11: billing.OrderItem
-	(*Payment)(nil),               // 12: billing.Payment
+	(InvoiceLineType)(0),                   // 0: billing.InvoiceLineType
+	(OrderStatus)(0),                       // 1: billing.OrderStatus
+	(*ItemRequest)(nil),                    // 2: billing.ItemRequest
+	(*PaymentRequest)(nil),                 // 3: billing.PaymentRequest
+	(*CreateOrderRequest)(nil),             // 4: billing.CreateOrderRequest
+	(*CreateOrderResponse)(nil),            // 5: billing.CreateOrderResponse
+	(*GetOrderRequest)(nil),                // 6: billing.GetOrderRequest
+	(*GetOrderResponse)(nil),               // 7: billing.GetOrderResponse
+	(*QuoteOrderRequest)(nil),              // 8: billing.QuoteOrderRequest
+	(*QuoteLine)(nil),                      // 9: billing.QuoteLine
+	(*QuoteOrderResponse)(nil),             // 10: billing.QuoteOrderResponse
+	(*InvoiceItemRequest)(nil),             // 11: billing.InvoiceItemRequest
+	(*CreateInvoiceRequest)(nil),           // 12: billing.CreateInvoiceRequest
+	(*ShippingFeeRequest)(nil),             // 13: billing.ShippingFeeRequest
+	(*CreateInvoiceResponse)(nil),          // 14: billing.CreateInvoiceResponse
+	(*PayInvoiceRequest)(nil),              // 15: billing.PayInvoiceRequest
+	(*PayInvoiceResponse)(nil),             // 16: billing.PayInvoiceResponse
+	(*GetShippableQuantitiesRequest)(nil),  // 17: billing.GetShippableQuantitiesRequest
+	(*ShippableQuantity)(nil),              // 18: billing.ShippableQuantity
+	(*GetShippableQuantitiesResponse)(nil), // 19: billing.GetShippableQuantitiesResponse
+	(*ListOrderInvoicesRequest)(nil),       // 20: billing.ListOrderInvoicesRequest
+	(*ListOrderInvoicesResponse)(nil),      // 21: billing.ListOrderInvoicesResponse
+	(*CreateCreditNoteRequest)(nil),        // 22: billing.CreateCreditNoteRequest
+	(*CreateCreditNoteResponse)(nil),       // 23: billing.CreateCreditNoteResponse
+	(*CreditNote)(nil),                     // 24: billing.CreditNote
+	(*CreditNoteItem)(nil),                 // 25: billing.CreditNoteItem
+	(*CreatePlanRequest)(nil),              // 26: billing.CreatePlanRequest
+	(*CreatePlanResponse)(nil),             // 27: billing.CreatePlanResponse
+	(*CreateSubscriptionRequest)(nil),      // 28: billing.CreateSubscriptionRequest
+	(*ChangeSubscriptionPlanRequest)(nil),  // 29: billing.ChangeSubscriptionPlanRequest
+	(*SubscriptionRequest)(nil),            // 30: billing.SubscriptionRequest
+	(*SubscriptionResponse)(nil),           // 31: billing.SubscriptionResponse
+	(*Invoice)(nil),                        // 32: billing.Invoice
+	(*InvoiceItem)(nil),                    // 33: billing.InvoiceItem
+	(*Order)(nil),                          // 34: billing.Order
+	(*OrderItem)(nil),                      // 35: billing.OrderItem
+	(*Payment)(nil),                        // 36: billing.Payment
+	(*Plan)(nil),                           // 37: billing.Plan
+	(*Subscription)(nil),                   // 38: billing.Subscription
+	(*PriceTier)(nil),                      // 39: billing.PriceTier
+	(*CreateMeterRequest)(nil),             // 40: billing.CreateMeterRequest
+	(*CreateMeterResponse)(nil),            // 41: billing.CreateMeterResponse
+	(*Meter)(nil),                          // 42: billing.Meter
+	(*UsageEvent)(nil),                     // 43: billing.UsageEvent
+	(*RejectedUsageEvent)(nil),             // 44: billing.RejectedUsageEvent
+	(*RecordUsageResponse)(nil),            // 45: billing.RecordUsageResponse
+	(*PriceListEntry)(nil),                 // 46: billing.PriceListEntry
+	(*CreatePriceListRequest)(nil),         // 47: billing.CreatePriceListRequest
+	(*CreatePriceListResponse)(nil),        // 48: billing.CreatePriceListResponse
+	(*PriceList)(nil),                      // 49: billing.PriceList
+	(*APIKey)(nil),                         // 50: billing.APIKey
+	(*IssueAPIKeyRequest)(nil),             // 51: billing.IssueAPIKeyRequest
+	(*APIKeySecretResponse)(nil),           // 52: billing.APIKeySecretResponse
+	(*ListAPIKeysRequest)(nil),             // 53: billing.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),            // 54: billing.ListAPIKeysResponse
+	(*APIKeyRequest)(nil),                  // 55: billing.APIKeyRequest
+	(*RotateAPIKeyRequest)(nil),            // 56: billing.RotateAPIKeyRequest
+	(*VerifyAPIKeyRequest)(nil),            // 57: billing.VerifyAPIKeyRequest
+	(*APIKeyResponse)(nil),                 // 58: billing.APIKeyResponse
 }
 var file_billing_proto_depIdxs = []int32{
-	1,  // 0: billing.CreateOrderRequest.items:type_name -> billing.ItemRequest
-	2,  // 1: billing.CreateOrderRequest.payments:type_name -> billing.PaymentRequest
-	10, // 2: billing.CreateOrderResponse.order:type_name -> billing.Order
-	5,  // 3: billing.CreateInvoiceRequest.items:type_name -> billing.InvoiceItemRequest
-	8,  // 4: billing.CreateInvoiceResponse.invoice:type_name -> billing.Invoice
-	9,  // 5: billing.Invoice.items:type_name -> billing.InvoiceItem
-	0,  // 6: billing.Order.status:type_name -> billing.OrderStatus
-	11, // 7: billing.Order.items:type_name -> billing.OrderItem
-	12, // 8: billing.Order.payments:type_name -> billing.Payment
-	3,  // 9: billing.BillingService.CreateOrder:input_type -> billing.CreateOrderRequest
-	6,  // 10: billing.BillingService.CreateInvoice:input_type -> billing.CreateInvoiceRequest
-	4,  // 11: billing.BillingService.CreateOrder:output_type -> billing.CreateOrderResponse
-	7,  // 12: billing.BillingService.CreateInvoice:output_type -> billing.CreateInvoiceResponse
-	11, // [11:13] is the sub-list for method output_type
-	9,  // [9:11] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	2,  // 0: billing.CreateOrderRequest.items:type_name -> billing.ItemRequest
+	3,  // 1: billing.CreateOrderRequest.payments:type_name -> billing.PaymentRequest
+	34, // 2: billing.CreateOrderResponse.order:type_name -> billing.Order
+	34, // 3: billing.GetOrderResponse.order:type_name -> billing.Order
+	2,  // 4: billing.QuoteOrderRequest.items:type_name -> billing.ItemRequest
+	9,  // 5: billing.QuoteOrderResponse.lines:type_name -> billing.QuoteLine
+	11, // 6: billing.CreateInvoiceRequest.items:type_name -> billing.InvoiceItemRequest
+	13, // 7: billing.CreateInvoiceRequest.shipping_fee:type_name -> billing.ShippingFeeRequest
+	32, // 8: billing.CreateInvoiceResponse.invoice:type_name -> billing.Invoice
+	32, // 9: billing.PayInvoiceResponse.invoice:type_name -> billing.Invoice
+	18, // 10: billing.GetShippableQuantitiesResponse.quantities:type_name -> billing.ShippableQuantity
+	32, // 11: billing.ListOrderInvoicesResponse.invoices:type_name -> billing.Invoice
+	11, // 12: billing.CreateCreditNoteRequest.items:type_name -> billing.InvoiceItemRequest
+	24, // 13: billing.CreateCreditNoteResponse.credit_note:type_name -> billing.CreditNote
+	25, // 14: billing.CreditNote.items:type_name -> billing.CreditNoteItem
+	37, // 15: billing.CreatePlanResponse.plan:type_name -> billing.Plan
+	38, // 16: billing.SubscriptionResponse.subscription:type_name -> billing.Subscription
+	33, // 17: billing.Invoice.items:type_name -> billing.InvoiceItem
+	0,  // 18: billing.InvoiceItem.line_type:type_name -> billing.InvoiceLineType
+	1,  // 19: billing.Order.status:type_name -> billing.OrderStatus
+	35, // 20: billing.Order.items:type_name -> billing.OrderItem
+	36, // 21: billing.Order.payments:type_name -> billing.Payment
+	37, // 22: billing.Subscription.plan:type_name -> billing.Plan
+	39, // 23: billing.CreateMeterRequest.tiers:type_name -> billing.PriceTier
+	42, // 24: billing.CreateMeterResponse.meter:type_name -> billing.Meter
+	39, // 25: billing.Meter.tiers:type_name -> billing.PriceTier
+	44, // 26: billing.RecordUsageResponse.rejected:type_name -> billing.RejectedUsageEvent
+	39, // 27: billing.PriceListEntry.tiers:type_name -> billing.PriceTier
+	46, // 28: billing.CreatePriceListRequest.entries:type_name -> billing.PriceListEntry
+	49, // 29: billing.CreatePriceListResponse.price_list:type_name -> billing.PriceList
+	46, // 30: billing.PriceList.entries:type_name -> billing.PriceListEntry
+	50, // 31: billing.APIKeySecretResponse.api_key:type_name -> billing.APIKey
+	50, // 32: billing.ListAPIKeysResponse.api_keys:type_name -> billing.APIKey
+	50, // 33: billing.APIKeyResponse.api_key:type_name -> billing.APIKey
+	4,  // 34: billing.BillingService.CreateOrder:input_type -> billing.CreateOrderRequest
+	8,  // 35: billing.BillingService.QuoteOrder:input_type -> billing.QuoteOrderRequest
+	6,  // 36: billing.BillingService.GetOrder:input_type -> billing.GetOrderRequest
+	12, // 37: billing.BillingService.CreateInvoice:input_type -> billing.CreateInvoiceRequest
+	15, // 38: billing.BillingService.PayInvoice:input_type -> billing.PayInvoiceRequest
+	17, // 39: billing.BillingService.GetShippableQuantities:input_type -> billing.GetShippableQuantitiesRequest
+	20, // 40: billing.BillingService.ListOrderInvoices:input_type -> billing.ListOrderInvoicesRequest
+	22, // 41: billing.BillingService.CreateCreditNote:input_type -> billing.CreateCreditNoteRequest
+	26, // 42: billing.BillingService.CreatePlan:input_type -> billing.CreatePlanRequest
+	28, // 43: billing.BillingService.CreateSubscription:input_type -> billing.CreateSubscriptionRequest
+	29, // 44: billing.BillingService.ChangeSubscriptionPlan:input_type -> billing.ChangeSubscriptionPlanRequest
+	30, // 45: billing.BillingService.PauseSubscription:input_type -> billing.SubscriptionRequest
+	30, // 46: billing.BillingService.ResumeSubscription:input_type -> billing.SubscriptionRequest
+	30, // 47: billing.BillingService.CancelSubscription:input_type -> billing.SubscriptionRequest
+	40, // 48: billing.BillingService.CreateMeter:input_type -> billing.CreateMeterRequest
+	43, // 49: billing.BillingService.RecordUsage:input_type -> billing.UsageEvent
+	47, // 50: billing.BillingService.CreatePriceList:input_type -> billing.CreatePriceListRequest
+	51, // 51: billing.BillingService.IssueAPIKey:input_type -> billing.IssueAPIKeyRequest
+	53, // 52: billing.BillingService.ListAPIKeys:input_type -> billing.ListAPIKeysRequest
+	55, // 53: billing.BillingService.RevokeAPIKey:input_type -> billing.APIKeyRequest
+	56, // 54: billing.BillingService.RotateAPIKey:input_type -> billing.RotateAPIKeyRequest
+	57, // 55: billing.BillingService.VerifyAPIKey:input_type -> billing.VerifyAPIKeyRequest
+	5,  // 56: billing.BillingService.CreateOrder:output_type -> billing.CreateOrderResponse
+	10, // 57: billing.BillingService.QuoteOrder:output_type -> billing.QuoteOrderResponse
+	7,  // 58: billing.BillingService.GetOrder:output_type -> billing.GetOrderResponse
+	14, // 59: billing.BillingService.CreateInvoice:output_type -> billing.CreateInvoiceResponse
+	16, // 60: billing.BillingService.PayInvoice:output_type -> billing.PayInvoiceResponse
+	19, // 61: billing.BillingService.GetShippableQuantities:output_type -> billing.GetShippableQuantitiesResponse
+	21, // 62: billing.BillingService.ListOrderInvoices:output_type -> billing.ListOrderInvoicesResponse
+	23, // 63: billing.BillingService.CreateCreditNote:output_type -> billing.CreateCreditNoteResponse
+	27, // 64: billing.BillingService.CreatePlan:output_type -> billing.CreatePlanResponse
+	31, // 65: billing.BillingService.CreateSubscription:output_type -> billing.SubscriptionResponse
+	31, // 66: billing.BillingService.ChangeSubscriptionPlan:output_type -> billing.SubscriptionResponse
+	31, // 67: billing.BillingService.PauseSubscription:output_type -> billing.SubscriptionResponse
+	31, // 68: billing.BillingService.ResumeSubscription:output_type -> billing.SubscriptionResponse
+	31, // 69: billing.BillingService.CancelSubscription:output_type -> billing.SubscriptionResponse
+	41, // 70: billing.BillingService.CreateMeter:output_type -> billing.CreateMeterResponse
+	45, // 71: billing.BillingService.RecordUsage:output_type -> billing.RecordUsageResponse
+	48, // 72: billing.BillingService.CreatePriceList:output_type -> billing.CreatePriceListResponse
+	52, // 73: billing.BillingService.IssueAPIKey:output_type -> billing.APIKeySecretResponse
+	54, // 74: billing.BillingService.ListAPIKeys:output_type -> billing.ListAPIKeysResponse
+	58, // 75: billing.BillingService.RevokeAPIKey:output_type -> billing.APIKeyResponse
+	52, // 76: billing.BillingService.RotateAPIKey:output_type -> billing.APIKeySecretResponse
+	58, // 77: billing.BillingService.VerifyAPIKey:output_type -> billing.APIKeyResponse
+	56, // [56:78] is the sub-list for method output_type
+	34, // [34:56] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_billing_proto_init() }
@@ -1006,8 +4555,8 @@ func file_billing_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_billing_proto_rawDesc), len(file_billing_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,86 @@
+// Command devcerts creates a local CA and the certificates the services use for mutual TLS in development.
+// Run it from the repository root, the configurations point at the certs directory it writes:
+//
+//	go run ./cmd/devcerts
+//
+// The CA of an earlier run is reused, so running it again rotates the service certificates
+// and running services pick them up on their next handshake.
+package main
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"billing-system/pkg/mtls"
+)
+
+func main() {
+	out := flag.String("out", "certs", "directory the CA and certificates are written to")
+	services := flag.String("services", "bff,billing,shipment", "comma separated services to issue certificates for")
+	validity := flag.Duration("validity", 90*24*time.Hour, "validity of the service certificates")
+	newCA := flag.Bool("new-ca", false, "create a new CA even when one exists, the services must then all be restarted")
+	flag.Parse()
+
+	if err := os.MkdirAll(*out, 0o700); err != nil {
+		log.Fatalf("Failed to create %s: %v", *out, err)
+	}
+
+	ca, err := loadOrCreateCA(*out, *newCA)
+	if err != nil {
+		log.Fatalf("Failed to prepare the CA: %v", err)
+	}
+
+	for _, service := range strings.Split(*services, ",") {
+		service = strings.TrimSpace(service)
+		certPEM, keyPEM, err := ca.Issue(service, *validity)
+		if err != nil {
+			log.Fatalf("Failed to issue the certificate of %s: %v", service, err)
+		}
+		// The key is written first, a service reloading in between fails to pair them and keeps its current ones
+		if err := mtls.WriteFile(filepath.Join(*out, service+".key"), keyPEM, 0o600); err != nil {
+			log.Fatalf("Failed to write the key of %s: %v", service, err)
+		}
+		if err := mtls.WriteFile(filepath.Join(*out, service+".crt"), certPEM, 0o644); err != nil {
+			log.Fatalf("Failed to write the certificate of %s: %v", service, err)
+		}
+		log.Printf("Issued %s for %s", filepath.Join(*out, service+".crt"), mtls.Identity(service))
+	}
+}
+
+// loadOrCreateCA reads the CA of the directory, creating it when there is none or a new one is asked for
+func loadOrCreateCA(dir string, fresh bool) (*mtls.DevCA, error) {
+	certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	if !fresh {
+		ca, err := mtls.LoadDevCA(certFile, keyFile)
+		if err == nil {
+			log.Printf("Reusing the CA of %s", certFile)
+			return ca, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	ca, err := mtls.NewDevCA()
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		return nil, err
+	}
+	if err := mtls.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return nil, err
+	}
+	if err := mtls.WriteFile(certFile, ca.CertPEM(), 0o644); err != nil {
+		return nil, err
+	}
+	log.Printf("Created the CA %s", certFile)
+	return ca, nil
+}
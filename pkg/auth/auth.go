@@ -0,0 +1,171 @@
+// Package auth verifies the bearer tokens of API callers and decides what they may do.
+// The BFF verifies the token of each request and forwards it to the services over gRPC metadata,
+// their interceptors verify it again and check the permission of the called method.
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Config configures token verification, it is the auth section of the configuration of every service
+type Config struct {
+	// Enabled turns authentication on, without it every caller may do anything
+	Enabled bool `yaml:"enabled"`
+	// Issuer and Audience are checked against the iss and aud claims when set
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// Leeway is the clock skew tolerated on the exp and nbf claims
+	Leeway time.Duration `yaml:"leeway"`
+	// JWKSFile is a JSON Web Key Set read again when it changes, used instead of StaticKeys when set
+	JWKSFile   string            `yaml:"jwks_file"`
+	StaticKeys []StaticKeyConfig `yaml:"static_keys"`
+}
+
+// StaticKeyConfig is a key tokens are verified with, either an HMAC secret or a PEM public key file
+type StaticKeyConfig struct {
+	// ID is matched against the kid header, a token without one is verified with the only key
+	ID            string `yaml:"id"`
+	Secret        string `yaml:"secret"`
+	PublicKeyFile string `yaml:"public_key_file"`
+}
+
+// Role is a role granted to a caller in the roles claim of its token
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleOps      Role = "ops"
+	RoleFinance  Role = "finance"
+	RoleAdmin    Role = "admin"
+)
+
+// Permission is what a route or gRPC method requires of its caller
+type Permission string
+
+const (
+	// PermissionPublic is granted to every caller, including the ones without a token
+	PermissionPublic Permission = "public"
+	// PermissionAuthenticated is granted to every caller with a valid token
+	PermissionAuthenticated Permission = "authenticated"
+	// PermissionAdmin is only granted to admins
+	PermissionAdmin Permission = "admin"
+
+	PermissionOrdersRead         Permission = "orders:read"
+	PermissionOrdersWrite        Permission = "orders:write"
+	PermissionInvoicesWrite      Permission = "invoices:write"
+	PermissionCatalogWrite       Permission = "catalog:write"
+	PermissionSubscriptionsWrite Permission = "subscriptions:write"
+	PermissionUsageWrite         Permission = "usage:write"
+	PermissionShipmentsRead      Permission = "shipments:read"
+	PermissionShipmentsWrite     Permission = "shipments:write"
+)
+
+// scopes are the permissions an API key can be narrowed to
+var scopes = []Permission{PermissionOrdersRead, PermissionOrdersWrite, PermissionInvoicesWrite, PermissionCatalogWrite,
+	PermissionSubscriptionsWrite, PermissionUsageWrite, PermissionShipmentsRead, PermissionShipmentsWrite, PermissionAdmin}
+
+// IsScope reports whether an API key can be narrowed to the permission
+func (p Permission) IsScope() bool {
+	return slices.Contains(scopes, p)
+}
+
+// impliedScopes are the scopes a key narrowed to a scope needs as well. The shipment service forwards the token
+// of its caller to the billing service, so shipments read their orders and are invoiced with the key's scopes.
+var impliedScopes = map[Permission][]Permission{
+	PermissionShipmentsRead:  {PermissionOrdersRead},
+	PermissionShipmentsWrite: {PermissionOrdersRead, PermissionInvoicesWrite},
+}
+
+// Implies returns the scopes an API key narrowed to the permission needs as well
+func (p Permission) Implies() []Permission {
+	return impliedScopes[p]
+}
+
+// rolePermissions are the permissions of each role, admins have every permission.
+// Customers are further limited to their own orders by the services.
+var rolePermissions = map[Role][]Permission{
+	RoleCustomer: {PermissionOrdersRead, PermissionOrdersWrite},
+	RoleOps:      {PermissionOrdersRead, PermissionInvoicesWrite, PermissionShipmentsRead, PermissionShipmentsWrite},
+	RoleFinance: {PermissionOrdersRead, PermissionInvoicesWrite, PermissionCatalogWrite, PermissionSubscriptionsWrite,
+		PermissionUsageWrite, PermissionShipmentsRead},
+}
+
+// IsKnown reports whether the services know the role, tokens with other roles are granted nothing by them
+func (r Role) IsKnown() bool {
+	_, known := rolePermissions[r]
+	return known || r == RoleAdmin
+}
+
+// Principal is a verified caller
+type Principal struct {
+	Subject string
+	// CustomerID is the customer a customer acts for, empty for staff
+	CustomerID string
+	Roles      []Role
+	// APIKeyID is the public id of the API key the caller authenticated with, empty for users
+	APIKeyID string
+	// Scopes narrow the permissions of the roles of an API key, the roles apply in full when empty
+	Scopes []Permission
+}
+
+// HasRole reports whether the principal was granted the role
+func (p *Principal) HasRole(role Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// Can reports whether one of the roles of the principal grants the permission and its scopes, if any, include it
+func (p *Principal) Can(permission Permission) bool {
+	if permission == PermissionPublic || permission == PermissionAuthenticated {
+		return true
+	}
+	if len(p.Scopes) > 0 && !slices.Contains(p.Scopes, permission) {
+		return false
+	}
+	if p.HasRole(RoleAdmin) {
+		return true
+	}
+	for _, role := range p.Roles {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStaff reports whether the principal works for the business rather than being a customer
+func (p *Principal) IsStaff() bool {
+	return p.HasRole(RoleOps) || p.HasRole(RoleFinance) || p.HasRole(RoleAdmin)
+}
+
+// CanActFor reports whether the principal may act for the customer.
+// Staff may act for any customer, a customer only for itself.
+func (p *Principal) CanActFor(customerID string) bool {
+	return p.IsStaff() || (p.CustomerID != "" && p.CustomerID == customerID)
+}
+
+type contextKey struct{}
+
+// authenticated is what the context of a verified request carries
+type authenticated struct {
+	principal *Principal
+	token     string
+}
+
+// NewContext returns a context carrying the principal and the token it was verified from, the token is forwarded on gRPC calls
+func NewContext(ctx context.Context, principal *Principal, token string) context.Context {
+	return context.WithValue(ctx, contextKey{}, authenticated{principal: principal, token: token})
+}
+
+// FromContext returns the principal of the request, there is none when authentication is disabled
+func FromContext(ctx context.Context) (*Principal, bool) {
+	a, ok := ctx.Value(contextKey{}).(authenticated)
+	return a.principal, ok
+}
+
+// tokenFromContext returns the token the principal of the request was verified from
+func tokenFromContext(ctx context.Context) string {
+	a, _ := ctx.Value(contextKey{}).(authenticated)
+	return a.token
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the ErrorInfo details of authentication and authorization failures
+const errorDomain = "auth.billing-system"
+
+// authorizationKey is the metadata key the bearer token is forwarded in
+const authorizationKey = "authorization"
+
+// Policy maps the full name of every gRPC method of a server to the permission it requires.
+// Methods missing from the policy are denied to everyone but admins.
+type Policy map[string]Permission
+
+// ReflectionMethods are the methods of the gRPC reflection service, any authenticated caller may describe the API
+var ReflectionMethods = Policy{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      PermissionAuthenticated,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": PermissionAuthenticated,
+}
+
+// With returns the policy with the methods of other added
+func (p Policy) With(other Policy) Policy {
+	merged := make(Policy, len(p)+len(other))
+	for method, permission := range p {
+		merged[method] = permission
+	}
+	for method, permission := range other {
+		merged[method] = permission
+	}
+	return merged
+}
+
+// UnaryServerInterceptor verifies the forwarded token of unary calls and checks the permission of the method.
+// A nil verifier lets every call through.
+func UnaryServerInterceptor(verifier *Verifier, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, verifier, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor verifies the forwarded token of streaming calls and checks the permission of the method.
+// A nil verifier lets every call through.
+func StreamServerInterceptor(verifier *Verifier, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(stream.Context(), verifier, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authorizedStream is a server stream whose context carries the principal
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize returns the context of the call with its principal, or the status of a call the caller may not make
+func authorize(ctx context.Context, verifier *Verifier, policy Policy, method string) (context.Context, error) {
+	if verifier == nil {
+		return ctx, nil
+	}
+
+	permission, ok := policy[method]
+	if !ok {
+		permission = PermissionAdmin
+	}
+
+	token := bearerToken(metadata.ValueFromIncomingContext(ctx, authorizationKey))
+	if token == "" {
+		if permission == PermissionPublic {
+			return ctx, nil
+		}
+		return nil, Unauthenticated(errors.New("missing bearer token"))
+	}
+
+	principal, err := verifier.Verify(token)
+	if err != nil {
+		return nil, Unauthenticated(err)
+	}
+	if !principal.Can(permission) {
+		return nil, PermissionDenied(permission)
+	}
+	return NewContext(ctx, principal, token), nil
+}
+
+// bearerToken returns the token of the first Bearer authorization value
+func bearerToken(values []string) string {
+	for _, value := range values {
+		if scheme, token, ok := strings.Cut(value, " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// BearerToken returns the token of an Authorization header, empty when it is not a bearer token
+func BearerToken(header string) string {
+	return bearerToken([]string{header})
+}
+
+// UnaryClientInterceptor forwards the token of the request on unary calls to other services
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the token of the request on streaming calls to other services
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// outgoingContext adds the token of the request to the outgoing metadata
+func outgoingContext(ctx context.Context) context.Context {
+	if token := tokenFromContext(ctx); token != "" {
+		return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+	}
+	return ctx
+}
+
+// Unauthenticated returns the status of a call without a valid token
+func Unauthenticated(err error) error {
+	reason := "INVALID_TOKEN"
+	if errors.Is(err, ErrExpiredToken) {
+		reason = "TOKEN_EXPIRED"
+	}
+	return withErrorInfo(status.New(codes.Unauthenticated, err.Error()), reason, nil)
+}
+
+// PermissionDenied returns the status of a call the roles of the caller do not allow
+func PermissionDenied(permission Permission) error {
+	return withErrorInfo(status.New(codes.PermissionDenied, "permission denied"), "PERMISSION_DENIED",
+		map[string]string{"permission": string(permission)})
+}
+
+// ForeignCustomer returns the status of a customer acting for another customer
+func ForeignCustomer() error {
+	return withErrorInfo(status.New(codes.PermissionDenied, "customers may only act for themselves"), "FOREIGN_CUSTOMER", nil)
+}
+
+// withErrorInfo returns the status error with an ErrorInfo detail, or without it when it cannot be encoded
+func withErrorInfo(st *status.Status, reason string, metadata map[string]string) error {
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	secret := []byte("secret")
+	v := testVerifier(map[string]any{"hmac": secret})
+	policy := Policy{
+		"/test.Service/Read":    PermissionOrdersRead,
+		"/test.Service/Webhook": PermissionPublic,
+	}
+	interceptor := UnaryServerInterceptor(v, policy)
+
+	customer, _ := SignHS256(validClaims(), "hmac", secret)
+	expired := validClaims()
+	expired.ExpiresAt = testNow.Add(-time.Hour).Unix()
+	expiredToken, _ := SignHS256(expired, "hmac", secret)
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+	}{
+		{name: "Permitted", method: "/test.Service/Read", token: customer, wantCode: codes.OK},
+		{name: "Public without token", method: "/test.Service/Webhook", wantCode: codes.OK},
+		{name: "Missing token", method: "/test.Service/Read", wantCode: codes.Unauthenticated},
+		{name: "Expired token", method: "/test.Service/Read", token: expiredToken, wantCode: codes.Unauthenticated},
+		{name: "Method not in the policy", method: "/test.Service/Delete", token: customer, wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+
+			var principal *Principal
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req any) (any, error) {
+				principal, _ = FromContext(ctx)
+				return nil, nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && tt.token != "" && (principal == nil || principal.Subject != "user-1") {
+				t.Errorf("principal = %+v, want the one of the token", principal)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_Disabled(t *testing.T) {
+	interceptor := UnaryServerInterceptor(nil, Policy{})
+	called := false
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Delete"}, func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if err != nil || !called {
+		t.Fatalf("disabled authentication blocked a call: %v", err)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	ctx := NewContext(context.Background(), &Principal{Subject: "user-1"}, "token-1")
+
+	var forwarded []string
+	err := UnaryClientInterceptor()(ctx, "/test.Service/Read", nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		forwarded = md.Get("authorization")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forwarded) != 1 || forwarded[0] != "Bearer token-1" {
+		t.Errorf("authorization = %v, want the token of the request", forwarded)
+	}
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// KeySource returns the key a token is verified with from the kid of its header.
+// Keys are []byte HMAC secrets, *rsa.PublicKey or *ecdsa.PublicKey.
+type KeySource interface {
+	Key(keyID string) (any, error)
+}
+
+// StaticKeys are keys read once from the configuration
+type StaticKeys struct {
+	keys map[string]any
+}
+
+// NewStaticKeys reads the configured secrets and public key files
+func NewStaticKeys(configs []StaticKeyConfig) (*StaticKeys, error) {
+	if len(configs) == 0 {
+		return nil, errors.New("no keys configured to verify tokens with")
+	}
+
+	keys := make(map[string]any, len(configs))
+	for _, cfg := range configs {
+		if _, ok := keys[cfg.ID]; ok {
+			return nil, fmt.Errorf("key %q is configured twice", cfg.ID)
+		}
+		switch {
+		case cfg.Secret != "" && cfg.PublicKeyFile != "":
+			return nil, fmt.Errorf("key %q has both a secret and a public key file", cfg.ID)
+		case cfg.Secret != "":
+			keys[cfg.ID] = []byte(cfg.Secret)
+		case cfg.PublicKeyFile != "":
+			key, err := readPublicKeyFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", cfg.ID, err)
+			}
+			keys[cfg.ID] = key
+		default:
+			return nil, fmt.Errorf("key %q has neither a secret nor a public key file", cfg.ID)
+		}
+	}
+	return &StaticKeys{keys: keys}, nil
+}
+
+// Key returns the key with the id, a token without a kid is verified with the only key
+func (s *StaticKeys) Key(keyID string) (any, error) {
+	return lookupKey(s.keys, keyID)
+}
+
+// readPublicKeyFile reads a PEM encoded PKIX public key
+func readPublicKeyFile(path string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not PEM encoded", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%s holds a %T, only RSA and ECDSA keys are supported", path, key)
+	}
+}
+
+// JWKSFile is a JSON Web Key Set file, such as the one an identity provider publishes.
+// The file is read again when its modification time changes, so keys can be rotated without a restart.
+type JWKSFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	keys    map[string]any
+}
+
+// NewJWKSFile reads the key set, it fails when the file cannot be read or holds no usable key
+func NewJWKSFile(path string) (*JWKSFile, error) {
+	f := &JWKSFile{path: path}
+	if _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Key returns the key with the id from the current content of the file.
+// The keys read last are kept when the file cannot be read, so a rotation caught halfway does not fail every call.
+func (f *JWKSFile) Key(keyID string) (any, error) {
+	keys, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return lookupKey(keys, keyID)
+}
+
+// load returns the keys of the file, reading it again when it changed
+func (f *JWKSFile) load() (map[string]any, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if f.keys != nil {
+			return f.keys, nil
+		}
+		return nil, err
+	}
+	if f.keys != nil && info.ModTime().Equal(f.modTime) {
+		return f.keys, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err == nil {
+		var keys map[string]any
+		if keys, err = parseJWKS(data); err == nil {
+			f.keys, f.modTime = keys, info.ModTime()
+			return keys, nil
+		}
+	}
+	if f.keys != nil {
+		return f.keys, nil
+	}
+	return nil, fmt.Errorf("failed to read key set %s: %w", f.path, err)
+}
+
+// jwk is a JSON Web Key of a key set
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Curve   string `json:"crv"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+	K       string `json:"k"`
+}
+
+// parseJWKS parses the signing keys of a key set, encryption keys are skipped
+func parseJWKS(data []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.KeyID, err)
+		}
+		keys[k.KeyID] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys in the key set")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the key, only P-256 curves are supported for EC keys
+func (k jwk) publicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("curve %q is not supported", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid P-256 point")
+		}
+		return ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, err
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("key type %q is not supported", k.KeyType)
+	}
+}
+
+// decodeBigInt decodes a base64url big-endian integer of a key
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// lookupKey returns the key with the id, or the only key when the id is empty
+func lookupKey(keys map[string]any, keyID string) (any, error) {
+	if key, ok := keys[keyID]; ok {
+		return key, nil
+	}
+	if keyID == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", keyID)
+}
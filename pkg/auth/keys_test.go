@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeJWKS writes the public keys as a key set with the modification time
+func writeJWKS(t *testing.T, path string, modTime time.Time, keys map[string]any) {
+	t.Helper()
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for id, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{"kty": "RSA", "kid": id, "use": "sig",
+				"n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())})
+		case *ecdsa.PublicKey:
+			point, err := key.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			set.Keys = append(set.Keys, map[string]string{"kty": "EC", "kid": id, "crv": "P-256",
+				"x": encode(point[1:33]), "y": encode(point[33:])})
+		}
+	}
+	// Encryption keys are skipped
+	set.Keys = append(set.Keys, map[string]string{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQ", "e": "AQ"})
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJWKSFile_Rotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, testNow, map[string]any{"old": &oldKey.PublicKey})
+	keys, err := NewJWKSFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := newVerifier(keys, Config{})
+	v.now = func() time.Time { return testNow }
+
+	oldToken := signToken(t, AlgorithmRS256, "old", oldKey, validClaims())
+	newToken := signToken(t, AlgorithmES256, "new", newKey, validClaims())
+	if _, err := v.Verify(oldToken); err != nil {
+		t.Fatalf("token of the old key: %v", err)
+	}
+	if _, err := v.Verify(newToken); err == nil {
+		t.Fatal("token of a key not published yet was accepted")
+	}
+
+	writeJWKS(t, path, testNow.Add(time.Minute), map[string]any{"new": &newKey.PublicKey})
+	if _, err := v.Verify(newToken); err != nil {
+		t.Fatalf("token of the rotated key: %v", err)
+	}
+	if _, err := v.Verify(oldToken); err == nil {
+		t.Fatal("token of a retired key was accepted")
+	}
+
+	// A broken file keeps the keys read last
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(newToken); err != nil {
+		t.Fatalf("token rejected while the key set is broken: %v", err)
+	}
+}
+
+func TestNewStaticKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := NewStaticKeys([]StaticKeyConfig{{ID: "ec", PublicKeyFile: path}, {ID: "hmac", Secret: "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, err := keys.Key("ec"); err != nil || !key.PublicKey.Equal(k) {
+		t.Errorf("Key(ec) = %v, %v", k, err)
+	}
+	if _, err := keys.Key(""); err == nil {
+		t.Error("a token without kid matched one of several keys")
+	}
+
+	for _, configs := range [][]StaticKeyConfig{
+		nil,
+		{{ID: "a"}},
+		{{ID: "a", Secret: "s", PublicKeyFile: path}},
+		{{ID: "a", Secret: "s"}, {ID: "a", Secret: "t"}},
+	} {
+		if _, err := NewStaticKeys(configs); err == nil {
+			t.Errorf("NewStaticKeys(%+v) accepted an invalid configuration", configs)
+		}
+	}
+}
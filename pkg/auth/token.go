@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Signing algorithms of the tokens accepted, each is bound to the type of key it is verified with
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+)
+
+// Claims are the claims of a token the services read
+type Claims struct {
+	Subject    string   `json:"sub"`
+	Issuer     string   `json:"iss,omitempty"`
+	Audience   audience `json:"aud,omitempty"`
+	ExpiresAt  int64    `json:"exp"`
+	NotBefore  int64    `json:"nbf,omitempty"`
+	IssuedAt   int64    `json:"iat,omitempty"`
+	Roles      []string `json:"roles"`
+	CustomerID string   `json:"customer_id,omitempty"`
+	// APIKeyID and Scopes are set on the tokens the BFF issues for API keys
+	APIKeyID string   `json:"api_key_id,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+}
+
+// audience is the aud claim, a single string or an array of them
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Verifier verifies signed JSON Web Tokens and returns the principal they were issued to
+type Verifier struct {
+	keys     KeySource
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// NewVerifier creates a verifier with the keys of the configuration.
+// It returns nil when authentication is disabled, the middleware and interceptors then let every call through.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var keys KeySource
+	var err error
+	if cfg.JWKSFile != "" {
+		keys, err = NewJWKSFile(cfg.JWKSFile)
+	} else {
+		keys, err = NewStaticKeys(cfg.StaticKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newVerifier(keys, cfg), nil
+}
+
+func newVerifier(keys KeySource, cfg Config) *Verifier {
+	return &Verifier{keys: keys, issuer: cfg.Issuer, audience: cfg.Audience, leeway: cfg.Leeway, now: time.Now}
+}
+
+// Verify checks the signature and claims of a compact serialized token.
+// Roles the services do not know are dropped, a token must have a subject and an expiry.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a signed JWT", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	key, err := v.keys.Key(h.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	if err := verifySignature(h.Algorithm, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	principal := &Principal{Subject: claims.Subject, CustomerID: claims.CustomerID, APIKeyID: claims.APIKeyID}
+	for _, role := range claims.Roles {
+		if Role(role).IsKnown() {
+			principal.Roles = append(principal.Roles, Role(role))
+		}
+	}
+	// Unknown scopes are kept, they narrow the key to nothing rather than widening it
+	for _, scope := range claims.Scopes {
+		principal.Scopes = append(principal.Scopes, Permission(scope))
+	}
+	return principal, nil
+}
+
+// checkClaims checks the subject, validity period, issuer and audience of a token
+func (v *Verifier) checkClaims(claims Claims) error {
+	now := v.now()
+	switch {
+	case claims.Subject == "":
+		return fmt.Errorf("%w: no subject", ErrInvalidToken)
+	case claims.ExpiresAt == 0:
+		return fmt.Errorf("%w: no expiry", ErrInvalidToken)
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(v.leeway)):
+		return ErrExpiredToken
+	case claims.NotBefore != 0 && now.Add(v.leeway).Before(time.Unix(claims.NotBefore, 0)):
+		return fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return fmt.Errorf("%w: issuer %q is not trusted", ErrInvalidToken, claims.Issuer)
+	case v.audience != "" && !slices.Contains(claims.Audience, v.audience):
+		return fmt.Errorf("%w: not issued for %s", ErrInvalidToken, v.audience)
+	}
+	return nil
+}
+
+// verifySignature verifies the signature of the signed part with the key.
+// The algorithm must match the type of the key, so an RSA public key is never used as an HMAC secret.
+func verifySignature(algorithm string, key any, signed string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+	switch key := key.(type) {
+	case []byte:
+		if algorithm != AlgorithmHS256 {
+			break
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRS256 {
+			break
+		}
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if algorithm != AlgorithmES256 {
+			break
+		}
+		if len(signature) != 64 {
+			return errors.New("signature mismatch")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+	return fmt.Errorf("algorithm %q does not match the key", algorithm)
+}
+
+// SignHS256 signs the claims with an HMAC secret, for the tokens the BFF issues for API keys, development tokens and tests
+func SignHS256(claims Claims, keyID string, secret []byte) (string, error) {
+	h, err := json.Marshal(header{Algorithm: AlgorithmHS256, KeyID: keyID})
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// decodeSegment decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// signToken signs the claims with the algorithm and a private key of the matching type
+func signToken(t *testing.T, algorithm, keyID string, key any, claims Claims) string {
+	t.Helper()
+	h, _ := json.Marshal(header{Algorithm: algorithm, KeyID: keyID})
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	default:
+		t.Fatalf("unsupported key %T", key)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() Claims {
+	return Claims{
+		Subject:    "user-1",
+		Issuer:     "issuer",
+		Audience:   []string{"billing-system"},
+		ExpiresAt:  testNow.Add(time.Hour).Unix(),
+		Roles:      []string{"customer", "superuser"},
+		CustomerID: "CUST001",
+	}
+}
+
+func testVerifier(keys map[string]any) *Verifier {
+	v := newVerifier(&StaticKeys{keys: keys}, Config{Issuer: "issuer", Audience: "billing-system", Leeway: time.Minute})
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestVerifier_Algorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+	v := testVerifier(map[string]any{"hmac": secret, "rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey})
+
+	hs256, err := SignHS256(validClaims(), "hmac", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := map[string]string{
+		"HS256": hs256,
+		"RS256": signToken(t, AlgorithmRS256, "rsa", rsaKey, validClaims()),
+		"ES256": signToken(t, AlgorithmES256, "ec", ecKey, validClaims()),
+	}
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			principal, err := v.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if principal.Subject != "user-1" || principal.CustomerID != "CUST001" {
+				t.Errorf("principal = %+v", principal)
+			}
+			if len(principal.Roles) != 1 || principal.Roles[0] != RoleCustomer {
+				t.Errorf("roles = %v, want the known customer role only", principal.Roles)
+			}
+		})
+	}
+}
+
+func TestVerifier_Rejects(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := testVerifier(map[string]any{"hmac": secret, "rsa": &rsaKey.PublicKey})
+
+	sign := func(mutate func(*Claims)) string {
+		claims := validClaims()
+		mutate(&claims)
+		token, err := SignHS256(claims, "hmac", secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	valid := sign(func(*Claims) {})
+
+	// An HS256 token signed with the RSA public key must not verify against it
+	publicKeyAsSecret, _ := SignHS256(validClaims(), "rsa", rsaKey.PublicKey.N.Bytes())
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "Expired", token: sign(func(c *Claims) { c.ExpiresAt = testNow.Add(-2 * time.Minute).Unix() }), wantErr: ErrExpiredToken},
+		{name: "Not valid yet", token: sign(func(c *Claims) { c.NotBefore = testNow.Add(2 * time.Minute).Unix() }), wantErr: ErrInvalidToken},
+		{name: "No expiry", token: sign(func(c *Claims) { c.ExpiresAt = 0 }), wantErr: ErrInvalidToken},
+		{name: "No subject", token: sign(func(c *Claims) { c.Subject = "" }), wantErr: ErrInvalidToken},
+		{name: "Other issuer", token: sign(func(c *Claims) { c.Issuer = "evil" }), wantErr: ErrInvalidToken},
+		{name: "Other audience", token: sign(func(c *Claims) { c.Audience = []string{"other"} }), wantErr: ErrInvalidToken},
+		{name: "Wrong secret", token: func() string { token, _ := SignHS256(validClaims(), "hmac", []byte("other")); return token }(), wantErr: ErrInvalidToken},
+		{name: "Tampered claims", token: valid[:len(valid)-60] + "x" + valid[len(valid)-59:], wantErr: ErrInvalidToken},
+		{name: "Unknown key", token: func() string { token, _ := SignHS256(validClaims(), "other", secret); return token }(), wantErr: ErrInvalidToken},
+		{name: "Algorithm confusion", token: publicKeyAsSecret, wantErr: ErrInvalidToken},
+		{name: "Unsigned", token: "eyJhbGciOiJub25lIn0.eyJzdWIiOiJ4In0.", wantErr: ErrInvalidToken},
+		{name: "Garbage", token: "not-a-token", wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Verify(tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Within the leeway a token is still accepted
+	if _, err := v.Verify(sign(func(c *Claims) { c.ExpiresAt = testNow.Add(-30 * time.Second).Unix() })); err != nil {
+		t.Errorf("token expired within the leeway: %v", err)
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	customer := &Principal{Subject: "c", CustomerID: "CUST001", Roles: []Role{RoleCustomer}}
+	ops := &Principal{Subject: "o", Roles: []Role{RoleOps}}
+	admin := &Principal{Subject: "a", Roles: []Role{RoleAdmin}}
+
+	if !customer.Can(PermissionOrdersRead) || customer.Can(PermissionShipmentsRead) {
+		t.Error("customers read orders but not shipments")
+	}
+	if !ops.Can(PermissionShipmentsWrite) || ops.Can(PermissionCatalogWrite) || ops.Can(PermissionAdmin) {
+		t.Error("ops write shipments but not the catalog")
+	}
+	if !admin.Can(PermissionCatalogWrite) || !admin.Can(PermissionAdmin) {
+		t.Error("admins can do anything")
+	}
+	if !customer.CanActFor("CUST001") || customer.CanActFor("CUST002") || customer.CanActFor("") {
+		t.Error("customers act for themselves only")
+	}
+	if !ops.CanActFor("CUST002") {
+		t.Error("staff act for any customer")
+	}
+
+	// Scopes narrow the roles of an API key, they never add to them
+	erp := &Principal{Subject: "apikey:k1", APIKeyID: "k1", Roles: []Role{RoleAdmin}, Scopes: []Permission{PermissionOrdersWrite, PermissionShipmentsRead}}
+	if !erp.Can(PermissionOrdersWrite) || !erp.Can(PermissionAuthenticated) || erp.Can(PermissionCatalogWrite) || erp.Can(PermissionAdmin) {
+		t.Error("an admin key scoped to orders and shipments can do nothing else")
+	}
+	scopedOps := &Principal{Subject: "apikey:k2", APIKeyID: "k2", Roles: []Role{RoleOps}, Scopes: []Permission{PermissionCatalogWrite}}
+	if scopedOps.Can(PermissionCatalogWrite) || scopedOps.Can(PermissionShipmentsRead) {
+		t.Error("a scope the roles do not grant is not granted")
+	}
+}
+
+func TestVerifier_APIKeyClaims(t *testing.T) {
+	secret := []byte("bff-secret")
+	v := testVerifier(map[string]any{"bff": secret})
+	claims := validClaims()
+	claims.Subject = "apikey:k1"
+	claims.APIKeyID = "k1"
+	claims.Scopes = []string{"orders:write", "unknown:scope"}
+	token, err := SignHS256(claims, "bff", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	principal, err := v.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify returned %v", err)
+	}
+	if principal.APIKeyID != "k1" || len(principal.Scopes) != 2 || !principal.Can(PermissionOrdersWrite) || principal.Can(PermissionOrdersRead) {
+		t.Errorf("principal = %+v, want key k1 scoped to orders:write", principal)
+	}
+}
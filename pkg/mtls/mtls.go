@@ -0,0 +1,215 @@
+// Package mtls secures the gRPC connections between the services with mutual TLS.
+// Certificates, keys and CA bundles are read again when their files change, so rotated certificates
+// are picked up by the next handshake without a restart. Servers only accept clients whose certificate
+// carries one of the allowed identities.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// IdentityPrefix starts the URI SAN identifying each service, such as spiffe://billing-system/bff
+const IdentityPrefix = "spiffe://billing-system/"
+
+// Identity returns the URI SAN identifying the service
+func Identity(service string) string {
+	return IdentityPrefix + service
+}
+
+// Config configures the certificate a service presents, as a server and as a client, and the CA it trusts
+type Config struct {
+	// Enabled turns TLS on, without it connections are in plaintext
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// CAFile is the PEM bundle of the CAs the certificates of the other services are verified with
+	CAFile string `yaml:"ca_file"`
+	// AllowedClients are the URI or DNS SANs of the clients the server accepts, any client certified by the CA when empty
+	AllowedClients []string `yaml:"allowed_clients"`
+}
+
+// ServerCredentials returns the transport credentials of a gRPC server, plaintext when TLS is disabled.
+// Clients must present a certificate of the CA with one of the allowed identities.
+func ServerCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	r, err := newReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(r.serverConfig()), nil
+}
+
+// ClientCredentials returns the transport credentials to dial a gRPC server, plaintext when TLS is disabled.
+// The server certificate must be valid for serverName, the host of the dialed address when empty.
+func ClientCredentials(cfg Config, serverName string) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	r, err := newReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(r.clientConfig(serverName)), nil
+}
+
+// reloader holds the certificate and CA pool of a configuration, read again when one of their files changes
+type reloader struct {
+	cfg Config
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	cert     *tls.Certificate
+	pool     *x509.CertPool
+}
+
+func newReloader(cfg Config) (*reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errors.New("TLS needs a certificate, a key and a CA file")
+	}
+	r := &reloader{cfg: cfg}
+	if _, _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load returns the current certificate and CA pool.
+// When the files cannot be read, such as while a rotation is halfway written, the ones read last are kept.
+func (r *reloader) load() (*tls.Certificate, *x509.CertPool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var modTimes [3]time.Time
+	for i, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return r.keep(err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	if r.cert != nil && modTimes == r.modTimes {
+		return r.cert, r.pool, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return r.keep(err)
+	}
+	caPEM, err := os.ReadFile(r.cfg.CAFile)
+	if err != nil {
+		return r.keep(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return r.keep(fmt.Errorf("%s holds no PEM certificate", r.cfg.CAFile))
+	}
+
+	if r.cert != nil {
+		log.Printf("Reloaded TLS certificate %s", r.cfg.CertFile)
+	}
+	r.cert, r.pool, r.modTimes = &cert, pool, modTimes
+	return r.cert, r.pool, nil
+}
+
+// keep returns the certificate and pool read last, or the error when nothing was read yet
+func (r *reloader) keep(err error) (*tls.Certificate, *x509.CertPool, error) {
+	if r.cert == nil {
+		return nil, nil, fmt.Errorf("failed to load TLS files: %w", err)
+	}
+	log.Printf("Keeping the previous TLS certificate, failed to reload: %v", err)
+	return r.cert, r.pool, nil
+}
+
+// serverConfig returns a config that builds the config of each handshake from the current files
+func (r *reloader) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool, err := r.load()
+			if err != nil {
+				return nil, err
+			}
+			return &tls.Config{
+				MinVersion:       tls.VersionTLS12,
+				NextProtos:       []string{"h2"},
+				Certificates:     []tls.Certificate{*cert},
+				ClientAuth:       tls.RequireAndVerifyClientCert,
+				ClientCAs:        pool,
+				VerifyConnection: r.checkClient,
+			}, nil
+		},
+	}
+}
+
+// checkClient accepts the client when its verified certificate carries an allowed identity
+func (r *reloader) checkClient(state tls.ConnectionState) error {
+	if len(r.cfg.AllowedClients) == 0 {
+		return nil
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("client presented no certificate")
+	}
+	identities := Identities(state.PeerCertificates[0])
+	for _, identity := range identities {
+		if slices.Contains(r.cfg.AllowedClients, identity) {
+			return nil
+		}
+	}
+	return fmt.Errorf("client identity %v is not allowed", identities)
+}
+
+// clientConfig returns a config presenting the current certificate and verifying the server with the current CA pool.
+// The standard verification is replaced because it would keep the pool the config was created with.
+func (r *reloader) clientConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _, err := r.load()
+			return cert, err
+		},
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			_, pool, err := r.load()
+			if err != nil {
+				return err
+			}
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			intermediates := x509.NewCertPool()
+			for _, cert := range state.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+				Roots:         pool,
+				Intermediates: intermediates,
+				DNSName:       state.ServerName,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})
+			return err
+		},
+	}
+}
+
+// Identities returns the URI and DNS SANs of a certificate
+func Identities(cert *x509.Certificate) []string {
+	identities := make([]string, 0, len(cert.URIs)+len(cert.DNSNames))
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return append(identities, cert.DNSNames...)
+}
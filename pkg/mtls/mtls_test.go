@@ -0,0 +1,188 @@
+package mtls
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// issueFiles writes the CA bundle and a certificate of the service with the modification time, returning its config
+func issueFiles(t *testing.T, dir string, ca *DevCA, service string, modTime time.Time) Config {
+	t.Helper()
+	certPEM, keyPEM, err := ca.Issue(service, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{
+		Enabled:  true,
+		CertFile: filepath.Join(dir, service+".crt"),
+		KeyFile:  filepath.Join(dir, service+".key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	for path, data := range map[string][]byte{cfg.CertFile: certPEM, cfg.KeyFile: keyPEM, cfg.CAFile: ca.CertPEM()} {
+		if err := WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cfg
+}
+
+// handshake connects the client to the server over a pipe and returns the certificate the server presented
+func handshake(t *testing.T, server, client credentials.TransportCredentials, serverName string) ([]byte, error) {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		_, _, err := server.ServerHandshake(serverConn)
+		serverConn.Close()
+		serverErr <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, info, clientErr := client.ClientHandshake(ctx, serverName+":443", clientConn)
+	// The pipe is unbuffered, what the server writes after the client finished, such as an alert, must be read
+	go io.Copy(io.Discard, clientConn)
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	if clientErr != nil {
+		return nil, clientErr
+	}
+	return info.(credentials.TLSInfo).State.PeerCertificates[0].Raw, nil
+}
+
+func newCredentials(t *testing.T, serverCfg, clientCfg Config) (credentials.TransportCredentials, credentials.TransportCredentials) {
+	t.Helper()
+	server, err := ServerCredentials(serverCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := ClientCredentials(clientCfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+func TestHandshake_AllowedClients(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := NewDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now()
+	serverCfg := issueFiles(t, dir, ca, "billing", modTime)
+	serverCfg.AllowedClients = []string{Identity("bff")}
+	bffCfg := issueFiles(t, dir, ca, "bff", modTime)
+	intruderCfg := issueFiles(t, dir, ca, "intruder", modTime)
+
+	server, bff := newCredentials(t, serverCfg, bffCfg)
+	if _, err := handshake(t, server, bff, "billing"); err != nil {
+		t.Fatalf("allowed client rejected: %v", err)
+	}
+
+	_, intruder := newCredentials(t, serverCfg, intruderCfg)
+	if _, err := handshake(t, server, intruder, "billing"); err == nil {
+		t.Fatal("client with a disallowed identity accepted")
+	}
+
+	if _, err := handshake(t, server, bff, "shipment"); err == nil {
+		t.Fatal("server certificate accepted for another server name")
+	}
+
+	otherCA, err := NewDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreignCfg := issueFiles(t, t.TempDir(), otherCA, "bff", modTime)
+	_, foreign := newCredentials(t, serverCfg, foreignCfg)
+	if _, err := handshake(t, server, foreign, "billing"); err == nil {
+		t.Fatal("client certified by another CA accepted")
+	}
+}
+
+func TestHandshake_ReloadsRotatedCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := NewDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-time.Minute)
+	serverCfg := issueFiles(t, dir, ca, "billing", modTime)
+	clientCfg := issueFiles(t, dir, ca, "bff", modTime)
+	server, client := newCredentials(t, serverCfg, clientCfg)
+
+	before, err := handshake(t, server, client, "billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new CA and new certificates replace the files, the credentials created before must use them
+	rotatedCA, err := NewDevCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issueFiles(t, dir, rotatedCA, "billing", modTime.Add(time.Minute))
+	issueFiles(t, dir, rotatedCA, "bff", modTime.Add(time.Minute))
+
+	after, err := handshake(t, server, client, "billing")
+	if err != nil {
+		t.Fatalf("handshake after rotation: %v", err)
+	}
+	if bytes.Equal(before, after) {
+		t.Fatal("server still presents the certificate of before the rotation")
+	}
+	rotated, err := os.ReadFile(serverCfg.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(rotated)
+	if !bytes.Equal(after, block.Bytes) {
+		t.Fatal("server does not present the rotated certificate")
+	}
+
+	// A broken file keeps the certificates read last
+	if err := os.WriteFile(serverCfg.CertFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	kept, err := handshake(t, server, client, "billing")
+	if err != nil {
+		t.Fatalf("handshake with a broken certificate file: %v", err)
+	}
+	if !bytes.Equal(after, kept) {
+		t.Fatal("server dropped its certificate on a failed reload")
+	}
+}
+
+func TestCredentials_Disabled(t *testing.T) {
+	server, client := newCredentials(t, Config{}, Config{})
+	if server.Info().SecurityProtocol != "insecure" || client.Info().SecurityProtocol != "insecure" {
+		t.Fatal("disabled TLS should fall back to plaintext credentials")
+	}
+}
+
+func TestServerCredentials_MissingFiles(t *testing.T) {
+	if _, err := ServerCredentials(Config{Enabled: true}); err == nil {
+		t.Fatal("expected an error without certificate files")
+	}
+	dir := t.TempDir()
+	cfg := Config{Enabled: true, CertFile: filepath.Join(dir, "a.crt"), KeyFile: filepath.Join(dir, "a.key"), CAFile: filepath.Join(dir, "ca.crt")}
+	if _, err := ServerCredentials(cfg); err == nil {
+		t.Fatal("expected an error for files that do not exist")
+	}
+}
@@ -2,9 +2,10 @@ package billing
 
 import (
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	billingPb "billing-system/billing_service/proto"
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 	"billing-system/shipment_service/config"
 )
 
@@ -15,9 +16,15 @@ type BillingConnectionAdapter struct {
 
 // NewConnection creates a new connection to the billing service
 func (adapter *BillingConnectionAdapter) NewConnection() (*grpc.ClientConn, error) {
+	creds, err := mtls.ClientCredentials(config.Service.TLS, config.Service.BillingConnection.ServerName)
+	if err != nil {
+		return nil, err
+	}
 	conn, err := grpc.Dial(
 		config.Service.BillingConnection.Address, // Billing service address
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(auth.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(auth.StreamClientInterceptor()),
 	)
 	if err != nil {
 		return nil, err
@@ -43,6 +43,13 @@ func (c *BillingClient) CreateInvoice(ctx context.Context, req CreateInvoiceRequ
 		OrderId:    req.OrderID,
 		Items:      pbItems,
 	}
+	if req.ShippingFee != nil {
+		pbRequest.ShippingFee = &billingPb.ShippingFeeRequest{
+			Amount:      req.ShippingFee.Amount,
+			Description: req.ShippingFee.Description,
+			TaxCategory: req.ShippingFee.TaxCategory,
+		}
+	}
 
 	// Call billing service
 	pbResponse, err := billingClient.CreateInvoice(ctx, pbRequest)
@@ -60,3 +67,102 @@ func (c *BillingClient) CreateInvoice(ctx context.Context, req CreateInvoiceRequ
 
 	return response, nil
 }
+
+// CreateCreditNote calls the billing service to reverse the invoiced amount of returned items
+func (c *BillingClient) CreateCreditNote(ctx context.Context, req CreateCreditNoteRequest) (*CreateCreditNoteResponse, error) {
+	// Get billing service client
+	clientInterface, _, err := c.Connection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		return nil, err
+	}
+
+	billingClient := clientInterface.(billingPb.BillingServiceClient)
+
+	// Convert request to protobuf
+	pbItems := make([]*billingPb.InvoiceItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		pbItems[i] = &billingPb.InvoiceItemRequest{
+			Sku:      item.Sku,
+			Quantity: item.Quantity,
+		}
+	}
+
+	pbResponse, err := billingClient.CreateCreditNote(ctx, &billingPb.CreateCreditNoteRequest{
+		ShipmentId: req.ShipmentID,
+		Reference:  req.Reference,
+		Reason:     req.Reason,
+		Items:      pbItems,
+	})
+	if err != nil {
+		log.Println("Error calling CreateCreditNote:", err)
+		return nil, err
+	}
+
+	// Convert response
+	response := &CreateCreditNoteResponse{
+		Code:    pbResponse.Code,
+		Message: pbResponse.Message,
+	}
+	if pbResponse.CreditNote != nil {
+		response.CreditNoteID = pbResponse.CreditNote.Id
+		response.Amount = pbResponse.CreditNote.Amount
+	}
+
+	return response, nil
+}
+
+// GetShippableQuantities calls the billing service to get what is left to ship of an order
+func (c *BillingClient) GetShippableQuantities(ctx context.Context, orderID int64) ([]ShippableQuantity, error) {
+	// Get billing service client
+	clientInterface, _, err := c.Connection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		return nil, err
+	}
+
+	billingClient := clientInterface.(billingPb.BillingServiceClient)
+
+	pbResponse, err := billingClient.GetShippableQuantities(ctx, &billingPb.GetShippableQuantitiesRequest{OrderId: orderID})
+	if err != nil {
+		log.Println("Error calling GetShippableQuantities:", err)
+		return nil, err
+	}
+
+	// Convert response
+	quantities := make([]ShippableQuantity, len(pbResponse.Quantities))
+	for i, quantity := range pbResponse.Quantities {
+		quantities[i] = ShippableQuantity{
+			Sku:       quantity.Sku,
+			Ordered:   int(quantity.Ordered),
+			Invoiced:  int(quantity.Invoiced),
+			Remaining: int(quantity.Remaining),
+		}
+	}
+
+	return quantities, nil
+}
+
+// GetOrder calls the billing service to get an order, which is not found for customers of other orders
+func (c *BillingClient) GetOrder(ctx context.Context, orderID int64) (*Order, error) {
+	// Get billing service client
+	clientInterface, _, err := c.Connection.NewClient()
+	if err != nil {
+		log.Println("Error connecting to billing service:", err)
+		return nil, err
+	}
+
+	billingClient := clientInterface.(billingPb.BillingServiceClient)
+
+	pbResponse, err := billingClient.GetOrder(ctx, &billingPb.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		log.Println("Error calling GetOrder:", err)
+		return nil, err
+	}
+
+	return &Order{
+		ID:         pbResponse.Order.Id,
+		CustomerID: pbResponse.Order.CustomerId,
+		Status:     pbResponse.Order.Status.String(),
+	}, nil
+}
@@ -6,11 +6,19 @@ type InvoiceItemRequest struct {
 	Quantity int32  `json:"quantity"`
 }
 
+// ShippingFeeRequest represents the shipping fee billed with the items of an invoice
+type ShippingFeeRequest struct {
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	TaxCategory string  `json:"tax_category"`
+}
+
 // CreateInvoiceRequest represents the request to create an invoice
 type CreateInvoiceRequest struct {
-	ShipmentID int64                `json:"shipment_id"`
-	OrderID    int64                `json:"order_id"`
-	Items      []InvoiceItemRequest `json:"items"`
+	ShipmentID  int64                `json:"shipment_id"`
+	OrderID     int64                `json:"order_id"`
+	Items       []InvoiceItemRequest `json:"items"`
+	ShippingFee *ShippingFeeRequest  `json:"shipping_fee,omitempty"`
 }
 
 // CreateInvoiceResponse represents the response from creating an invoice
@@ -29,3 +37,34 @@ type InvoiceData struct {
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
 }
+
+// CreateCreditNoteRequest represents the request to reverse invoiced items of a shipment
+type CreateCreditNoteRequest struct {
+	ShipmentID int64                `json:"shipment_id"`
+	Reference  string               `json:"reference"`
+	Reason     string               `json:"reason"`
+	Items      []InvoiceItemRequest `json:"items"`
+}
+
+// CreateCreditNoteResponse represents the response from creating a credit note
+type CreateCreditNoteResponse struct {
+	Code         string  `json:"code"`
+	Message      string  `json:"message"`
+	CreditNoteID int64   `json:"credit_note_id,omitempty"`
+	Amount       float64 `json:"amount,omitempty"`
+}
+
+// ShippableQuantity represents how much of an ordered SKU is left to ship
+type ShippableQuantity struct {
+	Sku       string `json:"sku"`
+	Ordered   int    `json:"ordered"`
+	Invoiced  int    `json:"invoiced"`
+	Remaining int    `json:"remaining"`
+}
+
+// Order represents the order shipments are created for
+type Order struct {
+	ID         int64  `json:"id"`
+	CustomerID string `json:"customer_id"`
+	Status     string `json:"status"`
+}
@@ -0,0 +1,56 @@
+// Command fakecarrier serves the fake carrier API for local development.
+// Scans can be added with POST /dev/consignments/{tracking_number}/scans?code=IN_TRANSIT&location=Hanoi
+// and are pushed to the webhook URL when one is given.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"net/http"
+
+	"billing-system/shipment_service/internal/carrier/fakecarrier"
+)
+
+func main() {
+	address := flag.String("address", "127.0.0.1:8090", "address to listen on")
+	secret := flag.String("webhook-secret", "fake-carrier-secret", "secret signing webhooks")
+	webhookURL := flag.String("webhook-url", "", "URL scans are pushed to, for example http://127.0.0.1:8080/api/v1/carriers/fake/webhook")
+	flag.Parse()
+
+	server := fakecarrier.NewServer(*secret)
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", server)
+	mux.HandleFunc("POST /dev/consignments/{trackingNumber}/scans", func(w http.ResponseWriter, r *http.Request) {
+		trackingNumber := r.PathValue("trackingNumber")
+		event, err := server.Scan(trackingNumber, r.URL.Query().Get("code"), r.URL.Query().Get("location"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if *webhookURL != "" {
+			header, body := server.Webhook(trackingNumber, event)
+			req, err := http.NewRequestWithContext(r.Context(), http.MethodPost, *webhookURL, bytes.NewReader(body))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			req.Header = header
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				log.Println("Failed to push webhook:", err)
+			} else {
+				resp.Body.Close()
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Println("Fake carrier listening on", *address)
+	if err := http.ListenAndServe(*address, mux); err != nil {
+		log.Fatalf("Failed to serve fake carrier: %v", err)
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"net"
 
 	"billing-system/shipment_service/config"
+	"billing-system/shipment_service/internal/blob"
+	"billing-system/shipment_service/internal/carrier"
 	shipment_handler "billing-system/shipment_service/internal/handler"
+	"billing-system/shipment_service/internal/model"
 	"billing-system/shipment_service/internal/repository"
 	"billing-system/shipment_service/internal/service"
 	"billing-system/shipment_service/pkg/db"
 
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 	shipment_pb "billing-system/shipment_service/proto"
 
 	"google.golang.org/grpc"
@@ -36,12 +41,39 @@ func main() {
 
 	// Initialize repositories
 	shipmentRepo := repository.NewShipmentRepository(gormDB)
+	shippingRepo := repository.NewShippingRepository(gormDB)
+	returnRepo := repository.NewReturnRepository(gormDB)
+	warehouseRepo := repository.NewWarehouseRepository(gormDB)
+
+	// Initialize carriers
+	carriers, err := newCarrierRegistry(config.Service.Carriers)
+	if err != nil {
+		log.Fatalf("Failed to configure carriers: %v", err)
+	}
+
+	blobs, err := blob.NewLocalStore(config.Service.Storage.BlobDir)
+	if err != nil {
+		log.Fatalf("Failed to open blob store: %v", err)
+	}
 
 	// Initialize services
-	shipmentService := service.NewShipmentService(shipmentRepo)
+	shipmentService := service.NewShipmentService(shipmentRepo, shippingRepo, warehouseRepo, carriers, service.ShippingConfig{
+		TaxCategory: config.Service.Shipping.TaxCategory,
+		DimDivisor:  config.Service.Shipping.DimDivisor,
+	}, service.DocumentConfig{
+		Sender: model.Address(config.Service.Documents.Sender),
+	}, blobs, service.WatchConfig{
+		HeartbeatInterval: config.Service.Watch.HeartbeatInterval,
+		PollInterval:      config.Service.Watch.PollInterval,
+		LateEventWindow:   config.Service.Watch.LateEventWindow,
+	}, service.BatchConfig{
+		Concurrency: config.Service.Batch.Concurrency,
+	})
+	returnService := service.NewReturnService(returnRepo)
+	allocationService := service.NewAllocationService(warehouseRepo)
 
 	// Initialize  handlers
-	shipmentHandler := shipment_handler.NewShipmentHandler(shipmentService)
+	shipmentHandler := shipment_handler.NewShipmentHandler(shipmentService, returnService, allocationService)
 
 	// server's address
 	address := fmt.Sprintf("%s:%s", config.Service.GRPCServer.Host, config.Service.GRPCServer.Port)
@@ -50,8 +82,30 @@ func main() {
 		log.Fatalf("Failed to listen on %s: %v", address, err)
 	}
 
+	// Callers are authorized by the token the BFF forwards
+	verifier, err := auth.NewVerifier(config.Service.Auth)
+	if err != nil {
+		log.Fatalf("Failed to configure authentication: %v", err)
+	}
+	if verifier == nil {
+		log.Println("Authentication is disabled, every caller may call every method")
+	}
+
+	// Callers must present a certificate of the CA with an allowed identity when TLS is enabled
+	creds, err := mtls.ServerCredentials(config.Service.TLS)
+	if err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
+	if !config.Service.TLS.Enabled {
+		log.Println("TLS is disabled, connections are in plaintext")
+	}
+
 	// start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(verifier, shipment_handler.MethodPermissions)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(verifier, shipment_handler.MethodPermissions)),
+	)
 	shipment_pb.RegisterShipmentServiceServer(grpcServer, shipmentHandler)
 	reflection.Register(grpcServer)
 
@@ -60,3 +114,12 @@ func main() {
 	}
 
 }
+
+// newCarrierRegistry creates the registry of the carriers enabled in the configuration
+func newCarrierRegistry(cfg config.CarriersConfig) (*carrier.Registry, error) {
+	var carriers []carrier.Carrier
+	if cfg.Fake.Enabled {
+		carriers = append(carriers, carrier.NewFakeCarrier("fake", cfg.Fake.BaseURL, cfg.Fake.WebhookSecret, cfg.Fake.Timeout))
+	}
+	return carrier.NewRegistry(cfg.Default, carriers...)
+}
@@ -2,6 +2,10 @@ package config
 
 import (
 	"os"
+	"time"
+
+	"billing-system/pkg/auth"
+	"billing-system/pkg/mtls"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,6 +14,14 @@ type Config struct {
 	Database          DatabaseConfig           `yaml:"database"`
 	GRPCServer        GRPCServerConfig         `yaml:"grpc_server"`
 	BillingConnection AdapterConnectionAddress `yaml:"billing_connection"`
+	Carriers          CarriersConfig           `yaml:"carriers"`
+	Shipping          ShippingConfig           `yaml:"shipping"`
+	Documents         DocumentsConfig          `yaml:"documents"`
+	Storage           StorageConfig            `yaml:"storage"`
+	Watch             WatchConfig              `yaml:"watch"`
+	Batch             BatchConfig              `yaml:"batch"`
+	Auth              auth.Config              `yaml:"auth"`
+	TLS               mtls.Config              `yaml:"tls"`
 }
 
 type DatabaseConfig struct {
@@ -27,6 +39,69 @@ type GRPCServerConfig struct {
 
 type AdapterConnectionAddress struct {
 	Address string `yaml:"address"`
+	// ServerName is the name the server certificate must be valid for, the host of the address when empty
+	ServerName string `yaml:"server_name"`
+}
+
+type CarriersConfig struct {
+	// Default is the code of the carrier used when a shipment does not name one
+	Default string            `yaml:"default"`
+	Fake    FakeCarrierConfig `yaml:"fake"`
+}
+
+// FakeCarrierConfig configures the reference adapter for the fake carrier API
+type FakeCarrierConfig struct {
+	Enabled       bool          `yaml:"enabled"`
+	BaseURL       string        `yaml:"base_url"`
+	WebhookSecret string        `yaml:"webhook_secret"`
+	Timeout       time.Duration `yaml:"timeout"`
+}
+
+// ShippingConfig configures the shipping fee billed on invoices
+type ShippingConfig struct {
+	// TaxCategory of the shipping line, billing defaults it to SHIPPING when empty
+	TaxCategory string `yaml:"tax_category"`
+	// DimDivisor converts cubic centimetres to dimensional kilograms, defaults to 5000
+	DimDivisor float64 `yaml:"dim_divisor"`
+}
+
+// DocumentsConfig configures the labels and packing slips printed for shipments
+type DocumentsConfig struct {
+	// Sender is the return address printed on labels
+	Sender AddressConfig `yaml:"sender"`
+}
+
+type AddressConfig struct {
+	Name       string `yaml:"name"`
+	Line1      string `yaml:"line1"`
+	Line2      string `yaml:"line2"`
+	City       string `yaml:"city"`
+	Region     string `yaml:"region"`
+	PostalCode string `yaml:"postal_code"`
+	Country    string `yaml:"country"`
+	Phone      string `yaml:"phone"`
+}
+
+// StorageConfig configures where the files shipments collect are kept
+type StorageConfig struct {
+	// BlobDir is the directory of the local blob store, relative to where the service runs
+	BlobDir string `yaml:"blob_dir"`
+}
+
+// WatchConfig configures the streams of shipment status changes
+type WatchConfig struct {
+	// HeartbeatInterval is how long a stream stays silent before a heartbeat is sent, defaults to 15s
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	// PollInterval is how often the events are read again to catch changes made by other instances, defaults to 5s
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// LateEventWindow is how long after it was recorded an event may still be committed, defaults to 1m
+	LateEventWindow time.Duration `yaml:"late_event_window"`
+}
+
+// BatchConfig configures the creation of shipments in batches
+type BatchConfig struct {
+	// Concurrency is how many orders of a batch are shipped at once, defaults to 4
+	Concurrency int `yaml:"concurrency"`
 }
 
 var Service Config
@@ -0,0 +1,21 @@
+// Package blob stores the files shipments collect, such as the signature and photos taken on delivery
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores opaque files under slash separated keys
+type BlobStore interface {
+	// Put stores the content read from r under the key, replacing what was stored there, and returns its size in bytes
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get opens the blob stored under the key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under the key, deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,67 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore returned %v", err)
+	}
+	ctx := context.Background()
+
+	size, err := store.Put(ctx, "shipments/1/delivery/photo.jpg", strings.NewReader("first"))
+	if err != nil || size != 5 {
+		t.Fatalf("Put returned %d, %v", size, err)
+	}
+	if _, err := store.Put(ctx, "shipments/1/delivery/photo.jpg", strings.NewReader("second")); err != nil {
+		t.Fatalf("Put over an existing blob returned %v", err)
+	}
+
+	content, err := store.Get(ctx, "shipments/1/delivery/photo.jpg")
+	if err != nil {
+		t.Fatalf("Get returned %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "second" {
+		t.Errorf("Get read %q, want the replaced content", data)
+	}
+
+	// No temporary files are left next to the blob
+	entries, _ := os.ReadDir(filepath.Join(root, "shipments", "1", "delivery"))
+	if len(entries) != 1 {
+		t.Errorf("blob directory holds %d files, want 1", len(entries))
+	}
+
+	if err := store.Delete(ctx, "shipments/1/delivery/photo.jpg"); err != nil {
+		t.Fatalf("Delete returned %v", err)
+	}
+	if err := store.Delete(ctx, "shipments/1/delivery/photo.jpg"); err != nil {
+		t.Errorf("Delete of a missing blob returned %v", err)
+	}
+	if _, err := store.Get(ctx, "shipments/1/delivery/photo.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a deleted blob returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalStore_InvalidKeys(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore returned %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "..", "a/../../outside", "a//b", "a/./b"} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x")); err == nil {
+			t.Errorf("Put accepted key %q", key)
+		}
+	}
+}
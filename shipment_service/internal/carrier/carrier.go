@@ -0,0 +1,88 @@
+package carrier
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrUnknownCarrier       = errors.New("unknown carrier")
+	ErrConsignmentNotFound  = errors.New("consignment not found")
+	ErrInvalidWebhook       = errors.New("invalid carrier webhook")
+	ErrCancellationRejected = errors.New("carrier rejected the cancellation")
+)
+
+// Carrier books and tracks shipments with a courier.
+// Adapters translate the courier's API and status codes into the shipment lifecycle.
+type Carrier interface {
+	// Code identifies the carrier on shipments and in webhook routes
+	Code() string
+	// QuoteRate returns the price of shipping the items
+	QuoteRate(ctx context.Context, req RateRequest) (*Rate, error)
+	// CreateConsignment books a pickup and returns the carrier's tracking number
+	CreateConsignment(ctx context.Context, req ConsignmentRequest) (*Consignment, error)
+	// CancelConsignment cancels a consignment that has not been picked up yet
+	CancelConsignment(ctx context.Context, trackingNumber string) error
+	// FetchTracking returns every tracking event of a consignment, oldest first
+	FetchTracking(ctx context.Context, trackingNumber string) ([]TrackingEvent, error)
+	// ParseWebhook verifies and decodes a tracking push from the carrier
+	ParseWebhook(header http.Header, body []byte) ([]TrackingEvent, error)
+}
+
+// Item is a SKU and quantity handed to a carrier
+type Item struct {
+	Sku      string
+	Quantity int
+}
+
+// Parcel is a package handed to a carrier
+type Parcel struct {
+	WeightKg float64
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+}
+
+// RateRequest describes what is shipped for a rate quote
+type RateRequest struct {
+	Items []Item
+	// Parcels are the packages the items are packed in, empty when the shipment is not packed yet
+	Parcels []Parcel
+	// WeightKg is the chargeable weight of the items, zero when their dimensions are unknown
+	WeightKg              float64
+	DestinationPostalCode string
+}
+
+// Rate is a carrier's price for shipping a request
+type Rate struct {
+	CarrierCode   string
+	Service       string
+	Amount        float64
+	EstimatedDays int
+}
+
+// ConsignmentRequest describes a shipment to book with a carrier
+type ConsignmentRequest struct {
+	// Reference is our identifier of the shipment, echoed back by the carrier
+	Reference string
+	OrderID   int64
+	Items     []Item
+	Parcels   []Parcel
+}
+
+// Consignment is a booked carrier shipment
+type Consignment struct {
+	TrackingNumber string
+}
+
+// TrackingEvent is a carrier scan translated into a shipment status
+type TrackingEvent struct {
+	TrackingNumber string
+	Status         model.ShipmentStatus
+	Timestamp      time.Time
+	Location       string
+	Note           string
+}
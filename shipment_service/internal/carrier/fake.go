@@ -0,0 +1,199 @@
+package carrier
+
+import (
+	"billing-system/shipment_service/internal/carrier/fakecarrier"
+	"billing-system/shipment_service/internal/model"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FakeCarrier is the reference adapter, it talks to the fake carrier API in package fakecarrier.
+// Real carriers follow the same shape: call the API over HTTP and map scan codes to shipment statuses.
+type FakeCarrier struct {
+	code          string
+	baseURL       string
+	webhookSecret []byte
+	client        *http.Client
+}
+
+// NewFakeCarrier creates an adapter for the fake carrier API at baseURL
+func NewFakeCarrier(code, baseURL, webhookSecret string, timeout time.Duration) *FakeCarrier {
+	return &FakeCarrier{
+		code:          code,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		webhookSecret: []byte(webhookSecret),
+		client:        &http.Client{Timeout: timeout},
+	}
+}
+
+// Code returns the carrier code
+func (c *FakeCarrier) Code() string {
+	return c.code
+}
+
+// QuoteRate returns the price of shipping the items
+func (c *FakeCarrier) QuoteRate(ctx context.Context, req RateRequest) (*Rate, error) {
+	var resp fakecarrier.RateResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/rates", fakecarrier.RateRequest{
+		Items:                 fakeItems(req.Items),
+		Parcels:               fakeParcels(req.Parcels),
+		WeightKg:              req.WeightKg,
+		DestinationPostalCode: req.DestinationPostalCode,
+	}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to quote rate: %w", err)
+	}
+
+	return &Rate{
+		CarrierCode:   c.code,
+		Service:       resp.Service,
+		Amount:        resp.Amount,
+		EstimatedDays: resp.EstimatedDays,
+	}, nil
+}
+
+// CreateConsignment books a pickup and returns the tracking number
+func (c *FakeCarrier) CreateConsignment(ctx context.Context, req ConsignmentRequest) (*Consignment, error) {
+	body := fakecarrier.ConsignmentRequest{
+		Reference: req.Reference,
+		OrderID:   req.OrderID,
+		Items:     fakeItems(req.Items),
+		Parcels:   fakeParcels(req.Parcels),
+	}
+
+	var resp fakecarrier.ConsignmentResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/consignments", body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create consignment: %w", err)
+	}
+
+	return &Consignment{TrackingNumber: resp.TrackingNumber}, nil
+}
+
+// CancelConsignment cancels a consignment that has not been picked up yet
+func (c *FakeCarrier) CancelConsignment(ctx context.Context, trackingNumber string) error {
+	if err := c.do(ctx, http.MethodDelete, "/v1/consignments/"+url.PathEscape(trackingNumber), nil, nil); err != nil {
+		return fmt.Errorf("failed to cancel consignment %s: %w", trackingNumber, err)
+	}
+	return nil
+}
+
+// FetchTracking returns every tracking event of a consignment, oldest first
+func (c *FakeCarrier) FetchTracking(ctx context.Context, trackingNumber string) ([]TrackingEvent, error) {
+	var resp fakecarrier.TrackingResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/consignments/"+url.PathEscape(trackingNumber)+"/events", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch tracking of %s: %w", trackingNumber, err)
+	}
+	return c.trackingEvents(resp), nil
+}
+
+// ParseWebhook verifies the signature of a tracking push and decodes its events
+func (c *FakeCarrier) ParseWebhook(header http.Header, body []byte) ([]TrackingEvent, error) {
+	signature := header.Get(fakecarrier.SignatureHeader)
+	if !hmac.Equal([]byte(signature), []byte(fakecarrier.Sign(c.webhookSecret, body))) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidWebhook)
+	}
+
+	var push fakecarrier.TrackingResponse
+	if err := json.Unmarshal(body, &push); err != nil || push.TrackingNumber == "" {
+		return nil, fmt.Errorf("%w: malformed body", ErrInvalidWebhook)
+	}
+
+	return c.trackingEvents(push), nil
+}
+
+// trackingEvents maps scans to shipment statuses, scans without a matching status are dropped
+func (c *FakeCarrier) trackingEvents(resp fakecarrier.TrackingResponse) []TrackingEvent {
+	events := make([]TrackingEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		status, ok := fakeStatuses[event.Code]
+		if !ok {
+			continue
+		}
+		events = append(events, TrackingEvent{
+			TrackingNumber: resp.TrackingNumber,
+			Status:         status,
+			Timestamp:      event.Time,
+			Location:       event.Location,
+			Note:           event.Description,
+		})
+	}
+	return events
+}
+
+// do sends a JSON request and decodes the JSON response into out when it is not nil
+func (c *FakeCarrier) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrConsignmentNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return ErrCancellationRejected
+	case resp.StatusCode >= 300:
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("carrier responded %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// fakeStatuses maps fake carrier scan codes to shipment statuses
+var fakeStatuses = map[string]model.ShipmentStatus{
+	fakecarrier.CodeInTransit:        model.InTransit,
+	fakecarrier.CodeOutForDelivery:   model.OutForDelivery,
+	fakecarrier.CodeDelivered:        model.Delivered,
+	fakecarrier.CodeDeliveryFailed:   model.FailedDelivery,
+	fakecarrier.CodeReturnedToSender: model.Returned,
+}
+
+func fakeItems(items []Item) []fakecarrier.Item {
+	fake := make([]fakecarrier.Item, len(items))
+	for i, item := range items {
+		fake[i] = fakecarrier.Item{Sku: item.Sku, Quantity: item.Quantity}
+	}
+	return fake
+}
+
+func fakeParcels(parcels []Parcel) []fakecarrier.Parcel {
+	fake := make([]fakecarrier.Parcel, len(parcels))
+	for i, parcel := range parcels {
+		fake[i] = fakecarrier.Parcel{
+			WeightKg: parcel.WeightKg,
+			LengthCm: parcel.LengthCm,
+			WidthCm:  parcel.WidthCm,
+			HeightCm: parcel.HeightCm,
+		}
+	}
+	return fake
+}
@@ -0,0 +1,178 @@
+package carrier
+
+import (
+	"billing-system/shipment_service/internal/carrier/fakecarrier"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newFakeCarrier(t *testing.T) (*FakeCarrier, *fakecarrier.Server) {
+	t.Helper()
+	server := fakecarrier.NewServer("secret")
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return NewFakeCarrier("fake", httpServer.URL, "secret", 5*time.Second), server
+}
+
+func TestFakeCarrier_QuoteRate(t *testing.T) {
+	c, _ := newFakeCarrier(t)
+
+	rate, err := c.QuoteRate(context.Background(), RateRequest{Items: []Item{{Sku: "SKU123", Quantity: 2}, {Sku: "SKU456", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("QuoteRate() error = %v", err)
+	}
+	if rate.CarrierCode != "fake" || rate.Amount != 21 || rate.Service != "STANDARD" {
+		t.Errorf("QuoteRate() = %+v, want fake STANDARD 21", rate)
+	}
+
+	// Every started kilogram adds to the rate
+	rate, err = c.QuoteRate(context.Background(), RateRequest{Items: []Item{{Sku: "SKU123", Quantity: 1}}, WeightKg: 2.5})
+	if err != nil {
+		t.Fatalf("QuoteRate() error = %v", err)
+	}
+	if rate.Amount != 29 {
+		t.Errorf("QuoteRate() amount = %v, want 29", rate.Amount)
+	}
+
+	// Every parcel after the first adds to the rate
+	rate, err = c.QuoteRate(context.Background(), RateRequest{
+		Items:   []Item{{Sku: "SKU123", Quantity: 1}},
+		Parcels: []Parcel{{WeightKg: 1}, {WeightKg: 1}, {WeightKg: 0.5}},
+	})
+	if err != nil {
+		t.Fatalf("QuoteRate() error = %v", err)
+	}
+	if rate.Amount != 23 {
+		t.Errorf("QuoteRate() amount = %v, want 23", rate.Amount)
+	}
+}
+
+func TestFakeCarrier_ConsignmentLifecycle(t *testing.T) {
+	c, server := newFakeCarrier(t)
+	ctx := context.Background()
+
+	consignment, err := c.CreateConsignment(ctx, ConsignmentRequest{Reference: "123", OrderID: 456, Items: []Item{{Sku: "SKU123", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CreateConsignment() error = %v", err)
+	}
+	if consignment.TrackingNumber == "" {
+		t.Fatal("CreateConsignment() returned no tracking number")
+	}
+
+	// Booking scans do not map to a shipment status
+	events, err := c.FetchTracking(ctx, consignment.TrackingNumber)
+	if err != nil {
+		t.Fatalf("FetchTracking() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("FetchTracking() = %d events, want 0", len(events))
+	}
+
+	if _, err := server.Scan(consignment.TrackingNumber, fakecarrier.CodeInTransit, "Hanoi hub"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if _, err := server.Scan(consignment.TrackingNumber, fakecarrier.CodeOutForDelivery, "Hoan Kiem"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	events, err = c.FetchTracking(ctx, consignment.TrackingNumber)
+	if err != nil {
+		t.Fatalf("FetchTracking() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Status != model.InTransit || events[1].Status != model.OutForDelivery {
+		t.Fatalf("FetchTracking() = %+v, want IN_TRANSIT then OUT_FOR_DELIVERY", events)
+	}
+	if events[0].Location != "Hanoi hub" || events[0].TrackingNumber != consignment.TrackingNumber {
+		t.Errorf("FetchTracking()[0] = %+v, want location and tracking number", events[0])
+	}
+
+	// Picked up consignments cannot be cancelled
+	if err := c.CancelConsignment(ctx, consignment.TrackingNumber); !errors.Is(err, ErrCancellationRejected) {
+		t.Errorf("CancelConsignment() error = %v, want %v", err, ErrCancellationRejected)
+	}
+}
+
+func TestFakeCarrier_CancelConsignment(t *testing.T) {
+	c, _ := newFakeCarrier(t)
+	ctx := context.Background()
+
+	consignment, err := c.CreateConsignment(ctx, ConsignmentRequest{Reference: "123", Items: []Item{{Sku: "SKU123", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CreateConsignment() error = %v", err)
+	}
+
+	if err := c.CancelConsignment(ctx, consignment.TrackingNumber); err != nil {
+		t.Errorf("CancelConsignment() error = %v", err)
+	}
+	if err := c.CancelConsignment(ctx, "FC99999999"); !errors.Is(err, ErrConsignmentNotFound) {
+		t.Errorf("CancelConsignment() error = %v, want %v", err, ErrConsignmentNotFound)
+	}
+}
+
+func TestFakeCarrier_ParseWebhook(t *testing.T) {
+	c, server := newFakeCarrier(t)
+	delivered := fakecarrier.Event{Code: fakecarrier.CodeDelivered, Time: time.Date(2023, 9, 15, 12, 30, 0, 0, time.UTC), Location: "Door"}
+
+	t.Run("Signed push", func(t *testing.T) {
+		header, body := server.Webhook("FC00000001", delivered)
+
+		events, err := c.ParseWebhook(header, body)
+		if err != nil {
+			t.Fatalf("ParseWebhook() error = %v", err)
+		}
+		if len(events) != 1 || events[0].Status != model.Delivered || !events[0].Timestamp.Equal(delivered.Time) {
+			t.Errorf("ParseWebhook() = %+v, want one DELIVERED event", events)
+		}
+	})
+
+	t.Run("Tampered body", func(t *testing.T) {
+		header, body := server.Webhook("FC00000001", delivered)
+		body[len(body)-2] = ' '
+
+		if _, err := c.ParseWebhook(header, body); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("ParseWebhook() error = %v, want %v", err, ErrInvalidWebhook)
+		}
+	})
+
+	t.Run("Signed with another secret", func(t *testing.T) {
+		header, body := fakecarrier.NewServer("other").Webhook("FC00000001", delivered)
+
+		if _, err := c.ParseWebhook(header, body); !errors.Is(err, ErrInvalidWebhook) {
+			t.Errorf("ParseWebhook() error = %v, want %v", err, ErrInvalidWebhook)
+		}
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	fast := NewFakeCarrier("fast", "http://fast", "", time.Second)
+	slow := NewFakeCarrier("slow", "http://slow", "", time.Second)
+
+	registry, err := NewRegistry("slow", fast, slow)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	if c, err := registry.Get(""); err != nil || c.Code() != "slow" {
+		t.Errorf("Get(\"\") = %v, %v, want the default carrier", c, err)
+	}
+	if c, err := registry.Get("fast"); err != nil || c.Code() != "fast" {
+		t.Errorf("Get(fast) = %v, %v, want fast", c, err)
+	}
+	if _, err := registry.Get("missing"); !errors.Is(err, ErrUnknownCarrier) {
+		t.Errorf("Get(missing) error = %v, want %v", err, ErrUnknownCarrier)
+	}
+	if all := registry.All(); len(all) != 2 || all[0].Code() != "fast" || all[1].Code() != "slow" {
+		t.Errorf("All() = %v, want fast and slow", all)
+	}
+
+	if _, err := NewRegistry("missing", fast); !errors.Is(err, ErrUnknownCarrier) {
+		t.Errorf("NewRegistry() with unknown default error = %v, want %v", err, ErrUnknownCarrier)
+	}
+	if _, err := NewRegistry("fast", fast, fast); err == nil {
+		t.Error("NewRegistry() with duplicate carriers succeeded")
+	}
+}
@@ -0,0 +1,239 @@
+// Package fakecarrier is an in-memory courier API for tests and local development.
+// It books consignments, records scans and signs tracking webhooks like a real carrier would.
+package fakecarrier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook body
+const SignatureHeader = "X-Fake-Carrier-Signature"
+
+// Scan codes reported by the fake carrier
+const (
+	CodeBooked           = "BOOKED"
+	CodeCancelled        = "CANCELLED"
+	CodeInTransit        = "IN_TRANSIT"
+	CodeOutForDelivery   = "OUT_FOR_DELIVERY"
+	CodeDelivered        = "DELIVERED"
+	CodeDeliveryFailed   = "DELIVERY_FAILED"
+	CodeReturnedToSender = "RETURNED_TO_SENDER"
+)
+
+// Item is a SKU and quantity in a rate or consignment request
+type Item struct {
+	Sku      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// Parcel is a package in a rate or consignment request
+type Parcel struct {
+	WeightKg float64 `json:"weight_kg"`
+	LengthCm float64 `json:"length_cm"`
+	WidthCm  float64 `json:"width_cm"`
+	HeightCm float64 `json:"height_cm"`
+}
+
+// RateRequest is the body of POST /v1/rates
+type RateRequest struct {
+	Items                 []Item   `json:"items"`
+	Parcels               []Parcel `json:"parcels,omitempty"`
+	WeightKg              float64  `json:"weight_kg"`
+	DestinationPostalCode string   `json:"destination_postal_code"`
+}
+
+// RateResponse is the response of POST /v1/rates
+type RateResponse struct {
+	Service       string  `json:"service"`
+	Amount        float64 `json:"amount"`
+	EstimatedDays int     `json:"estimated_days"`
+}
+
+// ConsignmentRequest is the body of POST /v1/consignments
+type ConsignmentRequest struct {
+	Reference string   `json:"reference"`
+	OrderID   int64    `json:"order_id"`
+	Items     []Item   `json:"items"`
+	Parcels   []Parcel `json:"parcels,omitempty"`
+}
+
+// ConsignmentResponse is the response of POST /v1/consignments
+type ConsignmentResponse struct {
+	TrackingNumber string `json:"tracking_number"`
+}
+
+// Event is a scan of a consignment
+type Event struct {
+	Code        string    `json:"code"`
+	Time        time.Time `json:"time"`
+	Location    string    `json:"location,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+// TrackingResponse is the response of GET /v1/consignments/{tracking_number}/events and the body of webhooks
+type TrackingResponse struct {
+	TrackingNumber string  `json:"tracking_number"`
+	Events         []Event `json:"events"`
+}
+
+type consignment struct {
+	request ConsignmentRequest
+	events  []Event
+}
+
+// Server is the fake carrier API
+type Server struct {
+	mu           sync.Mutex
+	secret       []byte
+	sequence     int
+	consignments map[string]*consignment
+	mux          *http.ServeMux
+}
+
+// NewServer creates a fake carrier that signs webhooks with webhookSecret
+func NewServer(webhookSecret string) *Server {
+	s := &Server{
+		secret:       []byte(webhookSecret),
+		consignments: make(map[string]*consignment),
+		mux:          http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("POST /v1/rates", s.handleRates)
+	s.mux.HandleFunc("POST /v1/consignments", s.handleCreateConsignment)
+	s.mux.HandleFunc("DELETE /v1/consignments/{trackingNumber}", s.handleCancelConsignment)
+	s.mux.HandleFunc("GET /v1/consignments/{trackingNumber}/events", s.handleEvents)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Scan records a scan of a consignment and returns it
+func (s *Server) Scan(trackingNumber, code, location string) (Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consignments[trackingNumber]
+	if !ok {
+		return Event{}, fmt.Errorf("consignment %s not found", trackingNumber)
+	}
+
+	event := Event{Code: code, Time: time.Now().UTC(), Location: location}
+	c.events = append(c.events, event)
+	return event, nil
+}
+
+// Webhook returns the signed push the carrier sends for the given events
+func (s *Server) Webhook(trackingNumber string, events ...Event) (http.Header, []byte) {
+	body, _ := json.Marshal(TrackingResponse{TrackingNumber: trackingNumber, Events: events})
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(SignatureHeader, Sign(s.secret, body))
+	return header, body
+}
+
+// Sign returns the webhook signature of body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) handleRates(w http.ResponseWriter, r *http.Request) {
+	var req RateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Items) == 0 {
+		http.Error(w, "items are required", http.StatusBadRequest)
+		return
+	}
+
+	// A flat fee plus a fee per unit, per started kilogram and per parcel after the first
+	units := 0
+	for _, item := range req.Items {
+		units += item.Quantity
+	}
+	extraParcels := max(len(req.Parcels)-1, 0)
+
+	writeJSON(w, http.StatusOK, RateResponse{
+		Service:       "STANDARD",
+		Amount:        15 + 2*float64(units) + 4*math.Ceil(req.WeightKg) + 3*float64(extraParcels),
+		EstimatedDays: 3,
+	})
+}
+
+func (s *Server) handleCreateConsignment(w http.ResponseWriter, r *http.Request) {
+	var req ConsignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Reference == "" || len(req.Items) == 0 {
+		http.Error(w, "reference and items are required", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.sequence++
+	trackingNumber := fmt.Sprintf("FC%08d", s.sequence)
+	s.consignments[trackingNumber] = &consignment{
+		request: req,
+		events:  []Event{{Code: CodeBooked, Time: time.Now().UTC()}},
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, ConsignmentResponse{TrackingNumber: trackingNumber})
+}
+
+func (s *Server) handleCancelConsignment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.consignments[r.PathValue("trackingNumber")]
+	if !ok {
+		http.Error(w, "consignment not found", http.StatusNotFound)
+		return
+	}
+
+	// Only consignments that were not picked up yet can be cancelled
+	last := c.events[len(c.events)-1]
+	switch last.Code {
+	case CodeCancelled:
+	case CodeBooked:
+		c.events = append(c.events, Event{Code: CodeCancelled, Time: time.Now().UTC()})
+	default:
+		http.Error(w, "consignment was already picked up", http.StatusConflict)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trackingNumber := r.PathValue("trackingNumber")
+	c, ok := s.consignments[trackingNumber]
+	if !ok {
+		http.Error(w, "consignment not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TrackingResponse{
+		TrackingNumber: trackingNumber,
+		Events:         append([]Event(nil), c.events...),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,59 @@
+package carrier
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Registry holds the configured carriers and picks the one a shipment is booked with
+type Registry struct {
+	carriers    map[string]Carrier
+	defaultCode string
+}
+
+// NewRegistry creates a registry of carriers.
+// defaultCode selects the carrier of shipments that do not name one, it must be registered.
+func NewRegistry(defaultCode string, carriers ...Carrier) (*Registry, error) {
+	registry := &Registry{
+		carriers:    make(map[string]Carrier, len(carriers)),
+		defaultCode: defaultCode,
+	}
+
+	for _, c := range carriers {
+		if _, ok := registry.carriers[c.Code()]; ok {
+			return nil, fmt.Errorf("carrier %s is registered twice", c.Code())
+		}
+		registry.carriers[c.Code()] = c
+	}
+
+	if _, ok := registry.carriers[defaultCode]; !ok {
+		return nil, fmt.Errorf("%w: default carrier %q is not registered", ErrUnknownCarrier, defaultCode)
+	}
+
+	return registry, nil
+}
+
+// Get returns the carrier with the given code, an empty code returns the default carrier
+func (r *Registry) Get(code string) (Carrier, error) {
+	if code == "" {
+		code = r.defaultCode
+	}
+
+	c, ok := r.carriers[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCarrier, code)
+	}
+	return c, nil
+}
+
+// All returns every registered carrier ordered by code
+func (r *Registry) All() []Carrier {
+	carriers := make([]Carrier, 0, len(r.carriers))
+	for _, c := range r.carriers {
+		carriers = append(carriers, c)
+	}
+	sort.Slice(carriers, func(i, j int) bool {
+		return carriers[i].Code() < carriers[j].Code()
+	})
+	return carriers
+}
@@ -1,6 +1,155 @@
 package dto
 
+import (
+	"billing-system/shipment_service/internal/model"
+	"time"
+)
+
 type ShipmentItemRequest struct {
 	Sku      string
 	Quantity int
 }
+
+// CreateShipmentRequest describes a shipment to book and invoice.
+// An empty CarrierCode selects the default carrier. A WarehouseID reserves the items from that warehouse's stock.
+type CreateShipmentRequest struct {
+	OrderID               int64
+	CarrierCode           string
+	DestinationPostalCode string
+	WarehouseID           int64
+	Items                 []ShipmentItemRequest
+	// Parcels are the packages the items are already packed in, they are priced instead of the SKU dimensions
+	Parcels []ParcelRequest
+	// ShipTo is the recipient printed on labels, its postal code defaults to DestinationPostalCode and the other way around
+	ShipTo model.Address
+}
+
+// ShipmentResult is the shipment created for a line of a batch, or why it could not be
+type ShipmentResult struct {
+	Shipment *model.Shipment
+	Err      error
+}
+
+// ParcelRequest describes a packed parcel and the quantity of each SKU in it
+type ParcelRequest struct {
+	WeightKg float64
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+	Items    []ShipmentItemRequest
+}
+
+// CreatePlannedShipmentsRequest describes the shipments of an allocation plan, booked with the same carrier
+type CreatePlannedShipmentsRequest struct {
+	OrderID               int64
+	CarrierCode           string
+	DestinationPostalCode string
+	ShipTo                model.Address
+	Shipments             []PlannedShipment
+}
+
+// AllocationRequest asks how to split an order across warehouses.
+// Empty Items allocates everything left to ship of the order, an empty Strategy ships from the nearest warehouses.
+type AllocationRequest struct {
+	OrderID               int64
+	DestinationPostalCode string
+	Strategy              model.AllocationStrategy
+	Items                 []ShipmentItemRequest
+}
+
+// AllocationPlan proposes one shipment per warehouse.
+// Unallocated lists the quantities no active warehouse has in stock.
+type AllocationPlan struct {
+	Strategy    model.AllocationStrategy
+	Shipments   []PlannedShipment
+	Unallocated []ShipmentItemRequest
+}
+
+// PlannedShipment is the part of an order shipped from one warehouse
+type PlannedShipment struct {
+	WarehouseID   int64
+	WarehouseCode string
+	Items         []ShipmentItemRequest
+}
+
+// ShippingQuoteRequest describes the items to quote shipping for.
+// An empty CarrierCode asks every carrier. Parcels, when given, must hold exactly the items.
+type ShippingQuoteRequest struct {
+	CarrierCode           string
+	DestinationPostalCode string
+	Items                 []ShipmentItemRequest
+	Parcels               []ParcelRequest
+}
+
+// ShippingQuote is the fee charged for shipping with one carrier.
+// CarrierAmount is what the carrier charges us, Fee is what the customer is billed.
+type ShippingQuote struct {
+	CarrierCode        string
+	Service            string
+	EstimatedDays      int
+	CarrierAmount      float64
+	ChargeableWeightKg float64
+	Zone               string
+	Fee                float64
+}
+
+// ShipmentFilter selects the shipments returned by a list query.
+// Zero values do not filter, CreatedTo is exclusive.
+type ShipmentFilter struct {
+	OrderID     int64
+	Status      model.ShipmentStatus
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	PageSize    int
+	Cursor      string
+}
+
+// WatchUpdate is a status change of a shipment of a watched order, or a heartbeat without an event
+type WatchUpdate struct {
+	OrderID    int64
+	ShipmentID int64
+	Event      *model.ShipmentEvent
+	Heartbeat  bool
+}
+
+// ShipmentEventRequest describes a status change reported for a shipment
+type ShipmentEventRequest struct {
+	Timestamp time.Time
+	Location  string
+	Actor     string
+	Note      string
+}
+
+// Document types and formats of GetShippingDocument
+const (
+	DocumentLabel       = "LABEL"
+	DocumentPackingSlip = "PACKING_SLIP"
+	FormatPDF           = "PDF"
+	FormatZPL           = "ZPL"
+)
+
+// ShippingDocument is a rendered label or packing slip
+type ShippingDocument struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// ConfirmDeliveryRequest is the evidence a courier uploads when handing a shipment over
+type ConfirmDeliveryRequest struct {
+	ShipmentID    int64
+	RecipientName string
+	Latitude      float64
+	Longitude     float64
+	// DeliveredAt defaults to when the proof is received
+	DeliveredAt time.Time
+	Actor       string
+	Note        string
+	Files       []DeliveryUpload
+}
+
+// DeliveryUpload is a signature or photo of a delivery, its content type is detected from the content
+type DeliveryUpload struct {
+	Kind    model.DeliveryFileKind
+	Content []byte
+}
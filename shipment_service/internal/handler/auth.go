@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"billing-system/pkg/auth"
+	pb "billing-system/shipment_service/proto"
+)
+
+// MethodPermissions are the permissions the methods of the shipment service require of their callers.
+// Carrier webhooks carry no token, the carrier signature is verified instead.
+var MethodPermissions = auth.Policy{
+	pb.ShipmentService_CreateShipment_FullMethodName:       auth.PermissionShipmentsWrite,
+	pb.ShipmentService_CreateShipments_FullMethodName:      auth.PermissionShipmentsWrite,
+	pb.ShipmentService_GetShipment_FullMethodName:          auth.PermissionShipmentsRead,
+	pb.ShipmentService_ListShipments_FullMethodName:        auth.PermissionShipmentsRead,
+	pb.ShipmentService_UpdateShipmentStatus_FullMethodName: auth.PermissionShipmentsWrite,
+	pb.ShipmentService_GetTrackingHistory_FullMethodName:   auth.PermissionShipmentsRead,
+	pb.ShipmentService_QuoteShippingRates_FullMethodName:   auth.PermissionShipmentsRead,
+	pb.ShipmentService_HandleCarrierWebhook_FullMethodName: auth.PermissionPublic,
+	pb.ShipmentService_RefreshTracking_FullMethodName:      auth.PermissionShipmentsWrite,
+	pb.ShipmentService_UpsertSkuDimensions_FullMethodName:  auth.PermissionShipmentsWrite,
+	pb.ShipmentService_UpsertShippingZone_FullMethodName:   auth.PermissionShipmentsWrite,
+	pb.ShipmentService_RequestReturn_FullMethodName:        auth.PermissionShipmentsWrite,
+	pb.ShipmentService_GetReturn_FullMethodName:            auth.PermissionShipmentsRead,
+	pb.ShipmentService_ApproveReturn_FullMethodName:        auth.PermissionShipmentsWrite,
+	pb.ShipmentService_RejectReturn_FullMethodName:         auth.PermissionShipmentsWrite,
+	pb.ShipmentService_ReceiveReturn_FullMethodName:        auth.PermissionShipmentsWrite,
+	pb.ShipmentService_InspectReturn_FullMethodName:        auth.PermissionShipmentsWrite,
+	pb.ShipmentService_UpsertWarehouse_FullMethodName:      auth.PermissionShipmentsWrite,
+	pb.ShipmentService_ListWarehouses_FullMethodName:       auth.PermissionShipmentsRead,
+	pb.ShipmentService_UpsertWarehouseStock_FullMethodName: auth.PermissionShipmentsWrite,
+	pb.ShipmentService_AllocateShipments_FullMethodName:    auth.PermissionShipmentsRead,
+	pb.ShipmentService_PackShipment_FullMethodName:         auth.PermissionShipmentsWrite,
+	pb.ShipmentService_GetShippingDocument_FullMethodName:  auth.PermissionShipmentsRead,
+	pb.ShipmentService_ConfirmDelivery_FullMethodName:      auth.PermissionShipmentsWrite,
+	pb.ShipmentService_GetDeliveryFile_FullMethodName:      auth.PermissionShipmentsRead,
+	pb.ShipmentService_WatchShipment_FullMethodName:        auth.PermissionShipmentsRead,
+	pb.ShipmentService_WatchOrder_FullMethodName:           auth.PermissionOrdersRead,
+}.With(auth.ReflectionMethods)
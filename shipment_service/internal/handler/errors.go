@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"billing-system/shipment_service/internal/service"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the statuses returned by the shipment service
+const errorDomain = "shipment.billing-system"
+
+// Reasons of errors that are not domain errors
+const (
+	reasonInternal    = "INTERNAL"
+	reasonUnavailable = "DEPENDENCY_UNAVAILABLE"
+)
+
+// mapErrorToGRPCStatus maps service errors to gRPC statuses
+func mapErrorToGRPCStatus(err error) *status.Status {
+	return errorStatus(err, nil)
+}
+
+// errorStatus maps a service error to a gRPC status with an ErrorInfo detail carrying its reason and the metadata.
+// Invalid items add a BadRequest detail with a field violation per line.
+// A failed precondition, invalid argument, missing resource or denied permission reported by a service called
+// on the way is passed on as is with its details, an unavailable one becomes Unavailable so the caller retries.
+// Anything else is Internal and its message is not exposed.
+func errorStatus(err error, metadata map[string]string) *status.Status {
+	var domainErr *service.Error
+	if errors.As(err, &domainErr) {
+		code := codes.Internal
+		switch domainErr.Kind {
+		case service.KindInvalid:
+			code = codes.InvalidArgument
+		case service.KindNotFound:
+			code = codes.NotFound
+		case service.KindConflict:
+			code = codes.FailedPrecondition
+		}
+
+		details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: domainErr.Reason, Domain: errorDomain, Metadata: metadata}}
+		var validationErr *service.ItemValidationError
+		if errors.As(err, &validationErr) {
+			details = append(details, itemViolationsToBadRequest(validationErr.Violations))
+		}
+		return withDetails(status.New(code, err.Error()), details...)
+	}
+
+	if downstream, ok := status.FromError(err); ok {
+		switch downstream.Code() {
+		case codes.FailedPrecondition, codes.InvalidArgument, codes.NotFound, codes.PermissionDenied:
+			// FromError puts the whole wrapped message in the status, the caller gets the one of the service instead
+			var grpcErr interface{ GRPCStatus() *status.Status }
+			if errors.As(err, &grpcErr) {
+				return grpcErr.GRPCStatus()
+			}
+			return downstream
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return withDetails(status.New(codes.Unavailable, "a service the shipment service depends on is unavailable, try again later"),
+				&errdetails.ErrorInfo{Reason: reasonUnavailable, Domain: errorDomain, Metadata: metadata})
+		}
+	}
+
+	return withDetails(status.New(codes.Internal, "internal server error"),
+		&errdetails.ErrorInfo{Reason: reasonInternal, Domain: errorDomain, Metadata: metadata})
+}
+
+// errorReason returns the reason of the ErrorInfo detail of a status, INTERNAL when it has none
+func errorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason != "" {
+			return info.Reason
+		}
+	}
+	return reasonInternal
+}
+
+// invalidArgument returns the status of a request the handler cannot convert, wrapping the service error with the detail
+func invalidArgument(err *service.Error, detail string) error {
+	return mapErrorToGRPCStatus(fmt.Errorf("%w: %s", err, detail)).Err()
+}
+
+// itemViolationsToBadRequest converts the violating lines of a shipment to field violations
+func itemViolationsToBadRequest(violations []service.ItemViolation) *errdetails.BadRequest {
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		field := fmt.Sprintf("items[%d].quantity", violation.Line)
+		if violation.Reason == service.ViolationMissingSku || violation.Reason == service.ViolationNotOrdered {
+			field = fmt.Sprintf("items[%d].sku", violation.Line)
+		}
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Reason:      violation.Reason,
+			Description: violation.String(),
+		})
+	}
+	return badRequest
+}
+
+// withDetails adds the details to the status, the status is returned without them when they cannot be encoded
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
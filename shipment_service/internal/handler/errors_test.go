@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"billing-system/shipment_service/internal/service"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorStatus(t *testing.T) {
+	validationErr := &service.ItemValidationError{Violations: []service.ItemViolation{
+		{Line: 0, Reason: service.ViolationNotOrdered, Sku: "SKU009"},
+		{Line: 2, Reason: service.ViolationExceedsRemaining, Sku: "SKU001", Requested: 5, Remaining: 3},
+	}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+		wantFields []string
+	}{
+		{name: "Wrapped not found", err: fmt.Errorf("%w: 7", service.ErrShipmentNotFound), wantCode: codes.NotFound, wantReason: "SHIPMENT_NOT_FOUND"},
+		{name: "Conflict", err: fmt.Errorf("failed to reserve: %w", service.ErrOutOfStock), wantCode: codes.FailedPrecondition, wantReason: "OUT_OF_STOCK"},
+		{name: "Item violations", err: validationErr, wantCode: codes.InvalidArgument, wantReason: "INVALID_ITEMS", wantFields: []string{"items[0].sku", "items[2].quantity"}},
+		{name: "Dependency unavailable", err: fmt.Errorf("failed to get order: %w", status.Error(codes.Unavailable, "connection refused")), wantCode: codes.Unavailable, wantReason: reasonUnavailable},
+		{name: "Unknown error", err: errors.New("connection reset"), wantCode: codes.Internal, wantReason: reasonInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := errorStatus(tt.err, map[string]string{"order_id": "1"})
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+
+			var info *errdetails.ErrorInfo
+			var fields []string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					info = detail
+				case *errdetails.BadRequest:
+					for _, violation := range detail.FieldViolations {
+						fields = append(fields, violation.Field)
+					}
+				}
+			}
+			if info == nil || info.Reason != tt.wantReason || info.Domain != errorDomain || info.Metadata["order_id"] != "1" {
+				t.Errorf("ErrorInfo = %v, want reason %s in %s with the metadata", info, tt.wantReason, errorDomain)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.wantFields) {
+				t.Errorf("field violations = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestErrorStatus_PassesDownstreamErrorsOn(t *testing.T) {
+	tests := []struct {
+		code    codes.Code
+		message string
+		reason  string
+	}{
+		{code: codes.FailedPrecondition, message: "customer is on hold", reason: "CUSTOMER_ON_HOLD"},
+		{code: codes.InvalidArgument, message: "quantity for item SKU001 exceeds the ordered quantity", reason: "INVALID_QUANTITY"},
+		{code: codes.NotFound, message: "order 7 not found", reason: "ORDER_NOT_FOUND"},
+		{code: codes.PermissionDenied, message: "missing permission invoices:write", reason: "PERMISSION_DENIED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			downstream, err := status.New(tt.code, tt.message).
+				WithDetails(&errdetails.ErrorInfo{Reason: tt.reason, Domain: "billing.billing-system"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			st := errorStatus(fmt.Errorf("failed to create invoice: %w", downstream.Err()), nil)
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Fatalf("status = %v %q", st.Code(), st.Message())
+			}
+			if details := st.Details(); len(details) != 1 || details[0].(*errdetails.ErrorInfo).Reason != tt.reason {
+				t.Errorf("details = %v, want the ErrorInfo of the billing service", details)
+			}
+		})
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	tests := []struct {
+		name string
+		st   *status.Status
+		want string
+	}{
+		{name: "Domain error", st: mapErrorToGRPCStatus(service.ErrOutOfStock), want: "OUT_OF_STOCK"},
+		{name: "Without details", st: status.New(codes.FailedPrecondition, "customer is on hold"), want: reasonInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorReason(tt.st); got != tt.want {
+				t.Errorf("errorReason = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,37 +1,57 @@
 package handler
 
 import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
 	"billing-system/shipment_service/internal/service"
 	"billing-system/shipment_service/pkg/utils"
 	pb "billing-system/shipment_service/proto"
 	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
 )
 
+// deliveryChunkSize is the size of the chunks delivery files are downloaded in
+const deliveryChunkSize = 64 << 10
+
 // ShipmentHandler handles gRPC requests related to shipments
 type ShipmentHandler struct {
 	pb.UnimplementedShipmentServiceServer
-	shipmentService service.ShipmentService
+	shipmentService   service.ShipmentService
+	returnService     service.ReturnService
+	allocationService service.AllocationService
 }
 
 // NewShipmentHandler creates a new ShipmentHandler
-func NewShipmentHandler(shipmentService service.ShipmentService) *ShipmentHandler {
+func NewShipmentHandler(
+	shipmentService service.ShipmentService,
+	returnService service.ReturnService,
+	allocationService service.AllocationService,
+) *ShipmentHandler {
 	return &ShipmentHandler{
-		shipmentService: shipmentService,
+		shipmentService:   shipmentService,
+		returnService:     returnService,
+		allocationService: allocationService,
 	}
 }
 
-// CreateShipment handles the gRPC request to create a new shipment
+// CreateShipment handles the gRPC request to create a new shipment, or one shipment per warehouse of a plan
 func (h *ShipmentHandler) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
-	// Convert proto ShipmentItemRequests to DTO ShipmentItemRequests
-	items := utils.ConvertProtoItemsToDTO(req.Items)
+	if len(req.Plan) > 0 {
+		return h.createPlannedShipments(ctx, req)
+	}
 
 	// Call the service layer to create the shipment
-	shipment, err := h.shipmentService.CreateShipment(ctx, req.OrderId, items)
+	shipment, err := h.shipmentService.CreateShipment(ctx, utils.ConvertProtoCreateShipmentRequestToDTO(req))
 	if err != nil {
-		return &pb.CreateShipmentResponse{
-			Code:    0, // Error code
-			Message: err.Error(),
-		}, nil
+		log.Println("Failed to create shipment:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
 	}
 
 	// Convert the domain shipment to proto shipment data
@@ -43,3 +63,506 @@ func (h *ShipmentHandler) CreateShipment(ctx context.Context, req *pb.CreateShip
 		Data:    shipmentData,
 	}, nil
 }
+
+// CreateShipments handles the gRPC stream creating a batch of shipments, one per message.
+// Every line gets a result, a line that cannot be created fails alone and the response counts the failures by reason.
+// Lines are numbered by their position in the stream unless they carry the line of the manifest they come from.
+func (h *ShipmentHandler) CreateShipments(stream grpc.ClientStreamingServer[pb.CreateShipmentsRequest, pb.CreateShipmentsResponse]) error {
+	var lines []*pb.CreateShipmentsRequest
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(lines) == service.MaxBatchLines {
+			return invalidArgument(service.ErrInvalidRequest, fmt.Sprintf("at most %d shipments are accepted in a batch", service.MaxBatchLines))
+		}
+		if msg.Line == 0 {
+			msg.Line = int32(len(lines) + 1)
+		}
+		lines = append(lines, msg)
+	}
+	if len(lines) == 0 {
+		return invalidArgument(service.ErrInvalidRequest, "at least one shipment is required")
+	}
+
+	// Lines the handler cannot convert fail here, the others are created by the service
+	errs := make([]error, len(lines))
+	var valid []int
+	var requests []dto.CreateShipmentRequest
+	for i, line := range lines {
+		switch {
+		case line.Shipment == nil:
+			errs[i] = fmt.Errorf("%w: the shipment is required", service.ErrInvalidRequest)
+		case len(line.Shipment.Plan) > 0:
+			errs[i] = fmt.Errorf("%w: shipments of a batch cannot carry an allocation plan", service.ErrInvalidRequest)
+		default:
+			valid = append(valid, i)
+			requests = append(requests, utils.ConvertProtoCreateShipmentRequestToDTO(line.Shipment))
+		}
+	}
+
+	shipments := make([]*pb.ShipmentData, len(lines))
+	if len(requests) > 0 {
+		for j, result := range h.shipmentService.CreateShipments(stream.Context(), requests) {
+			if result.Err != nil {
+				errs[valid[j]] = result.Err
+				continue
+			}
+			shipments[valid[j]] = utils.ConvertShipmentToProtoData(result.Shipment)
+		}
+	}
+
+	response := &pb.CreateShipmentsResponse{Reasons: make(map[string]int32)}
+	for i, line := range lines {
+		result := &pb.ShipmentLineResult{Line: line.Line, Reference: line.Reference}
+		if errs[i] == nil {
+			result.Shipment = shipments[i]
+			response.Confirmed = append(response.Confirmed, result)
+			continue
+		}
+
+		log.Printf("Failed to create the shipment of line %d: %v", line.Line, errs[i])
+		st := mapErrorToGRPCStatus(errs[i])
+		result.Reason = errorReason(st)
+		result.Message = st.Message()
+		response.Failed = append(response.Failed, result)
+		response.Reasons[result.Reason]++
+	}
+
+	return stream.SendAndClose(response)
+}
+
+// createPlannedShipments creates the shipments of an allocation plan
+func (h *ShipmentHandler) createPlannedShipments(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	shipments, err := h.shipmentService.CreatePlannedShipments(ctx, dto.CreatePlannedShipmentsRequest{
+		OrderID:               req.OrderId,
+		CarrierCode:           req.CarrierCode,
+		DestinationPostalCode: req.DestinationPostalCode,
+		ShipTo:                utils.ConvertProtoAddressToModel(req.ShipTo),
+		Shipments:             utils.ConvertProtoPlanToDTO(req.Plan),
+	})
+	if err != nil {
+		log.Println("Failed to create planned shipments:", err)
+		// The shipments created before the failure are kept, the caller needs them to retry the rest
+		var metadata map[string]string
+		if len(shipments) > 0 {
+			ids := make([]string, len(shipments))
+			for i, shipment := range shipments {
+				ids[i] = strconv.FormatInt(shipment.ID, 10)
+			}
+			metadata = map[string]string{"created_shipment_ids": strings.Join(ids, ",")}
+		}
+		return nil, errorStatus(err, metadata).Err()
+	}
+
+	return &pb.CreateShipmentResponse{
+		Code:      1, // Success code
+		Message:   "Create shipments successfully",
+		Shipments: utils.ConvertShipmentsToProtoData(shipments),
+	}, nil
+}
+
+// GetShipment handles the gRPC request to get a shipment with its items
+func (h *ShipmentHandler) GetShipment(ctx context.Context, req *pb.GetShipmentRequest) (*pb.GetShipmentResponse, error) {
+	shipment, err := h.shipmentService.GetShipment(ctx, req.ShipmentId)
+	if err != nil {
+		log.Println("Failed to get shipment:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.GetShipmentResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+	}, nil
+}
+
+// ListShipments handles the gRPC request to list shipments page by page
+func (h *ShipmentHandler) ListShipments(ctx context.Context, req *pb.ListShipmentsRequest) (*pb.ListShipmentsResponse, error) {
+	filter, err := utils.ConvertProtoListRequestToFilter(req)
+	if err != nil {
+		return nil, invalidArgument(service.ErrInvalidFilter, err.Error())
+	}
+
+	shipments, nextCursor, err := h.shipmentService.ListShipments(ctx, filter)
+	if err != nil {
+		log.Println("Failed to list shipments:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.ListShipmentsResponse{
+		Shipments:  utils.ConvertShipmentsToProtoData(shipments),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// UpdateShipmentStatus handles the gRPC request to move a shipment to the next status
+func (h *ShipmentHandler) UpdateShipmentStatus(ctx context.Context, req *pb.UpdateShipmentStatusRequest) (*pb.UpdateShipmentStatusResponse, error) {
+	event, err := utils.ConvertProtoStatusRequestToEvent(req)
+	if err != nil {
+		return nil, invalidArgument(service.ErrInvalidRequest, err.Error())
+	}
+
+	shipment, err := h.shipmentService.UpdateShipmentStatus(ctx, req.ShipmentId, model.ShipmentStatus(req.Status), event)
+	if err != nil {
+		log.Println("Failed to update shipment status:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.UpdateShipmentStatusResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+	}, nil
+}
+
+// GetTrackingHistory handles the gRPC request to get the status changes of a shipment
+func (h *ShipmentHandler) GetTrackingHistory(ctx context.Context, req *pb.GetTrackingHistoryRequest) (*pb.GetTrackingHistoryResponse, error) {
+	shipment, err := h.shipmentService.GetTrackingHistory(ctx, req.ShipmentId)
+	if err != nil {
+		log.Println("Failed to get tracking history:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.GetTrackingHistoryResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+		Events:   utils.ConvertShipmentEventsToProto(shipment.Events),
+	}, nil
+}
+
+// QuoteShippingRates handles the gRPC request to quote the shipping rates of carriers
+func (h *ShipmentHandler) QuoteShippingRates(ctx context.Context, req *pb.QuoteShippingRatesRequest) (*pb.QuoteShippingRatesResponse, error) {
+	quotes, err := h.shipmentService.QuoteShippingRates(ctx, dto.ShippingQuoteRequest{
+		CarrierCode:           req.CarrierCode,
+		DestinationPostalCode: req.DestinationPostalCode,
+		Items:                 utils.ConvertProtoItemsToDTO(req.Items),
+		Parcels:               utils.ConvertProtoParcelsToDTO(req.Parcels),
+	})
+	if err != nil {
+		log.Println("Failed to quote shipping rates:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.QuoteShippingRatesResponse{
+		Rates: utils.ConvertQuotesToProto(quotes),
+	}, nil
+}
+
+// HandleCarrierWebhook handles a tracking push forwarded from a carrier
+func (h *ShipmentHandler) HandleCarrierWebhook(ctx context.Context, req *pb.CarrierWebhookRequest) (*pb.CarrierWebhookResponse, error) {
+	header := http.Header{}
+	for key, value := range req.Headers {
+		header.Set(key, value)
+	}
+
+	applied, err := h.shipmentService.HandleCarrierWebhook(ctx, req.CarrierCode, header, req.Body)
+	if err != nil {
+		log.Println("Failed to handle carrier webhook:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.CarrierWebhookResponse{
+		Applied: int32(applied),
+	}, nil
+}
+
+// RefreshTracking handles the gRPC request to pull the tracking of a shipment from its carrier
+func (h *ShipmentHandler) RefreshTracking(ctx context.Context, req *pb.RefreshTrackingRequest) (*pb.GetTrackingHistoryResponse, error) {
+	shipment, err := h.shipmentService.RefreshTracking(ctx, req.ShipmentId)
+	if err != nil {
+		log.Println("Failed to refresh tracking:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.GetTrackingHistoryResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+		Events:   utils.ConvertShipmentEventsToProto(shipment.Events),
+	}, nil
+}
+
+// UpsertSkuDimensions handles the gRPC request to store the weight and size of SKUs
+func (h *ShipmentHandler) UpsertSkuDimensions(ctx context.Context, req *pb.UpsertSkuDimensionsRequest) (*pb.UpsertSkuDimensionsResponse, error) {
+	dimensions := utils.ConvertProtoDimensionsToModel(req.Dimensions)
+	if err := h.shipmentService.UpsertSkuDimensions(ctx, dimensions); err != nil {
+		log.Println("Failed to store SKU dimensions:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.UpsertSkuDimensionsResponse{
+		Updated: int32(len(dimensions)),
+	}, nil
+}
+
+// UpsertShippingZone handles the gRPC request to store a shipping zone
+func (h *ShipmentHandler) UpsertShippingZone(ctx context.Context, req *pb.UpsertShippingZoneRequest) (*pb.UpsertShippingZoneResponse, error) {
+	if req.Zone == nil {
+		return nil, invalidArgument(service.ErrInvalidZone, "zone is required")
+	}
+
+	zone, err := h.shipmentService.UpsertShippingZone(ctx, utils.ConvertProtoZoneToModel(req.Zone))
+	if err != nil {
+		log.Println("Failed to store shipping zone:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.UpsertShippingZoneResponse{
+		Zone: utils.ConvertZoneToProto(zone),
+	}, nil
+}
+
+// PackShipment handles the gRPC request to record the parcels of a shipment
+func (h *ShipmentHandler) PackShipment(ctx context.Context, req *pb.PackShipmentRequest) (*pb.PackShipmentResponse, error) {
+	shipment, err := h.shipmentService.PackShipment(ctx, req.ShipmentId, utils.ConvertProtoParcelsToDTO(req.Parcels))
+	if err != nil {
+		log.Println("Failed to pack shipment:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.PackShipmentResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+	}, nil
+}
+
+// GetShippingDocument handles the gRPC request to render the label or packing slip of a shipment
+func (h *ShipmentHandler) GetShippingDocument(ctx context.Context, req *pb.GetShippingDocumentRequest) (*pb.ShippingDocument, error) {
+	document, err := h.shipmentService.GetShippingDocument(ctx, req.ShipmentId, req.DocumentType, req.Format)
+	if err != nil {
+		log.Println("Failed to render shipping document:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.ShippingDocument{
+		Filename:    document.Filename,
+		ContentType: document.ContentType,
+		Content:     document.Content,
+	}, nil
+}
+
+// ConfirmDelivery handles the gRPC stream uploading the proof of delivery of a shipment.
+// The first message carries the details, a chunk with a kind starts a new file and the chunks without one continue it.
+func (h *ShipmentHandler) ConfirmDelivery(stream grpc.ClientStreamingServer[pb.ConfirmDeliveryRequest, pb.ConfirmDeliveryResponse]) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return invalidArgument(service.ErrInvalidDelivery, "delivery details are required")
+	}
+	if err != nil {
+		return err
+	}
+	if first.Details == nil {
+		return invalidArgument(service.ErrInvalidDelivery, "the first message must carry the delivery details")
+	}
+
+	req, err := utils.ConvertProtoDeliveryDetailsToDTO(first.Details)
+	if err != nil {
+		return invalidArgument(service.ErrInvalidDelivery, err.Error())
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Chunk == nil {
+			return invalidArgument(service.ErrInvalidDelivery, "only the first message can carry the delivery details")
+		}
+
+		// The limits are checked while receiving so an oversized upload is not held in memory
+		if msg.Chunk.Kind != "" {
+			if len(req.Files) == service.MaxDeliveryFiles {
+				return invalidArgument(service.ErrInvalidDelivery, fmt.Sprintf("at most %d files are accepted", service.MaxDeliveryFiles))
+			}
+			req.Files = append(req.Files, dto.DeliveryUpload{Kind: model.DeliveryFileKind(strings.ToUpper(msg.Chunk.Kind))})
+		} else if len(req.Files) == 0 {
+			return invalidArgument(service.ErrInvalidDelivery, "the first chunk of a file must carry its kind")
+		}
+
+		file := &req.Files[len(req.Files)-1]
+		if len(file.Content)+len(msg.Chunk.Data) > service.MaxDeliveryFileSize {
+			return invalidArgument(service.ErrInvalidDelivery, fmt.Sprintf("file %d is larger than %d bytes", len(req.Files), service.MaxDeliveryFileSize))
+		}
+		file.Content = append(file.Content, msg.Chunk.Data...)
+	}
+
+	shipment, err := h.shipmentService.ConfirmDelivery(stream.Context(), req)
+	if err != nil {
+		log.Println("Failed to confirm delivery:", err)
+		return mapErrorToGRPCStatus(err).Err()
+	}
+
+	return stream.SendAndClose(&pb.ConfirmDeliveryResponse{
+		Shipment: utils.ConvertShipmentToProtoData(shipment),
+	})
+}
+
+// GetDeliveryFile handles the gRPC request to download a signature or photo of a delivery proof.
+// The first chunk carries the kind and content type of the file.
+func (h *ShipmentHandler) GetDeliveryFile(req *pb.GetDeliveryFileRequest, stream grpc.ServerStreamingServer[pb.DeliveryFileChunk]) error {
+	file, content, err := h.shipmentService.OpenDeliveryFile(stream.Context(), req.ShipmentId, req.FileId)
+	if err != nil {
+		log.Println("Failed to open delivery file:", err)
+		return mapErrorToGRPCStatus(err).Err()
+	}
+	defer content.Close()
+
+	chunk := &pb.DeliveryFileChunk{Kind: string(file.Kind), ContentType: file.ContentType}
+	buf := make([]byte, deliveryChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+			chunk = &pb.DeliveryFileChunk{}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Failed to read delivery file %d: %v", req.FileId, err)
+			return mapErrorToGRPCStatus(err).Err()
+		}
+	}
+}
+
+// WatchShipment handles the gRPC request to stream the status changes of a shipment
+func (h *ShipmentHandler) WatchShipment(req *pb.WatchShipmentRequest, stream grpc.ServerStreamingServer[pb.WatchUpdate]) error {
+	w := &watchStream{stream: stream}
+	return w.result(h.shipmentService.WatchShipment(stream.Context(), req.ShipmentId, req.AfterEventId, w.send), "Failed to watch shipment:")
+}
+
+// WatchOrder handles the gRPC request to stream the status changes of the shipments of an order
+func (h *ShipmentHandler) WatchOrder(req *pb.WatchOrderRequest, stream grpc.ServerStreamingServer[pb.WatchUpdate]) error {
+	w := &watchStream{stream: stream}
+	return w.result(h.shipmentService.WatchOrder(stream.Context(), req.OrderId, req.AfterEventId, w.send), "Failed to watch order:")
+}
+
+// watchStream sends watch updates, Send blocks while the flow control window of the receiver is full
+type watchStream struct {
+	stream  grpc.ServerStreamingServer[pb.WatchUpdate]
+	sendErr error
+}
+
+func (w *watchStream) send(update dto.WatchUpdate) error {
+	w.sendErr = w.stream.Send(utils.ConvertWatchUpdateToProto(update))
+	return w.sendErr
+}
+
+// result maps the error that ended the watch, a failed send is returned as it is
+func (w *watchStream) result(err error, logMsg string) error {
+	if err == nil || err == w.sendErr {
+		return err
+	}
+	log.Println(logMsg, err)
+	return mapErrorToGRPCStatus(err).Err()
+}
+
+// RequestReturn handles the gRPC request to open a return for items of a shipment
+func (h *ShipmentHandler) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.RequestReturn(ctx, req.ShipmentId, req.Reason, utils.ConvertProtoItemsToDTO(req.Items))
+	return returnResponse(ret, err, "Failed to request return:")
+}
+
+// GetReturn handles the gRPC request to get a return with its items
+func (h *ShipmentHandler) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.GetReturn(ctx, req.ReturnId)
+	return returnResponse(ret, err, "Failed to get return:")
+}
+
+// ApproveReturn handles the gRPC request to approve a return
+func (h *ShipmentHandler) ApproveReturn(ctx context.Context, req *pb.ReturnActionRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.ApproveReturn(ctx, req.ReturnId, req.Note)
+	return returnResponse(ret, err, "Failed to approve return:")
+}
+
+// RejectReturn handles the gRPC request to reject a return
+func (h *ShipmentHandler) RejectReturn(ctx context.Context, req *pb.ReturnActionRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.RejectReturn(ctx, req.ReturnId, req.Note)
+	return returnResponse(ret, err, "Failed to reject return:")
+}
+
+// ReceiveReturn handles the gRPC request to receive the items of a return
+func (h *ShipmentHandler) ReceiveReturn(ctx context.Context, req *pb.ReturnActionRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.ReceiveReturn(ctx, req.ReturnId, req.Note)
+	return returnResponse(ret, err, "Failed to receive return:")
+}
+
+// InspectReturn handles the gRPC request to record the inspection of a return
+func (h *ShipmentHandler) InspectReturn(ctx context.Context, req *pb.ReturnActionRequest) (*pb.ReturnResponse, error) {
+	ret, err := h.returnService.InspectReturn(ctx, req.ReturnId, req.Note)
+	return returnResponse(ret, err, "Failed to inspect return:")
+}
+
+// UpsertWarehouse handles the gRPC request to store a warehouse
+func (h *ShipmentHandler) UpsertWarehouse(ctx context.Context, req *pb.UpsertWarehouseRequest) (*pb.UpsertWarehouseResponse, error) {
+	if req.Warehouse == nil {
+		return nil, invalidArgument(service.ErrInvalidWarehouse, "warehouse is required")
+	}
+
+	warehouse, err := h.allocationService.UpsertWarehouse(ctx, utils.ConvertProtoWarehouseToModel(req.Warehouse))
+	if err != nil {
+		log.Println("Failed to store warehouse:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.UpsertWarehouseResponse{
+		Warehouse: utils.ConvertWarehouseToProto(warehouse),
+	}, nil
+}
+
+// ListWarehouses handles the gRPC request to list every warehouse
+func (h *ShipmentHandler) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	warehouses, err := h.allocationService.ListWarehouses(ctx)
+	if err != nil {
+		log.Println("Failed to list warehouses:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.ListWarehousesResponse{
+		Warehouses: utils.ConvertWarehousesToProto(warehouses),
+	}, nil
+}
+
+// UpsertWarehouseStock handles the gRPC request to store the stock of warehouses
+func (h *ShipmentHandler) UpsertWarehouseStock(ctx context.Context, req *pb.UpsertWarehouseStockRequest) (*pb.UpsertWarehouseStockResponse, error) {
+	stock := utils.ConvertProtoStockToModel(req.Stock)
+	if err := h.allocationService.UpsertStock(ctx, stock); err != nil {
+		log.Println("Failed to store warehouse stock:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.UpsertWarehouseStockResponse{
+		Updated: int32(len(stock)),
+	}, nil
+}
+
+// AllocateShipments handles the gRPC request to split an order into per-warehouse shipments
+func (h *ShipmentHandler) AllocateShipments(ctx context.Context, req *pb.AllocateShipmentsRequest) (*pb.AllocateShipmentsResponse, error) {
+	plan, err := h.allocationService.ProposeAllocation(ctx, dto.AllocationRequest{
+		OrderID:               req.OrderId,
+		DestinationPostalCode: req.DestinationPostalCode,
+		Strategy:              model.AllocationStrategy(req.Strategy),
+		Items:                 utils.ConvertProtoItemsToDTO(req.Items),
+	})
+	if err != nil {
+		log.Println("Failed to allocate shipments:", err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return utils.ConvertPlanToProto(plan), nil
+}
+
+// returnResponse converts the result of a return request to its gRPC response
+func returnResponse(ret *model.Return, err error, logPrefix string) (*pb.ReturnResponse, error) {
+	if err != nil {
+		log.Println(logPrefix, err)
+		return nil, mapErrorToGRPCStatus(err).Err()
+	}
+
+	return &pb.ReturnResponse{
+		Data: utils.ConvertReturnToProtoData(ret),
+	}, nil
+}
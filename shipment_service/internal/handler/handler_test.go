@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"billing-system/shipment_service/internal/service"
+	pb "billing-system/shipment_service/proto"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuoteShippingRates_RequiresItems(t *testing.T) {
+	// The items are checked before any dependency of the service is used
+	h := NewShipmentHandler(&service.ShipmentServiceImpl{}, nil, nil)
+
+	_, err := h.QuoteShippingRates(context.Background(), &pb.QuoteShippingRatesRequest{DestinationPostalCode: "10000"})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+	if reason := errorReason(st); reason != "INVALID_ITEMS" {
+		t.Errorf("reason = %s, want INVALID_ITEMS", reason)
+	}
+}
@@ -0,0 +1,274 @@
+// Package label renders shipping labels and packing slips in pure Go.
+// Labels are 4x6 inches, as PDF for office printers and as ZPL for thermal printers. Packing slips are A4 PDFs.
+package label
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Data is what a label or packing slip is printed from
+type Data struct {
+	Shipment *model.Shipment
+	// From is the sender printed on labels
+	From model.Address
+	// WarehouseCode is the warehouse the shipment leaves from, empty when it has none
+	WarehouseCode string
+}
+
+// BarcodeValue returns the tracking number once the shipment is booked, the shipment number before
+func (d Data) BarcodeValue() string {
+	if d.Shipment.TrackingNumber != "" {
+		return d.Shipment.TrackingNumber
+	}
+	return "SHP" + strconv.FormatInt(d.Shipment.ID, 10)
+}
+
+// shipTo returns the recipient, shipments created without an address still get their postal code printed
+func (d Data) shipTo() model.Address {
+	to := d.Shipment.ShipTo
+	if to.PostalCode == "" {
+		to.PostalCode = d.Shipment.DestinationPostalCode
+	}
+	return to
+}
+
+// labelParcel is what a label says about the parcel it is stuck on
+type labelParcel struct {
+	sequence int
+	count    int
+	weightKg float64
+}
+
+// parcels returns one entry per label, a shipment that is not packed yet gets a single label
+func (d Data) parcels() []labelParcel {
+	if len(d.Shipment.Parcels) == 0 {
+		return []labelParcel{{sequence: 1, count: 1, weightKg: d.Shipment.ChargeableWeightKg}}
+	}
+	parcels := make([]labelParcel, len(d.Shipment.Parcels))
+	for i, parcel := range d.Shipment.Parcels {
+		parcels[i] = labelParcel{sequence: parcel.Sequence, count: len(d.Shipment.Parcels), weightKg: parcel.ChargeableWeightKg}
+	}
+	return parcels
+}
+
+// ShippingLabelPDF renders one 4x6 inch page per parcel
+func ShippingLabelPDF(d Data) ([]byte, error) {
+	barcode := d.BarcodeValue()
+	modules, err := Code128(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc pdfDocument
+	for _, parcel := range d.parcels() {
+		page := doc.addPage(labelWidth, labelHeight)
+		const margin = 14
+
+		page.text(margin, 22, 8, true, "FROM")
+		y := 34.0
+		for _, line := range append([]string{d.From.Name}, addressLines(d.From)...) {
+			page.text(margin, y, 9, false, line)
+			y += 11
+		}
+		page.line(margin, 100, labelWidth-margin, 100, 1)
+
+		to := d.shipTo()
+		page.text(margin, 116, 8, true, "SHIP TO")
+		page.text(margin, 134, 14, true, to.Name)
+		y = 150
+		for _, line := range addressLines(to) {
+			page.text(margin, y, 12, false, line)
+			y += 14
+		}
+		if to.Phone != "" {
+			page.text(margin, y, 9, false, "Tel "+to.Phone)
+		}
+		page.line(margin, 226, labelWidth-margin, 226, 1)
+
+		page.text(margin, 250, 18, true, strings.ToUpper(d.Shipment.CarrierCode))
+		page.text(margin, 268, 10, false, parcelLine(d, parcel))
+		page.text(margin, 282, 10, false, referenceLine(d))
+		page.line(margin, 296, labelWidth-margin, 296, 1)
+
+		// The barcode takes the full width between the margins, up to 1.5 points per module
+		moduleWidth := min(1.5, (labelWidth-2*margin)/float64(len(modules)))
+		x := (labelWidth - moduleWidth*float64(len(modules))) / 2
+		page.barcode(modules, x, 308, moduleWidth, 80)
+		page.text(centered(barcode, 11, labelWidth), 404, 11, false, barcode)
+	}
+
+	return doc.bytes(), nil
+}
+
+// PackingSlipPDF renders the items of the shipment and how they are packed on A4 pages
+func PackingSlipPDF(d Data) ([]byte, error) {
+	barcode := d.BarcodeValue()
+	modules, err := Code128(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	const margin = 40
+	var doc pdfDocument
+	page := doc.addPage(a4Width, a4Height)
+
+	page.text(margin, 60, 20, true, "Packing slip")
+	page.barcode(modules, a4Width-margin-float64(len(modules)), 36, 1, 36)
+	page.text(a4Width-margin-float64(len(modules))+10, 84, 9, false, barcode)
+
+	y := 110.0
+	details := [][2]string{
+		{"Shipment", "#" + strconv.FormatInt(d.Shipment.ID, 10)},
+		{"Order", "#" + strconv.FormatInt(d.Shipment.OrderID, 10)},
+		{"Date", d.Shipment.CreatedAt.Format("2006-01-02")},
+		{"Warehouse", d.WarehouseCode},
+		{"Carrier", d.Shipment.CarrierCode},
+		{"Tracking number", d.Shipment.TrackingNumber},
+	}
+	for _, detail := range details {
+		if detail[1] == "" {
+			continue
+		}
+		page.text(margin, y, 10, true, detail[0])
+		page.text(margin+100, y, 10, false, detail[1])
+		y += 14
+	}
+
+	to := d.shipTo()
+	y += 10
+	page.text(margin, y, 10, true, "Ship to")
+	for _, line := range append([]string{to.Name}, addressLines(to)...) {
+		y += 14
+		page.text(margin, y, 10, false, line)
+	}
+
+	// newLine moves down one row, to the top of a new page when the page is full
+	newLine := func(step float64) {
+		y += step
+		if y > a4Height-margin {
+			page = doc.addPage(a4Width, a4Height)
+			y = 60
+		}
+	}
+
+	newLine(30)
+	page.text(margin, y, 10, true, "SKU")
+	page.text(a4Width-margin-60, y, 10, true, "Quantity")
+	page.line(margin, y+5, a4Width-margin, y+5, 0.5)
+	for _, item := range d.Shipment.Items {
+		newLine(18)
+		page.text(margin, y, 10, false, item.Sku)
+		page.text(a4Width-margin-60, y, 10, false, strconv.Itoa(item.Quantity))
+	}
+
+	if len(d.Shipment.Parcels) > 0 {
+		newLine(30)
+		page.text(margin, y, 10, true, "Parcels")
+		page.line(margin, y+5, a4Width-margin, y+5, 0.5)
+		for _, parcel := range d.Shipment.Parcels {
+			newLine(18)
+			page.text(margin, y, 10, false, fmt.Sprintf("Parcel %d of %d, %s kg, %s x %s x %s cm",
+				parcel.Sequence, len(d.Shipment.Parcels), num(parcel.WeightKg), num(parcel.LengthCm), num(parcel.WidthCm), num(parcel.HeightCm)))
+			for _, item := range parcel.Items {
+				newLine(14)
+				page.text(margin+20, y, 10, false, fmt.Sprintf("%s x %d", item.Sku, item.Quantity))
+			}
+		}
+	}
+
+	return doc.bytes(), nil
+}
+
+// ShippingLabelZPL renders one 4x6 inch label per parcel for 203 dpi thermal printers.
+// The printer draws the barcode itself from the ^BC field.
+func ShippingLabelZPL(d Data) []byte {
+	var b strings.Builder
+	for _, parcel := range d.parcels() {
+		b.WriteString("^XA\n^CI28\n^PW812\n^LL1218\n")
+
+		zplText(&b, 40, 40, 22, "FROM")
+		y := 70
+		for _, line := range append([]string{d.From.Name}, addressLines(d.From)...) {
+			zplText(&b, 40, y, 26, line)
+			y += 30
+		}
+		b.WriteString("^FO40,270^GB732,3,3^FS\n")
+
+		to := d.shipTo()
+		zplText(&b, 40, 290, 22, "SHIP TO")
+		zplText(&b, 40, 320, 44, to.Name)
+		y = 372
+		for _, line := range addressLines(to) {
+			zplText(&b, 40, y, 36, line)
+			y += 42
+		}
+		if to.Phone != "" {
+			zplText(&b, 40, y, 26, "Tel "+to.Phone)
+		}
+		b.WriteString("^FO40,620^GB732,3,3^FS\n")
+
+		zplText(&b, 40, 645, 56, strings.ToUpper(d.Shipment.CarrierCode))
+		zplText(&b, 40, 712, 30, parcelLine(d, parcel))
+		zplText(&b, 40, 752, 30, referenceLine(d))
+		b.WriteString("^FO40,800^GB732,3,3^FS\n")
+
+		fmt.Fprintf(&b, "^FO60,840^BY3^BCN,230,Y,N,N^FH^FD%s^FS\n", zplEscape(d.BarcodeValue()))
+		b.WriteString("^XZ\n")
+	}
+	return []byte(b.String())
+}
+
+// zplText writes a field of text in the scalable font at x, y in dots
+func zplText(b *strings.Builder, x, y, height int, text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", x, y, height, height, zplEscape(text))
+}
+
+// zplEscape hex-escapes the characters ZPL reads as commands, ^FH makes _ the escape character
+func zplEscape(text string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(text)
+}
+
+// addressLines returns the lines of an address below its name, empty lines left out
+func addressLines(a model.Address) []string {
+	var lines []string
+	for _, line := range []string{
+		a.Line1,
+		a.Line2,
+		strings.TrimSpace(strings.Join([]string{a.PostalCode, a.City, a.Region}, " ")),
+		a.Country,
+	} {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parcelLine says which parcel of the shipment a label is for, with its zone and weight
+func parcelLine(d Data, parcel labelParcel) string {
+	line := fmt.Sprintf("Parcel %d of %d   %s kg", parcel.sequence, parcel.count, num(parcel.weightKg))
+	if d.Shipment.ShippingZone != "" {
+		line += "   Zone " + d.Shipment.ShippingZone
+	}
+	return line
+}
+
+// referenceLine identifies the order and shipment for the warehouse
+func referenceLine(d Data) string {
+	line := fmt.Sprintf("Order #%d   Shipment #%d", d.Shipment.OrderID, d.Shipment.ID)
+	if d.WarehouseCode != "" {
+		line += "   From " + d.WarehouseCode
+	}
+	return line
+}
+
+// centered returns the x where text of the size is centered on the page, from the average Helvetica width
+func centered(text string, size, pageWidth float64) float64 {
+	return max(0, (pageWidth-float64(len(text))*size*0.55)/2)
+}
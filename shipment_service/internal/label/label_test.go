@@ -0,0 +1,170 @@
+package label
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCode128(t *testing.T) {
+	for value, pattern := range code128Patterns {
+		want := 11
+		if value == code128Stop {
+			want = 13
+		}
+		sum := 0
+		for _, width := range pattern {
+			sum += int(width - '0')
+		}
+		if sum != want {
+			t.Errorf("pattern of value %d is %d modules wide, want %d", value, sum, want)
+		}
+	}
+
+	modules, err := Code128("PJJ123C")
+	if err != nil {
+		t.Fatalf("Code128() error = %v", err)
+	}
+	// Quiet zones, start B, seven characters, the checksum and the stop pattern
+	if len(modules) != 2*code128QuietZone+9*11+13 {
+		t.Fatalf("Code128() = %d modules, want %d", len(modules), 2*code128QuietZone+9*11+13)
+	}
+
+	// Start B, then the checksum (104 + 48 + 2*42 + 3*42 + 4*17 + 5*18 + 6*19 + 7*35) % 103 = 55
+	for _, symbol := range []struct{ position, value int }{{0, code128StartB}, {8, 55}} {
+		offset := code128QuietZone + symbol.position*11
+		if got := widths(modules[offset : offset+11]); got != code128Patterns[symbol.value] {
+			t.Errorf("symbol %d = %s, want %s", symbol.position, got, code128Patterns[symbol.value])
+		}
+	}
+
+	if _, err := Code128("Hà Nội"); err == nil {
+		t.Error("Code128() accepted characters code set B cannot encode")
+	}
+	if _, err := Code128(""); err == nil {
+		t.Error("Code128() accepted empty data")
+	}
+}
+
+// widths returns the widths of the bars and spaces of a symbol
+func widths(modules []bool) string {
+	var b strings.Builder
+	run := 1
+	for i := 1; i <= len(modules); i++ {
+		if i < len(modules) && modules[i] == modules[i-1] {
+			run++
+			continue
+		}
+		b.WriteString(strconv.Itoa(run))
+		run = 1
+	}
+	return b.String()
+}
+
+func testData() Data {
+	return Data{
+		Shipment: &model.Shipment{
+			Base:           model.Base{ID: 42},
+			OrderID:        7,
+			CarrierCode:    "fake",
+			TrackingNumber: "FC00000001",
+			ShippingZone:   "HN",
+			Items:          []model.ShipmentItem{{Sku: "SKU001", Quantity: 3}, {Sku: "SKU002", Quantity: 1}},
+			Parcels: []model.Parcel{
+				{Sequence: 1, WeightKg: 2, ChargeableWeightKg: 2, Items: []model.ParcelItem{{Sku: "SKU001", Quantity: 3}}},
+				{Sequence: 2, WeightKg: 0.5, ChargeableWeightKg: 0.5, Items: []model.ParcelItem{{Sku: "SKU002", Quantity: 1}}},
+			},
+			ShipTo: model.Address{Name: "Nguyen (Lan)", Line1: "12 Trang Tien^St", City: "Hanoi", PostalCode: "100000", Country: "VN"},
+		},
+		From:          model.Address{Name: "Fulfillment", Line1: "1 Warehouse Road", City: "Hanoi", PostalCode: "100000"},
+		WarehouseCode: "HAN",
+	}
+}
+
+func TestShippingLabelPDF(t *testing.T) {
+	pdf, err := ShippingLabelPDF(testData())
+	if err != nil {
+		t.Fatalf("ShippingLabelPDF() error = %v", err)
+	}
+
+	checkPDF(t, pdf)
+	if pages := bytes.Count(pdf, []byte("/Type /Page ")); pages != 2 {
+		t.Errorf("ShippingLabelPDF() = %d pages, want one per parcel", pages)
+	}
+	for _, text := range []string{"(Nguyen \\(Lan\\)) Tj", "(Parcel 2 of 2   0.5 kg   Zone HN) Tj", "(FC00000001) Tj", "/MediaBox [0 0 288 432]"} {
+		if !bytes.Contains(pdf, []byte(text)) {
+			t.Errorf("ShippingLabelPDF() does not contain %q", text)
+		}
+	}
+}
+
+func TestPackingSlipPDF(t *testing.T) {
+	pdf, err := PackingSlipPDF(testData())
+	if err != nil {
+		t.Fatalf("PackingSlipPDF() error = %v", err)
+	}
+
+	checkPDF(t, pdf)
+	for _, text := range []string{"(SKU001) Tj", "(SKU002 x 1) Tj", "(HAN) Tj", "/MediaBox [0 0 595 842]"} {
+		if !bytes.Contains(pdf, []byte(text)) {
+			t.Errorf("PackingSlipPDF() does not contain %q", text)
+		}
+	}
+}
+
+// checkPDF checks the cross-reference table points at every object and startxref at the table
+func checkPDF(t *testing.T, pdf []byte) {
+	t.Helper()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("PDF does not start with its header or end with the EOF marker")
+	}
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if match == nil {
+		t.Fatal("PDF has no startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the cross-reference table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+	if len(entries) == 0 {
+		t.Fatal("PDF cross-reference table is empty")
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("cross-reference entry %d points at %q, want %q", i+1, pdf[offset:offset+10], want)
+		}
+	}
+}
+
+func TestShippingLabelZPL(t *testing.T) {
+	zpl := string(ShippingLabelZPL(testData()))
+
+	if labels := strings.Count(zpl, "^XA"); labels != 2 || strings.Count(zpl, "^XZ") != 2 {
+		t.Errorf("ShippingLabelZPL() = %d labels, want one per parcel", labels)
+	}
+	for _, text := range []string{"^BCN,230,Y,N,N^FH^FDFC00000001^FS", "^FD12 Trang Tien_5ESt^FS", "^FDParcel 1 of 2   2 kg   Zone HN^FS", "^FDFAKE^FS"} {
+		if !strings.Contains(zpl, text) {
+			t.Errorf("ShippingLabelZPL() does not contain %q", text)
+		}
+	}
+}
+
+func TestData_BarcodeValue(t *testing.T) {
+	data := testData()
+	if got := data.BarcodeValue(); got != "FC00000001" {
+		t.Errorf("BarcodeValue() = %q, want the tracking number", got)
+	}
+
+	data.Shipment.TrackingNumber = ""
+	if got := data.BarcodeValue(); got != "SHP42" {
+		t.Errorf("BarcodeValue() = %q, want the shipment number before booking", got)
+	}
+}
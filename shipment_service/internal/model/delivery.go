@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// DeliveryFileKind is what a file of a delivery proof shows
+type DeliveryFileKind string
+
+const (
+	DeliverySignature DeliveryFileKind = "SIGNATURE"
+	DeliveryPhoto     DeliveryFileKind = "PHOTO"
+)
+
+// IsValid checks if the kind is one of the defined kinds
+func (k DeliveryFileKind) IsValid() bool {
+	return k == DeliverySignature || k == DeliveryPhoto
+}
+
+// DeliveryProof is the evidence collected by the courier when a shipment is handed over, kept for COD disputes
+type DeliveryProof struct {
+	Base
+	ShipmentID    int64  `json:"shipment_id" gorm:"uniqueIndex"`
+	RecipientName string `json:"recipient_name"`
+	// Latitude and Longitude are where the courier's device was when the shipment was handed over
+	Latitude    float64        `json:"latitude"`
+	Longitude   float64        `json:"longitude"`
+	DeliveredAt time.Time      `json:"delivered_at"`
+	Files       []DeliveryFile `json:"files" gorm:"foreignKey:DeliveryProofID"`
+}
+
+// DeliveryFile is a signature or photo of a delivery proof, its content is kept in the blob store
+type DeliveryFile struct {
+	Base
+	DeliveryProofID int64            `json:"delivery_proof_id" gorm:"index"`
+	Kind            DeliveryFileKind `json:"kind"`
+	// BlobKey locates the content in the blob store
+	BlobKey     string `json:"-"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	// Sha256 is the hex digest of the content, so the evidence can be shown to be unaltered
+	Sha256 string `json:"sha256"`
+}
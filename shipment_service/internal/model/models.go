@@ -6,10 +6,50 @@ import "time"
 type ShipmentStatus string
 
 const (
+	Created        ShipmentStatus = "CREATED"
+	Picked         ShipmentStatus = "PICKED"
+	Packed         ShipmentStatus = "PACKED"
+	InTransit      ShipmentStatus = "IN_TRANSIT"
+	OutForDelivery ShipmentStatus = "OUT_FOR_DELIVERY"
+	Delivered      ShipmentStatus = "DELIVERED"
+	FailedDelivery ShipmentStatus = "FAILED_DELIVERY"
+	Returned       ShipmentStatus = "RETURNED"
+	// Failed marks a shipment whose invoice could not be created, it never leaves the warehouse
+	Failed ShipmentStatus = "FAILED"
+	// Confirmed is the status of shipments created before the lifecycle existed, it moves on like Created
 	Confirmed ShipmentStatus = "CONFIRMED"
-	Failed    ShipmentStatus = "FAILED"
 )
 
+// shipmentTransitions lists the statuses each status can move to
+var shipmentTransitions = map[ShipmentStatus][]ShipmentStatus{
+	Created:        {Picked, Failed},
+	Confirmed:      {Picked},
+	Picked:         {Packed},
+	Packed:         {InTransit},
+	InTransit:      {OutForDelivery, FailedDelivery, Returned},
+	OutForDelivery: {Delivered, FailedDelivery},
+	FailedDelivery: {OutForDelivery, Returned},
+}
+
+// IsValid reports whether the status is a known shipment status
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case Created, Picked, Packed, InTransit, OutForDelivery, Delivered, FailedDelivery, Returned, Failed, Confirmed:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a shipment in this status can move to the next status
+func (s ShipmentStatus) CanTransitionTo(next ShipmentStatus) bool {
+	for _, allowed := range shipmentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Base struct {
 	ID        int64      `json:"id" gorm:"primaryKey;autoIncrement"`
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
@@ -17,12 +57,43 @@ type Base struct {
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// Address is where a shipment is sent from or to
+type Address struct {
+	Name       string `json:"name,omitempty"`
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Phone      string `json:"phone,omitempty"`
+}
+
 // Shipment represents a shipment in the system
 type Shipment struct {
 	Base
-	OrderID int64          `json:"order_id"`
-	Items   []ShipmentItem `json:"items" gorm:"foreignKey:ShipmentID"`
-	Status  ShipmentStatus `json:"status"`
+	OrderID int64           `json:"order_id" gorm:"index"`
+	Items   []ShipmentItem  `json:"items" gorm:"foreignKey:ShipmentID"`
+	Status  ShipmentStatus  `json:"status" gorm:"index"`
+	Events  []ShipmentEvent `json:"events,omitempty" gorm:"foreignKey:ShipmentID"`
+	// WarehouseID is the warehouse the items leave from, zero for shipments created without one
+	WarehouseID int64 `json:"warehouse_id,omitempty" gorm:"index"`
+	// Parcels are the packages the items are packed in, empty until the shipment is packed
+	Parcels []Parcel `json:"parcels,omitempty" gorm:"foreignKey:ShipmentID"`
+	// ShipTo is the recipient printed on labels, its postal code is the destination postal code
+	ShipTo Address `json:"ship_to" gorm:"embedded;embeddedPrefix:ship_to_"`
+	// DeliveryProof is the evidence captured on delivery, nil until the courier confirms it
+	DeliveryProof *DeliveryProof `json:"delivery_proof,omitempty" gorm:"foreignKey:ShipmentID"`
+	// CarrierCode and TrackingNumber identify the consignment booked with the carrier
+	CarrierCode    string `json:"carrier_code" gorm:"index:idx_shipments_tracking"`
+	TrackingNumber string `json:"tracking_number,omitempty" gorm:"index:idx_shipments_tracking"`
+	// ShippingFee is billed on the shipment's invoice, computed from the carrier rate and the destination zone
+	DestinationPostalCode string  `json:"destination_postal_code,omitempty"`
+	ShippingZone          string  `json:"shipping_zone,omitempty"`
+	ChargeableWeightKg    float64 `json:"chargeable_weight_kg"`
+	ShippingFee           float64 `json:"shipping_fee"`
+	// APIKeyID is the public id of the API key the shipment was created with, empty for users
+	APIKeyID string `json:"api_key_id,omitempty" gorm:"index"`
 }
 
 // ShipmentItem represents an item in a shipment
@@ -31,3 +102,17 @@ type ShipmentItem struct {
 	Sku        string `json:"sku" gorm:"primaryKey"`
 	Quantity   int    `json:"quantity"`
 }
+
+// ShipmentEvent records a status change of a shipment for its tracking history
+type ShipmentEvent struct {
+	ID             int64          `json:"id" gorm:"primaryKey;autoIncrement"`
+	ShipmentID     int64          `json:"shipment_id" gorm:"index"`
+	Status         ShipmentStatus `json:"status"`
+	PreviousStatus ShipmentStatus `json:"previous_status,omitempty"`
+	// Timestamp is when the change happened, which may be earlier than when it was reported
+	Timestamp time.Time `json:"timestamp"`
+	Location  string    `json:"location,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -0,0 +1,190 @@
+package model
+
+import "testing"
+
+func TestShipmentStatus_CanTransitionTo(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name string
+		from ShipmentStatus
+		to   ShipmentStatus
+		want bool
+	}{
+		{name: "Created to picked", from: Created, to: Picked, want: true},
+		{name: "Legacy confirmed to picked", from: Confirmed, to: Picked, want: true},
+		{name: "Picked to packed", from: Picked, to: Packed, want: true},
+		{name: "Packed to in transit", from: Packed, to: InTransit, want: true},
+		{name: "In transit to out for delivery", from: InTransit, to: OutForDelivery, want: true},
+		{name: "Out for delivery to delivered", from: OutForDelivery, to: Delivered, want: true},
+		{name: "Failed delivery retried", from: FailedDelivery, to: OutForDelivery, want: true},
+		{name: "Failed delivery returned", from: FailedDelivery, to: Returned, want: true},
+		{name: "Skipping packing", from: Picked, to: InTransit, want: false},
+		{name: "Moving backwards", from: InTransit, to: Packed, want: false},
+		{name: "Delivered is final", from: Delivered, to: Returned, want: false},
+		{name: "Returned is final", from: Returned, to: OutForDelivery, want: false},
+		{name: "Failed is final", from: Failed, to: Picked, want: false},
+		{name: "Same status", from: Picked, to: Picked, want: false},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShipmentStatus_IsValid(t *testing.T) {
+	for _, status := range []ShipmentStatus{Created, Picked, Packed, InTransit, OutForDelivery, Delivered, FailedDelivery, Returned, Failed, Confirmed} {
+		if !status.IsValid() {
+			t.Errorf("%s.IsValid() = false, want true", status)
+		}
+	}
+	for _, status := range []ShipmentStatus{"", "SHIPPED", "delivered"} {
+		if status.IsValid() {
+			t.Errorf("%q.IsValid() = true, want false", status)
+		}
+	}
+}
+
+func TestSkuDimension_ChargeableWeightKg(t *testing.T) {
+	tests := []struct {
+		name      string
+		dimension SkuDimension
+		divisor   float64
+		want      float64
+	}{
+		{name: "Actual weight is larger", dimension: SkuDimension{WeightKg: 3, LengthCm: 10, WidthCm: 10, HeightCm: 10}, divisor: 5000, want: 3},
+		{name: "Dimensional weight is larger", dimension: SkuDimension{WeightKg: 1, LengthCm: 50, WidthCm: 40, HeightCm: 25}, divisor: 5000, want: 10},
+		{name: "Default divisor", dimension: SkuDimension{LengthCm: 50, WidthCm: 40, HeightCm: 25}, want: 10},
+		{name: "Custom divisor", dimension: SkuDimension{LengthCm: 50, WidthCm: 40, HeightCm: 25}, divisor: 4000, want: 12.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dimension.ChargeableWeightKg(tt.divisor); got != tt.want {
+				t.Errorf("ChargeableWeightKg(%v) = %v, want %v", tt.divisor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchShippingZone(t *testing.T) {
+	zones := []ShippingZone{
+		{Code: "METRO", PostalPrefixes: []string{"10", "11"}, Multiplier: 1},
+		{Code: "ISLAND", PostalPrefixes: []string{"109"}, Multiplier: 1.5, Surcharge: 5},
+	}
+
+	tests := []struct {
+		name       string
+		postalCode string
+		wantCode   string
+		wantFee    float64
+	}{
+		{name: "Prefix match", postalCode: "11200", wantCode: "METRO", wantFee: 20},
+		{name: "Longest prefix wins", postalCode: "10950", wantCode: "ISLAND", wantFee: 35},
+		{name: "No zone charges the carrier rate", postalCode: "70000", wantCode: "", wantFee: 20},
+		{name: "Empty postal code", postalCode: "", wantCode: "", wantFee: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := MatchShippingZone(zones, tt.postalCode)
+			if zone.Code != tt.wantCode {
+				t.Errorf("MatchShippingZone(%q) = %q, want %q", tt.postalCode, zone.Code, tt.wantCode)
+			}
+			if fee := zone.Fee(20); fee != tt.wantFee {
+				t.Errorf("Fee(20) in %q = %v, want %v", zone.Code, fee, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestReturnStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from ReturnStatus
+		to   ReturnStatus
+		want bool
+	}{
+		{name: "Requested to approved", from: ReturnRequested, to: ReturnApproved, want: true},
+		{name: "Requested to rejected", from: ReturnRequested, to: ReturnRejected, want: true},
+		{name: "Approved to received", from: ReturnApproved, to: ReturnReceived, want: true},
+		{name: "Approved to rejected", from: ReturnApproved, to: ReturnRejected, want: true},
+		{name: "Received to inspected", from: ReturnReceived, to: ReturnInspected, want: true},
+		{name: "Receiving before approval", from: ReturnRequested, to: ReturnReceived, want: false},
+		{name: "Rejecting after receipt", from: ReturnReceived, to: ReturnRejected, want: false},
+		{name: "Rejected is final", from: ReturnRejected, to: ReturnApproved, want: false},
+		{name: "Inspected is final", from: ReturnInspected, to: ReturnReceived, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReturn_AwaitsCredit(t *testing.T) {
+	tests := []struct {
+		name string
+		ret  Return
+		want bool
+	}{
+		{name: "Received without credit", ret: Return{Status: ReturnReceived}, want: true},
+		{name: "Inspected without credit", ret: Return{Status: ReturnInspected}, want: true},
+		{name: "Inspected and credited", ret: Return{Status: ReturnInspected, CreditNoteID: 3}, want: false},
+		{name: "Approved", ret: Return{Status: ReturnApproved}, want: false},
+		{name: "Rejected", ret: Return{Status: ReturnRejected}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ret.AwaitsCredit(); got != tt.want {
+				t.Errorf("%s return with credit note %d: AwaitsCredit() = %v, want %v", tt.ret.Status, tt.ret.CreditNoteID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWarehouse_Proximity(t *testing.T) {
+	warehouse := Warehouse{PostalCode: "10115"}
+	tests := map[string]int{"10117": 4, "10999": 2, "80331": 0, "101": 3, "": 0}
+	for postalCode, want := range tests {
+		if got := warehouse.Proximity(postalCode); got != want {
+			t.Errorf("Proximity(%q) = %d, want %d", postalCode, got, want)
+		}
+	}
+}
+
+func TestParcel_CalculateWeights(t *testing.T) {
+	// Test cases
+	tests := []struct {
+		name            string
+		parcel          Parcel
+		dimDivisor      float64
+		wantDimensional float64
+		wantChargeable  float64
+	}{
+		{name: "Heavy parcel", parcel: Parcel{WeightKg: 12, LengthCm: 40, WidthCm: 30, HeightCm: 20}, dimDivisor: 5000, wantDimensional: 4.8, wantChargeable: 12},
+		{name: "Bulky parcel", parcel: Parcel{WeightKg: 2, LengthCm: 60, WidthCm: 40, HeightCm: 40}, dimDivisor: 5000, wantDimensional: 19.2, wantChargeable: 19.2},
+		{name: "Default divisor", parcel: Parcel{WeightKg: 1, LengthCm: 50, WidthCm: 50, HeightCm: 10}, wantDimensional: 5, wantChargeable: 5},
+		{name: "Size unknown", parcel: Parcel{WeightKg: 1.5}, dimDivisor: 6000, wantDimensional: 0, wantChargeable: 1.5},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parcel := tt.parcel
+			parcel.CalculateWeights(tt.dimDivisor)
+			if parcel.DimensionalWeightKg != tt.wantDimensional || parcel.ChargeableWeightKg != tt.wantChargeable {
+				t.Errorf("CalculateWeights() = %v dimensional and %v chargeable, want %v and %v",
+					parcel.DimensionalWeightKg, parcel.ChargeableWeightKg, tt.wantDimensional, tt.wantChargeable)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import "math"
+
+// Parcel is a package of a shipment as it leaves the packing station
+type Parcel struct {
+	Base
+	ShipmentID int64 `json:"shipment_id" gorm:"index"`
+	// Sequence numbers the parcels of a shipment from 1, as printed on their labels
+	Sequence int     `json:"sequence"`
+	WeightKg float64 `json:"weight_kg"`
+	LengthCm float64 `json:"length_cm"`
+	WidthCm  float64 `json:"width_cm"`
+	HeightCm float64 `json:"height_cm"`
+	// DimensionalWeightKg and ChargeableWeightKg are set by CalculateWeights when the parcel is packed
+	DimensionalWeightKg float64      `json:"dimensional_weight_kg"`
+	ChargeableWeightKg  float64      `json:"chargeable_weight_kg"`
+	Items               []ParcelItem `json:"items" gorm:"foreignKey:ParcelID"`
+}
+
+// ParcelItem is the quantity of a shipped SKU packed in a parcel
+type ParcelItem struct {
+	ParcelID int64  `json:"parcel_id" gorm:"primaryKey"`
+	Sku      string `json:"sku" gorm:"primaryKey"`
+	Quantity int    `json:"quantity"`
+}
+
+// CalculateWeights sets the dimensional weight of the parcel and its chargeable weight, the larger of the two
+func (p *Parcel) CalculateWeights(dimDivisor float64) {
+	p.DimensionalWeightKg = round3(DimensionalWeightKg(p.LengthCm, p.WidthCm, p.HeightCm, dimDivisor))
+	p.ChargeableWeightKg = math.Max(p.WeightKg, p.DimensionalWeightKg)
+}
+
+// DimensionalWeightKg converts a size in centimetres to the weight carriers charge it as
+func DimensionalWeightKg(lengthCm, widthCm, heightCm, dimDivisor float64) float64 {
+	if dimDivisor <= 0 {
+		dimDivisor = DefaultDimDivisor
+	}
+	return lengthCm * widthCm * heightCm / dimDivisor
+}
+
+func round3(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// ReturnStatus defines the status of a return
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "REQUESTED"
+	ReturnApproved  ReturnStatus = "APPROVED"
+	ReturnRejected  ReturnStatus = "REJECTED"
+	// ReturnReceived is set when the items are back in the warehouse, their invoiced amount is credited then
+	ReturnReceived  ReturnStatus = "RECEIVED"
+	ReturnInspected ReturnStatus = "INSPECTED"
+)
+
+// returnTransitions lists the statuses each return status can move to
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived, ReturnRejected},
+	ReturnReceived:  {ReturnInspected},
+}
+
+// CanTransitionTo reports whether a return in this status can move to the next status
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	for _, allowed := range returnTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Return is a request to send items of a delivered shipment back
+type Return struct {
+	Base
+	ShipmentID int64        `json:"shipment_id" gorm:"index"`
+	OrderID    int64        `json:"order_id" gorm:"index"`
+	Status     ReturnStatus `json:"status" gorm:"index"`
+	Reason     string       `json:"reason,omitempty"`
+	// Note is left by whoever last moved the return, such as the reason of a rejection or the inspection result
+	Note  string       `json:"note,omitempty"`
+	Items []ReturnItem `json:"items" gorm:"foreignKey:ReturnID"`
+	// CreditNoteID is set once billing reversed the invoiced amount of the items
+	CreditNoteID   int64      `json:"credit_note_id,omitempty"`
+	CreditedAmount float64    `json:"credited_amount"`
+	ReceivedAt     *time.Time `json:"received_at,omitempty"`
+}
+
+// ReturnItem is a returned quantity of a shipped SKU
+type ReturnItem struct {
+	ReturnID int64  `json:"return_id" gorm:"primaryKey"`
+	Sku      string `json:"sku" gorm:"primaryKey"`
+	Quantity int    `json:"quantity"`
+}
+
+// AwaitsCredit reports whether the items of the return are back but billing has not credited them yet
+func (r *Return) AwaitsCredit() bool {
+	return (r.Status == ReturnReceived || r.Status == ReturnInspected) && r.CreditNoteID == 0
+}
+
+// CreditReference identifies the credit note of the return in billing, so a retried credit is not issued twice
+func (r *Return) CreditReference() string {
+	return "RMA-" + strconv.FormatInt(r.ID, 10)
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultDimDivisor converts a volume in cubic centimetres to a dimensional weight in kilograms
+const DefaultDimDivisor = 5000
+
+// SkuDimension is the weight and packed size of one unit of a SKU
+type SkuDimension struct {
+	Sku       string    `json:"sku" gorm:"primaryKey"`
+	WeightKg  float64   `json:"weight_kg"`
+	LengthCm  float64   `json:"length_cm"`
+	WidthCm   float64   `json:"width_cm"`
+	HeightCm  float64   `json:"height_cm"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ChargeableWeightKg returns the larger of the actual and dimensional weight of one unit
+func (d SkuDimension) ChargeableWeightKg(dimDivisor float64) float64 {
+	return math.Max(d.WeightKg, DimensionalWeightKg(d.LengthCm, d.WidthCm, d.HeightCm, dimDivisor))
+}
+
+// ShippingZone adjusts the carrier rate of destinations whose postal code starts with one of its prefixes
+type ShippingZone struct {
+	Base
+	Code           string   `json:"code" gorm:"uniqueIndex"`
+	Name           string   `json:"name"`
+	PostalPrefixes []string `json:"postal_prefixes" gorm:"serializer:json"`
+	// Multiplier scales the carrier rate, Surcharge is then added to it
+	Multiplier float64 `json:"multiplier"`
+	Surcharge  float64 `json:"surcharge"`
+}
+
+// Fee returns the shipping fee of a carrier rate in this zone, rounded to cents
+func (z ShippingZone) Fee(rate float64) float64 {
+	return math.Round((rate*z.Multiplier+z.Surcharge)*100) / 100
+}
+
+// MatchShippingZone returns the zone with the longest prefix of the postal code.
+// Destinations no zone covers are charged the carrier rate as it is.
+func MatchShippingZone(zones []ShippingZone, postalCode string) ShippingZone {
+	match := ShippingZone{Multiplier: 1}
+	longest := 0
+	for _, zone := range zones {
+		for _, prefix := range zone.PostalPrefixes {
+			if len(prefix) > longest && strings.HasPrefix(postalCode, prefix) {
+				match = zone
+				longest = len(prefix)
+			}
+		}
+	}
+	return match
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// AllocationStrategy decides which warehouses an order is shipped from
+type AllocationStrategy string
+
+const (
+	// AllocateNearest ships from the warehouses closest to the destination first
+	AllocateNearest AllocationStrategy = "NEAREST"
+	// AllocateFewestSplits ships from as few warehouses as possible, the closest first when several would do
+	AllocateFewestSplits AllocationStrategy = "FEWEST_SPLITS"
+	// AllocatePriority ships from the warehouses with the lowest priority number first
+	AllocatePriority AllocationStrategy = "PRIORITY"
+)
+
+// IsValid reports whether the strategy is a known allocation strategy
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case AllocateNearest, AllocateFewestSplits, AllocatePriority:
+		return true
+	}
+	return false
+}
+
+// Warehouse is a fulfillment location shipments leave from
+type Warehouse struct {
+	Base
+	Code       string `json:"code" gorm:"uniqueIndex"`
+	Name       string `json:"name"`
+	PostalCode string `json:"postal_code"`
+	// Priority orders warehouses for the PRIORITY strategy and breaks ties of the others, lowest first
+	Priority int `json:"priority"`
+	// Active warehouses are the only ones orders are allocated to
+	Active bool `json:"active"`
+}
+
+// Proximity returns how many leading characters the warehouse's postal code shares with the destination.
+// Postal codes are assigned by region, so a longer shared prefix means a closer warehouse.
+func (w Warehouse) Proximity(postalCode string) int {
+	n := 0
+	for n < len(w.PostalCode) && n < len(postalCode) && w.PostalCode[n] == postalCode[n] {
+		n++
+	}
+	return n
+}
+
+// WarehouseStock is the quantity of a SKU on hand in a warehouse, shipments reserve it when they are created
+type WarehouseStock struct {
+	WarehouseID int64     `json:"warehouse_id" gorm:"primaryKey"`
+	Sku         string    `json:"sku" gorm:"primaryKey"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
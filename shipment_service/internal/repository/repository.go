@@ -3,9 +3,70 @@ package repository
 import (
 	"billing-system/shipment_service/internal/model"
 	"context"
+	"time"
 )
 
 type ShipmentRepository interface {
 	Create(ctx context.Context, shipment *model.Shipment) error
 	Update(ctx context.Context, shipment *model.Shipment) error
+	GetByID(ctx context.Context, id int64) (*model.Shipment, error)
+	GetByTrackingNumber(ctx context.Context, carrierCode string, trackingNumber string) (*model.Shipment, error)
+	List(ctx context.Context, query ShipmentQuery) ([]model.Shipment, error)
+	UpdateStatus(ctx context.Context, shipment *model.Shipment, from model.ShipmentStatus, event *model.ShipmentEvent) (bool, error)
+	ListEvents(ctx context.Context, shipmentID int64) ([]model.ShipmentEvent, error)
+	ListEventsAfter(ctx context.Context, query EventQuery) ([]model.ShipmentEvent, error)
+	ReplaceParcels(ctx context.Context, shipmentID int64, parcels []model.Parcel) error
+	SaveDeliveryProof(ctx context.Context, shipment *model.Shipment, from model.ShipmentStatus, event *model.ShipmentEvent, proof *model.DeliveryProof) (bool, error)
+}
+
+// ShippingRepository stores the data shipping fees are computed from
+type ShippingRepository interface {
+	GetDimensions(ctx context.Context, skus []string) ([]model.SkuDimension, error)
+	UpsertDimensions(ctx context.Context, dimensions []model.SkuDimension) error
+	ListZones(ctx context.Context) ([]model.ShippingZone, error)
+	UpsertZone(ctx context.Context, zone *model.ShippingZone) error
+}
+
+// WarehouseRepository stores warehouses and the stock they hold
+type WarehouseRepository interface {
+	UpsertWarehouse(ctx context.Context, warehouse *model.Warehouse) error
+	GetWarehouse(ctx context.Context, id int64) (*model.Warehouse, error)
+	ListWarehouses(ctx context.Context, activeOnly bool) ([]model.Warehouse, error)
+	GetStock(ctx context.Context, skus []string) ([]model.WarehouseStock, error)
+	UpsertStock(ctx context.Context, stock []model.WarehouseStock) error
+	ReserveStock(ctx context.Context, warehouseID int64, items []model.ShipmentItem) (bool, error)
+	ReleaseStock(ctx context.Context, warehouseID int64, items []model.ShipmentItem) error
+}
+
+// ReturnRepository stores returns of shipped items
+type ReturnRepository interface {
+	Create(ctx context.Context, ret *model.Return) error
+	CreateForShipment(ctx context.Context, shipmentID int64, build func(shipment *model.Shipment, existing []model.Return) (*model.Return, error)) (*model.Return, error)
+	RecordCredit(ctx context.Context, id int64, creditNoteID int64, amount float64) (bool, error)
+	GetByID(ctx context.Context, id int64) (*model.Return, error)
+	UpdateStatus(ctx context.Context, ret *model.Return, from model.ReturnStatus) (bool, error)
+}
+
+// ShipmentQuery selects shipments newest first. Zero values do not filter.
+// BeforeID is the keyset cursor, only shipments with a lower ID are returned.
+type ShipmentQuery struct {
+	OrderID     int64
+	Status      model.ShipmentStatus
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	BeforeID    int64
+	Limit       int
+}
+
+// EventQuery selects the events of a shipment, or of every shipment of an order, recorded after an event.
+// Events are returned by ID, which is the order they were recorded in. An ID is taken when the event is recorded
+// but the event is only read once committed, so an event may be read after events with a higher ID.
+// UpToID and RecordedAfter, when set, read again the events up to an ID recorded since a time, catching those.
+type EventQuery struct {
+	ShipmentID    int64
+	OrderID       int64
+	AfterID       int64
+	UpToID        int64
+	RecordedAfter time.Time
+	Limit         int
 }
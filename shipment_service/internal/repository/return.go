@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReturnRepository creates a new return repository
+func NewReturnRepository(db *gorm.DB) ReturnRepository {
+	return &ReturnRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create creates a new return with its items
+func (r *ReturnRepositoryImpl) Create(ctx context.Context, ret *model.Return) error {
+	return r.db.WithContext(ctx).Create(ret).Error
+}
+
+// CreateForShipment creates the return build makes of the shipment and the returns it already has.
+// The shipment row is locked until the return is created, so concurrent requests for the same shipment
+// are checked against each other's returns. Nothing is created when build fails, its error is returned.
+func (r *ReturnRepositoryImpl) CreateForShipment(
+	ctx context.Context,
+	shipmentID int64,
+	build func(shipment *model.Shipment, existing []model.Return) (*model.Return, error),
+) (*model.Return, error) {
+	var ret *model.Return
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var shipment model.Shipment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Items").
+			First(&shipment, shipmentID).Error
+		if err != nil {
+			return err
+		}
+
+		var existing []model.Return
+		err = tx.Preload("Items").
+			Where("shipment_id = ?", shipmentID).
+			Order("id").
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		ret, err = build(&shipment, existing)
+		if err != nil {
+			return err
+		}
+		return tx.Create(ret).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// RecordCredit records the credit note billing issued for a return that has none yet.
+// Only the credit columns are written, so a status change committed meanwhile is kept.
+// Returns false without changing anything when a credit note is already recorded.
+func (r *ReturnRepositoryImpl) RecordCredit(ctx context.Context, id int64, creditNoteID int64, amount float64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.Return{}).
+		Where("id = ? AND credit_note_id = 0", id).
+		UpdateColumns(map[string]any{
+			"credit_note_id":  creditNoteID,
+			"credited_amount": amount,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetByID retrieves a return with its items
+func (r *ReturnRepositoryImpl) GetByID(ctx context.Context, id int64) (*model.Return, error) {
+	var ret model.Return
+	if err := r.db.WithContext(ctx).Preload("Items").First(&ret, id).Error; err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// UpdateStatus moves the return from one status to its current status and saves its note and receipt time.
+// Returns false without changing anything when the return is no longer in the from status.
+func (r *ReturnRepositoryImpl) UpdateStatus(ctx context.Context, ret *model.Return, from model.ReturnStatus) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&model.Return{}).
+		Where("id = ? AND status = ?", ret.ID, from).
+		Updates(map[string]any{
+			"status":      ret.Status,
+			"note":        ret.Note,
+			"received_at": ret.ReceivedAt,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	ret.UpdatedAt = now
+	return true, nil
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"billing-system/shipment_service/internal/model"
 	"context"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ShipmentRepositoryImpl struct {
@@ -23,7 +25,195 @@ func (r *ShipmentRepositoryImpl) Create(ctx context.Context, shipment *model.Shi
 	return r.db.WithContext(ctx).Create(shipment).Error
 }
 
-// Update updates an existing shipment
+// Update updates the columns of an existing shipment, its items and events are left untouched
 func (r *ShipmentRepositoryImpl) Update(ctx context.Context, shipment *model.Shipment) error {
-	return r.db.WithContext(ctx).Save(shipment).Error
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(shipment).Error
+}
+
+// GetByID retrieves a shipment with its items and its delivery proof
+func (r *ShipmentRepositoryImpl) GetByID(ctx context.Context, id int64) (*model.Shipment, error) {
+	var shipment model.Shipment
+	db := preloadParcels(r.db.WithContext(ctx).Preload("Items")).Preload("DeliveryProof.Files")
+	if err := db.First(&shipment, id).Error; err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// GetByTrackingNumber retrieves the shipment booked with a carrier under the tracking number
+func (r *ShipmentRepositoryImpl) GetByTrackingNumber(ctx context.Context, carrierCode string, trackingNumber string) (*model.Shipment, error) {
+	var shipment model.Shipment
+	err := preloadParcels(r.db.WithContext(ctx).Preload("Items")).
+		Where("carrier_code = ? AND tracking_number = ?", carrierCode, trackingNumber).
+		First(&shipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// List retrieves the shipments matching the query with their items, newest first
+func (r *ShipmentRepositoryImpl) List(ctx context.Context, query ShipmentQuery) ([]model.Shipment, error) {
+	db := preloadParcels(r.db.WithContext(ctx).Preload("Items"))
+
+	if query.OrderID != 0 {
+		db = db.Where("order_id = ?", query.OrderID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if !query.CreatedFrom.IsZero() {
+		db = db.Where("created_at >= ?", query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		db = db.Where("created_at < ?", query.CreatedTo)
+	}
+	if query.BeforeID != 0 {
+		db = db.Where("id < ?", query.BeforeID)
+	}
+
+	var shipments []model.Shipment
+	if err := db.Order("id DESC").Limit(query.Limit).Find(&shipments).Error; err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+// UpdateStatus moves the shipment from one status to the status of the event and records the event.
+// Returns false without changing anything when the shipment is no longer in the from status.
+func (r *ShipmentRepositoryImpl) UpdateStatus(ctx context.Context, shipment *model.Shipment, from model.ShipmentStatus, event *model.ShipmentEvent) (bool, error) {
+	updated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&model.Shipment{}).
+			Where("id = ? AND status = ?", shipment.ID, from).
+			Updates(map[string]any{"status": event.Status, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		event.ShipmentID = shipment.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		shipment.Status = event.Status
+		shipment.UpdatedAt = now
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// SaveDeliveryProof stores the delivery proof of a shipment.
+// With an event it also moves the shipment from one status to the status of the event, like UpdateStatus,
+// and returns false without storing anything when the shipment is no longer in the from status.
+func (r *ShipmentRepositoryImpl) SaveDeliveryProof(
+	ctx context.Context,
+	shipment *model.Shipment,
+	from model.ShipmentStatus,
+	event *model.ShipmentEvent,
+	proof *model.DeliveryProof,
+) (bool, error) {
+	saved := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if event != nil {
+			result := tx.Model(&model.Shipment{}).
+				Where("id = ? AND status = ?", shipment.ID, from).
+				Updates(map[string]any{"status": event.Status, "updated_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+
+			event.ShipmentID = shipment.ID
+			if err := tx.Create(event).Error; err != nil {
+				return err
+			}
+		}
+
+		proof.ShipmentID = shipment.ID
+		if err := tx.Create(proof).Error; err != nil {
+			return err
+		}
+
+		if event != nil {
+			shipment.Status = event.Status
+			shipment.UpdatedAt = now
+		}
+		shipment.DeliveryProof = proof
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+// ReplaceParcels replaces the parcels of a shipment and their items
+func (r *ShipmentRepositoryImpl) ReplaceParcels(ctx context.Context, shipmentID int64, parcels []model.Parcel) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		previous := tx.Model(&model.Parcel{}).Select("id").Where("shipment_id = ?", shipmentID)
+		if err := tx.Where("parcel_id IN (?)", previous).Delete(&model.ParcelItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("shipment_id = ?", shipmentID).Delete(&model.Parcel{}).Error; err != nil {
+			return err
+		}
+
+		for i := range parcels {
+			parcels[i].ShipmentID = shipmentID
+		}
+		return tx.Create(&parcels).Error
+	})
+}
+
+// ListEvents retrieves the tracking history of a shipment, oldest first
+func (r *ShipmentRepositoryImpl) ListEvents(ctx context.Context, shipmentID int64) ([]model.ShipmentEvent, error) {
+	var events []model.ShipmentEvent
+	err := r.db.WithContext(ctx).
+		Where("shipment_id = ?", shipmentID).
+		Order("timestamp ASC, id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListEventsAfter retrieves the events matching the query, oldest recorded first
+func (r *ShipmentRepositoryImpl) ListEventsAfter(ctx context.Context, query EventQuery) ([]model.ShipmentEvent, error) {
+	db := r.db.WithContext(ctx).Where("id > ?", query.AfterID)
+	if query.ShipmentID != 0 {
+		db = db.Where("shipment_id = ?", query.ShipmentID)
+	}
+	if query.OrderID != 0 {
+		db = db.Where("shipment_id IN (?)", r.db.Model(&model.Shipment{}).Select("id").Where("order_id = ?", query.OrderID))
+	}
+	if query.UpToID != 0 {
+		db = db.Where("id <= ?", query.UpToID)
+	}
+	if !query.RecordedAfter.IsZero() {
+		db = db.Where("created_at > ?", query.RecordedAfter)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var events []model.ShipmentEvent
+	if err := db.Order("id ASC").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// preloadParcels loads the parcels of shipments in label order with their items
+func preloadParcels(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Parcels", func(db *gorm.DB) *gorm.DB { return db.Order("sequence") }).
+		Preload("Parcels.Items")
 }
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShippingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewShippingRepository creates a new shipping repository
+func NewShippingRepository(db *gorm.DB) ShippingRepository {
+	return &ShippingRepositoryImpl{
+		db: db,
+	}
+}
+
+// GetDimensions retrieves the dimensions of the SKUs, SKUs without dimensions are left out
+func (r *ShippingRepositoryImpl) GetDimensions(ctx context.Context, skus []string) ([]model.SkuDimension, error) {
+	var dimensions []model.SkuDimension
+	if err := r.db.WithContext(ctx).Where("sku IN ?", skus).Find(&dimensions).Error; err != nil {
+		return nil, err
+	}
+	return dimensions, nil
+}
+
+// UpsertDimensions creates or replaces the dimensions of the SKUs
+func (r *ShippingRepositoryImpl) UpsertDimensions(ctx context.Context, dimensions []model.SkuDimension) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "sku"}},
+			DoUpdates: clause.AssignmentColumns([]string{"weight_kg", "length_cm", "width_cm", "height_cm", "updated_at"}),
+		}).
+		Create(&dimensions).Error
+}
+
+// ListZones retrieves every shipping zone
+func (r *ShippingRepositoryImpl) ListZones(ctx context.Context) ([]model.ShippingZone, error) {
+	var zones []model.ShippingZone
+	if err := r.db.WithContext(ctx).Order("code").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// UpsertZone creates the zone or replaces the zone with the same code
+func (r *ShippingRepositoryImpl) UpsertZone(ctx context.Context, zone *model.ShippingZone) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "postal_prefixes", "multiplier", "surcharge", "updated_at"}),
+		}).
+		Create(zone).Error
+}
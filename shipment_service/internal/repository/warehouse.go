@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errStockShort rolls back a reservation when a SKU does not have enough stock
+var errStockShort = errors.New("not enough stock")
+
+type WarehouseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWarehouseRepository creates a new warehouse repository
+func NewWarehouseRepository(db *gorm.DB) WarehouseRepository {
+	return &WarehouseRepositoryImpl{
+		db: db,
+	}
+}
+
+// UpsertWarehouse creates the warehouse or replaces the warehouse with the same code
+func (r *WarehouseRepositoryImpl) UpsertWarehouse(ctx context.Context, warehouse *model.Warehouse) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "postal_code", "priority", "active", "updated_at"}),
+		}).
+		Create(warehouse).Error
+}
+
+// GetWarehouse retrieves a warehouse
+func (r *WarehouseRepositoryImpl) GetWarehouse(ctx context.Context, id int64) (*model.Warehouse, error) {
+	var warehouse model.Warehouse
+	if err := r.db.WithContext(ctx).First(&warehouse, id).Error; err != nil {
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+// ListWarehouses retrieves the warehouses by priority, only the active ones when activeOnly is set
+func (r *WarehouseRepositoryImpl) ListWarehouses(ctx context.Context, activeOnly bool) ([]model.Warehouse, error) {
+	db := r.db.WithContext(ctx)
+	if activeOnly {
+		db = db.Where("active = ?", true)
+	}
+
+	var warehouses []model.Warehouse
+	if err := db.Order("priority, id").Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+	return warehouses, nil
+}
+
+// GetStock retrieves the stock of the SKUs in every warehouse, SKUs a warehouse never held are left out
+func (r *WarehouseRepositoryImpl) GetStock(ctx context.Context, skus []string) ([]model.WarehouseStock, error) {
+	var stock []model.WarehouseStock
+	if err := r.db.WithContext(ctx).Where("sku IN ?", skus).Find(&stock).Error; err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+// UpsertStock creates or replaces the quantity of SKUs in warehouses
+func (r *WarehouseRepositoryImpl) UpsertStock(ctx context.Context, stock []model.WarehouseStock) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "sku"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+		}).
+		Create(&stock).Error
+}
+
+// ReserveStock takes the quantities of the items out of the warehouse's stock.
+// Returns false without changing anything when one of the SKUs does not have enough stock.
+func (r *WarehouseRepositoryImpl) ReserveStock(ctx context.Context, warehouseID int64, items []model.ShipmentItem) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			result := tx.Model(&model.WarehouseStock{}).
+				Where("warehouse_id = ? AND sku = ? AND quantity >= ?", warehouseID, item.Sku, item.Quantity).
+				Update("quantity", gorm.Expr("quantity - ?", item.Quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errStockShort
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errStockShort) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReleaseStock puts the quantities of the items back into the warehouse's stock
+func (r *WarehouseRepositoryImpl) ReleaseStock(ctx context.Context, warehouseID int64, items []model.ShipmentItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			err := tx.Model(&model.WarehouseStock{}).
+				Where("warehouse_id = ? AND sku = ?", warehouseID, item.Sku).
+				Update("quantity", gorm.Expr("quantity + ?", item.Quantity)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+type AllocationServiceImpl struct {
+	warehouseRepo repository.WarehouseRepository
+	billingClient *billing.BillingClient
+}
+
+func NewAllocationService(warehouseRepo repository.WarehouseRepository) AllocationService {
+	return &AllocationServiceImpl{
+		warehouseRepo: warehouseRepo,
+		billingClient: billing.NewBillingClient(),
+	}
+}
+
+// UpsertWarehouse creates the warehouse or replaces the warehouse with the same code
+func (s *AllocationServiceImpl) UpsertWarehouse(ctx context.Context, warehouse *model.Warehouse) (*model.Warehouse, error) {
+	if warehouse.Code == "" || warehouse.PostalCode == "" {
+		return nil, fmt.Errorf("%w: code and postal code are required", ErrInvalidWarehouse)
+	}
+	if warehouse.Priority < 0 {
+		return nil, fmt.Errorf("%w: priority must be non-negative", ErrInvalidWarehouse)
+	}
+
+	if err := s.warehouseRepo.UpsertWarehouse(ctx, warehouse); err != nil {
+		return nil, fmt.Errorf("failed to store warehouse: %w", err)
+	}
+	return warehouse, nil
+}
+
+// ListWarehouses returns every warehouse by priority
+func (s *AllocationServiceImpl) ListWarehouses(ctx context.Context) ([]model.Warehouse, error) {
+	warehouses, err := s.warehouseRepo.ListWarehouses(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list warehouses: %w", err)
+	}
+	return warehouses, nil
+}
+
+// UpsertStock creates or replaces the quantity of SKUs on hand in warehouses
+func (s *AllocationServiceImpl) UpsertStock(ctx context.Context, stock []model.WarehouseStock) error {
+	if len(stock) == 0 {
+		return fmt.Errorf("%w: at least one SKU is required", ErrInvalidStock)
+	}
+
+	warehouses := make(map[int64]bool)
+	for _, st := range stock {
+		if st.Sku == "" {
+			return fmt.Errorf("%w: SKU is required", ErrInvalidStock)
+		}
+		if st.Quantity < 0 {
+			return fmt.Errorf("%w: quantity of SKU %s must be non-negative", ErrInvalidStock, st.Sku)
+		}
+		warehouses[st.WarehouseID] = true
+	}
+
+	for id := range warehouses {
+		if _, err := s.warehouseRepo.GetWarehouse(ctx, id); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %d", ErrWarehouseNotFound, id)
+			}
+			return fmt.Errorf("failed to get warehouse %d: %w", id, err)
+		}
+	}
+
+	if err := s.warehouseRepo.UpsertStock(ctx, stock); err != nil {
+		return fmt.Errorf("failed to store warehouse stock: %w", err)
+	}
+	return nil
+}
+
+// ProposeAllocation splits what is left to ship of an order, or the requested items, into one shipment per warehouse.
+// Only active warehouses are used. Quantities no warehouse has in stock are returned as unallocated, nothing is reserved.
+func (s *AllocationServiceImpl) ProposeAllocation(ctx context.Context, req dto.AllocationRequest) (*dto.AllocationPlan, error) {
+	strategy := req.Strategy
+	if strategy == "" {
+		strategy = model.AllocateNearest
+	}
+	if !strategy.IsValid() {
+		return nil, fmt.Errorf("%w: unknown strategy %q", ErrInvalidAllocation, strategy)
+	}
+
+	shippable, err := getShippableQuantities(ctx, s.billingClient, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var demand []model.ShipmentItem
+	if len(req.Items) > 0 {
+		if demand, err = validateItems(req.Items, shippable); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, quantity := range shippable {
+			if quantity.Remaining > 0 {
+				demand = append(demand, model.ShipmentItem{Sku: quantity.Sku, Quantity: quantity.Remaining})
+			}
+		}
+		if len(demand) == 0 {
+			return nil, fmt.Errorf("%w: nothing is left to ship of order %d", ErrInvalidAllocation, req.OrderID)
+		}
+	}
+
+	warehouses, err := s.warehouseRepo.ListWarehouses(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list warehouses: %w", err)
+	}
+
+	skus := make([]string, len(demand))
+	for i, item := range demand {
+		skus[i] = item.Sku
+	}
+	stock, err := s.warehouseRepo.GetStock(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouse stock: %w", err)
+	}
+
+	plan := allocate(strategy, warehouses, stock, demand, req.DestinationPostalCode)
+	return &plan, nil
+}
+
+// allocate splits the demand across the warehouses following the strategy.
+// NEAREST and PRIORITY take as much as they can from each warehouse in their order.
+// FEWEST_SPLITS repeatedly takes from the warehouse that covers the most of what is left, nearest first on ties.
+func allocate(
+	strategy model.AllocationStrategy,
+	warehouses []model.Warehouse,
+	stock []model.WarehouseStock,
+	demand []model.ShipmentItem,
+	postalCode string,
+) dto.AllocationPlan {
+	onHand := make(map[int64]map[string]int, len(warehouses))
+	for _, st := range stock {
+		if onHand[st.WarehouseID] == nil {
+			onHand[st.WarehouseID] = make(map[string]int)
+		}
+		onHand[st.WarehouseID][st.Sku] = st.Quantity
+	}
+
+	ordered := sortWarehouses(strategy, warehouses, postalCode)
+
+	left := make([]model.ShipmentItem, len(demand))
+	copy(left, demand)
+
+	// take removes what the warehouse can ship from what is left and returns its planned items
+	take := func(w model.Warehouse) []dto.ShipmentItemRequest {
+		var items []dto.ShipmentItemRequest
+		for i := range left {
+			quantity := min(left[i].Quantity, onHand[w.ID][left[i].Sku])
+			if quantity <= 0 {
+				continue
+			}
+			left[i].Quantity -= quantity
+			items = append(items, dto.ShipmentItemRequest{Sku: left[i].Sku, Quantity: quantity})
+		}
+		return items
+	}
+
+	plan := dto.AllocationPlan{Strategy: strategy}
+	addShipment := func(w model.Warehouse, items []dto.ShipmentItemRequest) {
+		if len(items) > 0 {
+			plan.Shipments = append(plan.Shipments, dto.PlannedShipment{WarehouseID: w.ID, WarehouseCode: w.Code, Items: items})
+		}
+	}
+
+	if strategy == model.AllocateFewestSplits {
+		used := make([]bool, len(ordered))
+		for {
+			best, bestUnits := -1, 0
+			for i, w := range ordered {
+				if used[i] {
+					continue
+				}
+				units := 0
+				for _, item := range left {
+					units += min(item.Quantity, onHand[w.ID][item.Sku])
+				}
+				if units > bestUnits {
+					best, bestUnits = i, units
+				}
+			}
+			if best < 0 {
+				break
+			}
+			used[best] = true
+			addShipment(ordered[best], take(ordered[best]))
+		}
+	} else {
+		for _, w := range ordered {
+			addShipment(w, take(w))
+		}
+	}
+
+	for _, item := range left {
+		if item.Quantity > 0 {
+			plan.Unallocated = append(plan.Unallocated, dto.ShipmentItemRequest{Sku: item.Sku, Quantity: item.Quantity})
+		}
+	}
+
+	return plan
+}
+
+// sortWarehouses orders the warehouses as the strategy prefers them.
+// PRIORITY goes by priority, the others by proximity to the destination then priority.
+func sortWarehouses(strategy model.AllocationStrategy, warehouses []model.Warehouse, postalCode string) []model.Warehouse {
+	ordered := make([]model.Warehouse, len(warehouses))
+	copy(ordered, warehouses)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if strategy != model.AllocatePriority {
+			if pa, pb := a.Proximity(postalCode), b.Proximity(postalCode); pa != pb {
+				return pa > pb
+			}
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+
+	return ordered
+}
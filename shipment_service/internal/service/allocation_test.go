@@ -0,0 +1,80 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	warehouses := []model.Warehouse{
+		{Base: model.Base{ID: 1}, Code: "BER", PostalCode: "10115", Priority: 2, Active: true},
+		{Base: model.Base{ID: 2}, Code: "MUC", PostalCode: "80331", Priority: 3, Active: true},
+		{Base: model.Base{ID: 3}, Code: "POT", PostalCode: "10999", Priority: 1, Active: true},
+	}
+	stock := []model.WarehouseStock{
+		{WarehouseID: 1, Sku: "SKU001", Quantity: 2},
+		{WarehouseID: 1, Sku: "SKU002", Quantity: 5},
+		{WarehouseID: 2, Sku: "SKU001", Quantity: 5},
+		{WarehouseID: 2, Sku: "SKU002", Quantity: 5},
+		{WarehouseID: 3, Sku: "SKU001", Quantity: 1},
+	}
+	demand := []model.ShipmentItem{
+		{Sku: "SKU001", Quantity: 4},
+		{Sku: "SKU002", Quantity: 1},
+		{Sku: "SKU003", Quantity: 2},
+	}
+	unallocated := []dto.ShipmentItemRequest{{Sku: "SKU003", Quantity: 2}}
+
+	// Test cases
+	tests := []struct {
+		name     string
+		strategy model.AllocationStrategy
+		want     []dto.PlannedShipment
+	}{
+		{
+			name:     "Nearest first",
+			strategy: model.AllocateNearest,
+			want: []dto.PlannedShipment{
+				{WarehouseID: 1, WarehouseCode: "BER", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 2}, {Sku: "SKU002", Quantity: 1}}},
+				{WarehouseID: 3, WarehouseCode: "POT", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}}},
+				{WarehouseID: 2, WarehouseCode: "MUC", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}}},
+			},
+		},
+		{
+			name:     "Priority first",
+			strategy: model.AllocatePriority,
+			want: []dto.PlannedShipment{
+				{WarehouseID: 3, WarehouseCode: "POT", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}}},
+				{WarehouseID: 1, WarehouseCode: "BER", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 2}, {Sku: "SKU002", Quantity: 1}}},
+				{WarehouseID: 2, WarehouseCode: "MUC", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}}},
+			},
+		},
+		{
+			name:     "Fewest splits",
+			strategy: model.AllocateFewestSplits,
+			want: []dto.PlannedShipment{
+				{WarehouseID: 2, WarehouseCode: "MUC", Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 4}, {Sku: "SKU002", Quantity: 1}}},
+			},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := allocate(tt.strategy, warehouses, stock, demand, "10117")
+			if !reflect.DeepEqual(plan.Shipments, tt.want) {
+				t.Errorf("allocate() shipments = %+v, want %+v", plan.Shipments, tt.want)
+			}
+			if !reflect.DeepEqual(plan.Unallocated, unallocated) {
+				t.Errorf("allocate() unallocated = %+v, want %+v", plan.Unallocated, unallocated)
+			}
+		})
+	}
+
+	// The demand is left untouched for the caller
+	if demand[0].Quantity != 4 {
+		t.Errorf("allocate() changed the demand to %+v", demand)
+	}
+}
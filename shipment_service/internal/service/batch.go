@@ -0,0 +1,83 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"sync"
+)
+
+const (
+	// MaxBatchLines is the most lines accepted in a batch of shipments
+	MaxBatchLines = 1000
+
+	defaultBatchConcurrency = 4
+)
+
+// BatchConfig configures the creation of shipments in batches
+type BatchConfig struct {
+	// Concurrency is how many orders of a batch are shipped at once, each shipment calls billing twice. Defaults to 4
+	Concurrency int
+}
+
+func (c BatchConfig) concurrency() int {
+	if c.Concurrency <= 0 {
+		return defaultBatchConcurrency
+	}
+	return c.Concurrency
+}
+
+// CreateShipments creates a shipment per line like CreateShipment and returns the result of every line, in order.
+// A line that fails does not stop the others. Lines of different orders are created concurrently, the lines of
+// an order one after the other, so each is validated against what the lines before it left to ship.
+func (s *ShipmentServiceImpl) CreateShipments(ctx context.Context, lines []dto.CreateShipmentRequest) []dto.ShipmentResult {
+	return createBatch(ctx, lines, s.batch.concurrency(), s.CreateShipment)
+}
+
+// createBatch creates the lines with create, the lines of an order one after the other and concurrency orders at once
+func createBatch(
+	ctx context.Context,
+	lines []dto.CreateShipmentRequest,
+	concurrency int,
+	create func(context.Context, dto.CreateShipmentRequest) (*model.Shipment, error),
+) []dto.ShipmentResult {
+	results := make([]dto.ShipmentResult, len(lines))
+
+	// Group the lines by order, keeping the order of the lines within each group
+	var groups [][]int
+	groupOf := make(map[int64]int)
+	for i, line := range lines {
+		group, ok := groupOf[line.OrderID]
+		if !ok {
+			group = len(groups)
+			groupOf[line.OrderID] = group
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], i)
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			for _, i := range group {
+				// Lines left when the caller gives up fail without being tried
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					continue
+				}
+				shipment, err := create(ctx, lines[i])
+				results[i] = dto.ShipmentResult{Shipment: shipment, Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
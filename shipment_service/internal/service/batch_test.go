@@ -0,0 +1,81 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateBatch(t *testing.T) {
+	var lines []dto.CreateShipmentRequest
+	for i := 0; i < 12; i++ {
+		lines = append(lines, dto.CreateShipmentRequest{OrderID: int64(i%4 + 1), WarehouseID: int64(i)})
+	}
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	inOrder := make(map[int64]bool)
+	last := make(map[int64]int64)
+	create := func(_ context.Context, req dto.CreateShipmentRequest) (*model.Shipment, error) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		if inOrder[req.OrderID] {
+			t.Errorf("two lines of order %d ran at once", req.OrderID)
+		}
+		inOrder[req.OrderID] = true
+		if previous, ok := last[req.OrderID]; ok && previous > req.WarehouseID {
+			t.Errorf("line %d of order %d ran after line %d", req.WarehouseID, req.OrderID, previous)
+		}
+		last[req.OrderID] = req.WarehouseID
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		inOrder[req.OrderID] = false
+		mu.Unlock()
+		if req.WarehouseID == 5 {
+			return nil, ErrOutOfStock
+		}
+		shipment := &model.Shipment{OrderID: req.OrderID}
+		shipment.ID = req.WarehouseID
+		return shipment, nil
+	}
+
+	results := createBatch(context.Background(), lines, 2, create)
+	if len(results) != len(lines) {
+		t.Fatalf("results = %d, want %d", len(results), len(lines))
+	}
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak)
+	}
+	for i, result := range results {
+		if i == 5 {
+			if !errors.Is(result.Err, ErrOutOfStock) || result.Shipment != nil {
+				t.Errorf("result 5 = %+v, want the line to fail alone", result)
+			}
+			continue
+		}
+		if result.Err != nil || result.Shipment == nil || result.Shipment.ID != int64(i) {
+			t.Errorf("result %d = %+v, want the shipment of the line", i, result)
+		}
+	}
+}
+
+func TestCreateBatch_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := []dto.CreateShipmentRequest{{OrderID: 1}, {OrderID: 1}}
+	results := createBatch(ctx, lines, 1, func(context.Context, dto.CreateShipmentRequest) (*model.Shipment, error) {
+		cancel()
+		return &model.Shipment{}, nil
+	})
+	if results[0].Err != nil || !errors.Is(results[1].Err, context.Canceled) {
+		t.Errorf("results = %+v, want the line after the cancellation to fail", results)
+	}
+}
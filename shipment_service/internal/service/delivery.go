@@ -0,0 +1,211 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/blob"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDeliveryFileSize is the largest signature or photo accepted with a delivery proof, in bytes
+	MaxDeliveryFileSize = 10 << 20
+	// MaxDeliveryFiles is the most files accepted with a delivery proof
+	MaxDeliveryFiles = 10
+)
+
+// deliveryContentTypes are the image types accepted as evidence and the extension they are stored with
+var deliveryContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
+// ConfirmDelivery stores the signature and photos of a delivery with the recipient and where it happened,
+// and moves the shipment to DELIVERED. A shipment a carrier already reported as delivered only gets the proof attached.
+// A shipment has at most one delivery proof.
+func (s *ShipmentServiceImpl) ConfirmDelivery(ctx context.Context, req dto.ConfirmDeliveryRequest) (*model.Shipment, error) {
+	if err := validateDelivery(req); err != nil {
+		return nil, err
+	}
+
+	shipment, err := s.GetShipment(ctx, req.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.DeliveryProof != nil {
+		return nil, fmt.Errorf("%w: shipment %d already has a delivery proof", ErrInvalidTransition, shipment.ID)
+	}
+
+	from := shipment.Status
+	if from != model.Delivered && !from.CanTransitionTo(model.Delivered) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, model.Delivered)
+	}
+
+	deliveredAt := req.DeliveredAt
+	if deliveredAt.IsZero() {
+		deliveredAt = time.Now()
+	}
+	proof := &model.DeliveryProof{
+		RecipientName: strings.TrimSpace(req.RecipientName),
+		Latitude:      req.Latitude,
+		Longitude:     req.Longitude,
+		DeliveredAt:   deliveredAt,
+	}
+
+	if proof.Files, err = s.storeDeliveryFiles(ctx, shipment.ID, req.Files); err != nil {
+		return nil, err
+	}
+
+	var event *model.ShipmentEvent
+	if from != model.Delivered {
+		event = &model.ShipmentEvent{
+			Status:    model.Delivered,
+			Timestamp: deliveredAt,
+			Location:  fmt.Sprintf("%.6f,%.6f", req.Latitude, req.Longitude),
+			Actor:     req.Actor,
+			Note:      req.Note,
+		}
+		if event.Note == "" {
+			event.Note = "Delivered to " + proof.RecipientName
+		}
+		event.PreviousStatus = from
+	}
+
+	saved, err := s.shipmentRepo.SaveDeliveryProof(ctx, shipment, from, event, proof)
+	if err != nil {
+		s.deleteDeliveryFiles(ctx, proof.Files)
+		return nil, fmt.Errorf("failed to save delivery proof of shipment %d: %w", shipment.ID, err)
+	}
+	if !saved {
+		// Another update moved the shipment on since it was read
+		s.deleteDeliveryFiles(ctx, proof.Files)
+		return nil, fmt.Errorf("%w: shipment %d is no longer %s", ErrInvalidTransition, shipment.ID, from)
+	}
+	if event != nil {
+		s.hub.publish(shipment.OrderID)
+	}
+
+	return shipment, nil
+}
+
+// validateDelivery checks the recipient, the position and that the files are images within the limits.
+// A proof needs at least one file and at most one signature.
+func validateDelivery(req dto.ConfirmDeliveryRequest) error {
+	if strings.TrimSpace(req.RecipientName) == "" {
+		return fmt.Errorf("%w: recipient name is required", ErrInvalidDelivery)
+	}
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+		return fmt.Errorf("%w: %v,%v is not a valid position", ErrInvalidDelivery, req.Latitude, req.Longitude)
+	}
+	if req.DeliveredAt.After(time.Now().Add(5 * time.Minute)) {
+		return fmt.Errorf("%w: delivery time is in the future", ErrInvalidDelivery)
+	}
+
+	if len(req.Files) == 0 {
+		return fmt.Errorf("%w: a signature or photo is required", ErrInvalidDelivery)
+	}
+	if len(req.Files) > MaxDeliveryFiles {
+		return fmt.Errorf("%w: at most %d files are accepted", ErrInvalidDelivery, MaxDeliveryFiles)
+	}
+
+	signatures := 0
+	for i, file := range req.Files {
+		if !file.Kind.IsValid() {
+			return fmt.Errorf("%w: file %d has unknown kind %q", ErrInvalidDelivery, i+1, file.Kind)
+		}
+		if file.Kind == model.DeliverySignature {
+			signatures++
+		}
+		if len(file.Content) == 0 || len(file.Content) > MaxDeliveryFileSize {
+			return fmt.Errorf("%w: file %d must be between 1 byte and %d bytes", ErrInvalidDelivery, i+1, MaxDeliveryFileSize)
+		}
+		if contentType := http.DetectContentType(file.Content); deliveryContentTypes[contentType] == "" {
+			return fmt.Errorf("%w: file %d is %s, only PNG, JPEG and WebP images are accepted", ErrInvalidDelivery, i+1, contentType)
+		}
+	}
+	if signatures > 1 {
+		return fmt.Errorf("%w: at most one signature is accepted", ErrInvalidDelivery)
+	}
+
+	return nil
+}
+
+// storeDeliveryFiles puts the files in the blob store under keys unique to this upload,
+// so a concurrent upload for the same shipment cannot overwrite or delete them
+func (s *ShipmentServiceImpl) storeDeliveryFiles(ctx context.Context, shipmentID int64, uploads []dto.DeliveryUpload) ([]model.DeliveryFile, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate delivery file keys: %w", err)
+	}
+
+	files := make([]model.DeliveryFile, 0, len(uploads))
+	for i, upload := range uploads {
+		contentType := http.DetectContentType(upload.Content)
+		digest := sha256.Sum256(upload.Content)
+		file := model.DeliveryFile{
+			Kind: upload.Kind,
+			BlobKey: fmt.Sprintf("shipments/%d/delivery/%s-%d-%s%s",
+				shipmentID, hex.EncodeToString(token), i+1, strings.ToLower(string(upload.Kind)), deliveryContentTypes[contentType]),
+			ContentType: contentType,
+			Sha256:      hex.EncodeToString(digest[:]),
+		}
+
+		size, err := s.blobs.Put(ctx, file.BlobKey, bytes.NewReader(upload.Content))
+		if err != nil {
+			s.deleteDeliveryFiles(ctx, files)
+			return nil, fmt.Errorf("failed to store delivery file %d of shipment %d: %w", i+1, shipmentID, err)
+		}
+		file.SizeBytes = size
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// deleteDeliveryFiles removes the stored files of a delivery proof that could not be saved
+func (s *ShipmentServiceImpl) deleteDeliveryFiles(ctx context.Context, files []model.DeliveryFile) {
+	for _, file := range files {
+		if err := s.blobs.Delete(context.WithoutCancel(ctx), file.BlobKey); err != nil {
+			log.Printf("Failed to delete delivery file %s: %v", file.BlobKey, err)
+		}
+	}
+}
+
+// OpenDeliveryFile returns a signature or photo of a shipment's delivery proof with its content, the caller closes the content
+func (s *ShipmentServiceImpl) OpenDeliveryFile(ctx context.Context, shipmentID int64, fileID int64) (*model.DeliveryFile, io.ReadCloser, error) {
+	shipment, err := s.GetShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if shipment.DeliveryProof != nil {
+		for _, file := range shipment.DeliveryProof.Files {
+			if file.ID != fileID {
+				continue
+			}
+
+			content, err := s.blobs.Get(ctx, file.BlobKey)
+			if errors.Is(err, blob.ErrNotFound) {
+				return nil, nil, fmt.Errorf("%w: content of file %d is missing", ErrDeliveryFileNotFound, fileID)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to open delivery file %d: %w", fileID, err)
+			}
+			return &file, content, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: shipment %d has no delivery file %d", ErrDeliveryFileNotFound, shipmentID, fileID)
+}
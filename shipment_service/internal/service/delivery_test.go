@@ -0,0 +1,111 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/blob"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	pngContent  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegContent = []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+)
+
+func TestValidateDelivery(t *testing.T) {
+	valid := dto.ConfirmDeliveryRequest{
+		ShipmentID:    1,
+		RecipientName: "Nguyen Van A",
+		Latitude:      21.0285,
+		Longitude:     105.8542,
+		Files: []dto.DeliveryUpload{
+			{Kind: model.DeliverySignature, Content: pngContent},
+			{Kind: model.DeliveryPhoto, Content: jpegContent},
+		},
+	}
+	if err := validateDelivery(valid); err != nil {
+		t.Fatalf("validateDelivery returned %v for a valid proof", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *dto.ConfirmDeliveryRequest)
+	}{
+		{"missing recipient", func(req *dto.ConfirmDeliveryRequest) { req.RecipientName = "  " }},
+		{"latitude out of range", func(req *dto.ConfirmDeliveryRequest) { req.Latitude = 91 }},
+		{"longitude out of range", func(req *dto.ConfirmDeliveryRequest) { req.Longitude = -181 }},
+		{"delivered in the future", func(req *dto.ConfirmDeliveryRequest) { req.DeliveredAt = time.Now().Add(time.Hour) }},
+		{"no files", func(req *dto.ConfirmDeliveryRequest) { req.Files = nil }},
+		{"unknown kind", func(req *dto.ConfirmDeliveryRequest) { req.Files[1].Kind = "VIDEO" }},
+		{"two signatures", func(req *dto.ConfirmDeliveryRequest) { req.Files[1].Kind = model.DeliverySignature }},
+		{"empty file", func(req *dto.ConfirmDeliveryRequest) { req.Files[1].Content = nil }},
+		{"not an image", func(req *dto.ConfirmDeliveryRequest) { req.Files[1].Content = []byte("<html></html>") }},
+		{"too many files", func(req *dto.ConfirmDeliveryRequest) {
+			for len(req.Files) <= MaxDeliveryFiles {
+				req.Files = append(req.Files, dto.DeliveryUpload{Kind: model.DeliveryPhoto, Content: jpegContent})
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			req.Files = append([]dto.DeliveryUpload(nil), valid.Files...)
+			tt.modify(&req)
+			if err := validateDelivery(req); !errors.Is(err, ErrInvalidDelivery) {
+				t.Errorf("validateDelivery returned %v, want ErrInvalidDelivery", err)
+			}
+		})
+	}
+}
+
+func TestStoreDeliveryFiles(t *testing.T) {
+	store, err := blob.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore returned %v", err)
+	}
+	s := &ShipmentServiceImpl{blobs: store}
+	ctx := context.Background()
+
+	files, err := s.storeDeliveryFiles(ctx, 7, []dto.DeliveryUpload{
+		{Kind: model.DeliverySignature, Content: pngContent},
+		{Kind: model.DeliveryPhoto, Content: jpegContent},
+	})
+	if err != nil {
+		t.Fatalf("storeDeliveryFiles returned %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("storeDeliveryFiles stored %d files, want 2", len(files))
+	}
+
+	signature := files[0]
+	if !strings.HasPrefix(signature.BlobKey, "shipments/7/delivery/") || !strings.HasSuffix(signature.BlobKey, "-1-signature.png") {
+		t.Errorf("signature stored under %q", signature.BlobKey)
+	}
+	if signature.ContentType != "image/png" || signature.SizeBytes != int64(len(pngContent)) || len(signature.Sha256) != 64 {
+		t.Errorf("signature recorded as %+v", signature)
+	}
+	if files[1].ContentType != "image/jpeg" || !strings.HasSuffix(files[1].BlobKey, "-2-photo.jpg") {
+		t.Errorf("photo recorded as %+v", files[1])
+	}
+
+	content, err := store.Get(ctx, files[1].BlobKey)
+	if err != nil {
+		t.Fatalf("Get returned %v", err)
+	}
+	stored, _ := io.ReadAll(content)
+	content.Close()
+	if !bytes.Equal(stored, jpegContent) {
+		t.Errorf("photo stored as %q, want %q", stored, jpegContent)
+	}
+
+	s.deleteDeliveryFiles(ctx, files)
+	if _, err := store.Get(ctx, signature.BlobKey); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("Get after deleteDeliveryFiles returned %v, want ErrNotFound", err)
+	}
+}
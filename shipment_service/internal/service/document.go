@@ -0,0 +1,67 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/label"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// DocumentConfig configures the labels and packing slips printed for shipments
+type DocumentConfig struct {
+	// Sender is the return address printed on labels
+	Sender model.Address
+}
+
+// GetShippingDocument renders the label of a shipment as PDF or ZPL, or its packing slip as PDF.
+// Packed shipments get one label per parcel.
+func (s *ShipmentServiceImpl) GetShippingDocument(ctx context.Context, id int64, documentType string, format string) (*dto.ShippingDocument, error) {
+	documentType = strings.ToUpper(documentType)
+	format = strings.ToUpper(format)
+	if format == "" {
+		format = dto.FormatPDF
+	}
+
+	switch {
+	case documentType == dto.DocumentLabel && (format == dto.FormatPDF || format == dto.FormatZPL):
+	case documentType == dto.DocumentPackingSlip && format == dto.FormatPDF:
+	default:
+		return nil, fmt.Errorf("%w: %s is not available as %s", ErrInvalidDocument, documentType, format)
+	}
+
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data := label.Data{Shipment: shipment, From: s.documents.Sender}
+	if shipment.WarehouseID != 0 {
+		// The label can still be printed without the warehouse code
+		if warehouse, err := s.warehouseRepo.GetWarehouse(ctx, shipment.WarehouseID); err != nil {
+			log.Printf("Failed to get warehouse %d of shipment %d: %v", shipment.WarehouseID, id, err)
+		} else {
+			data.WarehouseCode = warehouse.Code
+		}
+	}
+
+	name := fmt.Sprintf("shipment-%d-%s", id, strings.ReplaceAll(strings.ToLower(documentType), "_", "-"))
+	document := &dto.ShippingDocument{Filename: name + ".pdf", ContentType: "application/pdf"}
+	switch {
+	case format == dto.FormatZPL:
+		document.Filename = name + ".zpl"
+		document.ContentType = "application/zpl"
+		document.Content = label.ShippingLabelZPL(data)
+	case documentType == dto.DocumentLabel:
+		document.Content, err = label.ShippingLabelPDF(data)
+	default:
+		document.Content, err = label.PackingSlipPDF(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s of shipment %d: %w", documentType, id, err)
+	}
+
+	return document, nil
+}
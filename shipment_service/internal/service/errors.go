@@ -0,0 +1,30 @@
+package service
+
+// ErrorKind classifies domain errors by what the caller can do about them
+type ErrorKind int
+
+const (
+	// KindInvalid is a request the caller has to correct
+	KindInvalid ErrorKind = iota + 1
+	// KindNotFound is a request for something that does not exist
+	KindNotFound
+	// KindConflict is a request the current state of the shipment or return does not allow
+	KindConflict
+)
+
+// Error is a domain error. The errors of this package are compared with errors.Is,
+// wrapping them with fmt.Errorf and %w adds detail to the message without losing the kind.
+type Error struct {
+	Kind ErrorKind
+	// Reason is the machine-readable cause, such as SHIPMENT_NOT_FOUND
+	Reason  string
+	message string
+}
+
+func newError(kind ErrorKind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/carrier"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"fmt"
+	"math"
+)
+
+// PackShipment records the parcels a shipment is packed in, replacing the ones recorded before.
+// Parcels can change until the shipment is packed, moving it to PACKED is left to a status update.
+func (s *ShipmentServiceImpl) PackShipment(ctx context.Context, id int64, parcels []dto.ParcelRequest) (*model.Shipment, error) {
+	if len(parcels) == 0 {
+		return nil, fmt.Errorf("%w: at least one parcel is required", ErrInvalidParcels)
+	}
+
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch shipment.Status {
+	case model.Created, model.Confirmed, model.Picked:
+	default:
+		return nil, fmt.Errorf("%w: shipment %d is %s, its parcels can no longer change", ErrInvalidTransition, id, shipment.Status)
+	}
+
+	packed, err := buildParcels(parcels, shipment.Items, s.shipping.DimDivisor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.shipmentRepo.ReplaceParcels(ctx, id, packed); err != nil {
+		return nil, fmt.Errorf("failed to record parcels of shipment %d: %w", id, err)
+	}
+	shipment.Parcels = packed
+
+	return shipment, nil
+}
+
+// buildParcels validates the parcels and computes their weights.
+// Every unit of the items must be packed in exactly one parcel.
+func buildParcels(requests []dto.ParcelRequest, items []model.ShipmentItem, dimDivisor float64) ([]model.Parcel, error) {
+	shipped := make(map[string]int, len(items))
+	for _, item := range items {
+		shipped[item.Sku] += item.Quantity
+	}
+
+	packed := make(map[string]int, len(items))
+	parcels := make([]model.Parcel, len(requests))
+	for i, req := range requests {
+		if req.WeightKg <= 0 || math.IsInf(req.WeightKg, 0) {
+			return nil, fmt.Errorf("%w: weight of parcel %d must be greater than 0", ErrInvalidParcels, i+1)
+		}
+		for _, value := range []float64{req.LengthCm, req.WidthCm, req.HeightCm} {
+			if !isNonNegative(value) {
+				return nil, fmt.Errorf("%w: size of parcel %d must be non-negative numbers", ErrInvalidParcels, i+1)
+			}
+		}
+		if len(req.Items) == 0 {
+			return nil, fmt.Errorf("%w: parcel %d is empty", ErrInvalidParcels, i+1)
+		}
+
+		parcel := model.Parcel{
+			Sequence: i + 1,
+			WeightKg: req.WeightKg,
+			LengthCm: req.LengthCm,
+			WidthCm:  req.WidthCm,
+			HeightCm: req.HeightCm,
+		}
+		parcel.CalculateWeights(dimDivisor)
+
+		// Quantities of a SKU listed twice in a parcel are merged, the item key is the SKU
+		quantities := make(map[string]int, len(req.Items))
+		for _, item := range req.Items {
+			if _, ok := shipped[item.Sku]; !ok {
+				return nil, fmt.Errorf("%w: SKU %q of parcel %d is not in the shipment", ErrInvalidParcels, item.Sku, i+1)
+			}
+			if item.Quantity <= 0 {
+				return nil, fmt.Errorf("%w: quantity of SKU %s in parcel %d must be greater than 0", ErrInvalidParcels, item.Sku, i+1)
+			}
+			if _, ok := quantities[item.Sku]; !ok {
+				parcel.Items = append(parcel.Items, model.ParcelItem{Sku: item.Sku})
+			}
+			quantities[item.Sku] += item.Quantity
+			packed[item.Sku] += item.Quantity
+		}
+		for j := range parcel.Items {
+			parcel.Items[j].Quantity = quantities[parcel.Items[j].Sku]
+		}
+
+		parcels[i] = parcel
+	}
+
+	for _, item := range items {
+		if packed[item.Sku] != shipped[item.Sku] {
+			return nil, fmt.Errorf("%w: %d of SKU %s packed, the shipment holds %d",
+				ErrInvalidParcels, packed[item.Sku], item.Sku, shipped[item.Sku])
+		}
+	}
+
+	return parcels, nil
+}
+
+// parcelsWeight returns the chargeable weight of the parcels in kilograms
+func parcelsWeight(parcels []model.Parcel) float64 {
+	weight := 0.0
+	for _, parcel := range parcels {
+		weight += parcel.ChargeableWeightKg
+	}
+	return math.Round(weight*1000) / 1000
+}
+
+// carrierParcels converts parcels to the packages handed to a carrier
+func carrierParcels(parcels []model.Parcel) []carrier.Parcel {
+	if len(parcels) == 0 {
+		return nil
+	}
+	converted := make([]carrier.Parcel, len(parcels))
+	for i, parcel := range parcels {
+		converted[i] = carrier.Parcel{
+			WeightKg: parcel.WeightKg,
+			LengthCm: parcel.LengthCm,
+			WidthCm:  parcel.WidthCm,
+			HeightCm: parcel.HeightCm,
+		}
+	}
+	return converted
+}
+
+// mergeItems merges the requested lines of a SKU, the first line of a SKU sets its position
+func mergeItems(items []dto.ShipmentItemRequest) []model.ShipmentItem {
+	positions := make(map[string]int, len(items))
+	var merged []model.ShipmentItem
+	for _, item := range items {
+		if i, ok := positions[item.Sku]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		positions[item.Sku] = len(merged)
+		merged = append(merged, model.ShipmentItem{Sku: item.Sku, Quantity: item.Quantity})
+	}
+	return merged
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuildParcels(t *testing.T) {
+	items := []model.ShipmentItem{{Sku: "SKU001", Quantity: 3}, {Sku: "SKU002", Quantity: 1}}
+
+	parcels, err := buildParcels([]dto.ParcelRequest{
+		{WeightKg: 2, LengthCm: 60, WidthCm: 40, HeightCm: 40, Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}, {Sku: "SKU001", Quantity: 1}}},
+		{WeightKg: 1.5, Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}, {Sku: "SKU002", Quantity: 1}}},
+	}, items, 5000)
+	if err != nil {
+		t.Fatalf("buildParcels() error = %v", err)
+	}
+	if len(parcels) != 2 || parcels[0].Sequence != 1 || parcels[1].Sequence != 2 {
+		t.Fatalf("buildParcels() = %+v, want two parcels numbered from 1", parcels)
+	}
+	if want := []model.ParcelItem{{Sku: "SKU001", Quantity: 2}}; !reflect.DeepEqual(parcels[0].Items, want) {
+		t.Errorf("buildParcels() first parcel items = %+v, want %+v", parcels[0].Items, want)
+	}
+	if got := parcelsWeight(parcels); got != 20.7 {
+		t.Errorf("parcelsWeight() = %v, want 20.7", got)
+	}
+
+	// Test cases
+	tests := []struct {
+		name    string
+		parcels []dto.ParcelRequest
+	}{
+		{name: "Unit left unpacked", parcels: []dto.ParcelRequest{{WeightKg: 1, Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 3}}}}},
+		{name: "Unit packed twice", parcels: []dto.ParcelRequest{{WeightKg: 1, Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 4}, {Sku: "SKU002", Quantity: 1}}}}},
+		{name: "SKU not in the shipment", parcels: []dto.ParcelRequest{{WeightKg: 1, Items: []dto.ShipmentItemRequest{{Sku: "SKU009", Quantity: 1}}}}},
+		{name: "Missing weight", parcels: []dto.ParcelRequest{{Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 3}, {Sku: "SKU002", Quantity: 1}}}}},
+		{name: "Negative size", parcels: []dto.ParcelRequest{{WeightKg: 1, LengthCm: -1, Items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 3}, {Sku: "SKU002", Quantity: 1}}}}},
+		{name: "Empty parcel", parcels: []dto.ParcelRequest{{WeightKg: 1}}},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildParcels(tt.parcels, items, 5000); !errors.Is(err, ErrInvalidParcels) {
+				t.Errorf("buildParcels() error = %v, want ErrInvalidParcels", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// creditNoteIssuer issues credit notes in billing, it is the part of the billing client returns use
+type creditNoteIssuer interface {
+	CreateCreditNote(ctx context.Context, req billing.CreateCreditNoteRequest) (*billing.CreateCreditNoteResponse, error)
+}
+
+type ReturnServiceImpl struct {
+	returnRepo    repository.ReturnRepository
+	billingClient creditNoteIssuer
+}
+
+func NewReturnService(returnRepo repository.ReturnRepository) ReturnService {
+	return &ReturnServiceImpl{
+		returnRepo:    returnRepo,
+		billingClient: billing.NewBillingClient(),
+	}
+}
+
+// RequestReturn opens a return for items of a delivered shipment.
+// The quantity of a SKU cannot exceed what was shipped less what other returns that were not rejected hold.
+// The quantities are checked with the shipment locked, so concurrent requests cannot return an item twice.
+func (s *ReturnServiceImpl) RequestReturn(ctx context.Context, shipmentID int64, reason string, items []dto.ShipmentItemRequest) (*model.Return, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidReturn)
+	}
+
+	// Quantities of a SKU listed twice are merged, the item key is the SKU
+	quantities := make(map[string]int, len(items))
+	var skus []string
+	for _, item := range items {
+		if item.Sku == "" {
+			return nil, fmt.Errorf("%w: SKU is required for all items", ErrInvalidReturn)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: quantity must be greater than 0 for SKU %s", ErrInvalidReturn, item.Sku)
+		}
+		if _, ok := quantities[item.Sku]; !ok {
+			skus = append(skus, item.Sku)
+		}
+		quantities[item.Sku] += item.Quantity
+	}
+
+	ret, err := s.returnRepo.CreateForShipment(ctx, shipmentID, func(shipment *model.Shipment, existing []model.Return) (*model.Return, error) {
+		return newReturn(shipment, existing, reason, skus, quantities)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShipmentNotFound
+		}
+		if errors.Is(err, ErrInvalidTransition) || errors.Is(err, ErrInvalidReturn) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	return ret, nil
+}
+
+// newReturn makes the return of the quantities of skus, checking them against what the shipment
+// shipped and what its existing returns hold
+func newReturn(shipment *model.Shipment, existing []model.Return, reason string, skus []string, quantities map[string]int) (*model.Return, error) {
+	if shipment.Status != model.Delivered {
+		return nil, fmt.Errorf("%w: shipment %d is %s, only delivered shipments can be returned", ErrInvalidTransition, shipment.ID, shipment.Status)
+	}
+
+	shipped := make(map[string]int, len(shipment.Items))
+	for _, item := range shipment.Items {
+		shipped[item.Sku] += item.Quantity
+	}
+
+	returned := make(map[string]int)
+	for _, ret := range existing {
+		if ret.Status == model.ReturnRejected {
+			continue
+		}
+		for _, item := range ret.Items {
+			returned[item.Sku] += item.Quantity
+		}
+	}
+
+	ret := &model.Return{
+		ShipmentID: shipment.ID,
+		OrderID:    shipment.OrderID,
+		Status:     model.ReturnRequested,
+		Reason:     reason,
+	}
+	for _, sku := range skus {
+		quantity := quantities[sku]
+		if remaining := shipped[sku] - returned[sku]; quantity > remaining {
+			return nil, fmt.Errorf("%w: quantity %d for SKU %s exceeds the %d shipped and not returned yet",
+				ErrInvalidReturn, quantity, sku, remaining)
+		}
+		ret.Items = append(ret.Items, model.ReturnItem{Sku: sku, Quantity: quantity})
+	}
+
+	return ret, nil
+}
+
+// GetReturn retrieves a return with its items
+func (s *ReturnServiceImpl) GetReturn(ctx context.Context, id int64) (*model.Return, error) {
+	ret, err := s.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return %d: %w", id, err)
+	}
+	return ret, nil
+}
+
+// ApproveReturn accepts a requested return so the customer can send the items
+func (s *ReturnServiceImpl) ApproveReturn(ctx context.Context, id int64, note string) (*model.Return, error) {
+	return s.move(ctx, id, model.ReturnApproved, note)
+}
+
+// RejectReturn refuses a return before its items are received, its quantities can be returned again
+func (s *ReturnServiceImpl) RejectReturn(ctx context.Context, id int64, note string) (*model.Return, error) {
+	return s.move(ctx, id, model.ReturnRejected, note)
+}
+
+// ReceiveReturn records the items as back in the warehouse and has billing credit their invoiced amount.
+// When crediting fails the return stays received, receiving it again retries the credit, also once it was inspected.
+func (s *ReturnServiceImpl) ReceiveReturn(ctx context.Context, id int64, note string) (*model.Return, error) {
+	ret, err := s.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A received or inspected return without a credit note only retries the credit
+	if !ret.AwaitsCredit() {
+		now := time.Now()
+		ret.ReceivedAt = &now
+		if err := s.transition(ctx, ret, model.ReturnReceived, note); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.credit(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// InspectReturn records the result of inspecting the received items
+func (s *ReturnServiceImpl) InspectReturn(ctx context.Context, id int64, note string) (*model.Return, error) {
+	return s.move(ctx, id, model.ReturnInspected, note)
+}
+
+// move loads the return and moves it to the next status
+func (s *ReturnServiceImpl) move(ctx context.Context, id int64, status model.ReturnStatus, note string) (*model.Return, error) {
+	ret, err := s.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transition(ctx, ret, status, note); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// transition moves the return from its current status to the next one, as long as no other update moved it first
+func (s *ReturnServiceImpl) transition(ctx context.Context, ret *model.Return, status model.ReturnStatus, note string) error {
+	from := ret.Status
+	if !from.CanTransitionTo(status) {
+		return fmt.Errorf("%w: return %d cannot move from %s to %s", ErrInvalidTransition, ret.ID, from, status)
+	}
+
+	ret.Status = status
+	ret.Note = note
+	updated, err := s.returnRepo.UpdateStatus(ctx, ret, from)
+	if err != nil {
+		return fmt.Errorf("failed to update return status: %w", err)
+	}
+	if !updated {
+		return fmt.Errorf("%w: return %d is no longer %s", ErrInvalidTransition, ret.ID, from)
+	}
+
+	return nil
+}
+
+// credit has billing reverse the invoiced amount of the returned items and records the credit note
+func (s *ReturnServiceImpl) credit(ctx context.Context, ret *model.Return) error {
+	items := make([]billing.InvoiceItemRequest, len(ret.Items))
+	for i, item := range ret.Items {
+		items[i] = billing.InvoiceItemRequest{
+			Sku:      item.Sku,
+			Quantity: int32(item.Quantity),
+		}
+	}
+
+	resp, err := s.billingClient.CreateCreditNote(ctx, billing.CreateCreditNoteRequest{
+		ShipmentID: ret.ShipmentID,
+		Reference:  ret.CreditReference(),
+		Reason:     ret.Reason,
+		Items:      items,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to credit return %d: %w", ret.ID, err)
+	}
+
+	// A concurrent retry may have recorded it first, billing returned the same note to both
+	if _, err := s.returnRepo.RecordCredit(ctx, ret.ID, resp.CreditNoteID, resp.Amount); err != nil {
+		return fmt.Errorf("failed to record credit note of return %d: %w", ret.ID, err)
+	}
+	ret.CreditNoteID = resp.CreditNoteID
+	ret.CreditedAmount = resp.Amount
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// returnRepository keeps the returns of one shipment in memory, the mutex stands for the lock on the shipment row
+type returnRepository struct {
+	repository.ReturnRepository
+
+	mu       sync.Mutex
+	shipment model.Shipment
+	returns  []model.Return
+}
+
+func (r *returnRepository) CreateForShipment(
+	ctx context.Context,
+	shipmentID int64,
+	build func(shipment *model.Shipment, existing []model.Return) (*model.Return, error),
+) (*model.Return, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if shipmentID != r.shipment.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	ret, err := build(&r.shipment, r.returns)
+	if err != nil {
+		return nil, err
+	}
+	ret.ID = int64(len(r.returns) + 1)
+	r.returns = append(r.returns, *ret)
+	return ret, nil
+}
+
+func (r *returnRepository) find(id int64) *model.Return {
+	for i := range r.returns {
+		if r.returns[i].ID == id {
+			return &r.returns[i]
+		}
+	}
+	return nil
+}
+
+func (r *returnRepository) GetByID(ctx context.Context, id int64) (*model.Return, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ret := r.find(id)
+	if ret == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	stored := *ret
+	return &stored, nil
+}
+
+func (r *returnRepository) UpdateStatus(ctx context.Context, ret *model.Return, from model.ReturnStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.find(ret.ID)
+	if stored == nil || stored.Status != from {
+		return false, nil
+	}
+	stored.Status, stored.Note, stored.ReceivedAt = ret.Status, ret.Note, ret.ReceivedAt
+	return true, nil
+}
+
+func (r *returnRepository) RecordCredit(ctx context.Context, id int64, creditNoteID int64, amount float64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.find(id)
+	if stored == nil || stored.CreditNoteID != 0 {
+		return false, nil
+	}
+	stored.CreditNoteID, stored.CreditedAmount = creditNoteID, amount
+	return true, nil
+}
+
+// creditNotes stands for billing, which credits the returned items at the price they were ordered at
+type creditNotes struct {
+	err      error
+	requests []billing.CreateCreditNoteRequest
+	// inFlight runs while the credit note is being issued
+	inFlight func()
+}
+
+func (c *creditNotes) CreateCreditNote(ctx context.Context, req billing.CreateCreditNoteRequest) (*billing.CreateCreditNoteResponse, error) {
+	c.requests = append(c.requests, req)
+	if c.inFlight != nil {
+		c.inFlight()
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &billing.CreateCreditNoteResponse{CreditNoteID: 30, Amount: 40}, nil
+}
+
+func newReturnRepository() *returnRepository {
+	return &returnRepository{
+		shipment: model.Shipment{
+			Base:    model.Base{ID: 1},
+			OrderID: 5,
+			Status:  model.Delivered,
+			Items:   []model.ShipmentItem{{Sku: "SKU001", Quantity: 2}, {Sku: "SKU002", Quantity: 1}},
+		},
+		returns: []model.Return{
+			{Base: model.Base{ID: 1}, ShipmentID: 1, Status: model.ReturnRejected, Items: []model.ReturnItem{{Sku: "SKU001", Quantity: 2}}},
+			{Base: model.Base{ID: 2}, ShipmentID: 1, Status: model.ReturnApproved, Reason: "damaged", Items: []model.ReturnItem{{Sku: "SKU001", Quantity: 1}}},
+		},
+	}
+}
+
+func TestRequestReturn(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int64
+		items   []dto.ShipmentItemRequest
+		wantErr error
+	}{
+		{name: "Rejected returns can be returned again", id: 1, items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}, {Sku: "SKU002", Quantity: 1}}},
+		{name: "SKU listed twice beyond what is left", id: 1, items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}, {Sku: "SKU001", Quantity: 1}}, wantErr: ErrInvalidReturn},
+		{name: "SKU not shipped", id: 1, items: []dto.ShipmentItemRequest{{Sku: "SKU003", Quantity: 1}}, wantErr: ErrInvalidReturn},
+		{name: "Unknown shipment", id: 2, items: []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}}, wantErr: ErrShipmentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewReturnService(newReturnRepository())
+
+			ret, err := svc.RequestReturn(context.Background(), tt.id, "damaged", tt.items)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequestReturn() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (ret.OrderID != 5 || ret.Status != model.ReturnRequested || len(ret.Items) != len(tt.items)) {
+				t.Errorf("RequestReturn() = %+v", ret)
+			}
+		})
+	}
+}
+
+func TestRequestReturn_ConcurrentRequests(t *testing.T) {
+	repo := newReturnRepository()
+	svc := NewReturnService(repo)
+
+	// One SKU001 is left to return, only one of the concurrent requests gets it
+	const requests = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.RequestReturn(context.Background(), 1, "damaged", []dto.ShipmentItemRequest{{Sku: "SKU001", Quantity: 1}})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrInvalidReturn):
+			t.Errorf("RequestReturn() error = %v, want %v", err, ErrInvalidReturn)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d returns created, want 1", created)
+	}
+}
+
+func TestReceiveReturn(t *testing.T) {
+	repo := newReturnRepository()
+	billingClient := &creditNotes{}
+	svc := &ReturnServiceImpl{returnRepo: repo, billingClient: billingClient}
+
+	ret, err := svc.ReceiveReturn(context.Background(), 2, "all items back")
+	if err != nil {
+		t.Fatalf("ReceiveReturn() error = %v", err)
+	}
+
+	if len(billingClient.requests) != 1 {
+		t.Fatalf("%d credit notes requested, want 1", len(billingClient.requests))
+	}
+	req := billingClient.requests[0]
+	if req.ShipmentID != 1 || req.Reference != "RMA-2" || len(req.Items) != 1 || req.Items[0].Sku != "SKU001" || req.Items[0].Quantity != 1 {
+		t.Errorf("credit note request = %+v", req)
+	}
+	stored, _ := repo.GetByID(context.Background(), 2)
+	for _, got := range []*model.Return{ret, stored} {
+		if got.Status != model.ReturnReceived || got.ReceivedAt == nil || got.CreditNoteID != 30 || got.CreditedAmount != 40 {
+			t.Errorf("return = %+v, want received and credited at the ordered price", got)
+		}
+	}
+}
+
+func TestReceiveReturn_CreditFailsAndIsRetriedAfterInspection(t *testing.T) {
+	repo := newReturnRepository()
+	billingClient := &creditNotes{err: errors.New("billing unavailable")}
+	svc := &ReturnServiceImpl{returnRepo: repo, billingClient: billingClient}
+
+	if _, err := svc.ReceiveReturn(context.Background(), 2, "all items back"); err == nil {
+		t.Fatal("ReceiveReturn() error = nil, want the billing error")
+	}
+	stored, _ := repo.GetByID(context.Background(), 2)
+	if stored.Status != model.ReturnReceived || stored.CreditNoteID != 0 {
+		t.Fatalf("return = %+v, want received without a credit note", stored)
+	}
+
+	if _, err := svc.InspectReturn(context.Background(), 2, "resellable"); err != nil {
+		t.Fatalf("InspectReturn() error = %v", err)
+	}
+
+	billingClient.err = nil
+	if _, err := svc.ReceiveReturn(context.Background(), 2, ""); err != nil {
+		t.Fatalf("ReceiveReturn() retry error = %v", err)
+	}
+	stored, _ = repo.GetByID(context.Background(), 2)
+	if stored.Status != model.ReturnInspected || stored.Note != "resellable" || stored.CreditNoteID != 30 {
+		t.Errorf("return = %+v, want inspected and credited", stored)
+	}
+	if len(billingClient.requests) != 2 || billingClient.requests[1].Reference != "RMA-2" {
+		t.Errorf("credit note requests = %+v, want the retry under the same reference", billingClient.requests)
+	}
+
+	// Once credited, receiving again is not a retry anymore
+	if _, err := svc.ReceiveReturn(context.Background(), 2, ""); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("ReceiveReturn() of a credited return error = %v, want %v", err, ErrInvalidTransition)
+	}
+	if len(billingClient.requests) != 2 {
+		t.Errorf("%d credit notes requested, want 2", len(billingClient.requests))
+	}
+}
+
+func TestReceiveReturn_InspectedWhileCrediting(t *testing.T) {
+	repo := newReturnRepository()
+	billingClient := &creditNotes{}
+	svc := &ReturnServiceImpl{returnRepo: repo, billingClient: billingClient}
+	billingClient.inFlight = func() {
+		if _, err := svc.InspectReturn(context.Background(), 2, "resellable"); err != nil {
+			t.Errorf("InspectReturn() error = %v", err)
+		}
+	}
+
+	if _, err := svc.ReceiveReturn(context.Background(), 2, "all items back"); err != nil {
+		t.Fatalf("ReceiveReturn() error = %v", err)
+	}
+
+	// Recording the credit note does not move the return back to received
+	stored, _ := repo.GetByID(context.Background(), 2)
+	if stored.Status != model.ReturnInspected || stored.Note != "resellable" || stored.CreditNoteID != 30 {
+		t.Errorf("return = %+v, want inspected and credited", stored)
+	}
+}
@@ -4,8 +4,68 @@ import (
 	"billing-system/shipment_service/internal/dto"
 	"billing-system/shipment_service/internal/model"
 	"context"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrInvalidRequest       = newError(KindInvalid, "INVALID_REQUEST", "invalid request")
+	ErrShipmentNotFound     = newError(KindNotFound, "SHIPMENT_NOT_FOUND", "shipment not found")
+	ErrInvalidFilter        = newError(KindInvalid, "INVALID_FILTER", "invalid shipment filter")
+	ErrInvalidStatus        = newError(KindInvalid, "INVALID_STATUS", "invalid shipment status")
+	ErrInvalidTransition    = newError(KindConflict, "INVALID_TRANSITION", "invalid shipment status transition")
+	ErrInvalidCarrier       = newError(KindInvalid, "INVALID_CARRIER", "invalid carrier")
+	ErrInvalidDimensions    = newError(KindInvalid, "INVALID_DIMENSIONS", "invalid SKU dimensions")
+	ErrInvalidZone          = newError(KindInvalid, "INVALID_ZONE", "invalid shipping zone")
+	ErrReturnNotFound       = newError(KindNotFound, "RETURN_NOT_FOUND", "return not found")
+	ErrInvalidReturn        = newError(KindInvalid, "INVALID_RETURN", "invalid return")
+	ErrInvalidItems         = newError(KindInvalid, "INVALID_ITEMS", "invalid shipment items")
+	ErrWarehouseNotFound    = newError(KindNotFound, "WAREHOUSE_NOT_FOUND", "warehouse not found")
+	ErrInvalidWarehouse     = newError(KindInvalid, "INVALID_WAREHOUSE", "invalid warehouse")
+	ErrInvalidStock         = newError(KindInvalid, "INVALID_STOCK", "invalid warehouse stock")
+	ErrOutOfStock           = newError(KindConflict, "OUT_OF_STOCK", "not enough stock in warehouse")
+	ErrInvalidAllocation    = newError(KindInvalid, "INVALID_ALLOCATION", "invalid allocation request")
+	ErrInvalidParcels       = newError(KindInvalid, "INVALID_PARCELS", "invalid parcels")
+	ErrInvalidDocument      = newError(KindInvalid, "INVALID_DOCUMENT", "invalid shipping document")
+	ErrInvalidDelivery      = newError(KindInvalid, "INVALID_DELIVERY", "invalid delivery proof")
+	ErrDeliveryFileNotFound = newError(KindNotFound, "DELIVERY_FILE_NOT_FOUND", "delivery file not found")
+	ErrOrderNotFound        = newError(KindNotFound, "ORDER_NOT_FOUND", "order not found")
 )
 
 type ShipmentService interface {
-	CreateShipment(ctx context.Context, orderID int64, items []dto.ShipmentItemRequest) (*model.Shipment, error)
+	CreateShipment(ctx context.Context, req dto.CreateShipmentRequest) (*model.Shipment, error)
+	CreatePlannedShipments(ctx context.Context, req dto.CreatePlannedShipmentsRequest) ([]model.Shipment, error)
+	CreateShipments(ctx context.Context, lines []dto.CreateShipmentRequest) []dto.ShipmentResult
+	GetShipment(ctx context.Context, id int64) (*model.Shipment, error)
+	ListShipments(ctx context.Context, filter dto.ShipmentFilter) ([]model.Shipment, string, error)
+	UpdateShipmentStatus(ctx context.Context, id int64, status model.ShipmentStatus, event dto.ShipmentEventRequest) (*model.Shipment, error)
+	GetTrackingHistory(ctx context.Context, id int64) (*model.Shipment, error)
+	QuoteShippingRates(ctx context.Context, req dto.ShippingQuoteRequest) ([]dto.ShippingQuote, error)
+	HandleCarrierWebhook(ctx context.Context, carrierCode string, header http.Header, body []byte) (int, error)
+	RefreshTracking(ctx context.Context, id int64) (*model.Shipment, error)
+	UpsertSkuDimensions(ctx context.Context, dimensions []model.SkuDimension) error
+	UpsertShippingZone(ctx context.Context, zone *model.ShippingZone) (*model.ShippingZone, error)
+	PackShipment(ctx context.Context, id int64, parcels []dto.ParcelRequest) (*model.Shipment, error)
+	GetShippingDocument(ctx context.Context, id int64, documentType string, format string) (*dto.ShippingDocument, error)
+	ConfirmDelivery(ctx context.Context, req dto.ConfirmDeliveryRequest) (*model.Shipment, error)
+	OpenDeliveryFile(ctx context.Context, shipmentID int64, fileID int64) (*model.DeliveryFile, io.ReadCloser, error)
+	WatchShipment(ctx context.Context, shipmentID int64, afterEventID int64, send func(dto.WatchUpdate) error) error
+	WatchOrder(ctx context.Context, orderID int64, afterEventID int64, send func(dto.WatchUpdate) error) error
+}
+
+type ReturnService interface {
+	RequestReturn(ctx context.Context, shipmentID int64, reason string, items []dto.ShipmentItemRequest) (*model.Return, error)
+	GetReturn(ctx context.Context, id int64) (*model.Return, error)
+	ApproveReturn(ctx context.Context, id int64, note string) (*model.Return, error)
+	RejectReturn(ctx context.Context, id int64, note string) (*model.Return, error)
+	ReceiveReturn(ctx context.Context, id int64, note string) (*model.Return, error)
+	InspectReturn(ctx context.Context, id int64, note string) (*model.Return, error)
+}
+
+// AllocationService manages warehouses and their stock, and splits orders into per-warehouse shipments
+type AllocationService interface {
+	UpsertWarehouse(ctx context.Context, warehouse *model.Warehouse) (*model.Warehouse, error)
+	ListWarehouses(ctx context.Context) ([]model.Warehouse, error)
+	UpsertStock(ctx context.Context, stock []model.WarehouseStock) error
+	ProposeAllocation(ctx context.Context, req dto.AllocationRequest) (*dto.AllocationPlan, error)
 }
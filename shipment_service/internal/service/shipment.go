@@ -1,58 +1,171 @@
 package service
 
 import (
+	"billing-system/pkg/auth"
 	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/blob"
+	"billing-system/shipment_service/internal/carrier"
 	"billing-system/shipment_service/internal/dto"
 	"billing-system/shipment_service/internal/model"
 	"billing-system/shipment_service/internal/repository"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+
+	// systemActor is the actor of events recorded by the shipment service itself
+	systemActor = "system"
 )
 
 type ShipmentServiceImpl struct {
 	shipmentRepo  repository.ShipmentRepository
+	shippingRepo  repository.ShippingRepository
+	warehouseRepo repository.WarehouseRepository
 	billingClient *billing.BillingClient
+	carriers      *carrier.Registry
+	shipping      ShippingConfig
+	documents     DocumentConfig
+	blobs         blob.BlobStore
+	watch         WatchConfig
+	batch         BatchConfig
+	hub           *eventHub
 }
 
-func NewShipmentService(shipmentRepo repository.ShipmentRepository) ShipmentService {
+func NewShipmentService(
+	shipmentRepo repository.ShipmentRepository,
+	shippingRepo repository.ShippingRepository,
+	warehouseRepo repository.WarehouseRepository,
+	carriers *carrier.Registry,
+	shipping ShippingConfig,
+	documents DocumentConfig,
+	blobs blob.BlobStore,
+	watch WatchConfig,
+	batch BatchConfig,
+) ShipmentService {
 	return &ShipmentServiceImpl{
 		shipmentRepo:  shipmentRepo,
+		shippingRepo:  shippingRepo,
+		warehouseRepo: warehouseRepo,
 		billingClient: billing.NewBillingClient(),
+		carriers:      carriers,
+		shipping:      shipping,
+		documents:     documents,
+		blobs:         blobs,
+		watch:         watch,
+		batch:         batch,
+		hub:           newEventHub(),
 	}
 }
 
-func (s *ShipmentServiceImpl) CreateShipment(ctx context.Context, orderID int64, items []dto.ShipmentItemRequest) (*model.Shipment, error) {
-	if len(items) == 0 {
-		return nil, fmt.Errorf("at least one item is required")
+// CreateShipment prices shipping, books the items with a carrier and invoices them with the shipping fee.
+// The items are validated against the order's remaining quantities first, an ItemValidationError lists every violating line.
+// A shipment from a warehouse reserves the items from its stock. Items already packed are priced by their parcels.
+// When booking or invoicing fails the shipment is kept as FAILED, a booked consignment is cancelled and the stock put back.
+func (s *ShipmentServiceImpl) CreateShipment(ctx context.Context, req dto.CreateShipmentRequest) (*model.Shipment, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidItems)
+	}
+	if req.DestinationPostalCode == "" {
+		req.DestinationPostalCode = req.ShipTo.PostalCode
+	}
+	if req.ShipTo.PostalCode == "" {
+		req.ShipTo.PostalCode = req.DestinationPostalCode
 	}
 
-	// Validate items
-	var shipmentItems []model.ShipmentItem
-	for _, itemReq := range items {
-		if itemReq.Sku == "" {
-			return nil, fmt.Errorf("SKU is required for all items")
-		}
-		if itemReq.Quantity <= 0 {
-			return nil, fmt.Errorf("quantity must be greater than 0 for SKU %s", itemReq.Sku)
+	// Validate items against what is left to ship, so nothing is booked for lines billing would refuse
+	shippable, err := getShippableQuantities(ctx, s.billingClient, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentItems, err := validateItems(req.Items, shippable)
+	if err != nil {
+		return nil, err
+	}
+
+	var parcels []model.Parcel
+	if len(req.Parcels) > 0 {
+		if parcels, err = buildParcels(req.Parcels, shipmentItems, s.shipping.DimDivisor); err != nil {
+			return nil, err
 		}
+	}
 
-		shipmentItems = append(shipmentItems, model.ShipmentItem{
-			Sku:      itemReq.Sku,
-			Quantity: itemReq.Quantity,
-		})
+	c, err := s.carriers.Get(req.CarrierCode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCarrier, err)
+	}
+
+	// Price shipping before anything is booked
+	quotes, errs, err := s.quoteShipping(ctx, []carrier.Carrier{c}, req.Items, parcels, req.DestinationPostalCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("failed to quote shipping: %w", errors.Join(errs...))
+	}
+	quote := quotes[0]
+
+	if req.WarehouseID != 0 {
+		if err := s.reserveStock(ctx, req.WarehouseID, shipmentItems); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create shipment
 	shipment := &model.Shipment{
-		OrderID: orderID,
-		Status:  model.Confirmed,
-		Items:   shipmentItems,
+		OrderID:               req.OrderID,
+		Status:                model.Created,
+		Items:                 shipmentItems,
+		WarehouseID:           req.WarehouseID,
+		Parcels:               parcels,
+		CarrierCode:           c.Code(),
+		DestinationPostalCode: req.DestinationPostalCode,
+		ShipTo:                req.ShipTo,
+		ShippingZone:          quote.Zone,
+		ChargeableWeightKg:    quote.ChargeableWeightKg,
+		ShippingFee:           quote.Fee,
+		APIKeyID:              apiKeyIDFromContext(ctx),
+		Events: []model.ShipmentEvent{{
+			Status:    model.Created,
+			Timestamp: time.Now(),
+			Actor:     systemActor,
+		}},
 	}
 
 	if err := s.shipmentRepo.Create(ctx, shipment); err != nil {
+		s.releaseStock(ctx, shipment)
 		return nil, fmt.Errorf("failed to create shipment: %w", err)
 	}
+	s.hub.publish(shipment.OrderID)
+
+	// Book the consignment with the carrier
+	consignment, err := c.CreateConsignment(ctx, carrier.ConsignmentRequest{
+		Reference: strconv.FormatInt(shipment.ID, 10),
+		OrderID:   shipment.OrderID,
+		Items:     carrierItems(shipment.Items),
+		Parcels:   carrierParcels(shipment.Parcels),
+	})
+	if err != nil {
+		s.markFailed(ctx, shipment, err.Error())
+		return nil, fmt.Errorf("failed to book shipment with %s: %w", c.Code(), err)
+	}
+
+	shipment.TrackingNumber = consignment.TrackingNumber
+	if err := s.shipmentRepo.Update(ctx, shipment); err != nil {
+		s.cancelConsignment(ctx, c, consignment.TrackingNumber)
+		s.markFailed(ctx, shipment, "failed to record tracking number")
+		return nil, fmt.Errorf("failed to record tracking number: %w", err)
+	}
 
 	// Create invoice in billing service
 	invoiceItems := make([]billing.InvoiceItemRequest, len(shipment.Items))
@@ -67,18 +180,280 @@ func (s *ShipmentServiceImpl) CreateShipment(ctx context.Context, orderID int64,
 		ShipmentID: shipment.ID,
 		OrderID:    shipment.OrderID,
 		Items:      invoiceItems,
+		ShippingFee: &billing.ShippingFeeRequest{
+			Amount:      shipment.ShippingFee,
+			Description: fmt.Sprintf("Shipping (%s %s)", c.Code(), quote.Service),
+			TaxCategory: s.shipping.TaxCategory,
+		},
+	}
+
+	_, err = s.billingClient.CreateInvoice(ctx, invoiceReq)
+	if err != nil {
+		// Update shipment status to Failed and release the courier
+		s.cancelConsignment(ctx, c, consignment.TrackingNumber)
+		s.markFailed(ctx, shipment, err.Error())
+
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// CreatePlannedShipments creates one shipment per warehouse of an allocation plan, in the plan's order.
+// The plan is validated as a whole first, the lines of its ItemValidationError are numbered across its shipments.
+// It stops at the first shipment that cannot be created, the shipments created before it are kept and returned with the error.
+func (s *ShipmentServiceImpl) CreatePlannedShipments(ctx context.Context, req dto.CreatePlannedShipmentsRequest) ([]model.Shipment, error) {
+	if len(req.Shipments) == 0 {
+		return nil, fmt.Errorf("%w: the plan has no shipments", ErrInvalidItems)
+	}
+
+	var items []dto.ShipmentItemRequest
+	for i, planned := range req.Shipments {
+		if planned.WarehouseID == 0 {
+			return nil, fmt.Errorf("%w: planned shipment %d has no warehouse", ErrInvalidWarehouse, i+1)
+		}
+		if len(planned.Items) == 0 {
+			return nil, fmt.Errorf("%w: planned shipment %d has no items", ErrInvalidItems, i+1)
+		}
+		items = append(items, planned.Items...)
+	}
+
+	shippable, err := getShippableQuantities(ctx, s.billingClient, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validateItems(items, shippable); err != nil {
+		return nil, err
+	}
+
+	shipments := make([]model.Shipment, 0, len(req.Shipments))
+	for i, planned := range req.Shipments {
+		shipment, err := s.CreateShipment(ctx, dto.CreateShipmentRequest{
+			OrderID:               req.OrderID,
+			CarrierCode:           req.CarrierCode,
+			DestinationPostalCode: req.DestinationPostalCode,
+			ShipTo:                req.ShipTo,
+			WarehouseID:           planned.WarehouseID,
+			Items:                 planned.Items,
+		})
+		if err != nil {
+			return shipments, fmt.Errorf("failed to create shipment %d of %d from warehouse %d: %w",
+				i+1, len(req.Shipments), planned.WarehouseID, err)
+		}
+		shipments = append(shipments, *shipment)
 	}
 
-	createInvoiceResponse, _ := s.billingClient.CreateInvoice(ctx, invoiceReq)
-	if createInvoiceResponse.Code == "ERROR" {
-		// Update shipment status to Failed
-		shipment.Status = model.Failed
-		if updateErr := s.shipmentRepo.Update(ctx, shipment); updateErr != nil {
-			log.Printf("Failed to update shipment status: %v", updateErr)
+	return shipments, nil
+}
+
+// reserveStock takes the items out of the stock of an active warehouse
+func (s *ShipmentServiceImpl) reserveStock(ctx context.Context, warehouseID int64, items []model.ShipmentItem) error {
+	warehouse, err := s.warehouseRepo.GetWarehouse(ctx, warehouseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWarehouseNotFound
 		}
+		return fmt.Errorf("failed to get warehouse %d: %w", warehouseID, err)
+	}
+	if !warehouse.Active {
+		return fmt.Errorf("%w: warehouse %s is not active", ErrInvalidWarehouse, warehouse.Code)
+	}
+
+	reserved, err := s.warehouseRepo.ReserveStock(ctx, warehouseID, items)
+	if err != nil {
+		return fmt.Errorf("failed to reserve stock in warehouse %s: %w", warehouse.Code, err)
+	}
+	if !reserved {
+		return fmt.Errorf("%w: %s cannot ship every item", ErrOutOfStock, warehouse.Code)
+	}
+	return nil
+}
 
-		return nil, fmt.Errorf("failed to create invoice: %s", createInvoiceResponse.Message)
+// releaseStock puts the items of a shipment back into its warehouse's stock, failures are logged for manual follow-up
+func (s *ShipmentServiceImpl) releaseStock(ctx context.Context, shipment *model.Shipment) {
+	if shipment.WarehouseID == 0 {
+		return
+	}
+	if err := s.warehouseRepo.ReleaseStock(ctx, shipment.WarehouseID, shipment.Items); err != nil {
+		log.Printf("Failed to release stock of shipment %d in warehouse %d: %v", shipment.ID, shipment.WarehouseID, err)
 	}
+}
+
+// markFailed moves a shipment that could not be booked or invoiced to FAILED and puts its stock back
+func (s *ShipmentServiceImpl) markFailed(ctx context.Context, shipment *model.Shipment, note string) {
+	event := &model.ShipmentEvent{
+		Status:    model.Failed,
+		Timestamp: time.Now(),
+		Actor:     systemActor,
+		Note:      note,
+	}
+	if err := s.transition(ctx, shipment, event); err != nil {
+		log.Printf("Failed to update shipment status: %v", err)
+		return
+	}
+	s.releaseStock(ctx, shipment)
+}
 
+// cancelConsignment cancels a booked consignment, failures are logged for manual follow-up
+func (s *ShipmentServiceImpl) cancelConsignment(ctx context.Context, c carrier.Carrier, trackingNumber string) {
+	if err := c.CancelConsignment(ctx, trackingNumber); err != nil {
+		log.Printf("Failed to cancel consignment %s with %s: %v", trackingNumber, c.Code(), err)
+	}
+}
+
+// GetShipment retrieves a shipment with its items
+func (s *ShipmentServiceImpl) GetShipment(ctx context.Context, id int64) (*model.Shipment, error) {
+	shipment, err := s.shipmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment %d: %w", id, err)
+	}
 	return shipment, nil
 }
+
+// ListShipments returns one page of the shipments matching the filter, newest first.
+// The returned cursor fetches the next page and is empty on the last page.
+func (s *ShipmentServiceImpl) ListShipments(ctx context.Context, filter dto.ShipmentFilter) ([]model.Shipment, string, error) {
+	pageSize := filter.PageSize
+	switch {
+	case pageSize < 0 || pageSize > maxPageSize:
+		return nil, "", fmt.Errorf("%w: page size must be between 1 and %d", ErrInvalidFilter, maxPageSize)
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	}
+
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedTo.After(filter.CreatedFrom) {
+		return nil, "", fmt.Errorf("%w: created to must be after created from", ErrInvalidFilter)
+	}
+
+	beforeID, err := decodeCursor(filter.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One extra shipment tells whether there is a next page
+	shipments, err := s.shipmentRepo.List(ctx, repository.ShipmentQuery{
+		OrderID:     filter.OrderID,
+		Status:      filter.Status,
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		BeforeID:    beforeID,
+		Limit:       pageSize + 1,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list shipments: %w", err)
+	}
+
+	if len(shipments) <= pageSize {
+		return shipments, "", nil
+	}
+
+	shipments = shipments[:pageSize]
+	return shipments, encodeCursor(shipments[pageSize-1].ID), nil
+}
+
+// UpdateShipmentStatus moves a shipment to the next status of its lifecycle and records the event.
+// Only the transitions allowed by the shipment's current status are accepted.
+func (s *ShipmentServiceImpl) UpdateShipmentStatus(
+	ctx context.Context,
+	id int64,
+	status model.ShipmentStatus,
+	eventReq dto.ShipmentEventRequest,
+) (*model.Shipment, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := shipment.Status
+	if !from.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidTransition, from, status)
+	}
+
+	event := &model.ShipmentEvent{
+		Status:    status,
+		Timestamp: eventReq.Timestamp,
+		Location:  eventReq.Location,
+		Actor:     eventReq.Actor,
+		Note:      eventReq.Note,
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	if err := s.transition(ctx, shipment, event); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// transition moves the shipment from its current status to the status of the event and records the event
+func (s *ShipmentServiceImpl) transition(ctx context.Context, shipment *model.Shipment, event *model.ShipmentEvent) error {
+	from := shipment.Status
+	event.PreviousStatus = from
+
+	updated, err := s.shipmentRepo.UpdateStatus(ctx, shipment, from, event)
+	if err != nil {
+		return fmt.Errorf("failed to update shipment status: %w", err)
+	}
+	if !updated {
+		// Another update moved the shipment on since it was read
+		return fmt.Errorf("%w: shipment %d is no longer %s", ErrInvalidTransition, shipment.ID, from)
+	}
+
+	s.hub.publish(shipment.OrderID)
+	return nil
+}
+
+// GetTrackingHistory retrieves a shipment with its status changes, oldest first
+func (s *ShipmentServiceImpl) GetTrackingHistory(ctx context.Context, id int64) (*model.Shipment, error) {
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.shipmentRepo.ListEvents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events of shipment %d: %w", id, err)
+	}
+	shipment.Events = events
+
+	return shipment, nil
+}
+
+// encodeCursor returns the opaque cursor of the page after the shipment with the given ID
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeCursor returns the shipment ID a cursor points after, an empty cursor returns zero
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidFilter)
+	}
+	return id, nil
+}
+
+// apiKeyIDFromContext returns the public id of the API key of the caller, empty for users
+func apiKeyIDFromContext(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.APIKeyID
+	}
+	return ""
+}
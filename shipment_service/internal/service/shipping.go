@@ -0,0 +1,152 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/carrier"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"fmt"
+	"math"
+)
+
+// ShippingConfig configures how shipping fees are computed and billed
+type ShippingConfig struct {
+	// TaxCategory is recorded on the shipping line of invoices, billing defaults it when empty
+	TaxCategory string
+	// DimDivisor converts cubic centimetres to dimensional kilograms, defaults to model.DefaultDimDivisor
+	DimDivisor float64
+}
+
+// UpsertSkuDimensions creates or replaces the weight and size of SKUs
+func (s *ShipmentServiceImpl) UpsertSkuDimensions(ctx context.Context, dimensions []model.SkuDimension) error {
+	if len(dimensions) == 0 {
+		return fmt.Errorf("%w: at least one SKU is required", ErrInvalidDimensions)
+	}
+
+	for _, d := range dimensions {
+		if d.Sku == "" {
+			return fmt.Errorf("%w: SKU is required", ErrInvalidDimensions)
+		}
+		for _, value := range []float64{d.WeightKg, d.LengthCm, d.WidthCm, d.HeightCm} {
+			if !isNonNegative(value) {
+				return fmt.Errorf("%w: weight and size of SKU %s must be non-negative numbers", ErrInvalidDimensions, d.Sku)
+			}
+		}
+	}
+
+	if err := s.shippingRepo.UpsertDimensions(ctx, dimensions); err != nil {
+		return fmt.Errorf("failed to store SKU dimensions: %w", err)
+	}
+	return nil
+}
+
+// UpsertShippingZone creates the zone or replaces the zone with the same code.
+// A zero multiplier is stored as 1 so a zone can add a surcharge on its own.
+func (s *ShipmentServiceImpl) UpsertShippingZone(ctx context.Context, zone *model.ShippingZone) (*model.ShippingZone, error) {
+	if zone.Code == "" || len(zone.PostalPrefixes) == 0 {
+		return nil, fmt.Errorf("%w: code and at least one postal prefix are required", ErrInvalidZone)
+	}
+	for _, prefix := range zone.PostalPrefixes {
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: postal prefixes cannot be empty", ErrInvalidZone)
+		}
+	}
+	if !isNonNegative(zone.Multiplier) || !isNonNegative(zone.Surcharge) {
+		return nil, fmt.Errorf("%w: multiplier and surcharge must be non-negative numbers", ErrInvalidZone)
+	}
+	if zone.Multiplier == 0 {
+		zone.Multiplier = 1
+	}
+
+	if err := s.shippingRepo.UpsertZone(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to store shipping zone: %w", err)
+	}
+	return zone, nil
+}
+
+// quoteShipping prices shipping the items to the destination with each of the carriers.
+// Packed items are priced by the weight of their parcels, the others by the dimensions of their SKUs.
+// Carriers that fail to quote are returned as errors next to the quotes of the others.
+func (s *ShipmentServiceImpl) quoteShipping(
+	ctx context.Context,
+	carriers []carrier.Carrier,
+	items []dto.ShipmentItemRequest,
+	parcels []model.Parcel,
+	postalCode string,
+) ([]dto.ShippingQuote, []error, error) {
+	weight := parcelsWeight(parcels)
+	if len(parcels) == 0 {
+		var err error
+		if weight, err = s.chargeableWeight(ctx, items); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	zones, err := s.shippingRepo.ListZones(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list shipping zones: %w", err)
+	}
+	zone := model.MatchShippingZone(zones, postalCode)
+
+	req := carrier.RateRequest{
+		Items:                 make([]carrier.Item, len(items)),
+		Parcels:               carrierParcels(parcels),
+		WeightKg:              weight,
+		DestinationPostalCode: postalCode,
+	}
+	for i, item := range items {
+		req.Items[i] = carrier.Item{Sku: item.Sku, Quantity: item.Quantity}
+	}
+
+	var errs []error
+	quotes := make([]dto.ShippingQuote, 0, len(carriers))
+	for _, c := range carriers {
+		rate, err := c.QuoteRate(ctx, req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Code(), err))
+			continue
+		}
+		quotes = append(quotes, dto.ShippingQuote{
+			CarrierCode:        rate.CarrierCode,
+			Service:            rate.Service,
+			EstimatedDays:      rate.EstimatedDays,
+			CarrierAmount:      rate.Amount,
+			ChargeableWeightKg: weight,
+			Zone:               zone.Code,
+			Fee:                zone.Fee(rate.Amount),
+		})
+	}
+
+	return quotes, errs, nil
+}
+
+// chargeableWeight returns the chargeable weight of the items in kilograms.
+// SKUs without recorded dimensions weigh nothing, the carrier then charges its base rate for them.
+func (s *ShipmentServiceImpl) chargeableWeight(ctx context.Context, items []dto.ShipmentItemRequest) (float64, error) {
+	skus := make([]string, len(items))
+	for i, item := range items {
+		skus[i] = item.Sku
+	}
+
+	dimensions, err := s.shippingRepo.GetDimensions(ctx, skus)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get SKU dimensions: %w", err)
+	}
+
+	bySku := make(map[string]model.SkuDimension, len(dimensions))
+	for _, d := range dimensions {
+		bySku[d.Sku] = d
+	}
+
+	weight := 0.0
+	for _, item := range items {
+		if d, ok := bySku[item.Sku]; ok {
+			weight += d.ChargeableWeightKg(s.shipping.DimDivisor) * float64(item.Quantity)
+		}
+	}
+	return math.Round(weight*1000) / 1000, nil
+}
+
+func isNonNegative(value float64) bool {
+	return value >= 0 && !math.IsInf(value, 0)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/carrier"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// QuoteShippingRates returns the shipping fee of each carrier for shipping the items to the destination.
+// An empty carrier code asks every registered carrier, carriers that fail to quote are skipped.
+func (s *ShipmentServiceImpl) QuoteShippingRates(ctx context.Context, req dto.ShippingQuoteRequest) ([]dto.ShippingQuote, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", ErrInvalidItems)
+	}
+
+	carriers := s.carriers.All()
+	if req.CarrierCode != "" {
+		c, err := s.carriers.Get(req.CarrierCode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCarrier, err)
+		}
+		carriers = []carrier.Carrier{c}
+	}
+
+	var parcels []model.Parcel
+	if len(req.Parcels) > 0 {
+		var err error
+		if parcels, err = buildParcels(req.Parcels, mergeItems(req.Items), s.shipping.DimDivisor); err != nil {
+			return nil, err
+		}
+	}
+
+	quotes, errs, err := s.quoteShipping(ctx, carriers, req.Items, parcels, req.DestinationPostalCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(quotes) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		log.Println("Carrier failed to quote a rate:", err)
+	}
+
+	return quotes, nil
+}
+
+// HandleCarrierWebhook applies a tracking push from a carrier to the shipments it concerns.
+// Returns the number of status changes applied, events already known or older than the last recorded one are skipped.
+func (s *ShipmentServiceImpl) HandleCarrierWebhook(ctx context.Context, carrierCode string, header http.Header, body []byte) (int, error) {
+	c, err := s.carriers.Get(carrierCode)
+	if err != nil || carrierCode == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCarrier, carrierCode)
+	}
+
+	events, err := c.ParseWebhook(header, body)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidCarrier, err)
+	}
+
+	// A push may carry events of several consignments
+	byTrackingNumber := make(map[string][]carrier.TrackingEvent)
+	var trackingNumbers []string
+	for _, event := range events {
+		if _, ok := byTrackingNumber[event.TrackingNumber]; !ok {
+			trackingNumbers = append(trackingNumbers, event.TrackingNumber)
+		}
+		byTrackingNumber[event.TrackingNumber] = append(byTrackingNumber[event.TrackingNumber], event)
+	}
+
+	var errs []error
+	applied := 0
+	for _, trackingNumber := range trackingNumbers {
+		shipment, err := s.shipmentRepo.GetByTrackingNumber(ctx, c.Code(), trackingNumber)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("Ignoring tracking of unknown consignment %s of %s", trackingNumber, c.Code())
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to get shipment of %s: %w", trackingNumber, err))
+			continue
+		}
+
+		n, err := s.applyTrackingEvents(ctx, shipment, byTrackingNumber[trackingNumber])
+		applied += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return applied, errors.Join(errs...)
+}
+
+// RefreshTracking fetches the tracking of a shipment from its carrier and applies the new events
+func (s *ShipmentServiceImpl) RefreshTracking(ctx context.Context, id int64) (*model.Shipment, error) {
+	shipment, err := s.GetShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.TrackingNumber == "" {
+		return nil, fmt.Errorf("%w: shipment %d was not booked with a carrier", ErrInvalidTransition, id)
+	}
+
+	c, err := s.carriers.Get(shipment.CarrierCode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCarrier, err)
+	}
+
+	events, err := c.FetchTracking(ctx, shipment.TrackingNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracking from %s: %w", c.Code(), err)
+	}
+
+	if _, err := s.applyTrackingEvents(ctx, shipment, events); err != nil {
+		return nil, err
+	}
+
+	return s.GetTrackingHistory(ctx, id)
+}
+
+// applyTrackingEvents moves the shipment through the carrier events newer than its last recorded event.
+// Events are applied in the order they happened, carriers do not always send them in that order.
+// Events the lifecycle does not allow from the current status are skipped, so replays are harmless.
+func (s *ShipmentServiceImpl) applyTrackingEvents(ctx context.Context, shipment *model.Shipment, events []carrier.TrackingEvent) (int, error) {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b carrier.TrackingEvent) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	history, err := s.shipmentRepo.ListEvents(ctx, shipment.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list events of shipment %d: %w", shipment.ID, err)
+	}
+
+	var last model.ShipmentEvent
+	if len(history) > 0 {
+		last = history[len(history)-1]
+	}
+
+	applied := 0
+	for _, event := range events {
+		if !event.Timestamp.After(last.Timestamp) {
+			continue
+		}
+		if !shipment.Status.CanTransitionTo(event.Status) {
+			log.Printf("Skipping %s event of shipment %d in status %s", event.Status, shipment.ID, shipment.Status)
+			continue
+		}
+
+		shipmentEvent := &model.ShipmentEvent{
+			Status:    event.Status,
+			Timestamp: event.Timestamp,
+			Location:  event.Location,
+			Actor:     "carrier:" + shipment.CarrierCode,
+			Note:      event.Note,
+		}
+		if err := s.transition(ctx, shipment, shipmentEvent); err != nil {
+			return applied, err
+		}
+
+		last = *shipmentEvent
+		applied++
+	}
+
+	return applied, nil
+}
+
+// carrierItems converts shipment items to the items handed to a carrier
+func carrierItems(items []model.ShipmentItem) []carrier.Item {
+	carrierItems := make([]carrier.Item, len(items))
+	for i, item := range items {
+		carrierItems[i] = carrier.Item{Sku: item.Sku, Quantity: item.Quantity}
+	}
+	return carrierItems
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/carrier"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"testing"
+	"time"
+)
+
+// trackingRepository records the status changes of one shipment in memory
+type trackingRepository struct {
+	repository.ShipmentRepository
+
+	events []model.ShipmentEvent
+}
+
+func (r *trackingRepository) ListEvents(ctx context.Context, shipmentID int64) ([]model.ShipmentEvent, error) {
+	return r.events, nil
+}
+
+func (r *trackingRepository) UpdateStatus(ctx context.Context, shipment *model.Shipment, from model.ShipmentStatus, event *model.ShipmentEvent) (bool, error) {
+	if shipment.Status != from {
+		return false, nil
+	}
+	event.ShipmentID = shipment.ID
+	r.events = append(r.events, *event)
+	shipment.Status = event.Status
+	return true, nil
+}
+
+func TestApplyTrackingEvents_OutOfOrder(t *testing.T) {
+	packedAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	repo := &trackingRepository{events: []model.ShipmentEvent{{Status: model.Packed, Timestamp: packedAt}}}
+	s := &ShipmentServiceImpl{shipmentRepo: repo, hub: newEventHub()}
+	shipment := &model.Shipment{Base: model.Base{ID: 1}, Status: model.Packed, CarrierCode: "ghn"}
+
+	// The carrier pushed the delivery first and an event older than the last recorded one
+	applied, err := s.applyTrackingEvents(context.Background(), shipment, []carrier.TrackingEvent{
+		{Status: model.Delivered, Timestamp: packedAt.Add(3 * time.Hour)},
+		{Status: model.InTransit, Timestamp: packedAt.Add(time.Hour)},
+		{Status: model.Packed, Timestamp: packedAt.Add(-time.Hour)},
+		{Status: model.OutForDelivery, Timestamp: packedAt.Add(2 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatalf("applyTrackingEvents() error = %v", err)
+	}
+
+	if applied != 3 {
+		t.Errorf("applyTrackingEvents() applied %d events, want 3", applied)
+	}
+	if shipment.Status != model.Delivered {
+		t.Errorf("shipment is %s, want %s", shipment.Status, model.Delivered)
+	}
+	want := []model.ShipmentStatus{model.Packed, model.InTransit, model.OutForDelivery, model.Delivered}
+	if len(repo.events) != len(want) {
+		t.Fatalf("%d events recorded, want %d", len(repo.events), len(want))
+	}
+	for i, event := range repo.events {
+		if event.Status != want[i] {
+			t.Errorf("event %d is %s, want %s", i, event.Status, want[i])
+		}
+		if i > 0 && event.PreviousStatus != want[i-1] {
+			t.Errorf("event %d moved from %s, want %s", i, event.PreviousStatus, want[i-1])
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reasons a requested line cannot be shipped
+const (
+	ViolationMissingSku       = "MISSING_SKU"
+	ViolationInvalidQuantity  = "INVALID_QUANTITY"
+	ViolationNotOrdered       = "NOT_ORDERED"
+	ViolationExceedsRemaining = "EXCEEDS_REMAINING"
+)
+
+// ItemViolation describes why a requested line cannot be shipped.
+// Line is the zero-based position of the line in the request. For EXCEEDS_REMAINING,
+// Requested is the total of every line of the SKU.
+type ItemViolation struct {
+	Line      int
+	Sku       string
+	Reason    string
+	Requested int
+	Remaining int
+}
+
+func (v ItemViolation) String() string {
+	switch v.Reason {
+	case ViolationMissingSku:
+		return fmt.Sprintf("line %d: SKU is required", v.Line)
+	case ViolationInvalidQuantity:
+		return fmt.Sprintf("line %d: quantity must be greater than 0 for SKU %s", v.Line, v.Sku)
+	case ViolationNotOrdered:
+		return fmt.Sprintf("line %d: SKU %s is not on the order", v.Line, v.Sku)
+	default:
+		return fmt.Sprintf("line %d: quantity %d for SKU %s exceeds the %d left to ship", v.Line, v.Requested, v.Sku, v.Remaining)
+	}
+}
+
+// ItemValidationError lists every requested line that cannot be shipped
+type ItemValidationError struct {
+	Violations []ItemViolation
+}
+
+func (e *ItemValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidItems, strings.Join(messages, "; "))
+}
+
+func (e *ItemValidationError) Unwrap() error {
+	return ErrInvalidItems
+}
+
+// validateItems checks the requested lines against what is left to ship of the order and returns the
+// shipment items, with the lines of a SKU merged. Every violating line is reported in an ItemValidationError.
+func validateItems(items []dto.ShipmentItemRequest, shippable []billing.ShippableQuantity) ([]model.ShipmentItem, error) {
+	remaining := make(map[string]int, len(shippable))
+	for _, quantity := range shippable {
+		remaining[quantity.Sku] = quantity.Remaining
+	}
+
+	requested := make(map[string]int)
+	for _, item := range items {
+		if item.Sku != "" && item.Quantity > 0 {
+			requested[item.Sku] += item.Quantity
+		}
+	}
+
+	var violations []ItemViolation
+	var shipmentItems []model.ShipmentItem
+	merged := make(map[string]bool)
+	for line, item := range items {
+		violation := ItemViolation{Line: line, Sku: item.Sku, Requested: requested[item.Sku]}
+		left, ordered := remaining[item.Sku]
+
+		switch {
+		case item.Sku == "":
+			violation.Reason = ViolationMissingSku
+		case item.Quantity <= 0:
+			violation.Reason = ViolationInvalidQuantity
+			violation.Requested = item.Quantity
+		case !ordered:
+			violation.Reason = ViolationNotOrdered
+		case requested[item.Sku] > left:
+			violation.Reason = ViolationExceedsRemaining
+			violation.Remaining = left
+		default:
+			if !merged[item.Sku] {
+				merged[item.Sku] = true
+				shipmentItems = append(shipmentItems, model.ShipmentItem{Sku: item.Sku, Quantity: requested[item.Sku]})
+			}
+			continue
+		}
+
+		violations = append(violations, violation)
+	}
+
+	if len(violations) > 0 {
+		return nil, &ItemValidationError{Violations: violations}
+	}
+
+	return shipmentItems, nil
+}
+
+// getShippableQuantities asks billing what is left to ship of each SKU of the order
+func getShippableQuantities(ctx context.Context, billingClient *billing.BillingClient, orderID int64) ([]billing.ShippableQuantity, error) {
+	shippable, err := billingClient.GetShippableQuantities(ctx, orderID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: order %d not found", ErrInvalidItems, orderID)
+		}
+		return nil, fmt.Errorf("failed to get shippable quantities of order %d: %w", orderID, err)
+	}
+	return shippable, nil
+}
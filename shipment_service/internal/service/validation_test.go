@@ -0,0 +1,57 @@
+package service
+
+import (
+	"billing-system/shipment_service/client/billing"
+	"billing-system/shipment_service/internal/dto"
+	"errors"
+	"testing"
+)
+
+func TestValidateItems(t *testing.T) {
+	shippable := []billing.ShippableQuantity{
+		{Sku: "SKU001", Ordered: 5, Invoiced: 2, Remaining: 3},
+		{Sku: "SKU002", Ordered: 1, Invoiced: 1, Remaining: 0},
+	}
+
+	items, err := validateItems([]dto.ShipmentItemRequest{
+		{Sku: "SKU001", Quantity: 1},
+		{Sku: "SKU001", Quantity: 2},
+	}, shippable)
+	if err != nil {
+		t.Fatalf("validateItems returned %v", err)
+	}
+	if len(items) != 1 || items[0].Sku != "SKU001" || items[0].Quantity != 3 {
+		t.Errorf("validateItems merged lines into %+v, want one SKU001 line of 3", items)
+	}
+
+	_, err = validateItems([]dto.ShipmentItemRequest{
+		{Sku: "", Quantity: 1},
+		{Sku: "SKU001", Quantity: 0},
+		{Sku: "SKU009", Quantity: 1},
+		{Sku: "SKU002", Quantity: 1},
+		{Sku: "SKU001", Quantity: 4},
+	}, shippable)
+	if !errors.Is(err, ErrInvalidItems) {
+		t.Fatalf("validateItems returned %v, want ErrInvalidItems", err)
+	}
+
+	var validationErr *ItemValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("validateItems returned %T, want *ItemValidationError", err)
+	}
+	want := []ItemViolation{
+		{Line: 0, Reason: ViolationMissingSku},
+		{Line: 1, Sku: "SKU001", Reason: ViolationInvalidQuantity},
+		{Line: 2, Sku: "SKU009", Reason: ViolationNotOrdered, Requested: 1},
+		{Line: 3, Sku: "SKU002", Reason: ViolationExceedsRemaining, Requested: 1},
+		{Line: 4, Sku: "SKU001", Reason: ViolationExceedsRemaining, Requested: 4, Remaining: 3},
+	}
+	if len(validationErr.Violations) != len(want) {
+		t.Fatalf("validateItems reported %+v, want %+v", validationErr.Violations, want)
+	}
+	for i, violation := range validationErr.Violations {
+		if violation != want[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, violation, want[i])
+		}
+	}
+}
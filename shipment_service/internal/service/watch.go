@@ -0,0 +1,239 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultPollInterval      = 5 * time.Second
+	defaultLateEventWindow   = time.Minute
+
+	// watchBatchSize bounds the events read and sent at once, so a watcher far behind catches up in steps
+	watchBatchSize = 100
+)
+
+// WatchConfig configures the streams of shipment status changes
+type WatchConfig struct {
+	// HeartbeatInterval is how long a stream stays silent before a heartbeat is sent, defaults to 15s
+	HeartbeatInterval time.Duration
+	// PollInterval is how often the events are read again, catching changes made by other instances, defaults to 5s
+	PollInterval time.Duration
+	// LateEventWindow is how long after it was recorded an event may still be committed, the events recorded within it
+	// are read again so one committed after a later event is not skipped, defaults to 1m
+	LateEventWindow time.Duration
+}
+
+func (c WatchConfig) heartbeatInterval() time.Duration {
+	if c.HeartbeatInterval <= 0 {
+		return defaultHeartbeatInterval
+	}
+	return c.HeartbeatInterval
+}
+
+func (c WatchConfig) pollInterval() time.Duration {
+	if c.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+	return c.PollInterval
+}
+
+func (c WatchConfig) lateEventWindow() time.Duration {
+	if c.LateEventWindow <= 0 {
+		return defaultLateEventWindow
+	}
+	return c.LateEventWindow
+}
+
+// eventHub wakes the watchers of an order when one of its shipments records an event.
+// A wake-up carries no event, the watcher reads the events from the database, so a missed or
+// coalesced wake-up loses nothing.
+type eventHub struct {
+	mu       sync.Mutex
+	watchers map[int64]map[chan struct{}]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{watchers: make(map[int64]map[chan struct{}]struct{})}
+}
+
+// subscribe returns a channel signalled on events of the order, and the function that unsubscribes it
+func (h *eventHub) subscribe(orderID int64) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+	if h == nil {
+		return wake, func() {}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watchers[orderID] == nil {
+		h.watchers[orderID] = make(map[chan struct{}]struct{})
+	}
+	h.watchers[orderID][wake] = struct{}{}
+
+	return wake, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.watchers[orderID], wake)
+		if len(h.watchers[orderID]) == 0 {
+			delete(h.watchers, orderID)
+		}
+	}
+}
+
+// publish wakes the watchers of the order without blocking, a watcher already woken stays woken once
+func (h *eventHub) publish(orderID int64) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for wake := range h.watchers[orderID] {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// WatchShipment sends the events of a shipment recorded after afterEventID, then each new one as it is recorded,
+// until ctx is done or send fails. Heartbeats are sent while no event is.
+func (s *ShipmentServiceImpl) WatchShipment(ctx context.Context, shipmentID int64, afterEventID int64, send func(dto.WatchUpdate) error) error {
+	if shipmentID <= 0 || afterEventID < 0 {
+		return fmt.Errorf("%w: shipment ID must be positive and the last event ID not negative", ErrInvalidRequest)
+	}
+	shipment, err := s.GetShipment(ctx, shipmentID)
+	if err != nil {
+		return err
+	}
+	return s.streamEvents(ctx, shipment.OrderID, repository.EventQuery{ShipmentID: shipmentID, AfterID: afterEventID}, send)
+}
+
+// WatchOrder sends the events of the shipments of an order recorded after afterEventID, then each new one as it is recorded,
+// until ctx is done or send fails. Heartbeats are sent while no event is.
+// The order is looked up in billing with the caller's token, so customers only watch their own orders.
+func (s *ShipmentServiceImpl) WatchOrder(ctx context.Context, orderID int64, afterEventID int64, send func(dto.WatchUpdate) error) error {
+	if orderID <= 0 || afterEventID < 0 {
+		return fmt.Errorf("%w: order ID must be positive and the last event ID not negative", ErrInvalidRequest)
+	}
+	if _, err := s.billingClient.GetOrder(ctx, orderID); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("failed to get order %d: %w", orderID, err)
+	}
+	return s.streamEvents(ctx, orderID, repository.EventQuery{OrderID: orderID, AfterID: afterEventID}, send)
+}
+
+// streamEvents opens with a heartbeat and sends the events matching the query in batches until it is caught up, then waits for the order to be woken,
+// for the poll interval or for the heartbeat interval. Send blocks while the receiver is slow, so events are read
+// no faster than they are delivered.
+// Once caught up, the events up to the last one sent recorded within the late event window are read again and the ones
+// not sent yet are sent, so an event committed after a later one is not skipped. When resuming after an event, those
+// recorded within the window before it may be sent again.
+func (s *ShipmentServiceImpl) streamEvents(ctx context.Context, orderID int64, query repository.EventQuery, send func(dto.WatchUpdate) error) error {
+	// Subscribe before the first read, so an event recorded in between still wakes the watcher
+	wake, unsubscribe := s.hub.subscribe(orderID)
+	defer unsubscribe()
+
+	// The opening heartbeat tells the client the watch was accepted before any event is recorded
+	if err := send(dto.WatchUpdate{OrderID: orderID, Heartbeat: true}); err != nil {
+		return err
+	}
+
+	poll := time.NewTicker(s.watch.pollInterval())
+	defer poll.Stop()
+	heartbeat := time.NewTimer(s.watch.heartbeatInterval())
+	defer heartbeat.Stop()
+
+	// sent holds when the events sent within the late event window were recorded
+	sent := make(map[int64]time.Time)
+	query.Limit = watchBatchSize
+	for {
+		events, err := s.shipmentRepo.ListEventsAfter(ctx, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to list events of order %d: %w", orderID, err)
+		}
+		caughtUp := len(events) < watchBatchSize
+		if caughtUp && query.AfterID > 0 {
+			late, err := s.lateEvents(ctx, query, sent)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("failed to list late events of order %d: %w", orderID, err)
+			}
+			events = append(late, events...)
+		}
+		for i := range events {
+			if err := send(watchUpdate(orderID, &events[i])); err != nil {
+				return err
+			}
+			sent[events[i].ID] = events[i].CreatedAt
+			query.AfterID = max(query.AfterID, events[i].ID)
+		}
+		if len(events) > 0 {
+			heartbeat.Reset(s.watch.heartbeatInterval())
+		}
+		if !caughtUp {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-poll.C:
+		case <-heartbeat.C:
+			if err := send(dto.WatchUpdate{OrderID: orderID, Heartbeat: true}); err != nil {
+				return err
+			}
+			heartbeat.Reset(s.watch.heartbeatInterval())
+		}
+	}
+}
+
+// lateEvents reads again the events up to the last one sent recorded within the late event window, and returns those
+// not sent yet. Events recorded before the window are forgotten from sent.
+func (s *ShipmentServiceImpl) lateEvents(ctx context.Context, query repository.EventQuery, sent map[int64]time.Time) ([]model.ShipmentEvent, error) {
+	since := time.Now().Add(-s.watch.lateEventWindow())
+	for id, recordedAt := range sent {
+		if !recordedAt.After(since) {
+			delete(sent, id)
+		}
+	}
+
+	events, err := s.shipmentRepo.ListEventsAfter(ctx, repository.EventQuery{
+		ShipmentID:    query.ShipmentID,
+		OrderID:       query.OrderID,
+		UpToID:        query.AfterID,
+		RecordedAfter: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	late := events[:0]
+	for _, event := range events {
+		if _, ok := sent[event.ID]; !ok {
+			late = append(late, event)
+		}
+	}
+	return late, nil
+}
+
+func watchUpdate(orderID int64, event *model.ShipmentEvent) dto.WatchUpdate {
+	return dto.WatchUpdate{OrderID: orderID, ShipmentID: event.ShipmentID, Event: event}
+}
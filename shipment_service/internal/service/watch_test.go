@@ -0,0 +1,229 @@
+package service
+
+import (
+	"billing-system/shipment_service/internal/dto"
+	"billing-system/shipment_service/internal/model"
+	"billing-system/shipment_service/internal/repository"
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRepository keeps the events of one order in memory
+type eventRepository struct {
+	repository.ShipmentRepository
+
+	mu     sync.Mutex
+	lastID int64
+	events []model.ShipmentEvent
+}
+
+func (r *eventRepository) add(event model.ShipmentEvent) {
+	r.commit(r.reserve(), event)
+}
+
+// reserve takes the ID of an event recorded but not committed yet
+func (r *eventRepository) reserve() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	return r.lastID
+}
+
+// commit makes the event with a reserved ID visible, in ID order, recorded now unless it says when
+func (r *eventRepository) commit(id int64, event model.ShipmentEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event.ID = id
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	i := sort.Search(len(r.events), func(i int) bool { return r.events[i].ID > id })
+	r.events = slices.Insert(r.events, i, event)
+}
+
+func (r *eventRepository) ListEventsAfter(ctx context.Context, query repository.EventQuery) ([]model.ShipmentEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []model.ShipmentEvent
+	for _, event := range r.events {
+		if event.ID <= query.AfterID || (query.ShipmentID != 0 && event.ShipmentID != query.ShipmentID) {
+			continue
+		}
+		if (query.UpToID != 0 && event.ID > query.UpToID) || !event.CreatedAt.After(query.RecordedAfter) {
+			continue
+		}
+		if query.Limit > 0 && len(events) == query.Limit {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func TestStreamEvents(t *testing.T) {
+	repo := &eventRepository{}
+	// Recorded before the late event window, so resuming after event 3 does not send the first ones again
+	for i := 0; i < watchBatchSize+5; i++ {
+		repo.add(model.ShipmentEvent{ShipmentID: int64(i%2 + 1), Status: model.Created, CreatedAt: time.Now().Add(-time.Hour)})
+	}
+	s := &ShipmentServiceImpl{
+		shipmentRepo: repo,
+		hub:          newEventHub(),
+		watch:        WatchConfig{HeartbeatInterval: 20 * time.Millisecond, PollInterval: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan dto.WatchUpdate, 2*watchBatchSize)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.streamEvents(ctx, 9, repository.EventQuery{AfterID: 3}, func(update dto.WatchUpdate) error {
+			updates <- update
+			return nil
+		})
+	}()
+
+	receive := func() dto.WatchUpdate {
+		t.Helper()
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(time.Second):
+			t.Fatal("no update within a second")
+			return dto.WatchUpdate{}
+		}
+	}
+
+	if update := receive(); !update.Heartbeat {
+		t.Fatalf("got update %+v first, want the opening heartbeat", update)
+	}
+
+	// Resumes after the last event seen, across more than one batch
+	for id := int64(4); id <= watchBatchSize+5; id++ {
+		update := receive()
+		if update.Heartbeat || update.Event.ID != id || update.OrderID != 9 || update.ShipmentID != update.Event.ShipmentID {
+			t.Fatalf("got update %+v, want event %d of order 9", update, id)
+		}
+	}
+
+	if update := receive(); !update.Heartbeat {
+		t.Fatalf("got update %+v while idle, want a heartbeat", update)
+	}
+
+	// A published event is sent without waiting for the poll interval
+	repo.add(model.ShipmentEvent{ShipmentID: 1, Status: model.InTransit})
+	s.hub.publish(9)
+	for {
+		update := receive()
+		if update.Heartbeat {
+			continue
+		}
+		if update.Event.ID != watchBatchSize+6 {
+			t.Fatalf("got event %d after the publish, want %d", update.Event.ID, watchBatchSize+6)
+		}
+		break
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("streamEvents returned %v after the context was cancelled, want nil", err)
+	}
+	if len(s.hub.watchers) != 0 {
+		t.Errorf("%d orders still have watchers after the stream ended", len(s.hub.watchers))
+	}
+}
+
+func TestStreamEventsSendsLateEvents(t *testing.T) {
+	repo := &eventRepository{}
+	repo.add(model.ShipmentEvent{ShipmentID: 1, Status: model.Created})
+	lateID := repo.reserve()
+	repo.add(model.ShipmentEvent{ShipmentID: 1, Status: model.InTransit})
+	s := &ShipmentServiceImpl{
+		shipmentRepo: repo,
+		hub:          newEventHub(),
+		watch:        WatchConfig{HeartbeatInterval: time.Hour, PollInterval: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan dto.WatchUpdate, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.streamEvents(ctx, 9, repository.EventQuery{}, func(update dto.WatchUpdate) error {
+			if !update.Heartbeat {
+				updates <- update
+			}
+			return nil
+		})
+	}()
+
+	receive := func() int64 {
+		t.Helper()
+		select {
+		case update := <-updates:
+			return update.Event.ID
+		case <-time.After(time.Second):
+			t.Fatal("no event within a second")
+			return 0
+		}
+	}
+
+	if first, second := receive(), receive(); first != 1 || second != 3 {
+		t.Fatalf("got events %d and %d, want 1 and 3", first, second)
+	}
+
+	// The event committed after event 3 is sent, and the events already sent are not sent again
+	repo.commit(lateID, model.ShipmentEvent{ShipmentID: 1, Status: model.Created})
+	s.hub.publish(9)
+	if id := receive(); id != lateID {
+		t.Fatalf("got event %d after the late commit, want %d", id, lateID)
+	}
+	repo.add(model.ShipmentEvent{ShipmentID: 1, Status: model.Delivered})
+	s.hub.publish(9)
+	if id := receive(); id != 4 {
+		t.Fatalf("got event %d after the next event, want 4", id)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("streamEvents returned %v after the context was cancelled, want nil", err)
+	}
+	select {
+	case update := <-updates:
+		t.Errorf("got event %d sent twice", update.Event.ID)
+	default:
+	}
+}
+
+func TestStreamEventsStopsWhenSendFails(t *testing.T) {
+	repo := &eventRepository{}
+	repo.add(model.ShipmentEvent{ShipmentID: 1})
+	repo.add(model.ShipmentEvent{ShipmentID: 1})
+	s := &ShipmentServiceImpl{shipmentRepo: repo, hub: newEventHub()}
+
+	sendErr := errors.New("client gone")
+	sent := 0
+	err := s.streamEvents(context.Background(), 1, repository.EventQuery{}, func(dto.WatchUpdate) error {
+		sent++
+		return sendErr
+	})
+	if !errors.Is(err, sendErr) || sent != 1 {
+		t.Errorf("streamEvents returned %v after %d sends, want the send error of the opening heartbeat", err, sent)
+	}
+}
+
+func TestWatchRejectsInvalidIDs(t *testing.T) {
+	s := &ShipmentServiceImpl{}
+	send := func(dto.WatchUpdate) error { return nil }
+	if err := s.WatchShipment(context.Background(), 0, 0, send); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("WatchShipment returned %v for shipment 0, want ErrInvalidRequest", err)
+	}
+	if err := s.WatchOrder(context.Background(), 1, -1, send); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("WatchOrder returned %v for a negative event ID, want ErrInvalidRequest", err)
+	}
+}
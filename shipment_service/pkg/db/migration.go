@@ -14,6 +14,17 @@ func MigrateDB(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&model.Shipment{},
 		&model.ShipmentItem{},
+		&model.ShipmentEvent{},
+		&model.SkuDimension{},
+		&model.ShippingZone{},
+		&model.Return{},
+		&model.ReturnItem{},
+		&model.Warehouse{},
+		&model.WarehouseStock{},
+		&model.Parcel{},
+		&model.ParcelItem{},
+		&model.DeliveryProof{},
+		&model.DeliveryFile{},
 	)
 	if err != nil {
 		return err
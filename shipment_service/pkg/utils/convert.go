@@ -4,6 +4,7 @@ import (
 	"billing-system/shipment_service/internal/dto"
 	"billing-system/shipment_service/internal/model"
 	pb "billing-system/shipment_service/proto"
+	"fmt"
 	"time"
 )
 
@@ -22,10 +23,20 @@ func ConvertProtoItemsToDTO(protoItems []*pb.ShipmentItemRequest) []dto.Shipment
 // ConvertShipmentToProtoData converts a domain Shipment to proto ShipmentData
 func ConvertShipmentToProtoData(shipment *model.Shipment) *pb.ShipmentData {
 	shipmentData := &pb.ShipmentData{
-		ShipmentId: shipment.ID,
-		OrderId:    shipment.OrderID,
-		Status:     string(shipment.Status),
-		CreatedAt:  shipment.CreatedAt.Format(time.RFC3339),
+		ShipmentId:     shipment.ID,
+		OrderId:        shipment.OrderID,
+		Status:         string(shipment.Status),
+		CreatedAt:      shipment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      shipment.UpdatedAt.Format(time.RFC3339),
+		CarrierCode:    shipment.CarrierCode,
+		TrackingNumber: shipment.TrackingNumber,
+
+		DestinationPostalCode: shipment.DestinationPostalCode,
+		ShippingZone:          shipment.ShippingZone,
+		ChargeableWeightKg:    shipment.ChargeableWeightKg,
+		ShippingFee:           shipment.ShippingFee,
+		WarehouseId:           shipment.WarehouseID,
+		ApiKeyId:              shipment.APIKeyID,
 	}
 
 	// Convert shipment items
@@ -37,6 +48,403 @@ func ConvertShipmentToProtoData(shipment *model.Shipment) *pb.ShipmentData {
 		}
 	}
 	shipmentData.Items = protoItems
+	shipmentData.Parcels = ConvertParcelsToProto(shipment.Parcels)
+	shipmentData.ShipTo = ConvertAddressToProto(shipment.ShipTo)
+	shipmentData.DeliveryProof = ConvertDeliveryProofToProto(shipment.DeliveryProof)
 
 	return shipmentData
 }
+
+// ConvertParcelsToProto converts domain Parcels to proto Parcels
+func ConvertParcelsToProto(parcels []model.Parcel) []*pb.Parcel {
+	protoParcels := make([]*pb.Parcel, len(parcels))
+	for i, parcel := range parcels {
+		protoParcels[i] = &pb.Parcel{
+			ParcelId:            parcel.ID,
+			Sequence:            int32(parcel.Sequence),
+			WeightKg:            parcel.WeightKg,
+			LengthCm:            parcel.LengthCm,
+			WidthCm:             parcel.WidthCm,
+			HeightCm:            parcel.HeightCm,
+			DimensionalWeightKg: parcel.DimensionalWeightKg,
+			ChargeableWeightKg:  parcel.ChargeableWeightKg,
+			Items:               make([]*pb.ShipmentItem, len(parcel.Items)),
+		}
+		for j, item := range parcel.Items {
+			protoParcels[i].Items[j] = &pb.ShipmentItem{
+				Sku:      item.Sku,
+				Quantity: int32(item.Quantity),
+			}
+		}
+	}
+	return protoParcels
+}
+
+// ConvertProtoParcelsToDTO converts proto ParcelRequests to DTO ParcelRequests
+func ConvertProtoParcelsToDTO(protoParcels []*pb.ParcelRequest) []dto.ParcelRequest {
+	parcels := make([]dto.ParcelRequest, len(protoParcels))
+	for i, parcel := range protoParcels {
+		parcels[i] = dto.ParcelRequest{
+			WeightKg: parcel.WeightKg,
+			LengthCm: parcel.LengthCm,
+			WidthCm:  parcel.WidthCm,
+			HeightCm: parcel.HeightCm,
+			Items:    ConvertProtoItemsToDTO(parcel.Items),
+		}
+	}
+	return parcels
+}
+
+// ConvertShipmentsToProtoData converts domain Shipments to proto ShipmentData
+func ConvertShipmentsToProtoData(shipments []model.Shipment) []*pb.ShipmentData {
+	shipmentData := make([]*pb.ShipmentData, len(shipments))
+	for i := range shipments {
+		shipmentData[i] = ConvertShipmentToProtoData(&shipments[i])
+	}
+	return shipmentData
+}
+
+// ConvertProtoListRequestToFilter converts a proto ListShipmentsRequest to a DTO ShipmentFilter
+func ConvertProtoListRequestToFilter(req *pb.ListShipmentsRequest) (dto.ShipmentFilter, error) {
+	filter := dto.ShipmentFilter{
+		OrderID:  req.OrderId,
+		Status:   model.ShipmentStatus(req.Status),
+		PageSize: int(req.PageSize),
+		Cursor:   req.Cursor,
+	}
+
+	if req.CreatedFrom != "" {
+		createdFrom, err := time.Parse(time.RFC3339, req.CreatedFrom)
+		if err != nil {
+			return dto.ShipmentFilter{}, fmt.Errorf("invalid created_from: %w", err)
+		}
+		filter.CreatedFrom = createdFrom
+	}
+
+	if req.CreatedTo != "" {
+		createdTo, err := time.Parse(time.RFC3339, req.CreatedTo)
+		if err != nil {
+			return dto.ShipmentFilter{}, fmt.Errorf("invalid created_to: %w", err)
+		}
+		filter.CreatedTo = createdTo
+	}
+
+	return filter, nil
+}
+
+// ConvertProtoStatusRequestToEvent converts a proto UpdateShipmentStatusRequest to a DTO ShipmentEventRequest
+func ConvertProtoStatusRequestToEvent(req *pb.UpdateShipmentStatusRequest) (dto.ShipmentEventRequest, error) {
+	event := dto.ShipmentEventRequest{
+		Location: req.Location,
+		Actor:    req.Actor,
+		Note:     req.Note,
+	}
+
+	if req.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, req.Timestamp)
+		if err != nil {
+			return dto.ShipmentEventRequest{}, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		event.Timestamp = timestamp
+	}
+
+	return event, nil
+}
+
+// ConvertShipmentEventsToProto converts domain ShipmentEvents to proto ShipmentEvents
+func ConvertShipmentEventsToProto(events []model.ShipmentEvent) []*pb.ShipmentEvent {
+	protoEvents := make([]*pb.ShipmentEvent, len(events))
+	for i := range events {
+		protoEvents[i] = ConvertShipmentEventToProto(&events[i])
+	}
+	return protoEvents
+}
+
+// ConvertShipmentEventToProto converts a domain ShipmentEvent to a proto ShipmentEvent
+func ConvertShipmentEventToProto(event *model.ShipmentEvent) *pb.ShipmentEvent {
+	return &pb.ShipmentEvent{
+		Id:             event.ID,
+		Status:         string(event.Status),
+		PreviousStatus: string(event.PreviousStatus),
+		Timestamp:      event.Timestamp.Format(time.RFC3339),
+		Location:       event.Location,
+		Actor:          event.Actor,
+		Note:           event.Note,
+	}
+}
+
+// ConvertWatchUpdateToProto converts a DTO WatchUpdate to a proto WatchUpdate
+func ConvertWatchUpdateToProto(update dto.WatchUpdate) *pb.WatchUpdate {
+	protoUpdate := &pb.WatchUpdate{
+		OrderId:    update.OrderID,
+		ShipmentId: update.ShipmentID,
+		Heartbeat:  update.Heartbeat,
+	}
+	if update.Event != nil {
+		protoUpdate.Event = ConvertShipmentEventToProto(update.Event)
+	}
+	return protoUpdate
+}
+
+// ConvertQuotesToProto converts DTO ShippingQuotes to proto ShippingRates
+func ConvertQuotesToProto(quotes []dto.ShippingQuote) []*pb.ShippingRate {
+	protoRates := make([]*pb.ShippingRate, len(quotes))
+	for i, quote := range quotes {
+		protoRates[i] = &pb.ShippingRate{
+			CarrierCode:        quote.CarrierCode,
+			Service:            quote.Service,
+			Amount:             quote.CarrierAmount,
+			EstimatedDays:      int32(quote.EstimatedDays),
+			ChargeableWeightKg: quote.ChargeableWeightKg,
+			ShippingZone:       quote.Zone,
+			ShippingFee:        quote.Fee,
+		}
+	}
+	return protoRates
+}
+
+// ConvertProtoDimensionsToModel converts proto SkuDimensions to domain SkuDimensions
+func ConvertProtoDimensionsToModel(protoDimensions []*pb.SkuDimension) []model.SkuDimension {
+	dimensions := make([]model.SkuDimension, len(protoDimensions))
+	for i, d := range protoDimensions {
+		dimensions[i] = model.SkuDimension{
+			Sku:      d.Sku,
+			WeightKg: d.WeightKg,
+			LengthCm: d.LengthCm,
+			WidthCm:  d.WidthCm,
+			HeightCm: d.HeightCm,
+		}
+	}
+	return dimensions
+}
+
+// ConvertProtoZoneToModel converts a proto ShippingZone to a domain ShippingZone
+func ConvertProtoZoneToModel(zone *pb.ShippingZone) *model.ShippingZone {
+	return &model.ShippingZone{
+		Code:           zone.Code,
+		Name:           zone.Name,
+		PostalPrefixes: zone.PostalPrefixes,
+		Multiplier:     zone.Multiplier,
+		Surcharge:      zone.Surcharge,
+	}
+}
+
+// ConvertZoneToProto converts a domain ShippingZone to a proto ShippingZone
+func ConvertZoneToProto(zone *model.ShippingZone) *pb.ShippingZone {
+	return &pb.ShippingZone{
+		Id:             zone.ID,
+		Code:           zone.Code,
+		Name:           zone.Name,
+		PostalPrefixes: zone.PostalPrefixes,
+		Multiplier:     zone.Multiplier,
+		Surcharge:      zone.Surcharge,
+	}
+}
+
+// ConvertReturnToProtoData converts a domain Return to proto ReturnData
+func ConvertReturnToProtoData(ret *model.Return) *pb.ReturnData {
+	data := &pb.ReturnData{
+		ReturnId:       ret.ID,
+		ShipmentId:     ret.ShipmentID,
+		OrderId:        ret.OrderID,
+		Status:         string(ret.Status),
+		Reason:         ret.Reason,
+		Note:           ret.Note,
+		CreditNoteId:   ret.CreditNoteID,
+		CreditedAmount: ret.CreditedAmount,
+		CreatedAt:      ret.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      ret.UpdatedAt.Format(time.RFC3339),
+	}
+	if ret.ReceivedAt != nil {
+		data.ReceivedAt = ret.ReceivedAt.Format(time.RFC3339)
+	}
+
+	data.Items = make([]*pb.ShipmentItem, len(ret.Items))
+	for i, item := range ret.Items {
+		data.Items[i] = &pb.ShipmentItem{
+			Sku:      item.Sku,
+			Quantity: int32(item.Quantity),
+		}
+	}
+
+	return data
+}
+
+// ConvertItemsToProto converts DTO ShipmentItemRequests to proto ShipmentItemRequests
+func ConvertItemsToProto(items []dto.ShipmentItemRequest) []*pb.ShipmentItemRequest {
+	protoItems := make([]*pb.ShipmentItemRequest, len(items))
+	for i, item := range items {
+		protoItems[i] = &pb.ShipmentItemRequest{
+			Sku:      item.Sku,
+			Quantity: int32(item.Quantity),
+		}
+	}
+	return protoItems
+}
+
+// ConvertProtoCreateShipmentRequestToDTO converts a proto CreateShipmentRequest without a plan to a DTO CreateShipmentRequest
+func ConvertProtoCreateShipmentRequestToDTO(req *pb.CreateShipmentRequest) dto.CreateShipmentRequest {
+	return dto.CreateShipmentRequest{
+		OrderID:               req.OrderId,
+		CarrierCode:           req.CarrierCode,
+		DestinationPostalCode: req.DestinationPostalCode,
+		WarehouseID:           req.WarehouseId,
+		Items:                 ConvertProtoItemsToDTO(req.Items),
+		Parcels:               ConvertProtoParcelsToDTO(req.Parcels),
+		ShipTo:                ConvertProtoAddressToModel(req.ShipTo),
+	}
+}
+
+// ConvertProtoPlanToDTO converts proto PlannedShipments to DTO PlannedShipments
+func ConvertProtoPlanToDTO(plan []*pb.PlannedShipment) []dto.PlannedShipment {
+	shipments := make([]dto.PlannedShipment, len(plan))
+	for i, planned := range plan {
+		shipments[i] = dto.PlannedShipment{
+			WarehouseID:   planned.WarehouseId,
+			WarehouseCode: planned.WarehouseCode,
+			Items:         ConvertProtoItemsToDTO(planned.Items),
+		}
+	}
+	return shipments
+}
+
+// ConvertPlanToProto converts a DTO AllocationPlan to its proto response
+func ConvertPlanToProto(plan *dto.AllocationPlan) *pb.AllocateShipmentsResponse {
+	shipments := make([]*pb.PlannedShipment, len(plan.Shipments))
+	for i, planned := range plan.Shipments {
+		shipments[i] = &pb.PlannedShipment{
+			WarehouseId:   planned.WarehouseID,
+			WarehouseCode: planned.WarehouseCode,
+			Items:         ConvertItemsToProto(planned.Items),
+		}
+	}
+	return &pb.AllocateShipmentsResponse{
+		Strategy:    string(plan.Strategy),
+		Shipments:   shipments,
+		Unallocated: ConvertItemsToProto(plan.Unallocated),
+	}
+}
+
+// ConvertProtoWarehouseToModel converts a proto Warehouse to a domain Warehouse
+func ConvertProtoWarehouseToModel(warehouse *pb.Warehouse) *model.Warehouse {
+	return &model.Warehouse{
+		Code:       warehouse.Code,
+		Name:       warehouse.Name,
+		PostalCode: warehouse.PostalCode,
+		Priority:   int(warehouse.Priority),
+		Active:     warehouse.Active,
+	}
+}
+
+// ConvertWarehouseToProto converts a domain Warehouse to a proto Warehouse
+func ConvertWarehouseToProto(warehouse *model.Warehouse) *pb.Warehouse {
+	return &pb.Warehouse{
+		Id:         warehouse.ID,
+		Code:       warehouse.Code,
+		Name:       warehouse.Name,
+		PostalCode: warehouse.PostalCode,
+		Priority:   int32(warehouse.Priority),
+		Active:     warehouse.Active,
+	}
+}
+
+// ConvertWarehousesToProto converts domain Warehouses to proto Warehouses
+func ConvertWarehousesToProto(warehouses []model.Warehouse) []*pb.Warehouse {
+	protoWarehouses := make([]*pb.Warehouse, len(warehouses))
+	for i := range warehouses {
+		protoWarehouses[i] = ConvertWarehouseToProto(&warehouses[i])
+	}
+	return protoWarehouses
+}
+
+// ConvertProtoStockToModel converts proto WarehouseStocks to domain WarehouseStocks
+func ConvertProtoStockToModel(protoStock []*pb.WarehouseStock) []model.WarehouseStock {
+	stock := make([]model.WarehouseStock, len(protoStock))
+	for i, st := range protoStock {
+		stock[i] = model.WarehouseStock{
+			WarehouseID: st.WarehouseId,
+			Sku:         st.Sku,
+			Quantity:    int(st.Quantity),
+		}
+	}
+	return stock
+}
+
+// ConvertProtoAddressToModel converts a proto Address to a domain Address, an unset address is empty
+func ConvertProtoAddressToModel(address *pb.Address) model.Address {
+	if address == nil {
+		return model.Address{}
+	}
+	return model.Address{
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+// ConvertAddressToProto converts a domain Address to a proto Address
+func ConvertAddressToProto(address model.Address) *pb.Address {
+	return &pb.Address{
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+// ConvertProtoDeliveryDetailsToDTO converts proto DeliveryDetails to a DTO ConfirmDeliveryRequest without its files
+func ConvertProtoDeliveryDetailsToDTO(details *pb.DeliveryDetails) (dto.ConfirmDeliveryRequest, error) {
+	req := dto.ConfirmDeliveryRequest{
+		ShipmentID:    details.ShipmentId,
+		RecipientName: details.RecipientName,
+		Latitude:      details.Latitude,
+		Longitude:     details.Longitude,
+		Actor:         details.Actor,
+		Note:          details.Note,
+	}
+
+	if details.DeliveredAt != "" {
+		deliveredAt, err := time.Parse(time.RFC3339, details.DeliveredAt)
+		if err != nil {
+			return dto.ConfirmDeliveryRequest{}, fmt.Errorf("invalid delivered_at: %w", err)
+		}
+		req.DeliveredAt = deliveredAt
+	}
+
+	return req, nil
+}
+
+// ConvertDeliveryProofToProto converts a domain DeliveryProof to a proto DeliveryProof, nil when there is none
+func ConvertDeliveryProofToProto(proof *model.DeliveryProof) *pb.DeliveryProof {
+	if proof == nil {
+		return nil
+	}
+
+	protoFiles := make([]*pb.DeliveryFile, len(proof.Files))
+	for i, file := range proof.Files {
+		protoFiles[i] = &pb.DeliveryFile{
+			FileId:      file.ID,
+			Kind:        string(file.Kind),
+			ContentType: file.ContentType,
+			SizeBytes:   file.SizeBytes,
+			Sha256:      file.Sha256,
+		}
+	}
+
+	return &pb.DeliveryProof{
+		RecipientName: proof.RecipientName,
+		Latitude:      proof.Latitude,
+		Longitude:     proof.Longitude,
+		DeliveredAt:   proof.DeliveredAt.Format(time.RFC3339),
+		Files:         protoFiles,
+	}
+}
@@ -150,6 +150,22 @@ func TestConvertShipmentToProtoData(t *testing.T) {
 				Items:      []*pb.ShipmentItem{},
 			},
 		},
+		{
+			name: "Shipment created with an API key",
+			shipment: &model.Shipment{
+				Base:     model.Base{ID: 124, CreatedAt: testTime, UpdatedAt: testTime},
+				OrderID:  456,
+				Status:   model.Created,
+				APIKeyID: "3f9a1c2b7d4e",
+			},
+			want: &pb.ShipmentData{
+				ShipmentId: 124,
+				OrderId:    456,
+				Status:     string(model.Created),
+				CreatedAt:  testTime.Format(time.RFC3339),
+				ApiKeyId:   "3f9a1c2b7d4e",
+			},
+		},
 	}
 
 	// Run tests
@@ -170,6 +186,9 @@ func TestConvertShipmentToProtoData(t *testing.T) {
 			if got.CreatedAt != tt.want.CreatedAt {
 				t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, tt.want.CreatedAt)
 			}
+			if got.ApiKeyId != tt.want.ApiKeyId {
+				t.Errorf("ApiKeyId = %v, want %v", got.ApiKeyId, tt.want.ApiKeyId)
+			}
 
 			// Check items
 			if len(got.Items) != len(tt.want.Items) {
@@ -186,3 +205,178 @@ func TestConvertShipmentToProtoData(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertProtoListRequestToFilter(t *testing.T) {
+	createdFrom := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test cases
+	tests := []struct {
+		name    string
+		req     *pb.ListShipmentsRequest
+		want    dto.ShipmentFilter
+		wantErr bool
+	}{
+		{
+			name: "Empty request",
+			req:  &pb.ListShipmentsRequest{},
+			want: dto.ShipmentFilter{},
+		},
+		{
+			name: "All filters",
+			req: &pb.ListShipmentsRequest{
+				OrderId:     456,
+				Status:      string(model.Confirmed),
+				CreatedFrom: createdFrom.Format(time.RFC3339),
+				CreatedTo:   createdTo.Format(time.RFC3339),
+				PageSize:    20,
+				Cursor:      "MTIz",
+			},
+			want: dto.ShipmentFilter{
+				OrderID:     456,
+				Status:      model.Confirmed,
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				PageSize:    20,
+				Cursor:      "MTIz",
+			},
+		},
+		{
+			name:    "Invalid created_from",
+			req:     &pb.ListShipmentsRequest{CreatedFrom: "2023-09-01"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid created_to",
+			req:     &pb.ListShipmentsRequest{CreatedTo: "yesterday"},
+			wantErr: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertProtoListRequestToFilter(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertProtoListRequestToFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertProtoListRequestToFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertProtoStatusRequestToEvent(t *testing.T) {
+	timestamp := time.Date(2023, 9, 15, 12, 30, 0, 0, time.UTC)
+
+	// Test cases
+	tests := []struct {
+		name    string
+		req     *pb.UpdateShipmentStatusRequest
+		want    dto.ShipmentEventRequest
+		wantErr bool
+	}{
+		{
+			name: "Without timestamp",
+			req:  &pb.UpdateShipmentStatusRequest{ShipmentId: 123, Status: "PICKED", Actor: "picker-7"},
+			want: dto.ShipmentEventRequest{Actor: "picker-7"},
+		},
+		{
+			name: "All fields",
+			req: &pb.UpdateShipmentStatusRequest{
+				ShipmentId: 123,
+				Status:     "IN_TRANSIT",
+				Timestamp:  timestamp.Format(time.RFC3339),
+				Location:   "Hanoi hub",
+				Actor:      "carrier",
+				Note:       "Left the hub",
+			},
+			want: dto.ShipmentEventRequest{
+				Timestamp: timestamp,
+				Location:  "Hanoi hub",
+				Actor:     "carrier",
+				Note:      "Left the hub",
+			},
+		},
+		{
+			name:    "Invalid timestamp",
+			req:     &pb.UpdateShipmentStatusRequest{Timestamp: "15/09/2023"},
+			wantErr: true,
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertProtoStatusRequestToEvent(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertProtoStatusRequestToEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConvertProtoStatusRequestToEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertWatchUpdateToProto(t *testing.T) {
+	timestamp := time.Date(2023, 9, 15, 12, 30, 0, 0, time.UTC)
+
+	// Test cases
+	tests := []struct {
+		name   string
+		update dto.WatchUpdate
+		want   *pb.WatchUpdate
+	}{
+		{
+			name: "Event",
+			update: dto.WatchUpdate{
+				OrderID:    7,
+				ShipmentID: 123,
+				Event: &model.ShipmentEvent{
+					ID:             42,
+					ShipmentID:     123,
+					Status:         model.InTransit,
+					PreviousStatus: model.Packed,
+					Timestamp:      timestamp,
+					Actor:          "carrier",
+				},
+			},
+			want: &pb.WatchUpdate{
+				OrderId:    7,
+				ShipmentId: 123,
+				Event: &pb.ShipmentEvent{
+					Id:             42,
+					Status:         "IN_TRANSIT",
+					PreviousStatus: "PACKED",
+					Timestamp:      timestamp.Format(time.RFC3339),
+					Actor:          "carrier",
+				},
+			},
+		},
+		{
+			name:   "Heartbeat",
+			update: dto.WatchUpdate{OrderID: 7, Heartbeat: true},
+			want:   &pb.WatchUpdate{OrderId: 7, Heartbeat: true},
+		},
+	}
+
+	// Run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertWatchUpdateToProto(tt.update)
+			if got.OrderId != tt.want.OrderId || got.ShipmentId != tt.want.ShipmentId || got.Heartbeat != tt.want.Heartbeat {
+				t.Errorf("ConvertWatchUpdateToProto() = %v, want %v", got, tt.want)
+			}
+			if (got.Event == nil) != (tt.want.Event == nil) {
+				t.Fatalf("ConvertWatchUpdateToProto() event = %v, want %v", got.Event, tt.want.Event)
+			}
+			if got.Event != nil && (got.Event.Id != tt.want.Event.Id || got.Event.Status != tt.want.Event.Status ||
+				got.Event.PreviousStatus != tt.want.Event.PreviousStatus || got.Event.Timestamp != tt.want.Event.Timestamp ||
+				got.Event.Actor != tt.want.Event.Actor) {
+				t.Errorf("ConvertWatchUpdateToProto() event = %v, want %v", got.Event, tt.want.Event)
+			}
+		})
+	}
+}
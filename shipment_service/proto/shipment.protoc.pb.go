@@ -76,11 +76,17 @@ func (x *ShipmentItemRequest) GetQuantity() int32 {
 
 // Request message for creating a shipment
 type CreateShipmentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Items         []*ShipmentItemRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	OrderId               int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items                 []*ShipmentItemRequest `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	CarrierCode           string                 `protobuf:"bytes,3,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"`                                 // Defaults to the configured carrier
+	DestinationPostalCode string                 `protobuf:"bytes,4,opt,name=destination_postal_code,json=destinationPostalCode,proto3" json:"destination_postal_code,omitempty"` // Selects the shipping zone
+	WarehouseId           int64                  `protobuf:"varint,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`                                // Reserves the items from the warehouse's stock
+	Plan                  []*PlannedShipment     `protobuf:"bytes,6,rep,name=plan,proto3" json:"plan,omitempty"`                                                                  // Creates one shipment per planned shipment instead, items and warehouse_id are ignored
+	Parcels               []*ParcelRequest       `protobuf:"bytes,7,rep,name=parcels,proto3" json:"parcels,omitempty"`                                                            // Packages the items are already packed in, priced instead of the SKU dimensions
+	ShipTo                *Address               `protobuf:"bytes,8,opt,name=ship_to,json=shipTo,proto3" json:"ship_to,omitempty"`                                                // Recipient printed on labels, its postal code defaults to destination_postal_code
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateShipmentRequest) Reset() {
@@ -127,12 +133,56 @@ func (x *CreateShipmentRequest) GetItems() []*ShipmentItemRequest {
 	return nil
 }
 
+func (x *CreateShipmentRequest) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetDestinationPostalCode() string {
+	if x != nil {
+		return x.DestinationPostalCode
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *CreateShipmentRequest) GetPlan() []*PlannedShipment {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetParcels() []*ParcelRequest {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+func (x *CreateShipmentRequest) GetShipTo() *Address {
+	if x != nil {
+		return x.ShipTo
+	}
+	return nil
+}
+
 // Response message for creating a shipment
+// Failures are returned as error statuses, invalid items with a BadRequest detail listing every violating line
 type CreateShipmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"` // Always 1, kept for older clients
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *ShipmentData          `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *ShipmentData          `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`           // Unset when a plan was given
+	Shipments     []*ShipmentData        `protobuf:"bytes,5,rep,name=shipments,proto3" json:"shipments,omitempty"` // Shipments created from the plan
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -188,21 +238,239 @@ func (x *CreateShipmentResponse) GetData() *ShipmentData {
 	return nil
 }
 
-// Shipment data in response
-type ShipmentData struct {
+func (x *CreateShipmentResponse) GetShipments() []*ShipmentData {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+// A line of a CreateShipments stream
+type CreateShipmentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
-	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Items         []*ShipmentItem        `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Shipment      *CreateShipmentRequest `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`   // Plans are not accepted, send a line per planned shipment
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`          // Line of the manifest, echoed in its result, the position in the stream when zero
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"` // Reference of the sender, echoed in its result
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentsRequest) Reset() {
+	*x = CreateShipmentsRequest{}
+	mi := &file_shipment_protoc_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentsRequest) ProtoMessage() {}
+
+func (x *CreateShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateShipmentsRequest) GetShipment() *CreateShipmentRequest {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+func (x *CreateShipmentsRequest) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *CreateShipmentsRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+// Results of the lines of a CreateShipments stream, confirmed and failed, each in the order of the stream
+type CreateShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Confirmed     []*ShipmentLineResult  `protobuf:"bytes,1,rep,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Failed        []*ShipmentLineResult  `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	Reasons       map[string]int32       `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Number of failed lines per reason
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentsResponse) Reset() {
+	*x = CreateShipmentsResponse{}
+	mi := &file_shipment_protoc_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentsResponse) ProtoMessage() {}
+
+func (x *CreateShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*CreateShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateShipmentsResponse) GetConfirmed() []*ShipmentLineResult {
+	if x != nil {
+		return x.Confirmed
+	}
+	return nil
+}
+
+func (x *CreateShipmentsResponse) GetFailed() []*ShipmentLineResult {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+func (x *CreateShipmentsResponse) GetReasons() map[string]int32 {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+// Result of a line of a CreateShipments stream
+type ShipmentLineResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	Shipment      *ShipmentData          `protobuf:"bytes,3,opt,name=shipment,proto3" json:"shipment,omitempty"` // Set on confirmed lines
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`     // Reason of the error of a failed line, such as OUT_OF_STOCK
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ShipmentLineResult) Reset() {
+	*x = ShipmentLineResult{}
+	mi := &file_shipment_protoc_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentLineResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentLineResult) ProtoMessage() {}
+
+func (x *ShipmentLineResult) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentLineResult.ProtoReflect.Descriptor instead.
+func (*ShipmentLineResult) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{5}
+}
+
+func (x *ShipmentLineResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ShipmentLineResult) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ShipmentLineResult) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+func (x *ShipmentLineResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ShipmentLineResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Shipment data in response
+type ShipmentData struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId            int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId               int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status                string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Items                 []*ShipmentItem        `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CarrierCode           string                 `protobuf:"bytes,7,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"`
+	TrackingNumber        string                 `protobuf:"bytes,8,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	DestinationPostalCode string                 `protobuf:"bytes,9,opt,name=destination_postal_code,json=destinationPostalCode,proto3" json:"destination_postal_code,omitempty"`
+	ShippingZone          string                 `protobuf:"bytes,10,opt,name=shipping_zone,json=shippingZone,proto3" json:"shipping_zone,omitempty"`
+	ChargeableWeightKg    float64                `protobuf:"fixed64,11,opt,name=chargeable_weight_kg,json=chargeableWeightKg,proto3" json:"chargeable_weight_kg,omitempty"`
+	ShippingFee           float64                `protobuf:"fixed64,12,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"` // Billed on the shipment's invoice
+	WarehouseId           int64                  `protobuf:"varint,13,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`  // Zero when created without a warehouse
+	Parcels               []*Parcel              `protobuf:"bytes,14,rep,name=parcels,proto3" json:"parcels,omitempty"`                              // Empty until the shipment is packed
+	ShipTo                *Address               `protobuf:"bytes,15,opt,name=ship_to,json=shipTo,proto3" json:"ship_to,omitempty"`
+	DeliveryProof         *DeliveryProof         `protobuf:"bytes,16,opt,name=delivery_proof,json=deliveryProof,proto3" json:"delivery_proof,omitempty"` // Unset until the delivery is confirmed
+	ApiKeyId              string                 `protobuf:"bytes,17,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`              // Public id of the API key the shipment was created with, empty for users
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
 func (x *ShipmentData) Reset() {
 	*x = ShipmentData{}
-	mi := &file_shipment_protoc_msgTypes[3]
+	mi := &file_shipment_protoc_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -214,7 +482,7 @@ func (x *ShipmentData) String() string {
 func (*ShipmentData) ProtoMessage() {}
 
 func (x *ShipmentData) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_protoc_msgTypes[3]
+	mi := &file_shipment_protoc_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +495,7 @@ func (x *ShipmentData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShipmentData.ProtoReflect.Descriptor instead.
 func (*ShipmentData) Descriptor() ([]byte, []int) {
-	return file_shipment_protoc_rawDescGZIP(), []int{3}
+	return file_shipment_protoc_rawDescGZIP(), []int{6}
 }
 
 func (x *ShipmentData) GetShipmentId() int64 {
@@ -265,30 +533,120 @@ func (x *ShipmentData) GetCreatedAt() string {
 	return ""
 }
 
-// Shipment item in response
-type ShipmentItem struct {
+func (x *ShipmentData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ShipmentData) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *ShipmentData) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *ShipmentData) GetDestinationPostalCode() string {
+	if x != nil {
+		return x.DestinationPostalCode
+	}
+	return ""
+}
+
+func (x *ShipmentData) GetShippingZone() string {
+	if x != nil {
+		return x.ShippingZone
+	}
+	return ""
+}
+
+func (x *ShipmentData) GetChargeableWeightKg() float64 {
+	if x != nil {
+		return x.ChargeableWeightKg
+	}
+	return 0
+}
+
+func (x *ShipmentData) GetShippingFee() float64 {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return 0
+}
+
+func (x *ShipmentData) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *ShipmentData) GetParcels() []*Parcel {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+func (x *ShipmentData) GetShipTo() *Address {
+	if x != nil {
+		return x.ShipTo
+	}
+	return nil
+}
+
+func (x *ShipmentData) GetDeliveryProof() *DeliveryProof {
+	if x != nil {
+		return x.DeliveryProof
+	}
+	return nil
+}
+
+func (x *ShipmentData) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+// Postal address of a recipient
+type Address struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Line1         string                 `protobuf:"bytes,2,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,3,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"`
+	Phone         string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShipmentItem) Reset() {
-	*x = ShipmentItem{}
-	mi := &file_shipment_protoc_msgTypes[4]
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_shipment_protoc_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShipmentItem) String() string {
+func (x *Address) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShipmentItem) ProtoMessage() {}
+func (*Address) ProtoMessage() {}
 
-func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
-	mi := &file_shipment_protoc_msgTypes[4]
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,25 +657,3341 @@ func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
-func (*ShipmentItem) Descriptor() ([]byte, []int) {
-	return file_shipment_protoc_rawDescGZIP(), []int{4}
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{7}
 }
 
-func (x *ShipmentItem) GetSku() string {
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+// Shipment item in response
+type ShipmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_shipment_protoc_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{8}
+}
+
+func (x *ShipmentItem) GetSku() string {
 	if x != nil {
 		return x.Sku
 	}
 	return ""
 }
 
-func (x *ShipmentItem) GetQuantity() int32 {
+func (x *ShipmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Request message for getting a shipment
+type GetShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentRequest) Reset() {
+	*x = GetShipmentRequest{}
+	mi := &file_shipment_protoc_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentRequest) ProtoMessage() {}
+
+func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentRequest.ProtoReflect.Descriptor instead.
+func (*GetShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{9}
+}
+
+func (x *GetShipmentRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+// Response message for getting a shipment
+type GetShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *ShipmentData          `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentResponse) Reset() {
+	*x = GetShipmentResponse{}
+	mi := &file_shipment_protoc_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentResponse) ProtoMessage() {}
+
+func (x *GetShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentResponse.ProtoReflect.Descriptor instead.
+func (*GetShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{10}
+}
+
+func (x *GetShipmentResponse) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Request message for listing shipments, unset filters match every shipment
+type ListShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedFrom   string                 `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // RFC 3339, inclusive
+	CreatedTo     string                 `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`       // RFC 3339, exclusive
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`         // Defaults to 50, at most 200
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`                              // next_cursor of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsRequest) Reset() {
+	*x = ListShipmentsRequest{}
+	mi := &file_shipment_protoc_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsRequest) ProtoMessage() {}
+
+func (x *ListShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{11}
+}
+
+func (x *ListShipmentsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ListShipmentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListShipmentsRequest) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *ListShipmentsRequest) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *ListShipmentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListShipmentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Response message for listing shipments
+type ListShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*ShipmentData        `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsResponse) Reset() {
+	*x = ListShipmentsResponse{}
+	mi := &file_shipment_protoc_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsResponse) ProtoMessage() {}
+
+func (x *ListShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{12}
+}
+
+func (x *ListShipmentsResponse) GetShipments() []*ShipmentData {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *ListShipmentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Request message for updating the status of a shipment
+type UpdateShipmentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`       // CREATED, PICKED, PACKED, IN_TRANSIT, OUT_FOR_DELIVERY, DELIVERED, FAILED_DELIVERY, RETURNED
+	Timestamp     string                 `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // RFC 3339, defaults to now
+	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentStatusRequest) Reset() {
+	*x = UpdateShipmentStatusRequest{}
+	mi := &file_shipment_protoc_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentStatusRequest) ProtoMessage() {}
+
+func (x *UpdateShipmentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateShipmentStatusRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *UpdateShipmentStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UpdateShipmentStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Response message for updating the status of a shipment
+type UpdateShipmentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *ShipmentData          `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateShipmentStatusResponse) Reset() {
+	*x = UpdateShipmentStatusResponse{}
+	mi := &file_shipment_protoc_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateShipmentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateShipmentStatusResponse) ProtoMessage() {}
+
+func (x *UpdateShipmentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateShipmentStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateShipmentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateShipmentStatusResponse) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Status change of a shipment
+type ShipmentEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,3,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Timestamp      string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Location       string                 `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Actor          string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	Note           string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShipmentEvent) Reset() {
+	*x = ShipmentEvent{}
+	mi := &file_shipment_protoc_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentEvent) ProtoMessage() {}
+
+func (x *ShipmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentEvent.ProtoReflect.Descriptor instead.
+func (*ShipmentEvent) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{15}
+}
+
+func (x *ShipmentEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShipmentEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ShipmentEvent) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Request message for getting the tracking history of a shipment
+type GetTrackingHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackingHistoryRequest) Reset() {
+	*x = GetTrackingHistoryRequest{}
+	mi := &file_shipment_protoc_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackingHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackingHistoryRequest) ProtoMessage() {}
+
+func (x *GetTrackingHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackingHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTrackingHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTrackingHistoryRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+// Response message for getting the tracking history of a shipment
+type GetTrackingHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *ShipmentData          `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	Events        []*ShipmentEvent       `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrackingHistoryResponse) Reset() {
+	*x = GetTrackingHistoryResponse{}
+	mi := &file_shipment_protoc_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrackingHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrackingHistoryResponse) ProtoMessage() {}
+
+func (x *GetTrackingHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrackingHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTrackingHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTrackingHistoryResponse) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+func (x *GetTrackingHistoryResponse) GetEvents() []*ShipmentEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Request message for quoting shipping rates
+type QuoteShippingRatesRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Items                 []*ShipmentItemRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	CarrierCode           string                 `protobuf:"bytes,2,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"`                                 // Empty asks every carrier
+	DestinationPostalCode string                 `protobuf:"bytes,3,opt,name=destination_postal_code,json=destinationPostalCode,proto3" json:"destination_postal_code,omitempty"` // Selects the shipping zone
+	Parcels               []*ParcelRequest       `protobuf:"bytes,4,rep,name=parcels,proto3" json:"parcels,omitempty"`                                                            // Must hold exactly the items when given
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRatesRequest) Reset() {
+	*x = QuoteShippingRatesRequest{}
+	mi := &file_shipment_protoc_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRatesRequest) ProtoMessage() {}
+
+func (x *QuoteShippingRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRatesRequest.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRatesRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{18}
+}
+
+func (x *QuoteShippingRatesRequest) GetItems() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *QuoteShippingRatesRequest) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *QuoteShippingRatesRequest) GetDestinationPostalCode() string {
+	if x != nil {
+		return x.DestinationPostalCode
+	}
+	return ""
+}
+
+func (x *QuoteShippingRatesRequest) GetParcels() []*ParcelRequest {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+// Shipping rate of a carrier
+type ShippingRate struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CarrierCode        string                 `protobuf:"bytes,1,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"`
+	Service            string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Amount             float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"` // Charged by the carrier
+	EstimatedDays      int32                  `protobuf:"varint,4,opt,name=estimated_days,json=estimatedDays,proto3" json:"estimated_days,omitempty"`
+	ChargeableWeightKg float64                `protobuf:"fixed64,5,opt,name=chargeable_weight_kg,json=chargeableWeightKg,proto3" json:"chargeable_weight_kg,omitempty"` // Larger of the actual and dimensional weight
+	ShippingZone       string                 `protobuf:"bytes,6,opt,name=shipping_zone,json=shippingZone,proto3" json:"shipping_zone,omitempty"`                       // Empty when no zone covers the destination
+	ShippingFee        float64                `protobuf:"fixed64,7,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"`                        // Billed to the customer
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ShippingRate) Reset() {
+	*x = ShippingRate{}
+	mi := &file_shipment_protoc_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingRate) ProtoMessage() {}
+
+func (x *ShippingRate) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingRate.ProtoReflect.Descriptor instead.
+func (*ShippingRate) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{19}
+}
+
+func (x *ShippingRate) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *ShippingRate) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ShippingRate) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ShippingRate) GetEstimatedDays() int32 {
+	if x != nil {
+		return x.EstimatedDays
+	}
+	return 0
+}
+
+func (x *ShippingRate) GetChargeableWeightKg() float64 {
+	if x != nil {
+		return x.ChargeableWeightKg
+	}
+	return 0
+}
+
+func (x *ShippingRate) GetShippingZone() string {
+	if x != nil {
+		return x.ShippingZone
+	}
+	return ""
+}
+
+func (x *ShippingRate) GetShippingFee() float64 {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return 0
+}
+
+// Response message for quoting shipping rates
+type QuoteShippingRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*ShippingRate        `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteShippingRatesResponse) Reset() {
+	*x = QuoteShippingRatesResponse{}
+	mi := &file_shipment_protoc_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteShippingRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteShippingRatesResponse) ProtoMessage() {}
+
+func (x *QuoteShippingRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteShippingRatesResponse.ProtoReflect.Descriptor instead.
+func (*QuoteShippingRatesResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{20}
+}
+
+func (x *QuoteShippingRatesResponse) GetRates() []*ShippingRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// Request message carrying a carrier webhook as it was received
+type CarrierWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CarrierCode   string                 `protobuf:"bytes,1,opt,name=carrier_code,json=carrierCode,proto3" json:"carrier_code,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Body          []byte                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarrierWebhookRequest) Reset() {
+	*x = CarrierWebhookRequest{}
+	mi := &file_shipment_protoc_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarrierWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarrierWebhookRequest) ProtoMessage() {}
+
+func (x *CarrierWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarrierWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CarrierWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{21}
+}
+
+func (x *CarrierWebhookRequest) GetCarrierCode() string {
+	if x != nil {
+		return x.CarrierCode
+	}
+	return ""
+}
+
+func (x *CarrierWebhookRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *CarrierWebhookRequest) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+// Response message for a carrier webhook
+type CarrierWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       int32                  `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"` // Number of status changes applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarrierWebhookResponse) Reset() {
+	*x = CarrierWebhookResponse{}
+	mi := &file_shipment_protoc_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarrierWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarrierWebhookResponse) ProtoMessage() {}
+
+func (x *CarrierWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarrierWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CarrierWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{22}
+}
+
+func (x *CarrierWebhookResponse) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+// Request message for refreshing the tracking of a shipment
+type RefreshTrackingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTrackingRequest) Reset() {
+	*x = RefreshTrackingRequest{}
+	mi := &file_shipment_protoc_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTrackingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTrackingRequest) ProtoMessage() {}
+
+func (x *RefreshTrackingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTrackingRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTrackingRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{23}
+}
+
+func (x *RefreshTrackingRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+// Weight and packed size of one unit of a SKU
+type SkuDimension struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightKg      float64                `protobuf:"fixed64,2,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	LengthCm      float64                `protobuf:"fixed64,3,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm       float64                `protobuf:"fixed64,4,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm      float64                `protobuf:"fixed64,5,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkuDimension) Reset() {
+	*x = SkuDimension{}
+	mi := &file_shipment_protoc_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkuDimension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkuDimension) ProtoMessage() {}
+
+func (x *SkuDimension) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkuDimension.ProtoReflect.Descriptor instead.
+func (*SkuDimension) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{24}
+}
+
+func (x *SkuDimension) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *SkuDimension) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *SkuDimension) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *SkuDimension) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *SkuDimension) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+// Request message for storing SKU dimensions
+type UpsertSkuDimensionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dimensions    []*SkuDimension        `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertSkuDimensionsRequest) Reset() {
+	*x = UpsertSkuDimensionsRequest{}
+	mi := &file_shipment_protoc_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertSkuDimensionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertSkuDimensionsRequest) ProtoMessage() {}
+
+func (x *UpsertSkuDimensionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertSkuDimensionsRequest.ProtoReflect.Descriptor instead.
+func (*UpsertSkuDimensionsRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{25}
+}
+
+func (x *UpsertSkuDimensionsRequest) GetDimensions() []*SkuDimension {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+// Response message for storing SKU dimensions
+type UpsertSkuDimensionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertSkuDimensionsResponse) Reset() {
+	*x = UpsertSkuDimensionsResponse{}
+	mi := &file_shipment_protoc_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertSkuDimensionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertSkuDimensionsResponse) ProtoMessage() {}
+
+func (x *UpsertSkuDimensionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertSkuDimensionsResponse.ProtoReflect.Descriptor instead.
+func (*UpsertSkuDimensionsResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{26}
+}
+
+func (x *UpsertSkuDimensionsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+// Shipping zone adjusting the carrier rate of the postal codes it covers
+type ShippingZone struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PostalPrefixes []string               `protobuf:"bytes,4,rep,name=postal_prefixes,json=postalPrefixes,proto3" json:"postal_prefixes,omitempty"`
+	Multiplier     float64                `protobuf:"fixed64,5,opt,name=multiplier,proto3" json:"multiplier,omitempty"` // Scales the carrier rate, defaults to 1
+	Surcharge      float64                `protobuf:"fixed64,6,opt,name=surcharge,proto3" json:"surcharge,omitempty"`   // Added after the multiplier
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShippingZone) Reset() {
+	*x = ShippingZone{}
+	mi := &file_shipment_protoc_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingZone) ProtoMessage() {}
+
+func (x *ShippingZone) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingZone.ProtoReflect.Descriptor instead.
+func (*ShippingZone) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{27}
+}
+
+func (x *ShippingZone) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShippingZone) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ShippingZone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingZone) GetPostalPrefixes() []string {
+	if x != nil {
+		return x.PostalPrefixes
+	}
+	return nil
+}
+
+func (x *ShippingZone) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *ShippingZone) GetSurcharge() float64 {
+	if x != nil {
+		return x.Surcharge
+	}
+	return 0
+}
+
+// Request message for storing a shipping zone
+type UpsertShippingZoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zone          *ShippingZone          `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertShippingZoneRequest) Reset() {
+	*x = UpsertShippingZoneRequest{}
+	mi := &file_shipment_protoc_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertShippingZoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertShippingZoneRequest) ProtoMessage() {}
+
+func (x *UpsertShippingZoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertShippingZoneRequest.ProtoReflect.Descriptor instead.
+func (*UpsertShippingZoneRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{28}
+}
+
+func (x *UpsertShippingZoneRequest) GetZone() *ShippingZone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+// Response message for storing a shipping zone
+type UpsertShippingZoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zone          *ShippingZone          `protobuf:"bytes,1,opt,name=zone,proto3" json:"zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertShippingZoneResponse) Reset() {
+	*x = UpsertShippingZoneResponse{}
+	mi := &file_shipment_protoc_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertShippingZoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertShippingZoneResponse) ProtoMessage() {}
+
+func (x *UpsertShippingZoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertShippingZoneResponse.ProtoReflect.Descriptor instead.
+func (*UpsertShippingZoneResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{29}
+}
+
+func (x *UpsertShippingZoneResponse) GetZone() *ShippingZone {
+	if x != nil {
+		return x.Zone
+	}
+	return nil
+}
+
+// Request message for opening a return
+type RequestReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Items         []*ShipmentItemRequest `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_shipment_protoc_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnRequest) ProtoMessage() {}
+
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{30}
+}
+
+func (x *RequestReturnRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *RequestReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetItems() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Request message for getting a return
+type GetReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_shipment_protoc_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{31}
+}
+
+func (x *GetReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+// Request message for moving a return to its next status
+type ReturnActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Note          string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnActionRequest) Reset() {
+	*x = ReturnActionRequest{}
+	mi := &file_shipment_protoc_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnActionRequest) ProtoMessage() {}
+
+func (x *ReturnActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnActionRequest.ProtoReflect.Descriptor instead.
+func (*ReturnActionRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{32}
+}
+
+func (x *ReturnActionRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *ReturnActionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Return data in response
+type ReturnData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId       int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	ShipmentId     int64                  `protobuf:"varint,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	OrderId        int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // REQUESTED, APPROVED, REJECTED, RECEIVED, INSPECTED
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Note           string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	CreditNoteId   int64                  `protobuf:"varint,8,opt,name=credit_note_id,json=creditNoteId,proto3" json:"credit_note_id,omitempty"` // Set once billing credited the items
+	CreditedAmount float64                `protobuf:"fixed64,9,opt,name=credited_amount,json=creditedAmount,proto3" json:"credited_amount,omitempty"`
+	ReceivedAt     string                 `protobuf:"bytes,10,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReturnData) Reset() {
+	*x = ReturnData{}
+	mi := &file_shipment_protoc_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnData) ProtoMessage() {}
+
+func (x *ReturnData) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnData.ProtoReflect.Descriptor instead.
+func (*ReturnData) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{33}
+}
+
+func (x *ReturnData) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *ReturnData) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *ReturnData) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReturnData) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReturnData) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReturnData) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ReturnData) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ReturnData) GetCreditNoteId() int64 {
+	if x != nil {
+		return x.CreditNoteId
+	}
+	return 0
+}
+
+func (x *ReturnData) GetCreditedAmount() float64 {
+	if x != nil {
+		return x.CreditedAmount
+	}
+	return 0
+}
+
+func (x *ReturnData) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+func (x *ReturnData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ReturnData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Response message for return requests
+type ReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *ReturnData            `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnResponse) Reset() {
+	*x = ReturnResponse{}
+	mi := &file_shipment_protoc_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnResponse) ProtoMessage() {}
+
+func (x *ReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReturnResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{34}
+}
+
+func (x *ReturnResponse) GetData() *ReturnData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Fulfillment location shipments leave from
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,4,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"` // Lowest first
+	Active        bool                   `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`     // Only active warehouses are allocated to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_shipment_protoc_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{35}
+}
+
+func (x *Warehouse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Warehouse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Warehouse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Warehouse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// Request message for storing a warehouse
+type UpsertWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertWarehouseRequest) Reset() {
+	*x = UpsertWarehouseRequest{}
+	mi := &file_shipment_protoc_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertWarehouseRequest) ProtoMessage() {}
+
+func (x *UpsertWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpsertWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{36}
+}
+
+func (x *UpsertWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// Response message for storing a warehouse
+type UpsertWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertWarehouseResponse) Reset() {
+	*x = UpsertWarehouseResponse{}
+	mi := &file_shipment_protoc_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertWarehouseResponse) ProtoMessage() {}
+
+func (x *UpsertWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*UpsertWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{37}
+}
+
+func (x *UpsertWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// Request message for listing warehouses
+type ListWarehousesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_shipment_protoc_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{38}
+}
+
+// Response message for listing warehouses
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_shipment_protoc_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{39}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+// Quantity of a SKU on hand in a warehouse
+type WarehouseStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarehouseStock) Reset() {
+	*x = WarehouseStock{}
+	mi := &file_shipment_protoc_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarehouseStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarehouseStock) ProtoMessage() {}
+
+func (x *WarehouseStock) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarehouseStock.ProtoReflect.Descriptor instead.
+func (*WarehouseStock) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{40}
+}
+
+func (x *WarehouseStock) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *WarehouseStock) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *WarehouseStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Request message for storing warehouse stock
+type UpsertWarehouseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stock         []*WarehouseStock      `protobuf:"bytes,1,rep,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertWarehouseStockRequest) Reset() {
+	*x = UpsertWarehouseStockRequest{}
+	mi := &file_shipment_protoc_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertWarehouseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertWarehouseStockRequest) ProtoMessage() {}
+
+func (x *UpsertWarehouseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertWarehouseStockRequest.ProtoReflect.Descriptor instead.
+func (*UpsertWarehouseStockRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{41}
+}
+
+func (x *UpsertWarehouseStockRequest) GetStock() []*WarehouseStock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+// Response message for storing warehouse stock
+type UpsertWarehouseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertWarehouseStockResponse) Reset() {
+	*x = UpsertWarehouseStockResponse{}
+	mi := &file_shipment_protoc_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertWarehouseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertWarehouseStockResponse) ProtoMessage() {}
+
+func (x *UpsertWarehouseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertWarehouseStockResponse.ProtoReflect.Descriptor instead.
+func (*UpsertWarehouseStockResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{42}
+}
+
+func (x *UpsertWarehouseStockResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+// Request message for splitting an order across warehouses
+type AllocateShipmentsRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	OrderId               int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	DestinationPostalCode string                 `protobuf:"bytes,2,opt,name=destination_postal_code,json=destinationPostalCode,proto3" json:"destination_postal_code,omitempty"` // Ranks warehouses by proximity
+	Strategy              string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`                                                          // NEAREST (default), FEWEST_SPLITS, PRIORITY
+	Items                 []*ShipmentItemRequest `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`                                                                // Defaults to everything left to ship of the order
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AllocateShipmentsRequest) Reset() {
+	*x = AllocateShipmentsRequest{}
+	mi := &file_shipment_protoc_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateShipmentsRequest) ProtoMessage() {}
+
+func (x *AllocateShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*AllocateShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{43}
+}
+
+func (x *AllocateShipmentsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *AllocateShipmentsRequest) GetDestinationPostalCode() string {
+	if x != nil {
+		return x.DestinationPostalCode
+	}
+	return ""
+}
+
+func (x *AllocateShipmentsRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *AllocateShipmentsRequest) GetItems() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Part of an order shipped from one warehouse
+type PlannedShipment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   int64                  `protobuf:"varint,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	WarehouseCode string                 `protobuf:"bytes,2,opt,name=warehouse_code,json=warehouseCode,proto3" json:"warehouse_code,omitempty"`
+	Items         []*ShipmentItemRequest `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlannedShipment) Reset() {
+	*x = PlannedShipment{}
+	mi := &file_shipment_protoc_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlannedShipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedShipment) ProtoMessage() {}
+
+func (x *PlannedShipment) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedShipment.ProtoReflect.Descriptor instead.
+func (*PlannedShipment) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{44}
+}
+
+func (x *PlannedShipment) GetWarehouseId() int64 {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return 0
+}
+
+func (x *PlannedShipment) GetWarehouseCode() string {
+	if x != nil {
+		return x.WarehouseCode
+	}
+	return ""
+}
+
+func (x *PlannedShipment) GetItems() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Response message for splitting an order across warehouses
+type AllocateShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategy      string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Shipments     []*PlannedShipment     `protobuf:"bytes,2,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	Unallocated   []*ShipmentItemRequest `protobuf:"bytes,3,rep,name=unallocated,proto3" json:"unallocated,omitempty"` // Quantities no active warehouse has in stock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocateShipmentsResponse) Reset() {
+	*x = AllocateShipmentsResponse{}
+	mi := &file_shipment_protoc_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocateShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateShipmentsResponse) ProtoMessage() {}
+
+func (x *AllocateShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*AllocateShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{45}
+}
+
+func (x *AllocateShipmentsResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *AllocateShipmentsResponse) GetShipments() []*PlannedShipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *AllocateShipmentsResponse) GetUnallocated() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Unallocated
+	}
+	return nil
+}
+
+// Packed parcel and the quantity of each SKU in it
+type ParcelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WeightKg      float64                `protobuf:"fixed64,1,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	LengthCm      float64                `protobuf:"fixed64,2,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm       float64                `protobuf:"fixed64,3,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm      float64                `protobuf:"fixed64,4,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	Items         []*ShipmentItemRequest `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParcelRequest) Reset() {
+	*x = ParcelRequest{}
+	mi := &file_shipment_protoc_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParcelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParcelRequest) ProtoMessage() {}
+
+func (x *ParcelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParcelRequest.ProtoReflect.Descriptor instead.
+func (*ParcelRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{46}
+}
+
+func (x *ParcelRequest) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *ParcelRequest) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *ParcelRequest) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *ParcelRequest) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+func (x *ParcelRequest) GetItems() []*ShipmentItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Parcel of a shipment
+type Parcel struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ParcelId            int64                  `protobuf:"varint,1,opt,name=parcel_id,json=parcelId,proto3" json:"parcel_id,omitempty"`
+	Sequence            int32                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"` // From 1, as printed on the labels
+	WeightKg            float64                `protobuf:"fixed64,3,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	LengthCm            float64                `protobuf:"fixed64,4,opt,name=length_cm,json=lengthCm,proto3" json:"length_cm,omitempty"`
+	WidthCm             float64                `protobuf:"fixed64,5,opt,name=width_cm,json=widthCm,proto3" json:"width_cm,omitempty"`
+	HeightCm            float64                `protobuf:"fixed64,6,opt,name=height_cm,json=heightCm,proto3" json:"height_cm,omitempty"`
+	DimensionalWeightKg float64                `protobuf:"fixed64,7,opt,name=dimensional_weight_kg,json=dimensionalWeightKg,proto3" json:"dimensional_weight_kg,omitempty"`
+	ChargeableWeightKg  float64                `protobuf:"fixed64,8,opt,name=chargeable_weight_kg,json=chargeableWeightKg,proto3" json:"chargeable_weight_kg,omitempty"` // Larger of the actual and dimensional weight
+	Items               []*ShipmentItem        `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Parcel) Reset() {
+	*x = Parcel{}
+	mi := &file_shipment_protoc_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parcel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parcel) ProtoMessage() {}
+
+func (x *Parcel) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parcel.ProtoReflect.Descriptor instead.
+func (*Parcel) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{47}
+}
+
+func (x *Parcel) GetParcelId() int64 {
+	if x != nil {
+		return x.ParcelId
+	}
+	return 0
+}
+
+func (x *Parcel) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Parcel) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *Parcel) GetLengthCm() float64 {
+	if x != nil {
+		return x.LengthCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetWidthCm() float64 {
+	if x != nil {
+		return x.WidthCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetHeightCm() float64 {
+	if x != nil {
+		return x.HeightCm
+	}
+	return 0
+}
+
+func (x *Parcel) GetDimensionalWeightKg() float64 {
+	if x != nil {
+		return x.DimensionalWeightKg
+	}
+	return 0
+}
+
+func (x *Parcel) GetChargeableWeightKg() float64 {
+	if x != nil {
+		return x.ChargeableWeightKg
+	}
+	return 0
+}
+
+func (x *Parcel) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Request message for recording the parcels of a shipment, replacing the ones recorded before
+type PackShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	Parcels       []*ParcelRequest       `protobuf:"bytes,2,rep,name=parcels,proto3" json:"parcels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackShipmentRequest) Reset() {
+	*x = PackShipmentRequest{}
+	mi := &file_shipment_protoc_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackShipmentRequest) ProtoMessage() {}
+
+func (x *PackShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackShipmentRequest.ProtoReflect.Descriptor instead.
+func (*PackShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{48}
+}
+
+func (x *PackShipmentRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *PackShipmentRequest) GetParcels() []*ParcelRequest {
+	if x != nil {
+		return x.Parcels
+	}
+	return nil
+}
+
+// Response message for recording the parcels of a shipment
+type PackShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *ShipmentData          `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackShipmentResponse) Reset() {
+	*x = PackShipmentResponse{}
+	mi := &file_shipment_protoc_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackShipmentResponse) ProtoMessage() {}
+
+func (x *PackShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackShipmentResponse.ProtoReflect.Descriptor instead.
+func (*PackShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{49}
+}
+
+func (x *PackShipmentResponse) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// Request message for rendering a shipping document
+type GetShippingDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	DocumentType  string                 `protobuf:"bytes,2,opt,name=document_type,json=documentType,proto3" json:"document_type,omitempty"` // LABEL or PACKING_SLIP
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`                                 // PDF (default) or ZPL, packing slips are PDF only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingDocumentRequest) Reset() {
+	*x = GetShippingDocumentRequest{}
+	mi := &file_shipment_protoc_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingDocumentRequest) ProtoMessage() {}
+
+func (x *GetShippingDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetShippingDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{50}
+}
+
+func (x *GetShippingDocumentRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *GetShippingDocumentRequest) GetDocumentType() string {
+	if x != nil {
+		return x.DocumentType
+	}
+	return ""
+}
+
+func (x *GetShippingDocumentRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// Rendered shipping document
+type ShippingDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingDocument) Reset() {
+	*x = ShippingDocument{}
+	mi := &file_shipment_protoc_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingDocument) ProtoMessage() {}
+
+func (x *ShippingDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingDocument.ProtoReflect.Descriptor instead.
+func (*ShippingDocument) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{51}
+}
+
+func (x *ShippingDocument) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ShippingDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ShippingDocument) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Proof of delivery
+type DeliveryProof struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientName string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	DeliveredAt   string                 `protobuf:"bytes,4,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	Files         []*DeliveryFile        `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryProof) Reset() {
+	*x = DeliveryProof{}
+	mi := &file_shipment_protoc_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryProof) ProtoMessage() {}
+
+func (x *DeliveryProof) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryProof.ProtoReflect.Descriptor instead.
+func (*DeliveryProof) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{52}
+}
+
+func (x *DeliveryProof) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *DeliveryProof) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *DeliveryProof) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *DeliveryProof) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *DeliveryProof) GetFiles() []*DeliveryFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type DeliveryFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        int64                  `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // SIGNATURE or PHOTO
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"` // Hex digest of the content
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryFile) Reset() {
+	*x = DeliveryFile{}
+	mi := &file_shipment_protoc_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryFile) ProtoMessage() {}
+
+func (x *DeliveryFile) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryFile.ProtoReflect.Descriptor instead.
+func (*DeliveryFile) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{53}
+}
+
+func (x *DeliveryFile) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+func (x *DeliveryFile) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeliveryFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DeliveryFile) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *DeliveryFile) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type DeliveryDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	RecipientName string                 `protobuf:"bytes,2,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	DeliveredAt   string                 `protobuf:"bytes,5,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"` // RFC 3339, defaults to now
+	Actor         string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	Note          string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryDetails) Reset() {
+	*x = DeliveryDetails{}
+	mi := &file_shipment_protoc_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryDetails) ProtoMessage() {}
+
+func (x *DeliveryDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryDetails.ProtoReflect.Descriptor instead.
+func (*DeliveryDetails) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{54}
+}
+
+func (x *DeliveryDetails) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *DeliveryDetails) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *DeliveryDetails) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *DeliveryDetails) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *DeliveryDetails) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *DeliveryDetails) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *DeliveryDetails) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type DeliveryFileChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`                                  // SIGNATURE or PHOTO, set on the first chunk of each file
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Set on the first chunk of a download, detected from the content on upload
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryFileChunk) Reset() {
+	*x = DeliveryFileChunk{}
+	mi := &file_shipment_protoc_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryFileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryFileChunk) ProtoMessage() {}
+
+func (x *DeliveryFileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryFileChunk.ProtoReflect.Descriptor instead.
+func (*DeliveryFileChunk) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{55}
+}
+
+func (x *DeliveryFileChunk) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DeliveryFileChunk) GetContentType() string {
 	if x != nil {
-		return x.Quantity
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DeliveryFileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// The first message of a ConfirmDelivery stream carries the details, every following message a file chunk
+type ConfirmDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Details       *DeliveryDetails       `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	Chunk         *DeliveryFileChunk     `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmDeliveryRequest) Reset() {
+	*x = ConfirmDeliveryRequest{}
+	mi := &file_shipment_protoc_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmDeliveryRequest) ProtoMessage() {}
+
+func (x *ConfirmDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{56}
+}
+
+func (x *ConfirmDeliveryRequest) GetDetails() *DeliveryDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *ConfirmDeliveryRequest) GetChunk() *DeliveryFileChunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ConfirmDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *ShipmentData          `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmDeliveryResponse) Reset() {
+	*x = ConfirmDeliveryResponse{}
+	mi := &file_shipment_protoc_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmDeliveryResponse) ProtoMessage() {}
+
+func (x *ConfirmDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{57}
+}
+
+func (x *ConfirmDeliveryResponse) GetShipment() *ShipmentData {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type GetDeliveryFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	FileId        int64                  `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryFileRequest) Reset() {
+	*x = GetDeliveryFileRequest{}
+	mi := &file_shipment_protoc_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryFileRequest) ProtoMessage() {}
+
+func (x *GetDeliveryFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryFileRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryFileRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{58}
+}
+
+func (x *GetDeliveryFileRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *GetDeliveryFileRequest) GetFileId() int64 {
+	if x != nil {
+		return x.FileId
+	}
+	return 0
+}
+
+// Request message for watching a shipment
+type WatchShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    int64                  `protobuf:"varint,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	AfterEventId  int64                  `protobuf:"varint,2,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"` // Id of the last event received, zero streams the whole history first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchShipmentRequest) Reset() {
+	*x = WatchShipmentRequest{}
+	mi := &file_shipment_protoc_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchShipmentRequest) ProtoMessage() {}
+
+func (x *WatchShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchShipmentRequest.ProtoReflect.Descriptor instead.
+func (*WatchShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{59}
+}
+
+func (x *WatchShipmentRequest) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return 0
+}
+
+func (x *WatchShipmentRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+// Request message for watching the shipments of an order
+type WatchOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	AfterEventId  int64                  `protobuf:"varint,2,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"` // Id of the last event received, zero streams the whole history first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_shipment_protoc_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{60}
+}
+
+func (x *WatchOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *WatchOrderRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+// A status change of a shipment, or a heartbeat sent while nothing changes.
+// A watch opens with a heartbeat once it is accepted. Event ids increase, the id of the last event received resumes a watch without gaps.
+type WatchUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ShipmentId    int64                  `protobuf:"varint,2,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"` // Zero on heartbeats
+	Event         *ShipmentEvent         `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`                              // Unset on heartbeats
+	Heartbeat     bool                   `protobuf:"varint,4,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchUpdate) Reset() {
+	*x = WatchUpdate{}
+	mi := &file_shipment_protoc_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUpdate) ProtoMessage() {}
+
+func (x *WatchUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_shipment_protoc_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUpdate.ProtoReflect.Descriptor instead.
+func (*WatchUpdate) Descriptor() ([]byte, []int) {
+	return file_shipment_protoc_rawDescGZIP(), []int{61}
+}
+
+func (x *WatchUpdate) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *WatchUpdate) GetShipmentId() int64 {
+	if x != nil {
+		return x.ShipmentId
 	}
 	return 0
 }
 
+func (x *WatchUpdate) GetEvent() *ShipmentEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchUpdate) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
 var File_shipment_protoc protoreflect.FileDescriptor
 
 const file_shipment_protoc_rawDesc = "" +
@@ -325,14 +3999,38 @@ const file_shipment_protoc_rawDesc = "" +
 	"\x0fshipment.protoc\x12\bshipment\"C\n" +
 	"\x13ShipmentItemRequest\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"g\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\xf3\x02\n" +
 	"\x15CreateShipmentRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x123\n" +
-	"\x05items\x18\x02 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\"r\n" +
+	"\x05items\x18\x02 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\x12!\n" +
+	"\fcarrier_code\x18\x03 \x01(\tR\vcarrierCode\x126\n" +
+	"\x17destination_postal_code\x18\x04 \x01(\tR\x15destinationPostalCode\x12!\n" +
+	"\fwarehouse_id\x18\x05 \x01(\x03R\vwarehouseId\x12-\n" +
+	"\x04plan\x18\x06 \x03(\v2\x19.shipment.PlannedShipmentR\x04plan\x121\n" +
+	"\aparcels\x18\a \x03(\v2\x17.shipment.ParcelRequestR\aparcels\x12*\n" +
+	"\aship_to\x18\b \x01(\v2\x11.shipment.AddressR\x06shipTo\"\xa8\x01\n" +
 	"\x16CreateShipmentResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
-	"\x04data\x18\x03 \x01(\v2\x16.shipment.ShipmentDataR\x04data\"\xaf\x01\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.shipment.ShipmentDataR\x04data\x124\n" +
+	"\tshipments\x18\x05 \x03(\v2\x16.shipment.ShipmentDataR\tshipments\"\x87\x01\n" +
+	"\x16CreateShipmentsRequest\x12;\n" +
+	"\bshipment\x18\x01 \x01(\v2\x1f.shipment.CreateShipmentRequestR\bshipment\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"\x91\x02\n" +
+	"\x17CreateShipmentsResponse\x12:\n" +
+	"\tconfirmed\x18\x01 \x03(\v2\x1c.shipment.ShipmentLineResultR\tconfirmed\x124\n" +
+	"\x06failed\x18\x02 \x03(\v2\x1c.shipment.ShipmentLineResultR\x06failed\x12H\n" +
+	"\areasons\x18\x03 \x03(\v2..shipment.CreateShipmentsResponse.ReasonsEntryR\areasons\x1a:\n" +
+	"\fReasonsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xac\x01\n" +
+	"\x12ShipmentLineResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x122\n" +
+	"\bshipment\x18\x03 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xa5\x05\n" +
 	"\fShipmentData\x12\x1f\n" +
 	"\vshipment_id\x18\x01 \x01(\x03R\n" +
 	"shipmentId\x12\x19\n" +
@@ -340,12 +4038,305 @@ const file_shipment_protoc_rawDesc = "" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12,\n" +
 	"\x05items\x18\x04 \x03(\v2\x16.shipment.ShipmentItemR\x05items\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\"<\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12!\n" +
+	"\fcarrier_code\x18\a \x01(\tR\vcarrierCode\x12'\n" +
+	"\x0ftracking_number\x18\b \x01(\tR\x0etrackingNumber\x126\n" +
+	"\x17destination_postal_code\x18\t \x01(\tR\x15destinationPostalCode\x12#\n" +
+	"\rshipping_zone\x18\n" +
+	" \x01(\tR\fshippingZone\x120\n" +
+	"\x14chargeable_weight_kg\x18\v \x01(\x01R\x12chargeableWeightKg\x12!\n" +
+	"\fshipping_fee\x18\f \x01(\x01R\vshippingFee\x12!\n" +
+	"\fwarehouse_id\x18\r \x01(\x03R\vwarehouseId\x12*\n" +
+	"\aparcels\x18\x0e \x03(\v2\x10.shipment.ParcelR\aparcels\x12*\n" +
+	"\aship_to\x18\x0f \x01(\v2\x11.shipment.AddressR\x06shipTo\x12>\n" +
+	"\x0edelivery_proof\x18\x10 \x01(\v2\x17.shipment.DeliveryProofR\rdeliveryProof\x12\x1c\n" +
+	"\n" +
+	"api_key_id\x18\x11 \x01(\tR\bapiKeyId\"\xc6\x01\n" +
+	"\aAddress\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x02 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x03 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\a \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\"<\n" +
 	"\fShipmentItem\x12\x10\n" +
 	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity2h\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"5\n" +
+	"\x12GetShipmentRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\"I\n" +
+	"\x13GetShipmentResponse\x122\n" +
+	"\bshipment\x18\x01 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\"\xc0\x01\n" +
+	"\x14ListShipmentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\fcreated_from\x18\x03 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\tR\tcreatedTo\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\"n\n" +
+	"\x15ListShipmentsResponse\x124\n" +
+	"\tshipments\x18\x01 \x03(\v2\x16.shipment.ShipmentDataR\tshipments\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xba\x01\n" +
+	"\x1bUpdateShipmentStatusRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\"R\n" +
+	"\x1cUpdateShipmentStatusResponse\x122\n" +
+	"\bshipment\x18\x01 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\"\xc4\x01\n" +
+	"\rShipmentEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12'\n" +
+	"\x0fprevious_status\x18\x03 \x01(\tR\x0epreviousStatus\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12\x1a\n" +
+	"\blocation\x18\x05 \x01(\tR\blocation\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\"<\n" +
+	"\x19GetTrackingHistoryRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\"\x81\x01\n" +
+	"\x1aGetTrackingHistoryResponse\x122\n" +
+	"\bshipment\x18\x01 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\x12/\n" +
+	"\x06events\x18\x02 \x03(\v2\x17.shipment.ShipmentEventR\x06events\"\xde\x01\n" +
+	"\x19QuoteShippingRatesRequest\x123\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\x12!\n" +
+	"\fcarrier_code\x18\x02 \x01(\tR\vcarrierCode\x126\n" +
+	"\x17destination_postal_code\x18\x03 \x01(\tR\x15destinationPostalCode\x121\n" +
+	"\aparcels\x18\x04 \x03(\v2\x17.shipment.ParcelRequestR\aparcels\"\x84\x02\n" +
+	"\fShippingRate\x12!\n" +
+	"\fcarrier_code\x18\x01 \x01(\tR\vcarrierCode\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12%\n" +
+	"\x0eestimated_days\x18\x04 \x01(\x05R\restimatedDays\x120\n" +
+	"\x14chargeable_weight_kg\x18\x05 \x01(\x01R\x12chargeableWeightKg\x12#\n" +
+	"\rshipping_zone\x18\x06 \x01(\tR\fshippingZone\x12!\n" +
+	"\fshipping_fee\x18\a \x01(\x01R\vshippingFee\"J\n" +
+	"\x1aQuoteShippingRatesResponse\x12,\n" +
+	"\x05rates\x18\x01 \x03(\v2\x16.shipment.ShippingRateR\x05rates\"\xd2\x01\n" +
+	"\x15CarrierWebhookRequest\x12!\n" +
+	"\fcarrier_code\x18\x01 \x01(\tR\vcarrierCode\x12F\n" +
+	"\aheaders\x18\x02 \x03(\v2,.shipment.CarrierWebhookRequest.HeadersEntryR\aheaders\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\fR\x04body\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"2\n" +
+	"\x16CarrierWebhookResponse\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\x05R\aapplied\"9\n" +
+	"\x16RefreshTrackingRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\"\x92\x01\n" +
+	"\fSkuDimension\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x1b\n" +
+	"\tweight_kg\x18\x02 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x03 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x04 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x05 \x01(\x01R\bheightCm\"T\n" +
+	"\x1aUpsertSkuDimensionsRequest\x126\n" +
+	"\n" +
+	"dimensions\x18\x01 \x03(\v2\x16.shipment.SkuDimensionR\n" +
+	"dimensions\"7\n" +
+	"\x1bUpsertSkuDimensionsResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"\xad\x01\n" +
+	"\fShippingZone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12'\n" +
+	"\x0fpostal_prefixes\x18\x04 \x03(\tR\x0epostalPrefixes\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x05 \x01(\x01R\n" +
+	"multiplier\x12\x1c\n" +
+	"\tsurcharge\x18\x06 \x01(\x01R\tsurcharge\"G\n" +
+	"\x19UpsertShippingZoneRequest\x12*\n" +
+	"\x04zone\x18\x01 \x01(\v2\x16.shipment.ShippingZoneR\x04zone\"H\n" +
+	"\x1aUpsertShippingZoneResponse\x12*\n" +
+	"\x04zone\x18\x01 \x01(\v2\x16.shipment.ShippingZoneR\x04zone\"\x84\x01\n" +
+	"\x14RequestReturnRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x123\n" +
+	"\x05items\x18\x03 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\"/\n" +
+	"\x10GetReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\"F\n" +
+	"\x13ReturnActionRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"\x85\x03\n" +
+	"\n" +
+	"ReturnData\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\x12\x1f\n" +
+	"\vshipment_id\x18\x02 \x01(\x03R\n" +
+	"shipmentId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12,\n" +
+	"\x05items\x18\a \x03(\v2\x16.shipment.ShipmentItemR\x05items\x12$\n" +
+	"\x0ecredit_note_id\x18\b \x01(\x03R\fcreditNoteId\x12'\n" +
+	"\x0fcredited_amount\x18\t \x01(\x01R\x0ecreditedAmount\x12\x1f\n" +
+	"\vreceived_at\x18\n" +
+	" \x01(\tR\n" +
+	"receivedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\":\n" +
+	"\x0eReturnResponse\x12(\n" +
+	"\x04data\x18\x01 \x01(\v2\x14.shipment.ReturnDataR\x04data\"\x98\x01\n" +
+	"\tWarehouse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vpostal_code\x18\x04 \x01(\tR\n" +
+	"postalCode\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active\"K\n" +
+	"\x16UpsertWarehouseRequest\x121\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x13.shipment.WarehouseR\twarehouse\"L\n" +
+	"\x17UpsertWarehouseResponse\x121\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x13.shipment.WarehouseR\twarehouse\"\x17\n" +
+	"\x15ListWarehousesRequest\"M\n" +
+	"\x16ListWarehousesResponse\x123\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x13.shipment.WarehouseR\n" +
+	"warehouses\"a\n" +
+	"\x0eWarehouseStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03R\vwarehouseId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"M\n" +
+	"\x1bUpsertWarehouseStockRequest\x12.\n" +
+	"\x05stock\x18\x01 \x03(\v2\x18.shipment.WarehouseStockR\x05stock\"8\n" +
+	"\x1cUpsertWarehouseStockResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"\xbe\x01\n" +
+	"\x18AllocateShipmentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x126\n" +
+	"\x17destination_postal_code\x18\x02 \x01(\tR\x15destinationPostalCode\x12\x1a\n" +
+	"\bstrategy\x18\x03 \x01(\tR\bstrategy\x123\n" +
+	"\x05items\x18\x04 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\"\x90\x01\n" +
+	"\x0fPlannedShipment\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\x03R\vwarehouseId\x12%\n" +
+	"\x0ewarehouse_code\x18\x02 \x01(\tR\rwarehouseCode\x123\n" +
+	"\x05items\x18\x03 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\"\xb1\x01\n" +
+	"\x19AllocateShipmentsResponse\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x127\n" +
+	"\tshipments\x18\x02 \x03(\v2\x19.shipment.PlannedShipmentR\tshipments\x12?\n" +
+	"\vunallocated\x18\x03 \x03(\v2\x1d.shipment.ShipmentItemRequestR\vunallocated\"\xb6\x01\n" +
+	"\rParcelRequest\x12\x1b\n" +
+	"\tweight_kg\x18\x01 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x02 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x03 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x04 \x01(\x01R\bheightCm\x123\n" +
+	"\x05items\x18\x05 \x03(\v2\x1d.shipment.ShipmentItemRequestR\x05items\"\xc7\x02\n" +
+	"\x06Parcel\x12\x1b\n" +
+	"\tparcel_id\x18\x01 \x01(\x03R\bparcelId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x05R\bsequence\x12\x1b\n" +
+	"\tweight_kg\x18\x03 \x01(\x01R\bweightKg\x12\x1b\n" +
+	"\tlength_cm\x18\x04 \x01(\x01R\blengthCm\x12\x19\n" +
+	"\bwidth_cm\x18\x05 \x01(\x01R\awidthCm\x12\x1b\n" +
+	"\theight_cm\x18\x06 \x01(\x01R\bheightCm\x122\n" +
+	"\x15dimensional_weight_kg\x18\a \x01(\x01R\x13dimensionalWeightKg\x120\n" +
+	"\x14chargeable_weight_kg\x18\b \x01(\x01R\x12chargeableWeightKg\x12,\n" +
+	"\x05items\x18\t \x03(\v2\x16.shipment.ShipmentItemR\x05items\"i\n" +
+	"\x13PackShipmentRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x121\n" +
+	"\aparcels\x18\x02 \x03(\v2\x17.shipment.ParcelRequestR\aparcels\"J\n" +
+	"\x14PackShipmentResponse\x122\n" +
+	"\bshipment\x18\x01 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\"z\n" +
+	"\x1aGetShippingDocumentRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12#\n" +
+	"\rdocument_type\x18\x02 \x01(\tR\fdocumentType\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\"k\n" +
+	"\x10ShippingDocument\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\xc1\x01\n" +
+	"\rDeliveryProof\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12!\n" +
+	"\fdelivered_at\x18\x04 \x01(\tR\vdeliveredAt\x12,\n" +
+	"\x05files\x18\x05 \x03(\v2\x16.shipment.DeliveryFileR\x05files\"\x95\x01\n" +
+	"\fDeliveryFile\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\x03R\x06fileId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\"\xe0\x01\n" +
+	"\x0fDeliveryDetails\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12%\n" +
+	"\x0erecipient_name\x18\x02 \x01(\tR\rrecipientName\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12!\n" +
+	"\fdelivered_at\x18\x05 \x01(\tR\vdeliveredAt\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\"^\n" +
+	"\x11DeliveryFileChunk\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x80\x01\n" +
+	"\x16ConfirmDeliveryRequest\x123\n" +
+	"\adetails\x18\x01 \x01(\v2\x19.shipment.DeliveryDetailsR\adetails\x121\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x1b.shipment.DeliveryFileChunkR\x05chunk\"M\n" +
+	"\x17ConfirmDeliveryResponse\x122\n" +
+	"\bshipment\x18\x01 \x01(\v2\x16.shipment.ShipmentDataR\bshipment\"R\n" +
+	"\x16GetDeliveryFileRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\x03R\x06fileId\"]\n" +
+	"\x14WatchShipmentRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\x03R\n" +
+	"shipmentId\x12$\n" +
+	"\x0eafter_event_id\x18\x02 \x01(\x03R\fafterEventId\"T\n" +
+	"\x11WatchOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12$\n" +
+	"\x0eafter_event_id\x18\x02 \x01(\x03R\fafterEventId\"\x96\x01\n" +
+	"\vWatchUpdate\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x1f\n" +
+	"\vshipment_id\x18\x02 \x01(\x03R\n" +
+	"shipmentId\x12-\n" +
+	"\x05event\x18\x03 \x01(\v2\x17.shipment.ShipmentEventR\x05event\x12\x1c\n" +
+	"\theartbeat\x18\x04 \x01(\bR\theartbeat2\xc3\x12\n" +
 	"\x0fShipmentService\x12U\n" +
-	"\x0eCreateShipment\x12\x1f.shipment.CreateShipmentRequest\x1a .shipment.CreateShipmentResponse\"\x00B'Z%billing-system/shipment_service/protob\x06proto3"
+	"\x0eCreateShipment\x12\x1f.shipment.CreateShipmentRequest\x1a .shipment.CreateShipmentResponse\"\x00\x12Z\n" +
+	"\x0fCreateShipments\x12 .shipment.CreateShipmentsRequest\x1a!.shipment.CreateShipmentsResponse\"\x00(\x01\x12L\n" +
+	"\vGetShipment\x12\x1c.shipment.GetShipmentRequest\x1a\x1d.shipment.GetShipmentResponse\"\x00\x12R\n" +
+	"\rListShipments\x12\x1e.shipment.ListShipmentsRequest\x1a\x1f.shipment.ListShipmentsResponse\"\x00\x12g\n" +
+	"\x14UpdateShipmentStatus\x12%.shipment.UpdateShipmentStatusRequest\x1a&.shipment.UpdateShipmentStatusResponse\"\x00\x12a\n" +
+	"\x12GetTrackingHistory\x12#.shipment.GetTrackingHistoryRequest\x1a$.shipment.GetTrackingHistoryResponse\"\x00\x12a\n" +
+	"\x12QuoteShippingRates\x12#.shipment.QuoteShippingRatesRequest\x1a$.shipment.QuoteShippingRatesResponse\"\x00\x12[\n" +
+	"\x14HandleCarrierWebhook\x12\x1f.shipment.CarrierWebhookRequest\x1a .shipment.CarrierWebhookResponse\"\x00\x12[\n" +
+	"\x0fRefreshTracking\x12 .shipment.RefreshTrackingRequest\x1a$.shipment.GetTrackingHistoryResponse\"\x00\x12d\n" +
+	"\x13UpsertSkuDimensions\x12$.shipment.UpsertSkuDimensionsRequest\x1a%.shipment.UpsertSkuDimensionsResponse\"\x00\x12a\n" +
+	"\x12UpsertShippingZone\x12#.shipment.UpsertShippingZoneRequest\x1a$.shipment.UpsertShippingZoneResponse\"\x00\x12K\n" +
+	"\rRequestReturn\x12\x1e.shipment.RequestReturnRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12C\n" +
+	"\tGetReturn\x12\x1a.shipment.GetReturnRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12J\n" +
+	"\rApproveReturn\x12\x1d.shipment.ReturnActionRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12I\n" +
+	"\fRejectReturn\x12\x1d.shipment.ReturnActionRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12J\n" +
+	"\rReceiveReturn\x12\x1d.shipment.ReturnActionRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12J\n" +
+	"\rInspectReturn\x12\x1d.shipment.ReturnActionRequest\x1a\x18.shipment.ReturnResponse\"\x00\x12X\n" +
+	"\x0fUpsertWarehouse\x12 .shipment.UpsertWarehouseRequest\x1a!.shipment.UpsertWarehouseResponse\"\x00\x12U\n" +
+	"\x0eListWarehouses\x12\x1f.shipment.ListWarehousesRequest\x1a .shipment.ListWarehousesResponse\"\x00\x12g\n" +
+	"\x14UpsertWarehouseStock\x12%.shipment.UpsertWarehouseStockRequest\x1a&.shipment.UpsertWarehouseStockResponse\"\x00\x12^\n" +
+	"\x11AllocateShipments\x12\".shipment.AllocateShipmentsRequest\x1a#.shipment.AllocateShipmentsResponse\"\x00\x12O\n" +
+	"\fPackShipment\x12\x1d.shipment.PackShipmentRequest\x1a\x1e.shipment.PackShipmentResponse\"\x00\x12Y\n" +
+	"\x13GetShippingDocument\x12$.shipment.GetShippingDocumentRequest\x1a\x1a.shipment.ShippingDocument\"\x00\x12Z\n" +
+	"\x0fConfirmDelivery\x12 .shipment.ConfirmDeliveryRequest\x1a!.shipment.ConfirmDeliveryResponse\"\x00(\x01\x12T\n" +
+	"\x0fGetDeliveryFile\x12 .shipment.GetDeliveryFileRequest\x1a\x1b.shipment.DeliveryFileChunk\"\x000\x01\x12J\n" +
+	"\rWatchShipment\x12\x1e.shipment.WatchShipmentRequest\x1a\x15.shipment.WatchUpdate\"\x000\x01\x12D\n" +
+	"\n" +
+	"WatchOrder\x12\x1b.shipment.WatchOrderRequest\x1a\x15.shipment.WatchUpdate\"\x000\x01B'Z%billing-system/shipment_service/protob\x06proto3"
 
 var (
 	file_shipment_protoc_rawDescOnce sync.Once
@@ -359,25 +4350,180 @@ func file_shipment_protoc_rawDescGZIP() []byte {
 	return file_shipment_protoc_rawDescData
 }
 
-var file_shipment_protoc_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_shipment_protoc_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_shipment_protoc_goTypes = []any{
-	(*ShipmentItemRequest)(nil),    // 0: shipment.ShipmentItemRequest
-	(*CreateShipmentRequest)(nil),  // 1: shipment.CreateShipmentRequest
-	(*CreateShipmentResponse)(nil), // 2: shipment.CreateShipmentResponse
-	(*ShipmentData)(nil),           // 3: shipment.ShipmentData
-	(*ShipmentItem)(nil),           // 4: shipment.ShipmentItem
+	(*ShipmentItemRequest)(nil),          // 0: shipment.ShipmentItemRequest
+	(*CreateShipmentRequest)(nil),        // 1: shipment.CreateShipmentRequest
+	(*CreateShipmentResponse)(nil),       // 2: shipment.CreateShipmentResponse
+	(*CreateShipmentsRequest)(nil),       // 3: shipment.CreateShipmentsRequest
+	(*CreateShipmentsResponse)(nil),      // 4: shipment.CreateShipmentsResponse
+	(*ShipmentLineResult)(nil),           // 5: shipment.ShipmentLineResult
+	(*ShipmentData)(nil),                 // 6: shipment.ShipmentData
+	(*Address)(nil),                      // 7: shipment.Address
+	(*ShipmentItem)(nil),                 // 8: shipment.ShipmentItem
+	(*GetShipmentRequest)(nil),           // 9: shipment.GetShipmentRequest
+	(*GetShipmentResponse)(nil),          // 10: shipment.GetShipmentResponse
+	(*ListShipmentsRequest)(nil),         // 11: shipment.ListShipmentsRequest
+	(*ListShipmentsResponse)(nil),        // 12: shipment.ListShipmentsResponse
+	(*UpdateShipmentStatusRequest)(nil),  // 13: shipment.UpdateShipmentStatusRequest
+	(*UpdateShipmentStatusResponse)(nil), // 14: shipment.UpdateShipmentStatusResponse
+	(*ShipmentEvent)(nil),                // 15: shipment.ShipmentEvent
+	(*GetTrackingHistoryRequest)(nil),    // 16: shipment.GetTrackingHistoryRequest
+	(*GetTrackingHistoryResponse)(nil),   // 17: shipment.GetTrackingHistoryResponse
+	(*QuoteShippingRatesRequest)(nil),    // 18: shipment.QuoteShippingRatesRequest
+	(*ShippingRate)(nil),                 // 19: shipment.ShippingRate
+	(*QuoteShippingRatesResponse)(nil),   // 20: shipment.QuoteShippingRatesResponse
+	(*CarrierWebhookRequest)(nil),        // 21: shipment.CarrierWebhookRequest
+	(*CarrierWebhookResponse)(nil),       // 22: shipment.CarrierWebhookResponse
+	(*RefreshTrackingRequest)(nil),       // 23: shipment.RefreshTrackingRequest
+	(*SkuDimension)(nil),                 // 24: shipment.SkuDimension
+	(*UpsertSkuDimensionsRequest)(nil),   // 25: shipment.UpsertSkuDimensionsRequest
+	(*UpsertSkuDimensionsResponse)(nil),  // 26: shipment.UpsertSkuDimensionsResponse
+	(*ShippingZone)(nil),                 // 27: shipment.ShippingZone
+	(*UpsertShippingZoneRequest)(nil),    // 28: shipment.UpsertShippingZoneRequest
+	(*UpsertShippingZoneResponse)(nil),   // 29: shipment.UpsertShippingZoneResponse
+	(*RequestReturnRequest)(nil),         // 30: shipment.RequestReturnRequest
+	(*GetReturnRequest)(nil),             // 31: shipment.GetReturnRequest
+	(*ReturnActionRequest)(nil),          // 32: shipment.ReturnActionRequest
+	(*ReturnData)(nil),                   // 33: shipment.ReturnData
+	(*ReturnResponse)(nil),               // 34: shipment.ReturnResponse
+	(*Warehouse)(nil),                    // 35: shipment.Warehouse
+	(*UpsertWarehouseRequest)(nil),       // 36: shipment.UpsertWarehouseRequest
+	(*UpsertWarehouseResponse)(nil),      // 37: shipment.UpsertWarehouseResponse
+	(*ListWarehousesRequest)(nil),        // 38: shipment.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),       // 39: shipment.ListWarehousesResponse
+	(*WarehouseStock)(nil),               // 40: shipment.WarehouseStock
+	(*UpsertWarehouseStockRequest)(nil),  // 41: shipment.UpsertWarehouseStockRequest
+	(*UpsertWarehouseStockResponse)(nil), // 42: shipment.UpsertWarehouseStockResponse
+	(*AllocateShipmentsRequest)(nil),     // 43: shipment.AllocateShipmentsRequest
+	(*PlannedShipment)(nil),              // 44: shipment.PlannedShipment
+	(*AllocateShipmentsResponse)(nil),    // 45: shipment.AllocateShipmentsResponse
+	(*ParcelRequest)(nil),                // 46: shipment.ParcelRequest
+	(*Parcel)(nil),                       // 47: shipment.Parcel
+	(*PackShipmentRequest)(nil),          // 48: shipment.PackShipmentRequest
+	(*PackShipmentResponse)(nil),         // 49: shipment.PackShipmentResponse
+	(*GetShippingDocumentRequest)(nil),   // 50: shipment.GetShippingDocumentRequest
+	(*ShippingDocument)(nil),             // 51: shipment.ShippingDocument
+	(*DeliveryProof)(nil),                // 52: shipment.DeliveryProof
+	(*DeliveryFile)(nil),                 // 53: shipment.DeliveryFile
+	(*DeliveryDetails)(nil),              // 54: shipment.DeliveryDetails
+	(*DeliveryFileChunk)(nil),            // 55: shipment.DeliveryFileChunk
+	(*ConfirmDeliveryRequest)(nil),       // 56: shipment.ConfirmDeliveryRequest
+	(*ConfirmDeliveryResponse)(nil),      // 57: shipment.ConfirmDeliveryResponse
+	(*GetDeliveryFileRequest)(nil),       // 58: shipment.GetDeliveryFileRequest
+	(*WatchShipmentRequest)(nil),         // 59: shipment.WatchShipmentRequest
+	(*WatchOrderRequest)(nil),            // 60: shipment.WatchOrderRequest
+	(*WatchUpdate)(nil),                  // 61: shipment.WatchUpdate
+	nil,                                  // 62: shipment.CreateShipmentsResponse.ReasonsEntry
+	nil,                                  // 63: shipment.CarrierWebhookRequest.HeadersEntry
 }
 var file_shipment_protoc_depIdxs = []int32{
-	0, // 0: shipment.CreateShipmentRequest.items:type_name -> shipment.ShipmentItemRequest
-	3, // 1: shipment.CreateShipmentResponse.data:type_name -> shipment.ShipmentData
-	4, // 2: shipment.ShipmentData.items:type_name -> shipment.ShipmentItem
-	1, // 3: shipment.ShipmentService.CreateShipment:input_type -> shipment.CreateShipmentRequest
-	2, // 4: shipment.ShipmentService.CreateShipment:output_type -> shipment.CreateShipmentResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: shipment.CreateShipmentRequest.items:type_name -> shipment.ShipmentItemRequest
+	44, // 1: shipment.CreateShipmentRequest.plan:type_name -> shipment.PlannedShipment
+	46, // 2: shipment.CreateShipmentRequest.parcels:type_name -> shipment.ParcelRequest
+	7,  // 3: shipment.CreateShipmentRequest.ship_to:type_name -> shipment.Address
+	6,  // 4: shipment.CreateShipmentResponse.data:type_name -> shipment.ShipmentData
+	6,  // 5: shipment.CreateShipmentResponse.shipments:type_name -> shipment.ShipmentData
+	1,  // 6: shipment.CreateShipmentsRequest.shipment:type_name -> shipment.CreateShipmentRequest
+	5,  // 7: shipment.CreateShipmentsResponse.confirmed:type_name -> shipment.ShipmentLineResult
+	5,  // 8: shipment.CreateShipmentsResponse.failed:type_name -> shipment.ShipmentLineResult
+	62, // 9: shipment.CreateShipmentsResponse.reasons:type_name -> shipment.CreateShipmentsResponse.ReasonsEntry
+	6,  // 10: shipment.ShipmentLineResult.shipment:type_name -> shipment.ShipmentData
+	8,  // 11: shipment.ShipmentData.items:type_name -> shipment.ShipmentItem
+	47, // 12: shipment.ShipmentData.parcels:type_name -> shipment.Parcel
+	7,  // 13: shipment.ShipmentData.ship_to:type_name -> shipment.Address
+	52, // 14: shipment.ShipmentData.delivery_proof:type_name -> shipment.DeliveryProof
+	6,  // 15: shipment.GetShipmentResponse.shipment:type_name -> shipment.ShipmentData
+	6,  // 16: shipment.ListShipmentsResponse.shipments:type_name -> shipment.ShipmentData
+	6,  // 17: shipment.UpdateShipmentStatusResponse.shipment:type_name -> shipment.ShipmentData
+	6,  // 18: shipment.GetTrackingHistoryResponse.shipment:type_name -> shipment.ShipmentData
+	15, // 19: shipment.GetTrackingHistoryResponse.events:type_name -> shipment.ShipmentEvent
+	0,  // 20: shipment.QuoteShippingRatesRequest.items:type_name -> shipment.ShipmentItemRequest
+	46, // 21: shipment.QuoteShippingRatesRequest.parcels:type_name -> shipment.ParcelRequest
+	19, // 22: shipment.QuoteShippingRatesResponse.rates:type_name -> shipment.ShippingRate
+	63, // 23: shipment.CarrierWebhookRequest.headers:type_name -> shipment.CarrierWebhookRequest.HeadersEntry
+	24, // 24: shipment.UpsertSkuDimensionsRequest.dimensions:type_name -> shipment.SkuDimension
+	27, // 25: shipment.UpsertShippingZoneRequest.zone:type_name -> shipment.ShippingZone
+	27, // 26: shipment.UpsertShippingZoneResponse.zone:type_name -> shipment.ShippingZone
+	0,  // 27: shipment.RequestReturnRequest.items:type_name -> shipment.ShipmentItemRequest
+	8,  // 28: shipment.ReturnData.items:type_name -> shipment.ShipmentItem
+	33, // 29: shipment.ReturnResponse.data:type_name -> shipment.ReturnData
+	35, // 30: shipment.UpsertWarehouseRequest.warehouse:type_name -> shipment.Warehouse
+	35, // 31: shipment.UpsertWarehouseResponse.warehouse:type_name -> shipment.Warehouse
+	35, // 32: shipment.ListWarehousesResponse.warehouses:type_name -> shipment.Warehouse
+	40, // 33: shipment.UpsertWarehouseStockRequest.stock:type_name -> shipment.WarehouseStock
+	0,  // 34: shipment.AllocateShipmentsRequest.items:type_name -> shipment.ShipmentItemRequest
+	0,  // 35: shipment.PlannedShipment.items:type_name -> shipment.ShipmentItemRequest
+	44, // 36: shipment.AllocateShipmentsResponse.shipments:type_name -> shipment.PlannedShipment
+	0,  // 37: shipment.AllocateShipmentsResponse.unallocated:type_name -> shipment.ShipmentItemRequest
+	0,  // 38: shipment.ParcelRequest.items:type_name -> shipment.ShipmentItemRequest
+	8,  // 39: shipment.Parcel.items:type_name -> shipment.ShipmentItem
+	46, // 40: shipment.PackShipmentRequest.parcels:type_name -> shipment.ParcelRequest
+	6,  // 41: shipment.PackShipmentResponse.shipment:type_name -> shipment.ShipmentData
+	53, // 42: shipment.DeliveryProof.files:type_name -> shipment.DeliveryFile
+	54, // 43: shipment.ConfirmDeliveryRequest.details:type_name -> shipment.DeliveryDetails
+	55, // 44: shipment.ConfirmDeliveryRequest.chunk:type_name -> shipment.DeliveryFileChunk
+	6,  // 45: shipment.ConfirmDeliveryResponse.shipment:type_name -> shipment.ShipmentData
+	15, // 46: shipment.WatchUpdate.event:type_name -> shipment.ShipmentEvent
+	1,  // 47: shipment.ShipmentService.CreateShipment:input_type -> shipment.CreateShipmentRequest
+	3,  // 48: shipment.ShipmentService.CreateShipments:input_type -> shipment.CreateShipmentsRequest
+	9,  // 49: shipment.ShipmentService.GetShipment:input_type -> shipment.GetShipmentRequest
+	11, // 50: shipment.ShipmentService.ListShipments:input_type -> shipment.ListShipmentsRequest
+	13, // 51: shipment.ShipmentService.UpdateShipmentStatus:input_type -> shipment.UpdateShipmentStatusRequest
+	16, // 52: shipment.ShipmentService.GetTrackingHistory:input_type -> shipment.GetTrackingHistoryRequest
+	18, // 53: shipment.ShipmentService.QuoteShippingRates:input_type -> shipment.QuoteShippingRatesRequest
+	21, // 54: shipment.ShipmentService.HandleCarrierWebhook:input_type -> shipment.CarrierWebhookRequest
+	23, // 55: shipment.ShipmentService.RefreshTracking:input_type -> shipment.RefreshTrackingRequest
+	25, // 56: shipment.ShipmentService.UpsertSkuDimensions:input_type -> shipment.UpsertSkuDimensionsRequest
+	28, // 57: shipment.ShipmentService.UpsertShippingZone:input_type -> shipment.UpsertShippingZoneRequest
+	30, // 58: shipment.ShipmentService.RequestReturn:input_type -> shipment.RequestReturnRequest
+	31, // 59: shipment.ShipmentService.GetReturn:input_type -> shipment.GetReturnRequest
+	32, // 60: shipment.ShipmentService.ApproveReturn:input_type -> shipment.ReturnActionRequest
+	32, // 61: shipment.ShipmentService.RejectReturn:input_type -> shipment.ReturnActionRequest
+	32, // 62: shipment.ShipmentService.ReceiveReturn:input_type -> shipment.ReturnActionRequest
+	32, // 63: shipment.ShipmentService.InspectReturn:input_type -> shipment.ReturnActionRequest
+	36, // 64: shipment.ShipmentService.UpsertWarehouse:input_type -> shipment.UpsertWarehouseRequest
+	38, // 65: shipment.ShipmentService.ListWarehouses:input_type -> shipment.ListWarehousesRequest
+	41, // 66: shipment.ShipmentService.UpsertWarehouseStock:input_type -> shipment.UpsertWarehouseStockRequest
+	43, // 67: shipment.ShipmentService.AllocateShipments:input_type -> shipment.AllocateShipmentsRequest
+	48, // 68: shipment.ShipmentService.PackShipment:input_type -> shipment.PackShipmentRequest
+	50, // 69: shipment.ShipmentService.GetShippingDocument:input_type -> shipment.GetShippingDocumentRequest
+	56, // 70: shipment.ShipmentService.ConfirmDelivery:input_type -> shipment.ConfirmDeliveryRequest
+	58, // 71: shipment.ShipmentService.GetDeliveryFile:input_type -> shipment.GetDeliveryFileRequest
+	59, // 72: shipment.ShipmentService.WatchShipment:input_type -> shipment.WatchShipmentRequest
+	60, // 73: shipment.ShipmentService.WatchOrder:input_type -> shipment.WatchOrderRequest
+	2,  // 74: shipment.ShipmentService.CreateShipment:output_type -> shipment.CreateShipmentResponse
+	4,  // 75: shipment.ShipmentService.CreateShipments:output_type -> shipment.CreateShipmentsResponse
+	10, // 76: shipment.ShipmentService.GetShipment:output_type -> shipment.GetShipmentResponse
+	12, // 77: shipment.ShipmentService.ListShipments:output_type -> shipment.ListShipmentsResponse
+	14, // 78: shipment.ShipmentService.UpdateShipmentStatus:output_type -> shipment.UpdateShipmentStatusResponse
+	17, // 79: shipment.ShipmentService.GetTrackingHistory:output_type -> shipment.GetTrackingHistoryResponse
+	20, // 80: shipment.ShipmentService.QuoteShippingRates:output_type -> shipment.QuoteShippingRatesResponse
+	22, // 81: shipment.ShipmentService.HandleCarrierWebhook:output_type -> shipment.CarrierWebhookResponse
+	17, // 82: shipment.ShipmentService.RefreshTracking:output_type -> shipment.GetTrackingHistoryResponse
+	26, // 83: shipment.ShipmentService.UpsertSkuDimensions:output_type -> shipment.UpsertSkuDimensionsResponse
+	29, // 84: shipment.ShipmentService.UpsertShippingZone:output_type -> shipment.UpsertShippingZoneResponse
+	34, // 85: shipment.ShipmentService.RequestReturn:output_type -> shipment.ReturnResponse
+	34, // 86: shipment.ShipmentService.GetReturn:output_type -> shipment.ReturnResponse
+	34, // 87: shipment.ShipmentService.ApproveReturn:output_type -> shipment.ReturnResponse
+	34, // 88: shipment.ShipmentService.RejectReturn:output_type -> shipment.ReturnResponse
+	34, // 89: shipment.ShipmentService.ReceiveReturn:output_type -> shipment.ReturnResponse
+	34, // 90: shipment.ShipmentService.InspectReturn:output_type -> shipment.ReturnResponse
+	37, // 91: shipment.ShipmentService.UpsertWarehouse:output_type -> shipment.UpsertWarehouseResponse
+	39, // 92: shipment.ShipmentService.ListWarehouses:output_type -> shipment.ListWarehousesResponse
+	42, // 93: shipment.ShipmentService.UpsertWarehouseStock:output_type -> shipment.UpsertWarehouseStockResponse
+	45, // 94: shipment.ShipmentService.AllocateShipments:output_type -> shipment.AllocateShipmentsResponse
+	49, // 95: shipment.ShipmentService.PackShipment:output_type -> shipment.PackShipmentResponse
+	51, // 96: shipment.ShipmentService.GetShippingDocument:output_type -> shipment.ShippingDocument
+	57, // 97: shipment.ShipmentService.ConfirmDelivery:output_type -> shipment.ConfirmDeliveryResponse
+	55, // 98: shipment.ShipmentService.GetDeliveryFile:output_type -> shipment.DeliveryFileChunk
+	61, // 99: shipment.ShipmentService.WatchShipment:output_type -> shipment.WatchUpdate
+	61, // 100: shipment.ShipmentService.WatchOrder:output_type -> shipment.WatchUpdate
+	74, // [74:101] is the sub-list for method output_type
+	47, // [47:74] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_shipment_protoc_init() }
@@ -391,7 +4537,7 @@ func file_shipment_protoc_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shipment_protoc_rawDesc), len(file_shipment_protoc_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShipmentService_CreateShipment_FullMethodName = "/shipment.ShipmentService/CreateShipment"
+	ShipmentService_CreateShipment_FullMethodName       = "/shipment.ShipmentService/CreateShipment"
+	ShipmentService_CreateShipments_FullMethodName      = "/shipment.ShipmentService/CreateShipments"
+	ShipmentService_GetShipment_FullMethodName          = "/shipment.ShipmentService/GetShipment"
+	ShipmentService_ListShipments_FullMethodName        = "/shipment.ShipmentService/ListShipments"
+	ShipmentService_UpdateShipmentStatus_FullMethodName = "/shipment.ShipmentService/UpdateShipmentStatus"
+	ShipmentService_GetTrackingHistory_FullMethodName   = "/shipment.ShipmentService/GetTrackingHistory"
+	ShipmentService_QuoteShippingRates_FullMethodName   = "/shipment.ShipmentService/QuoteShippingRates"
+	ShipmentService_HandleCarrierWebhook_FullMethodName = "/shipment.ShipmentService/HandleCarrierWebhook"
+	ShipmentService_RefreshTracking_FullMethodName      = "/shipment.ShipmentService/RefreshTracking"
+	ShipmentService_UpsertSkuDimensions_FullMethodName  = "/shipment.ShipmentService/UpsertSkuDimensions"
+	ShipmentService_UpsertShippingZone_FullMethodName   = "/shipment.ShipmentService/UpsertShippingZone"
+	ShipmentService_RequestReturn_FullMethodName        = "/shipment.ShipmentService/RequestReturn"
+	ShipmentService_GetReturn_FullMethodName            = "/shipment.ShipmentService/GetReturn"
+	ShipmentService_ApproveReturn_FullMethodName        = "/shipment.ShipmentService/ApproveReturn"
+	ShipmentService_RejectReturn_FullMethodName         = "/shipment.ShipmentService/RejectReturn"
+	ShipmentService_ReceiveReturn_FullMethodName        = "/shipment.ShipmentService/ReceiveReturn"
+	ShipmentService_InspectReturn_FullMethodName        = "/shipment.ShipmentService/InspectReturn"
+	ShipmentService_UpsertWarehouse_FullMethodName      = "/shipment.ShipmentService/UpsertWarehouse"
+	ShipmentService_ListWarehouses_FullMethodName       = "/shipment.ShipmentService/ListWarehouses"
+	ShipmentService_UpsertWarehouseStock_FullMethodName = "/shipment.ShipmentService/UpsertWarehouseStock"
+	ShipmentService_AllocateShipments_FullMethodName    = "/shipment.ShipmentService/AllocateShipments"
+	ShipmentService_PackShipment_FullMethodName         = "/shipment.ShipmentService/PackShipment"
+	ShipmentService_GetShippingDocument_FullMethodName  = "/shipment.ShipmentService/GetShippingDocument"
+	ShipmentService_ConfirmDelivery_FullMethodName      = "/shipment.ShipmentService/ConfirmDelivery"
+	ShipmentService_GetDeliveryFile_FullMethodName      = "/shipment.ShipmentService/GetDeliveryFile"
+	ShipmentService_WatchShipment_FullMethodName        = "/shipment.ShipmentService/WatchShipment"
+	ShipmentService_WatchOrder_FullMethodName           = "/shipment.ShipmentService/WatchOrder"
 )
 
 // ShipmentServiceClient is the client API for ShipmentService service.
@@ -28,6 +54,61 @@ const (
 type ShipmentServiceClient interface {
 	// CreateShipment creates a new shipment with items
 	CreateShipment(ctx context.Context, in *CreateShipmentRequest, opts ...grpc.CallOption) (*CreateShipmentResponse, error)
+	// CreateShipments creates a shipment per line streamed, like CreateShipment, and returns the result of every line.
+	// A line that cannot be created fails alone, the lines of different orders are created concurrently.
+	CreateShipments(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateShipmentsRequest, CreateShipmentsResponse], error)
+	// GetShipment returns a shipment with its items
+	GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*GetShipmentResponse, error)
+	// ListShipments returns a page of shipments matching the filters, newest first
+	ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error)
+	// UpdateShipmentStatus moves a shipment to the next status of its lifecycle
+	UpdateShipmentStatus(ctx context.Context, in *UpdateShipmentStatusRequest, opts ...grpc.CallOption) (*UpdateShipmentStatusResponse, error)
+	// GetTrackingHistory returns a shipment with its status changes, oldest first
+	GetTrackingHistory(ctx context.Context, in *GetTrackingHistoryRequest, opts ...grpc.CallOption) (*GetTrackingHistoryResponse, error)
+	// QuoteShippingRates returns the rate of each carrier for shipping the items
+	QuoteShippingRates(ctx context.Context, in *QuoteShippingRatesRequest, opts ...grpc.CallOption) (*QuoteShippingRatesResponse, error)
+	// HandleCarrierWebhook applies a tracking push received from a carrier
+	HandleCarrierWebhook(ctx context.Context, in *CarrierWebhookRequest, opts ...grpc.CallOption) (*CarrierWebhookResponse, error)
+	// RefreshTracking pulls the tracking of a shipment from its carrier
+	RefreshTracking(ctx context.Context, in *RefreshTrackingRequest, opts ...grpc.CallOption) (*GetTrackingHistoryResponse, error)
+	// UpsertSkuDimensions creates or replaces the weight and size of SKUs
+	UpsertSkuDimensions(ctx context.Context, in *UpsertSkuDimensionsRequest, opts ...grpc.CallOption) (*UpsertSkuDimensionsResponse, error)
+	// UpsertShippingZone creates or replaces a shipping zone by code
+	UpsertShippingZone(ctx context.Context, in *UpsertShippingZoneRequest, opts ...grpc.CallOption) (*UpsertShippingZoneResponse, error)
+	// RequestReturn opens a return for items of a delivered shipment
+	RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// GetReturn returns a return with its items
+	GetReturn(ctx context.Context, in *GetReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// ApproveReturn accepts a requested return
+	ApproveReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// RejectReturn refuses a return before its items are received
+	RejectReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// ReceiveReturn records the returned items and credits their invoiced amount
+	ReceiveReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// InspectReturn records the inspection of received items
+	InspectReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error)
+	// UpsertWarehouse creates or replaces a warehouse by code
+	UpsertWarehouse(ctx context.Context, in *UpsertWarehouseRequest, opts ...grpc.CallOption) (*UpsertWarehouseResponse, error)
+	// ListWarehouses returns every warehouse by priority
+	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
+	// UpsertWarehouseStock creates or replaces the quantity of SKUs on hand in warehouses
+	UpsertWarehouseStock(ctx context.Context, in *UpsertWarehouseStockRequest, opts ...grpc.CallOption) (*UpsertWarehouseStockResponse, error)
+	// AllocateShipments proposes how to split an order into per-warehouse shipments
+	AllocateShipments(ctx context.Context, in *AllocateShipmentsRequest, opts ...grpc.CallOption) (*AllocateShipmentsResponse, error)
+	// PackShipment records the parcels a shipment is packed in
+	PackShipment(ctx context.Context, in *PackShipmentRequest, opts ...grpc.CallOption) (*PackShipmentResponse, error)
+	// GetShippingDocument renders the label or packing slip of a shipment
+	GetShippingDocument(ctx context.Context, in *GetShippingDocumentRequest, opts ...grpc.CallOption) (*ShippingDocument, error)
+	// ConfirmDelivery uploads the proof of delivery: the details first, then the signature and photos in chunks
+	ConfirmDelivery(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConfirmDeliveryRequest, ConfirmDeliveryResponse], error)
+	// GetDeliveryFile downloads a signature or photo of a delivery proof in chunks
+	GetDeliveryFile(ctx context.Context, in *GetDeliveryFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeliveryFileChunk], error)
+	// WatchShipment streams the status changes of a shipment recorded after after_event_id, then each new one.
+	// Events are not always sent by id, and events recorded shortly before after_event_id may be sent again.
+	WatchShipment(ctx context.Context, in *WatchShipmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchUpdate], error)
+	// WatchOrder streams the status changes of every shipment of an order like WatchShipment,
+	// including shipments created while watching. Customers only watch their own orders.
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchUpdate], error)
 }
 
 type shipmentServiceClient struct {
@@ -48,12 +129,360 @@ func (c *shipmentServiceClient) CreateShipment(ctx context.Context, in *CreateSh
 	return out, nil
 }
 
+func (c *shipmentServiceClient) CreateShipments(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateShipmentsRequest, CreateShipmentsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShipmentService_ServiceDesc.Streams[0], ShipmentService_CreateShipments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CreateShipmentsRequest, CreateShipmentsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_CreateShipmentsClient = grpc.ClientStreamingClient[CreateShipmentsRequest, CreateShipmentsResponse]
+
+func (c *shipmentServiceClient) GetShipment(ctx context.Context, in *GetShipmentRequest, opts ...grpc.CallOption) (*GetShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ListShipments(ctx context.Context, in *ListShipmentsRequest, opts ...grpc.CallOption) (*ListShipmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShipmentsResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ListShipments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) UpdateShipmentStatus(ctx context.Context, in *UpdateShipmentStatusRequest, opts ...grpc.CallOption) (*UpdateShipmentStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateShipmentStatusResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_UpdateShipmentStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) GetTrackingHistory(ctx context.Context, in *GetTrackingHistoryRequest, opts ...grpc.CallOption) (*GetTrackingHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrackingHistoryResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetTrackingHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) QuoteShippingRates(ctx context.Context, in *QuoteShippingRatesRequest, opts ...grpc.CallOption) (*QuoteShippingRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteShippingRatesResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_QuoteShippingRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) HandleCarrierWebhook(ctx context.Context, in *CarrierWebhookRequest, opts ...grpc.CallOption) (*CarrierWebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarrierWebhookResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_HandleCarrierWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) RefreshTracking(ctx context.Context, in *RefreshTrackingRequest, opts ...grpc.CallOption) (*GetTrackingHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrackingHistoryResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_RefreshTracking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) UpsertSkuDimensions(ctx context.Context, in *UpsertSkuDimensionsRequest, opts ...grpc.CallOption) (*UpsertSkuDimensionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertSkuDimensionsResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_UpsertSkuDimensions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) UpsertShippingZone(ctx context.Context, in *UpsertShippingZoneRequest, opts ...grpc.CallOption) (*UpsertShippingZoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertShippingZoneResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_UpsertShippingZone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_RequestReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) GetReturn(ctx context.Context, in *GetReturnRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_GetReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ApproveReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ApproveReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) RejectReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_RejectReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ReceiveReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ReceiveReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) InspectReturn(ctx context.Context, in *ReturnActionRequest, opts ...grpc.CallOption) (*ReturnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_InspectReturn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) UpsertWarehouse(ctx context.Context, in *UpsertWarehouseRequest, opts ...grpc.CallOption) (*UpsertWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertWarehouseResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_UpsertWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarehousesResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_ListWarehouses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) UpsertWarehouseStock(ctx context.Context, in *UpsertWarehouseStockRequest, opts ...grpc.CallOption) (*UpsertWarehouseStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertWarehouseStockResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_UpsertWarehouseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) AllocateShipments(ctx context.Context, in *AllocateShipmentsRequest, opts ...grpc.CallOption) (*AllocateShipmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocateShipmentsResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_AllocateShipments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) PackShipment(ctx context.Context, in *PackShipmentRequest, opts ...grpc.CallOption) (*PackShipmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PackShipmentResponse)
+	err := c.cc.Invoke(ctx, ShipmentService_PackShipment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) GetShippingDocument(ctx context.Context, in *GetShippingDocumentRequest, opts ...grpc.CallOption) (*ShippingDocument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShippingDocument)
+	err := c.cc.Invoke(ctx, ShipmentService_GetShippingDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shipmentServiceClient) ConfirmDelivery(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ConfirmDeliveryRequest, ConfirmDeliveryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShipmentService_ServiceDesc.Streams[1], ShipmentService_ConfirmDelivery_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ConfirmDeliveryRequest, ConfirmDeliveryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_ConfirmDeliveryClient = grpc.ClientStreamingClient[ConfirmDeliveryRequest, ConfirmDeliveryResponse]
+
+func (c *shipmentServiceClient) GetDeliveryFile(ctx context.Context, in *GetDeliveryFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeliveryFileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShipmentService_ServiceDesc.Streams[2], ShipmentService_GetDeliveryFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetDeliveryFileRequest, DeliveryFileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_GetDeliveryFileClient = grpc.ServerStreamingClient[DeliveryFileChunk]
+
+func (c *shipmentServiceClient) WatchShipment(ctx context.Context, in *WatchShipmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShipmentService_ServiceDesc.Streams[3], ShipmentService_WatchShipment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchShipmentRequest, WatchUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_WatchShipmentClient = grpc.ServerStreamingClient[WatchUpdate]
+
+func (c *shipmentServiceClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShipmentService_ServiceDesc.Streams[4], ShipmentService_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderRequest, WatchUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_WatchOrderClient = grpc.ServerStreamingClient[WatchUpdate]
+
 // ShipmentServiceServer is the server API for ShipmentService service.
 // All implementations must embed UnimplementedShipmentServiceServer
 // for forward compatibility.
 type ShipmentServiceServer interface {
 	// CreateShipment creates a new shipment with items
 	CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error)
+	// CreateShipments creates a shipment per line streamed, like CreateShipment, and returns the result of every line.
+	// A line that cannot be created fails alone, the lines of different orders are created concurrently.
+	CreateShipments(grpc.ClientStreamingServer[CreateShipmentsRequest, CreateShipmentsResponse]) error
+	// GetShipment returns a shipment with its items
+	GetShipment(context.Context, *GetShipmentRequest) (*GetShipmentResponse, error)
+	// ListShipments returns a page of shipments matching the filters, newest first
+	ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error)
+	// UpdateShipmentStatus moves a shipment to the next status of its lifecycle
+	UpdateShipmentStatus(context.Context, *UpdateShipmentStatusRequest) (*UpdateShipmentStatusResponse, error)
+	// GetTrackingHistory returns a shipment with its status changes, oldest first
+	GetTrackingHistory(context.Context, *GetTrackingHistoryRequest) (*GetTrackingHistoryResponse, error)
+	// QuoteShippingRates returns the rate of each carrier for shipping the items
+	QuoteShippingRates(context.Context, *QuoteShippingRatesRequest) (*QuoteShippingRatesResponse, error)
+	// HandleCarrierWebhook applies a tracking push received from a carrier
+	HandleCarrierWebhook(context.Context, *CarrierWebhookRequest) (*CarrierWebhookResponse, error)
+	// RefreshTracking pulls the tracking of a shipment from its carrier
+	RefreshTracking(context.Context, *RefreshTrackingRequest) (*GetTrackingHistoryResponse, error)
+	// UpsertSkuDimensions creates or replaces the weight and size of SKUs
+	UpsertSkuDimensions(context.Context, *UpsertSkuDimensionsRequest) (*UpsertSkuDimensionsResponse, error)
+	// UpsertShippingZone creates or replaces a shipping zone by code
+	UpsertShippingZone(context.Context, *UpsertShippingZoneRequest) (*UpsertShippingZoneResponse, error)
+	// RequestReturn opens a return for items of a delivered shipment
+	RequestReturn(context.Context, *RequestReturnRequest) (*ReturnResponse, error)
+	// GetReturn returns a return with its items
+	GetReturn(context.Context, *GetReturnRequest) (*ReturnResponse, error)
+	// ApproveReturn accepts a requested return
+	ApproveReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error)
+	// RejectReturn refuses a return before its items are received
+	RejectReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error)
+	// ReceiveReturn records the returned items and credits their invoiced amount
+	ReceiveReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error)
+	// InspectReturn records the inspection of received items
+	InspectReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error)
+	// UpsertWarehouse creates or replaces a warehouse by code
+	UpsertWarehouse(context.Context, *UpsertWarehouseRequest) (*UpsertWarehouseResponse, error)
+	// ListWarehouses returns every warehouse by priority
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
+	// UpsertWarehouseStock creates or replaces the quantity of SKUs on hand in warehouses
+	UpsertWarehouseStock(context.Context, *UpsertWarehouseStockRequest) (*UpsertWarehouseStockResponse, error)
+	// AllocateShipments proposes how to split an order into per-warehouse shipments
+	AllocateShipments(context.Context, *AllocateShipmentsRequest) (*AllocateShipmentsResponse, error)
+	// PackShipment records the parcels a shipment is packed in
+	PackShipment(context.Context, *PackShipmentRequest) (*PackShipmentResponse, error)
+	// GetShippingDocument renders the label or packing slip of a shipment
+	GetShippingDocument(context.Context, *GetShippingDocumentRequest) (*ShippingDocument, error)
+	// ConfirmDelivery uploads the proof of delivery: the details first, then the signature and photos in chunks
+	ConfirmDelivery(grpc.ClientStreamingServer[ConfirmDeliveryRequest, ConfirmDeliveryResponse]) error
+	// GetDeliveryFile downloads a signature or photo of a delivery proof in chunks
+	GetDeliveryFile(*GetDeliveryFileRequest, grpc.ServerStreamingServer[DeliveryFileChunk]) error
+	// WatchShipment streams the status changes of a shipment recorded after after_event_id, then each new one.
+	// Events are not always sent by id, and events recorded shortly before after_event_id may be sent again.
+	WatchShipment(*WatchShipmentRequest, grpc.ServerStreamingServer[WatchUpdate]) error
+	// WatchOrder streams the status changes of every shipment of an order like WatchShipment,
+	// including shipments created while watching. Customers only watch their own orders.
+	WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[WatchUpdate]) error
 	mustEmbedUnimplementedShipmentServiceServer()
 }
 
@@ -67,6 +496,84 @@ type UnimplementedShipmentServiceServer struct{}
 func (UnimplementedShipmentServiceServer) CreateShipment(context.Context, *CreateShipmentRequest) (*CreateShipmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShipment not implemented")
 }
+func (UnimplementedShipmentServiceServer) CreateShipments(grpc.ClientStreamingServer[CreateShipmentsRequest, CreateShipmentsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateShipments not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetShipment(context.Context, *GetShipmentRequest) (*GetShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShipment not implemented")
+}
+func (UnimplementedShipmentServiceServer) ListShipments(context.Context, *ListShipmentsRequest) (*ListShipmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShipments not implemented")
+}
+func (UnimplementedShipmentServiceServer) UpdateShipmentStatus(context.Context, *UpdateShipmentStatusRequest) (*UpdateShipmentStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShipmentStatus not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetTrackingHistory(context.Context, *GetTrackingHistoryRequest) (*GetTrackingHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrackingHistory not implemented")
+}
+func (UnimplementedShipmentServiceServer) QuoteShippingRates(context.Context, *QuoteShippingRatesRequest) (*QuoteShippingRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteShippingRates not implemented")
+}
+func (UnimplementedShipmentServiceServer) HandleCarrierWebhook(context.Context, *CarrierWebhookRequest) (*CarrierWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleCarrierWebhook not implemented")
+}
+func (UnimplementedShipmentServiceServer) RefreshTracking(context.Context, *RefreshTrackingRequest) (*GetTrackingHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshTracking not implemented")
+}
+func (UnimplementedShipmentServiceServer) UpsertSkuDimensions(context.Context, *UpsertSkuDimensionsRequest) (*UpsertSkuDimensionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertSkuDimensions not implemented")
+}
+func (UnimplementedShipmentServiceServer) UpsertShippingZone(context.Context, *UpsertShippingZoneRequest) (*UpsertShippingZoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertShippingZone not implemented")
+}
+func (UnimplementedShipmentServiceServer) RequestReturn(context.Context, *RequestReturnRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetReturn(context.Context, *GetReturnRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) ApproveReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) RejectReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) ReceiveReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReceiveReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) InspectReturn(context.Context, *ReturnActionRequest) (*ReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectReturn not implemented")
+}
+func (UnimplementedShipmentServiceServer) UpsertWarehouse(context.Context, *UpsertWarehouseRequest) (*UpsertWarehouseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertWarehouse not implemented")
+}
+func (UnimplementedShipmentServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWarehouses not implemented")
+}
+func (UnimplementedShipmentServiceServer) UpsertWarehouseStock(context.Context, *UpsertWarehouseStockRequest) (*UpsertWarehouseStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertWarehouseStock not implemented")
+}
+func (UnimplementedShipmentServiceServer) AllocateShipments(context.Context, *AllocateShipmentsRequest) (*AllocateShipmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateShipments not implemented")
+}
+func (UnimplementedShipmentServiceServer) PackShipment(context.Context, *PackShipmentRequest) (*PackShipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PackShipment not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetShippingDocument(context.Context, *GetShippingDocumentRequest) (*ShippingDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShippingDocument not implemented")
+}
+func (UnimplementedShipmentServiceServer) ConfirmDelivery(grpc.ClientStreamingServer[ConfirmDeliveryRequest, ConfirmDeliveryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ConfirmDelivery not implemented")
+}
+func (UnimplementedShipmentServiceServer) GetDeliveryFile(*GetDeliveryFileRequest, grpc.ServerStreamingServer[DeliveryFileChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetDeliveryFile not implemented")
+}
+func (UnimplementedShipmentServiceServer) WatchShipment(*WatchShipmentRequest, grpc.ServerStreamingServer[WatchUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchShipment not implemented")
+}
+func (UnimplementedShipmentServiceServer) WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[WatchUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
 func (UnimplementedShipmentServiceServer) mustEmbedUnimplementedShipmentServiceServer() {}
 func (UnimplementedShipmentServiceServer) testEmbeddedByValue()                         {}
 
@@ -106,18 +613,553 @@ func _ShipmentService_CreateShipment_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
-// ShipmentService_ServiceDesc is the grpc.ServiceDesc for ShipmentService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var ShipmentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "shipment.ShipmentService",
-	HandlerType: (*ShipmentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateShipment",
-			Handler:    _ShipmentService_CreateShipment_Handler,
+func _ShipmentService_CreateShipments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShipmentServiceServer).CreateShipments(&grpc.GenericServerStream[CreateShipmentsRequest, CreateShipmentsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_CreateShipmentsServer = grpc.ClientStreamingServer[CreateShipmentsRequest, CreateShipmentsResponse]
+
+func _ShipmentService_GetShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetShipment(ctx, req.(*GetShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ListShipments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShipmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ListShipments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ListShipments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ListShipments(ctx, req.(*ListShipmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_UpdateShipmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateShipmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).UpdateShipmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_UpdateShipmentStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).UpdateShipmentStatus(ctx, req.(*UpdateShipmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_GetTrackingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrackingHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetTrackingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetTrackingHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetTrackingHistory(ctx, req.(*GetTrackingHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_QuoteShippingRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteShippingRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).QuoteShippingRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_QuoteShippingRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).QuoteShippingRates(ctx, req.(*QuoteShippingRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_HandleCarrierWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CarrierWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).HandleCarrierWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_HandleCarrierWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).HandleCarrierWebhook(ctx, req.(*CarrierWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_RefreshTracking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTrackingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).RefreshTracking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_RefreshTracking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).RefreshTracking(ctx, req.(*RefreshTrackingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_UpsertSkuDimensions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertSkuDimensionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).UpsertSkuDimensions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_UpsertSkuDimensions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).UpsertSkuDimensions(ctx, req.(*UpsertSkuDimensionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_UpsertShippingZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertShippingZoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).UpsertShippingZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_UpsertShippingZone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).UpsertShippingZone(ctx, req.(*UpsertShippingZoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_RequestReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).RequestReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_RequestReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).RequestReturn(ctx, req.(*RequestReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_GetReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetReturn(ctx, req.(*GetReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ApproveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ApproveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ApproveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ApproveReturn(ctx, req.(*ReturnActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_RejectReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).RejectReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_RejectReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).RejectReturn(ctx, req.(*ReturnActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ReceiveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ReceiveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ReceiveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ReceiveReturn(ctx, req.(*ReturnActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_InspectReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).InspectReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_InspectReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).InspectReturn(ctx, req.(*ReturnActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_UpsertWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).UpsertWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_UpsertWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).UpsertWarehouse(ctx, req.(*UpsertWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_UpsertWarehouseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertWarehouseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).UpsertWarehouseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_UpsertWarehouseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).UpsertWarehouseStock(ctx, req.(*UpsertWarehouseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_AllocateShipments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateShipmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).AllocateShipments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_AllocateShipments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).AllocateShipments(ctx, req.(*AllocateShipmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_PackShipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PackShipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).PackShipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_PackShipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).PackShipment(ctx, req.(*PackShipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_GetShippingDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShippingDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShipmentServiceServer).GetShippingDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShipmentService_GetShippingDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShipmentServiceServer).GetShippingDocument(ctx, req.(*GetShippingDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShipmentService_ConfirmDelivery_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShipmentServiceServer).ConfirmDelivery(&grpc.GenericServerStream[ConfirmDeliveryRequest, ConfirmDeliveryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_ConfirmDeliveryServer = grpc.ClientStreamingServer[ConfirmDeliveryRequest, ConfirmDeliveryResponse]
+
+func _ShipmentService_GetDeliveryFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetDeliveryFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShipmentServiceServer).GetDeliveryFile(m, &grpc.GenericServerStream[GetDeliveryFileRequest, DeliveryFileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_GetDeliveryFileServer = grpc.ServerStreamingServer[DeliveryFileChunk]
+
+func _ShipmentService_WatchShipment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchShipmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShipmentServiceServer).WatchShipment(m, &grpc.GenericServerStream[WatchShipmentRequest, WatchUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_WatchShipmentServer = grpc.ServerStreamingServer[WatchUpdate]
+
+func _ShipmentService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShipmentServiceServer).WatchOrder(m, &grpc.GenericServerStream[WatchOrderRequest, WatchUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShipmentService_WatchOrderServer = grpc.ServerStreamingServer[WatchUpdate]
+
+// ShipmentService_ServiceDesc is the grpc.ServiceDesc for ShipmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShipmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "shipment.ShipmentService",
+	HandlerType: (*ShipmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateShipment",
+			Handler:    _ShipmentService_CreateShipment_Handler,
+		},
+		{
+			MethodName: "GetShipment",
+			Handler:    _ShipmentService_GetShipment_Handler,
+		},
+		{
+			MethodName: "ListShipments",
+			Handler:    _ShipmentService_ListShipments_Handler,
+		},
+		{
+			MethodName: "UpdateShipmentStatus",
+			Handler:    _ShipmentService_UpdateShipmentStatus_Handler,
+		},
+		{
+			MethodName: "GetTrackingHistory",
+			Handler:    _ShipmentService_GetTrackingHistory_Handler,
+		},
+		{
+			MethodName: "QuoteShippingRates",
+			Handler:    _ShipmentService_QuoteShippingRates_Handler,
+		},
+		{
+			MethodName: "HandleCarrierWebhook",
+			Handler:    _ShipmentService_HandleCarrierWebhook_Handler,
+		},
+		{
+			MethodName: "RefreshTracking",
+			Handler:    _ShipmentService_RefreshTracking_Handler,
+		},
+		{
+			MethodName: "UpsertSkuDimensions",
+			Handler:    _ShipmentService_UpsertSkuDimensions_Handler,
+		},
+		{
+			MethodName: "UpsertShippingZone",
+			Handler:    _ShipmentService_UpsertShippingZone_Handler,
+		},
+		{
+			MethodName: "RequestReturn",
+			Handler:    _ShipmentService_RequestReturn_Handler,
+		},
+		{
+			MethodName: "GetReturn",
+			Handler:    _ShipmentService_GetReturn_Handler,
+		},
+		{
+			MethodName: "ApproveReturn",
+			Handler:    _ShipmentService_ApproveReturn_Handler,
+		},
+		{
+			MethodName: "RejectReturn",
+			Handler:    _ShipmentService_RejectReturn_Handler,
+		},
+		{
+			MethodName: "ReceiveReturn",
+			Handler:    _ShipmentService_ReceiveReturn_Handler,
+		},
+		{
+			MethodName: "InspectReturn",
+			Handler:    _ShipmentService_InspectReturn_Handler,
+		},
+		{
+			MethodName: "UpsertWarehouse",
+			Handler:    _ShipmentService_UpsertWarehouse_Handler,
+		},
+		{
+			MethodName: "ListWarehouses",
+			Handler:    _ShipmentService_ListWarehouses_Handler,
+		},
+		{
+			MethodName: "UpsertWarehouseStock",
+			Handler:    _ShipmentService_UpsertWarehouseStock_Handler,
+		},
+		{
+			MethodName: "AllocateShipments",
+			Handler:    _ShipmentService_AllocateShipments_Handler,
+		},
+		{
+			MethodName: "PackShipment",
+			Handler:    _ShipmentService_PackShipment_Handler,
+		},
+		{
+			MethodName: "GetShippingDocument",
+			Handler:    _ShipmentService_GetShippingDocument_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateShipments",
+			Handler:       _ShipmentService_CreateShipments_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ConfirmDelivery",
+			Handler:       _ShipmentService_ConfirmDelivery_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetDeliveryFile",
+			Handler:       _ShipmentService_GetDeliveryFile_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchShipment",
+			Handler:       _ShipmentService_WatchShipment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _ShipmentService_WatchOrder_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "shipment.protoc",
 }